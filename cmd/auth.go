@@ -69,6 +69,10 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return usageErrorf("missing credentials: set GWS_CLIENT_ID and GWS_CLIENT_SECRET environment variables, or use --client-id and --client-secret flags")
 	}
 
+	if config.IsOffline() {
+		return p.PrintError(fmt.Errorf("login requires network access; remove --offline"))
+	}
+
 	// Determine scopes and services based on --services flag, config, or all
 	scopes, grantedServices := resolveScopes(cmd)
 
@@ -109,6 +113,47 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// interactiveReauth is client.ReauthHandler under --interactive: it runs the
+// login flow for the previously granted services (or the configured/default
+// set) and saves the new token, so the interrupted command can continue.
+// Login progress goes to stderr to keep the command's stdout clean.
+func interactiveReauth(ctx context.Context) error {
+	clientID := config.GetClientID()
+	clientSecret := config.GetClientSecret()
+	if clientID == "" || clientSecret == "" {
+		return fmt.Errorf("missing OAuth credentials")
+	}
+
+	scopes := auth.AllScopes
+	granted := auth.LoadGrantedServices()
+	if len(granted) > 0 {
+		scopes = auth.ScopesForServices(granted)
+	} else if configServices := config.GetServices(); len(configServices) > 0 {
+		scopes = auth.ScopesForServices(configServices)
+		granted = configServices
+	}
+
+	fmt.Fprintln(os.Stderr, "Stored credentials are missing or no longer valid; starting login...")
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	token, err := auth.NewOAuthClient(clientID, clientSecret, scopes).Login(ctx)
+	os.Stdout = stdout
+	if err != nil {
+		return err
+	}
+
+	existing, _ := auth.LoadToken()
+	if err := auth.SaveToken(auth.MergeToken(existing, token)); err != nil {
+		return err
+	}
+	if len(granted) > 0 {
+		if err := auth.SaveGrantedServices(granted); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save granted services: %v\n", err)
+		}
+	}
+	return nil
+}
+
 func runLogout(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 
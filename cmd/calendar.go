@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -302,6 +307,60 @@ var calendarSettingsCmd = &cobra.Command{
 	RunE:  runCalendarSettings,
 }
 
+var calendarImportEventsCmd = &cobra.Command{
+	Use:   "import-events",
+	Short: "Create events in bulk from a CSV or JSON file",
+	Long: `Creates one event per row of --file. CSV files need a header row with
+summary, start, and end columns; location, description, and attendees are
+optional. Multiple attendees in one CSV cell are separated by ";". JSON
+files hold an array of objects with the same keys (attendees may be an
+array or a ";"-separated string).
+
+Times accept RFC3339 or 'YYYY-MM-DD HH:MM' (local time). Rows that fail
+validation or creation are skipped and reported in "failed" with their
+1-based row number; the remaining rows are still created.
+
+Examples:
+  gws calendar import-events --file events.csv
+  gws calendar import-events --file events.json --calendar-id team@group.calendar.google.com --send-updates all`,
+	RunE: runCalendarImportEvents,
+}
+
+var calendarSetOOOCmd = &cobra.Command{
+	Use:   "set-ooo",
+	Short: "Create an out-of-office event",
+	Long: `Creates an out-of-office event (eventType outOfOffice) on your primary
+calendar. Generic event creation cannot set this event type.
+
+--from and --to accept RFC3339, 'YYYY-MM-DD HH:MM', or a plain date. A plain
+--to date is inclusive: the event runs until the end of that day. With
+--decline-meetings, meeting invitations that conflict with the event are
+declined automatically, with --message as the decline note.
+
+Examples:
+  gws calendar set-ooo --from 2026-08-03 --to 2026-08-14 --decline-meetings --message "Away"
+  gws calendar set-ooo --from "2026-08-03 13:00" --to "2026-08-03 18:00" --title "Doctor"`,
+	Args: cobra.NoArgs,
+	RunE: runCalendarSetOOO,
+}
+
+var calendarSetFocusTimeCmd = &cobra.Command{
+	Use:   "set-focus-time",
+	Short: "Create a focus time event",
+	Long: `Creates a focus time event (eventType focusTime) on your primary calendar.
+Generic event creation cannot set this event type.
+
+--from and --to accept the same formats as set-ooo. With --decline-meetings,
+conflicting meeting invitations are declined automatically, with --message
+as the decline note. --chat-status sets your Chat status for the duration.
+
+Examples:
+  gws calendar set-focus-time --from "2026-08-03 09:00" --to "2026-08-03 11:00"
+  gws calendar set-focus-time --from "2026-08-03 14:00" --to "2026-08-03 16:00" --decline-meetings --chat-status doNotDisturb`,
+	Args: cobra.NoArgs,
+	RunE: runCalendarSetFocusTime,
+}
+
 var validRsvpResponses = map[string]bool{
 	"accepted":  true,
 	"declined":  true,
@@ -343,6 +402,9 @@ func init() {
 	calendarCmd.AddCommand(calendarFreebusyCmd)
 	calendarCmd.AddCommand(calendarColorsCmd)
 	calendarCmd.AddCommand(calendarSettingsCmd)
+	calendarCmd.AddCommand(calendarImportEventsCmd)
+	calendarCmd.AddCommand(calendarSetOOOCmd)
+	calendarCmd.AddCommand(calendarSetFocusTimeCmd)
 
 	// Events flags
 	calendarEventsCmd.Flags().Int("days", 7, "Number of days to look ahead")
@@ -483,6 +545,31 @@ func init() {
 	calendarFreebusyCmd.Flags().String("calendars", "primary", "Comma-separated calendar IDs")
 	calendarFreebusyCmd.MarkFlagRequired("from")
 	calendarFreebusyCmd.MarkFlagRequired("to")
+
+	// Import-events flags
+	calendarImportEventsCmd.Flags().String("file", "", "Path to a CSV or JSON file of events (required)")
+	calendarImportEventsCmd.Flags().String("calendar-id", "primary", "Calendar ID")
+	calendarImportEventsCmd.Flags().String("send-updates", "none", "Who receives invitations: all, externalOnly, none")
+	calendarImportEventsCmd.MarkFlagRequired("file")
+
+	// Set-ooo flags
+	calendarSetOOOCmd.Flags().String("from", "", "Start time or date (required)")
+	calendarSetOOOCmd.Flags().String("to", "", "End time, or inclusive end date (required)")
+	calendarSetOOOCmd.Flags().String("title", "Out of office", "Event title")
+	calendarSetOOOCmd.Flags().Bool("decline-meetings", false, "Automatically decline conflicting meeting invitations")
+	calendarSetOOOCmd.Flags().String("message", "", "Message sent with automatically declined invitations")
+	calendarSetOOOCmd.MarkFlagRequired("from")
+	calendarSetOOOCmd.MarkFlagRequired("to")
+
+	// Set-focus-time flags
+	calendarSetFocusTimeCmd.Flags().String("from", "", "Start time or date (required)")
+	calendarSetFocusTimeCmd.Flags().String("to", "", "End time, or inclusive end date (required)")
+	calendarSetFocusTimeCmd.Flags().String("title", "Focus time", "Event title")
+	calendarSetFocusTimeCmd.Flags().Bool("decline-meetings", false, "Automatically decline conflicting meeting invitations")
+	calendarSetFocusTimeCmd.Flags().String("message", "", "Message sent with automatically declined invitations")
+	calendarSetFocusTimeCmd.Flags().String("chat-status", "", "Chat status during focus time: available, doNotDisturb")
+	calendarSetFocusTimeCmd.MarkFlagRequired("from")
+	calendarSetFocusTimeCmd.MarkFlagRequired("to")
 }
 
 func runCalendarList(cmd *cobra.Command, args []string) error {
@@ -619,7 +706,8 @@ func runCalendarEvents(cmd *cobra.Command, args []string) error {
 }
 
 // calendarServiceForTest, when non-nil, replaces the factory-built calendar
-// service in runCalendarCreate. Tests set this to point at httptest endpoints;
+// service in runCalendarCreate, runCalendarImportEvents, and the status
+// event commands (set-ooo, set-focus-time). Tests set this to point at httptest endpoints;
 // production code never assigns it so the factory path is taken.
 var calendarServiceForTest *calendar.Service
 
@@ -2039,3 +2127,385 @@ func resolveIANA(t time.Time) string {
 	}
 	return ""
 }
+
+// validSendUpdates lists the sendUpdates values accepted by Events.Insert.
+var validSendUpdates = map[string]bool{
+	"all":          true,
+	"externalOnly": true,
+	"none":         true,
+}
+
+// importEventRow is one event read from an import-events file. Row is the
+// 1-based data row (the CSV header is not counted).
+type importEventRow struct {
+	Row         int
+	Summary     string
+	Start       string
+	End         string
+	Location    string
+	Description string
+	Attendees   []string
+}
+
+// splitAttendees splits a ";"- or ","-separated attendee cell into emails.
+func splitAttendees(s string) []string {
+	var emails []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' }) {
+		if email := strings.TrimSpace(part); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// parseImportEventsCSV reads events from CSV with a header row. Column names
+// are matched case-insensitively; unknown columns are ignored.
+func parseImportEventsCSV(r io.Reader) ([]importEventRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"summary", "start", "end"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing required column %q", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := cols[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []importEventRow
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row %d: %w", n, err)
+		}
+		rows = append(rows, importEventRow{
+			Row:         n,
+			Summary:     field(record, "summary"),
+			Start:       field(record, "start"),
+			End:         field(record, "end"),
+			Location:    field(record, "location"),
+			Description: field(record, "description"),
+			Attendees:   splitAttendees(field(record, "attendees")),
+		})
+	}
+	return rows, nil
+}
+
+// parseImportEventsJSON reads events from a JSON array of objects. attendees
+// may be an array of emails or a single ";"-separated string.
+func parseImportEventsJSON(data []byte) ([]importEventRow, error) {
+	var items []struct {
+		Summary     string          `json:"summary"`
+		Start       string          `json:"start"`
+		End         string          `json:"end"`
+		Location    string          `json:"location"`
+		Description string          `json:"description"`
+		Attendees   json.RawMessage `json:"attendees"`
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON file: %w", err)
+	}
+
+	rows := make([]importEventRow, 0, len(items))
+	for i, item := range items {
+		row := importEventRow{
+			Row:         i + 1,
+			Summary:     item.Summary,
+			Start:       item.Start,
+			End:         item.End,
+			Location:    item.Location,
+			Description: item.Description,
+		}
+		if len(item.Attendees) > 0 {
+			var list []string
+			var single string
+			switch {
+			case json.Unmarshal(item.Attendees, &list) == nil:
+				for _, a := range list {
+					row.Attendees = append(row.Attendees, splitAttendees(a)...)
+				}
+			case json.Unmarshal(item.Attendees, &single) == nil:
+				row.Attendees = splitAttendees(single)
+			default:
+				return nil, fmt.Errorf("row %d: attendees must be a string or an array of strings", i+1)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// buildImportEvent validates a row and converts it to a calendar event.
+func buildImportEvent(row importEventRow) (*calendar.Event, error) {
+	if row.Summary == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+	if row.Start == "" || row.End == "" {
+		return nil, fmt.Errorf("start and end are required")
+	}
+	startTime, err := parseTime(row.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time: %w", err)
+	}
+	endTime, err := parseTime(row.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time: %w", err)
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end (%s) must be after start (%s)", row.End, row.Start)
+	}
+
+	startDT := &calendar.EventDateTime{DateTime: startTime.Format(time.RFC3339)}
+	if tz := resolveIANA(startTime); tz != "" {
+		startDT.TimeZone = tz
+	}
+	endDT := &calendar.EventDateTime{DateTime: endTime.Format(time.RFC3339)}
+	if tz := resolveIANA(endTime); tz != "" {
+		endDT.TimeZone = tz
+	}
+
+	event := &calendar.Event{
+		Summary:     row.Summary,
+		Description: row.Description,
+		Location:    row.Location,
+		Start:       startDT,
+		End:         endDT,
+	}
+	for _, email := range row.Attendees {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
+	}
+	return event, nil
+}
+
+func runCalendarImportEvents(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	filePath, _ := cmd.Flags().GetString("file")
+	calendarID, _ := cmd.Flags().GetString("calendar-id")
+	sendUpdates, _ := cmd.Flags().GetString("send-updates")
+
+	if !validSendUpdates[sendUpdates] {
+		return usageErrorf("invalid --send-updates %q: must be all, externalOnly, or none", sendUpdates)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read file %s: %w", filePath, err))
+	}
+
+	var rows []importEventRow
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		rows, err = parseImportEventsJSON(data)
+	} else {
+		rows, err = parseImportEventsCSV(bytes.NewReader(data))
+	}
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if len(rows) == 0 {
+		return p.PrintError(fmt.Errorf("no events found in file"))
+	}
+
+	var svc *calendar.Service
+	if calendarServiceForTest != nil {
+		svc = calendarServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Calendar()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	created := []map[string]interface{}{}
+	var failed []map[string]interface{}
+	for _, row := range rows {
+		event, err := buildImportEvent(row)
+		if err == nil {
+			var inserted *calendar.Event
+			inserted, err = svc.Events.Insert(calendarID, event).SendUpdates(sendUpdates).Do()
+			if err == nil {
+				created = append(created, map[string]interface{}{
+					"row":       row.Row,
+					"id":        inserted.Id,
+					"summary":   inserted.Summary,
+					"html_link": inserted.HtmlLink,
+				})
+				continue
+			}
+			err = fmt.Errorf("failed to create event: %w", err)
+		}
+		failed = append(failed, map[string]interface{}{
+			"row":     row.Row,
+			"summary": row.Summary,
+			"error":   err.Error(),
+		})
+	}
+
+	out := map[string]interface{}{
+		"status":      "imported",
+		"calendar_id": calendarID,
+		"created":     created,
+		"count":       len(created),
+	}
+	if len(failed) > 0 {
+		out["failed"] = failed
+		out["failed_count"] = len(failed)
+	}
+	return p.Print(out)
+}
+
+// statusEventRange parses --from/--to for set-ooo and set-focus-time. These
+// event types cannot be all-day, so a plain --to date is treated as
+// inclusive and the event ends at midnight after it.
+func statusEventRange(fromStr, toStr string) (start, end time.Time, err error) {
+	start, err = parseTime(fromStr)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid --from: %w", err)
+	}
+	end, err = parseTime(toStr)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid --to: %w", err)
+	}
+	if _, dateErr := time.ParseInLocation("2006-01-02", toStr, time.Local); dateErr == nil {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return start, end, fmt.Errorf("--to (%s) must be after --from (%s)", toStr, fromStr)
+	}
+	return start, end, nil
+}
+
+// statusEventDateTime converts t to a timed EventDateTime, adding the IANA
+// zone when it can be resolved.
+func statusEventDateTime(t time.Time) *calendar.EventDateTime {
+	dt := &calendar.EventDateTime{DateTime: t.Format(time.RFC3339)}
+	if tz := resolveIANA(t); tz != "" {
+		dt.TimeZone = tz
+	}
+	return dt
+}
+
+// autoDeclineMode maps --decline-meetings to the API's AutoDeclineMode.
+func autoDeclineMode(decline bool) string {
+	if decline {
+		return "declineAllConflictingInvitations"
+	}
+	return "declineNone"
+}
+
+func runCalendarSetOOO(cmd *cobra.Command, args []string) error {
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+	title, _ := cmd.Flags().GetString("title")
+	decline, _ := cmd.Flags().GetBool("decline-meetings")
+	message, _ := cmd.Flags().GetString("message")
+
+	start, end, err := statusEventRange(fromStr, toStr)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	event := &calendar.Event{
+		EventType: "outOfOffice",
+		Summary:   title,
+		Start:     statusEventDateTime(start),
+		End:       statusEventDateTime(end),
+		OutOfOfficeProperties: &calendar.EventOutOfOfficeProperties{
+			AutoDeclineMode: autoDeclineMode(decline),
+			DeclineMessage:  message,
+		},
+	}
+	return createStatusEvent(event)
+}
+
+func runCalendarSetFocusTime(cmd *cobra.Command, args []string) error {
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+	title, _ := cmd.Flags().GetString("title")
+	decline, _ := cmd.Flags().GetBool("decline-meetings")
+	message, _ := cmd.Flags().GetString("message")
+	chatStatus, _ := cmd.Flags().GetString("chat-status")
+
+	if chatStatus != "" && chatStatus != "available" && chatStatus != "doNotDisturb" {
+		return usageErrorf("invalid --chat-status %q: must be available or doNotDisturb", chatStatus)
+	}
+	start, end, err := statusEventRange(fromStr, toStr)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	event := &calendar.Event{
+		EventType: "focusTime",
+		Summary:   title,
+		Start:     statusEventDateTime(start),
+		End:       statusEventDateTime(end),
+		FocusTimeProperties: &calendar.EventFocusTimeProperties{
+			AutoDeclineMode: autoDeclineMode(decline),
+			DeclineMessage:  message,
+			ChatStatus:      chatStatus,
+		},
+	}
+	return createStatusEvent(event)
+}
+
+// createStatusEvent inserts an out-of-office or focus time event. Both types
+// are only supported on the user's primary calendar.
+func createStatusEvent(event *calendar.Event) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	var svc *calendar.Service
+	if calendarServiceForTest != nil {
+		svc = calendarServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Calendar()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	created, err := svc.Events.Insert("primary", event).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to create %s event: %w", event.EventType, err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":     "created",
+		"id":         created.Id,
+		"event_type": created.EventType,
+		"summary":    created.Summary,
+		"start":      event.Start.DateTime,
+		"end":        event.End.DateTime,
+		"html_link":  created.HtmlLink,
+	})
+}
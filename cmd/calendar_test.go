@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -2381,3 +2382,234 @@ func readJSONBody(r *http.Request) ([]byte, error) {
 	defer r.Body.Close()
 	return io.ReadAll(r.Body)
 }
+
+func TestCalendarImportEventsCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(calendarCmd, "import-events")
+	if cmd == nil {
+		t.Fatal("calendar import-events command not found")
+	}
+	for _, name := range []string{"file", "calendar-id", "send-updates"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+	if def := cmd.Flags().Lookup("send-updates").DefValue; def != "none" {
+		t.Errorf("expected --send-updates default 'none', got %q", def)
+	}
+}
+
+func TestParseImportEventsCSV(t *testing.T) {
+	input := "Summary,Start,End,Attendees,Location\n" +
+		"Standup,2026-05-04 09:00,2026-05-04 09:15,a@example.com; b@example.com,Room 1\n" +
+		"\"Review, Q2\",2026-05-05T14:00:00Z,2026-05-05T15:00:00Z,,\n"
+
+	rows, err := parseImportEventsCSV(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].Row != 1 || rows[0].Summary != "Standup" || rows[0].Location != "Room 1" {
+		t.Errorf("unexpected first row: %+v", rows[0])
+	}
+	if len(rows[0].Attendees) != 2 || rows[0].Attendees[1] != "b@example.com" {
+		t.Errorf("expected 2 attendees, got %v", rows[0].Attendees)
+	}
+	if rows[1].Summary != "Review, Q2" || len(rows[1].Attendees) != 0 {
+		t.Errorf("unexpected second row: %+v", rows[1])
+	}
+
+	if _, err := parseImportEventsCSV(bytes.NewReader([]byte("summary,start\nx,y\n"))); err == nil {
+		t.Error("expected error for missing end column")
+	}
+}
+
+func TestParseImportEventsJSON(t *testing.T) {
+	data := []byte(`[
+		{"summary": "One", "start": "2026-05-04 09:00", "end": "2026-05-04 10:00", "attendees": ["a@example.com"]},
+		{"summary": "Two", "start": "2026-05-04 11:00", "end": "2026-05-04 12:00", "attendees": "b@example.com;c@example.com"}
+	]`)
+	rows, err := parseImportEventsJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || len(rows[0].Attendees) != 1 || len(rows[1].Attendees) != 2 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestBuildImportEvent_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		row  importEventRow
+		want string
+	}{
+		{"missing summary", importEventRow{Start: "2026-05-04 09:00", End: "2026-05-04 10:00"}, "summary is required"},
+		{"bad start", importEventRow{Summary: "x", Start: "tomorrow", End: "2026-05-04 10:00"}, "invalid start time"},
+		{"end before start", importEventRow{Summary: "x", Start: "2026-05-04 10:00", End: "2026-05-04 09:00"}, "must be after start"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildImportEvent(tt.row)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCalendarImportEvents_CreatesAndCollectsFailures(t *testing.T) {
+	var inserted []string
+	var sendUpdates string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/calendars/primary/events" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sendUpdates = r.URL.Query().Get("sendUpdates")
+		var ev calendar.Event
+		_ = json.NewDecoder(r.Body).Decode(&ev)
+		if ev.Summary == "Rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":400,"message":"bad event"}}`))
+			return
+		}
+		inserted = append(inserted, ev.Summary)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "evt-" + ev.Summary, "summary": ev.Summary})
+	}))
+	defer server.Close()
+
+	svc, err := calendar.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+	calendarServiceForTest = svc
+	defer func() { calendarServiceForTest = nil }()
+
+	path := t.TempDir() + "/events.csv"
+	csvData := "summary,start,end\n" +
+		"Good,2026-05-04 09:00,2026-05-04 10:00\n" +
+		"Bad time,not-a-time,2026-05-04 10:00\n" +
+		"Rejected,2026-05-04 11:00,2026-05-04 12:00\n"
+	if err := os.WriteFile(path, []byte(csvData), 0o600); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+
+	cmd := &cobra.Command{Use: "import-events", RunE: runCalendarImportEvents}
+	cmd.Flags().String("file", "", "")
+	cmd.Flags().String("calendar-id", "primary", "")
+	cmd.Flags().String("send-updates", "none", "")
+	_ = cmd.Flags().Set("file", path)
+
+	out, runErr := captureStdout(t, func() error { return cmd.RunE(cmd, nil) })
+	if runErr != nil {
+		t.Fatalf("import-events returned error: %v\noutput: %s", runErr, out)
+	}
+
+	var result struct {
+		Count       int                      `json:"count"`
+		FailedCount int                      `json:"failed_count"`
+		Failed      []map[string]interface{} `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\nraw: %s", err, out)
+	}
+	if result.Count != 1 || result.FailedCount != 2 {
+		t.Errorf("count/failed_count = %d/%d, want 1/2", result.Count, result.FailedCount)
+	}
+	if len(inserted) != 1 || inserted[0] != "Good" {
+		t.Errorf("expected only 'Good' inserted, got %v", inserted)
+	}
+	if sendUpdates != "none" {
+		t.Errorf("sendUpdates = %q, want none", sendUpdates)
+	}
+	if len(result.Failed) == 2 && result.Failed[0]["row"] != float64(2) {
+		t.Errorf("expected first failure on row 2, got %v", result.Failed[0]["row"])
+	}
+}
+
+func TestStatusEventRange(t *testing.T) {
+	start, end, err := statusEventRange("2026-08-03", "2026-08-05")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := end.Sub(start); got != 72*time.Hour {
+		t.Errorf("plain --to date should be inclusive; span = %v, want 72h", got)
+	}
+
+	start, end, err = statusEventRange("2026-08-03 09:00", "2026-08-03 11:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := end.Sub(start); got != 150*time.Minute {
+		t.Errorf("timed span = %v, want 2h30m", got)
+	}
+
+	if _, _, err := statusEventRange("2026-08-03 11:00", "2026-08-03 09:00"); err == nil {
+		t.Error("expected error when --to is before --from")
+	}
+	if _, _, err := statusEventRange("soon", "2026-08-03"); err == nil {
+		t.Error("expected error for invalid --from")
+	}
+}
+
+func TestCalendarSetOOO_MockServer(t *testing.T) {
+	var got calendar.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/calendars/primary/events" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "ooo-1", "eventType": got.EventType, "summary": got.Summary})
+	}))
+	defer server.Close()
+
+	svc, err := calendar.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create calendar service: %v", err)
+	}
+	calendarServiceForTest = svc
+	defer func() { calendarServiceForTest = nil }()
+
+	cmd := &cobra.Command{Use: "set-ooo", RunE: runCalendarSetOOO}
+	cmd.Flags().String("from", "", "")
+	cmd.Flags().String("to", "", "")
+	cmd.Flags().String("title", "Out of office", "")
+	cmd.Flags().Bool("decline-meetings", false, "")
+	cmd.Flags().String("message", "", "")
+	_ = cmd.Flags().Set("from", "2026-08-03")
+	_ = cmd.Flags().Set("to", "2026-08-07")
+	_ = cmd.Flags().Set("decline-meetings", "true")
+	_ = cmd.Flags().Set("message", "Away")
+
+	out, runErr := captureStdout(t, func() error { return cmd.RunE(cmd, nil) })
+	if runErr != nil {
+		t.Fatalf("set-ooo returned error: %v\noutput: %s", runErr, out)
+	}
+
+	if got.EventType != "outOfOffice" || got.Summary != "Out of office" {
+		t.Errorf("unexpected event: type=%q summary=%q", got.EventType, got.Summary)
+	}
+	if got.Start == nil || got.Start.DateTime == "" || got.Start.Date != "" {
+		t.Errorf("expected a timed start, got %+v", got.Start)
+	}
+	if got.OutOfOfficeProperties == nil ||
+		got.OutOfOfficeProperties.AutoDeclineMode != "declineAllConflictingInvitations" ||
+		got.OutOfOfficeProperties.DeclineMessage != "Away" {
+		t.Errorf("unexpected out-of-office properties: %+v", got.OutOfOfficeProperties)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\nraw: %s", err, out)
+	}
+	if result["status"] != "created" || result["id"] != "ooo-1" || result["event_type"] != "outOfOffice" {
+		t.Errorf("unexpected output: %v", result)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -9,13 +10,19 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/omriariav/workspace-cli/internal/auth"
 	"github.com/omriariav/workspace-cli/internal/client"
+	"github.com/omriariav/workspace-cli/internal/printer"
+	"github.com/omriariav/workspace-cli/internal/snooze"
 	"github.com/omriariav/workspace-cli/internal/spacecache"
 	"github.com/omriariav/workspace-cli/internal/usercache"
 	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/chat/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/people/v1"
 )
 
@@ -97,9 +104,20 @@ var chatGetCmd = &cobra.Command{
 var chatUpdateCmd = &cobra.Command{
 	Use:   "update <message-name>",
 	Short: "Update a message",
-	Long:  "Updates the text of an existing message.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runChatUpdate,
+	Long: `Updates the text of an existing message.
+
+With --if-unchanged-since, the message is fetched first and the update is
+aborted if it was edited after the given RFC3339 time (its last update time,
+or its create time if it was never edited). On conflict the current
+last_update_time is reported and the command exits non-zero. The check and
+the update are separate calls, so a concurrent edit between them is still
+possible; the window is small but not zero.
+
+Examples:
+  gws chat update spaces/AAAA/messages/BBBB --text "Deploy finished"
+  gws chat update spaces/AAAA/messages/BBBB --text "Deploy 3/5" --if-unchanged-since 2026-10-17T09:30:00Z`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatUpdate,
 }
 
 var chatDeleteCmd = &cobra.Command{
@@ -162,9 +180,52 @@ var chatDeleteSpaceCmd = &cobra.Command{
 var chatUpdateSpaceCmd = &cobra.Command{
 	Use:   "update-space <space>",
 	Short: "Update a space",
-	Long:  "Updates a Chat space's display name or description.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runChatUpdateSpace,
+	Long: `Updates a Chat space's display name, description, or guidelines.
+
+Description and guidelines share the space_details field mask, so changing
+only one of them keeps the current value of the other.
+
+Examples:
+  gws chat update-space spaces/AAAA --display-name "Eng Team"
+  gws chat update-space spaces/AAAA --guidelines "Be kind. Use threads."`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatUpdateSpace,
+}
+
+var chatSetPermissionsCmd = &cobra.Command{
+	Use:   "set-permissions <space>",
+	Short: "Set who can post or reply in a space",
+	Long: `Sets who may post new messages (--who-can-post) or reply in threads
+(--who-can-reply) in a named space. MANAGERS_ONLY allows space owners and
+managers; ALL also allows regular members. Posting restricted to managers
+turns the space into an announcement space.
+
+Only spaces of type SPACE have permission settings; group chats and direct
+messages are rejected. You must be a manager of the space.
+
+Examples:
+  gws chat set-permissions spaces/AAAA --who-can-post MANAGERS_ONLY
+  gws chat set-permissions spaces/AAAA --who-can-post MANAGERS_ONLY --who-can-reply ALL
+  gws chat set-permissions AAAA --who-can-post ALL`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatSetPermissions,
+}
+
+var chatMyRoleCmd = &cobra.Command{
+	Use:   "my-role <space>",
+	Short: "Show your own membership and role in a space",
+	Long: `Looks up your own membership in a Chat space and reports your role
+(ROLE_MEMBER or ROLE_MANAGER) and when you joined. Your user ID is looked
+up with the People API and mapped to the space membership, as in leave.
+
+When you are not a member, member is false and no error is returned, so
+scripts can check "am I a manager here?" before manager-only operations.
+
+Examples:
+  gws chat my-role spaces/AAAA
+  gws chat my-role AAAA`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatMyRole,
 }
 
 var chatSearchSpacesCmd = &cobra.Command{
@@ -190,8 +251,17 @@ Examples:
 var chatSetupSpaceCmd = &cobra.Command{
 	Use:   "setup-space",
 	Short: "Set up a space with members",
-	Long:  "Creates a space and adds initial members in one call.",
-	RunE:  runChatSetupSpace,
+	Long: `Creates a space and adds initial members in one call.
+
+With --welcome, a first message is posted to the new space right after it is
+created, and its resource name is returned as welcome_message. If the post
+fails, the space is still reported (it already exists) together with
+welcome_error, and the command exits non-zero.
+
+Examples:
+  gws chat setup-space --display-name "Launch" --members users/111,users/222
+  gws chat setup-space --display-name "Launch" --members users/111 --welcome "Welcome aboard!"`,
+	RunE: runChatSetupSpace,
 }
 
 // --- Member Management ---
@@ -212,6 +282,25 @@ var chatAddMemberCmd = &cobra.Command{
 	RunE:  runChatAddMember,
 }
 
+var chatAddMembersCmd = &cobra.Command{
+	Use:   "add-members <space>",
+	Short: "Add many members to a space from a file",
+	Long: `Adds every user listed in --file to a Chat space. The file has one email
+address (or users/<id> resource name) per line; blank lines and lines
+starting with # are ignored, and duplicates are added once.
+
+Memberships are created concurrently (--concurrency workers) but no faster
+than --rate per second overall. Users who are already members are reported
+as already_member; other failures are reported per user and do not stop
+the batch.
+
+Examples:
+  gws chat add-members spaces/AAAA --file team.txt
+  gws chat add-members AAAA --file new-hires.txt --rate 2 --concurrency 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatAddMembers,
+}
+
 var chatRemoveMemberCmd = &cobra.Command{
 	Use:   "remove-member <member-name>",
 	Short: "Remove a member",
@@ -348,6 +437,229 @@ type only; otherwise --refresh rebuilds for all space types.`,
 	RunE: runChatFindSpace,
 }
 
+var chatUserSpacesCmd = &cobra.Command{
+	Use:   "user-spaces",
+	Short: "List cached spaces a user belongs to",
+	Long: `Looks up every space in the local space cache that lists the given user
+as a member (case-insensitive email match). Useful for offboarding reviews.
+
+The default 'gws chat build-cache' run only caches GROUP_CHAT; pass --refresh
+to rebuild the cache for all space types before looking up. Spaces whose
+member list could not be fetched are not matched and are reported in
+unresolved_spaces.`,
+	RunE: runChatUserSpaces,
+}
+
+var chatFindDuplicatesCmd = &cobra.Command{
+	Use:   "find-duplicates",
+	Short: "Find cached spaces with identical members",
+	Long: `Groups spaces in the local space cache that have exactly the same members
+(case-insensitive email match), which usually means the same conversation was
+started more than once, and lists spaces with one or no human members. Each
+group is keyed by a stable signature of its sorted member list.
+
+The default 'gws chat build-cache' run only caches GROUP_CHAT; pass --refresh
+to rebuild the cache for all space types first (or only --type, when set).
+Spaces whose member list could not be fetched are never grouped and are
+counted in unresolved_spaces.
+
+Examples:
+  gws chat find-duplicates
+  gws chat find-duplicates --type GROUP_CHAT --refresh`,
+	RunE: runChatFindDuplicates,
+}
+
+var chatActivityCmd = &cobra.Command{
+	Use:   "activity <space-id>",
+	Short: "Summarize recent activity in a space",
+	Long: `Pages messages created in the last --days and reports message counts by
+sender (top posters), messages per day (UTC), and the number of distinct
+threads. Useful for spotting quiet or noisy spaces without exporting every
+message.
+
+Examples:
+  gws chat activity spaces/AAAA --days 7
+  gws chat activity AAAA --days 30 --humans-only --top 5
+  gws chat activity spaces/AAAA --resolve-senders`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatActivity,
+}
+
+var chatDigestCmd = &cobra.Command{
+	Use:   "digest <space-id>",
+	Short: "Post a digest of recent activity in a space",
+	Long: `Summarizes the messages posted in a space since --since (message, thread,
+and sender counts, the busiest threads, and the most active senders) as a
+formatted text message and posts it to --post-to.
+
+--post-to takes a space, or a thread (spaces/X/threads/Y) to reply in it.
+--dry-run prints the digest text without posting, and needs no --post-to.
+A space snoozed with 'gws chat snooze' is skipped (status "skipped" with
+snoozed_until) unless --include-snoozed is set.
+Sender names are resolved from the space's membership unless
+--resolve-senders=false.
+
+Examples:
+  gws chat digest spaces/AAAA --since 24h --dry-run
+  gws chat digest spaces/AAAA --since 24h --post-to spaces/BBBB
+  gws chat digest AAAA --since 7d --humans-only --post-to spaces/BBBB/threads/CCCC`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatDigest,
+}
+
+var chatBroadcastCmd = &cobra.Command{
+	Use:   "broadcast",
+	Short: "Send the same message to multiple spaces",
+	Long: `Sends --text to every space in --spaces, or to every space of a type
+with --all-type. Messages are sent concurrently (--concurrency workers) but
+no faster than --rate messages per second overall, to stay under Chat API
+write quotas.
+
+A failure in one space does not stop the others; each space is reported
+with its created message name or its error.
+
+Examples:
+  gws chat broadcast --spaces spaces/AAA,spaces/BBB --text "Deploy at 5pm"
+  gws chat broadcast --all-type SPACE --text "Office closed Friday" --rate 2`,
+	Args: cobra.NoArgs,
+	RunE: runChatBroadcast,
+}
+
+var chatLeaveCmd = &cobra.Command{
+	Use:   "leave <space>",
+	Short: "Leave a space",
+	Long: `Removes your own membership from a Chat space. Your user ID is looked up
+with the People API and mapped to the space membership.
+
+Direct messages cannot be left. Group chats and some managed spaces may also
+refuse; the API error is reported as-is.
+
+Examples:
+  gws chat leave spaces/AAAA
+  gws chat leave AAAA`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatLeave,
+}
+
+var chatUnreadCountsCmd = &cobra.Command{
+	Use:   "unread-counts",
+	Short: "Count unread messages per space",
+	Long: `Counts unread messages in every space you belong to (or every space of
+--type). For each space, the read state's last read time is compared with the
+messages created after it; counting stops at --cap per space, and capped
+counts are flagged.
+
+Spaces are checked concurrently (--concurrency workers), starting no more than
+--rate spaces per second. Spaces with no unread messages are left out; the
+rest are sorted by unread count, busiest first.
+
+Examples:
+  gws chat unread-counts
+  gws chat unread-counts --type SPACE --top 10
+  gws chat unread-counts --cap 500 --concurrency 8 --rate 10`,
+	Args: cobra.NoArgs,
+	RunE: runChatUnreadCounts,
+}
+
+var chatLinkCmd = &cobra.Command{
+	Use:   "link <message-name>",
+	Short: "Get the web link to a message",
+	Long: `Builds the Google Chat web permalink for a message, for pasting into other
+systems. Links use the format https://chat.google.com/room/<space>/<thread>/<message>.
+
+Server-assigned message IDs ("<thread>.<message>") are converted locally
+with no API call. Client-assigned IDs (client-...) are looked up once to
+find the message's server ID.
+
+Examples:
+  gws chat link spaces/AAAA/messages/r9sgTEtHmEU.r9sgTEtHmEU
+  gws chat link spaces/AAAA/messages/client-deploy-42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatLink,
+}
+
+var chatSpaceLinkCmd = &cobra.Command{
+	Use:   "space-link <space>",
+	Short: "Get the web link to a space",
+	Long: `Builds the Google Chat web link for a space, group chat, or direct
+message: https://chat.google.com/room/<space>. Runs locally with no API call.
+
+Examples:
+  gws chat space-link spaces/AAAA
+  gws chat space-link AAAA`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatSpaceLink,
+}
+
+var chatSnoozeCmd = &cobra.Command{
+	Use:   "snooze <space>",
+	Short: "Snooze a space in unread triage",
+	Long: `Snoozes a space locally until --until. The Chat API has no mute control,
+so the snooze is recorded in chat-snoozes.json in the gws config directory
+and only affects gws: unread-counts and digest skip snoozed spaces, and
+unread reports the snooze. Nothing changes on the server or in other Chat clients.
+
+--until takes a duration (8h, 3d), a date (snoozed until that day starts,
+local time), "YYYY-MM-DD HH:MM", or an RFC3339 timestamp. Snoozing a space
+again replaces its snooze.
+
+Examples:
+  gws chat snooze spaces/AAAA --until 3d
+  gws chat snooze AAAA --until 2026-10-20`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatSnooze,
+}
+
+var chatUnsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze <space>",
+	Short: "Remove a space's snooze",
+	Long: `Removes a local snooze set with 'gws chat snooze' before it expires.
+
+Examples:
+  gws chat unsnooze spaces/AAAA`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChatUnsnooze,
+}
+
+var chatSnoozedCmd = &cobra.Command{
+	Use:   "snoozed",
+	Short: "List snoozed spaces",
+	Long: `Lists the spaces snoozed with 'gws chat snooze', soonest to expire first.
+Expired snoozes are removed from the snooze file. Runs locally with no API
+call.
+
+Examples:
+  gws chat snoozed`,
+	Args: cobra.NoArgs,
+	RunE: runChatSnoozed,
+}
+
+var chatDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check Chat setup, auth, and permissions",
+	Long: `Runs a series of read-only checks against the Chat API and reports each as
+ok, warn, fail, or skip, with a remedy for anything that is not ok:
+
+  credentials  the stored OAuth token can be refreshed
+  auth_mode    how gws calls Chat (always as the signed-in user)
+  scopes       the chat service was granted at login
+  identity     the signed-in user's Chat ID and email (People API)
+  list_spaces  spaces can be listed, and at least one is visible
+  messages     messages can be read in the first space
+  read_state   read state can be read in the first space (used by unread)
+
+Common setup problems are recognized from the API's errors: the Chat API not
+enabled in the Cloud project, no Chat app configured (the API answers 404),
+and missing scopes. Exits non-zero when any check fails; warnings alone do
+not fail.
+
+Examples:
+  gws chat doctor
+  gws chat doctor --format text`,
+	Args: cobra.NoArgs,
+	RunE: runChatDoctor,
+}
+
 func init() {
 	rootCmd.AddCommand(chatCmd)
 	chatCmd.AddCommand(chatListCmd)
@@ -370,6 +682,7 @@ func init() {
 	chatCmd.AddCommand(chatSetupSpaceCmd)
 	chatCmd.AddCommand(chatGetMemberCmd)
 	chatCmd.AddCommand(chatAddMemberCmd)
+	chatCmd.AddCommand(chatAddMembersCmd)
 	chatCmd.AddCommand(chatRemoveMemberCmd)
 	chatCmd.AddCommand(chatUpdateMemberCmd)
 	chatCmd.AddCommand(chatReadStateCmd)
@@ -384,6 +697,21 @@ func init() {
 	chatCmd.AddCommand(chatBuildCacheCmd)
 	chatCmd.AddCommand(chatFindGroupCmd)
 	chatCmd.AddCommand(chatFindSpaceCmd)
+	chatCmd.AddCommand(chatUserSpacesCmd)
+	chatCmd.AddCommand(chatFindDuplicatesCmd)
+	chatCmd.AddCommand(chatActivityCmd)
+	chatCmd.AddCommand(chatDigestCmd)
+	chatCmd.AddCommand(chatBroadcastCmd)
+	chatCmd.AddCommand(chatLeaveCmd)
+	chatCmd.AddCommand(chatUnreadCountsCmd)
+	chatCmd.AddCommand(chatLinkCmd)
+	chatCmd.AddCommand(chatSpaceLinkCmd)
+	chatCmd.AddCommand(chatSetPermissionsCmd)
+	chatCmd.AddCommand(chatMyRoleCmd)
+	chatCmd.AddCommand(chatSnoozeCmd)
+	chatCmd.AddCommand(chatUnsnoozeCmd)
+	chatCmd.AddCommand(chatSnoozedCmd)
+	chatCmd.AddCommand(chatDoctorCmd)
 
 	// List flags
 	chatListCmd.Flags().String("filter", "", "Filter spaces (e.g. 'spaceType = \"SPACE\"')")
@@ -424,6 +752,7 @@ func init() {
 
 	// Update flags
 	chatUpdateCmd.Flags().String("text", "", "New message text (required)")
+	chatUpdateCmd.Flags().String("if-unchanged-since", "", "Abort if the message was edited after this RFC3339 time")
 	chatUpdateCmd.MarkFlagRequired("text")
 
 	// Delete flags
@@ -447,6 +776,11 @@ func init() {
 	// Update space flags
 	chatUpdateSpaceCmd.Flags().String("display-name", "", "New display name")
 	chatUpdateSpaceCmd.Flags().String("description", "", "New description")
+	chatUpdateSpaceCmd.Flags().String("guidelines", "", "New space guidelines (rules shown to members)")
+
+	// Set-permissions flags
+	chatSetPermissionsCmd.Flags().String("who-can-post", "", "Who can post messages: MANAGERS_ONLY or ALL")
+	chatSetPermissionsCmd.Flags().String("who-can-reply", "", "Who can reply in threads: MANAGERS_ONLY or ALL")
 
 	// Search spaces flags
 	chatSearchSpacesCmd.Flags().String("query", "", "Search query (required)")
@@ -470,12 +804,20 @@ func init() {
 	chatSetupSpaceCmd.Flags().String("display-name", "", "Space display name (required for SPACE type)")
 	chatSetupSpaceCmd.Flags().String("type", "SPACE", "Space type: SPACE, GROUP_CHAT, or DIRECT_MESSAGE")
 	chatSetupSpaceCmd.Flags().String("members", "", "Comma-separated user resource names")
+	chatSetupSpaceCmd.Flags().String("welcome", "", "Post this message to the space once it is created")
 
 	// Add member flags
 	chatAddMemberCmd.Flags().String("user", "", "User resource name (required, e.g. users/123)")
 	chatAddMemberCmd.Flags().String("role", "ROLE_MEMBER", "Member role: ROLE_MEMBER or ROLE_MANAGER")
 	chatAddMemberCmd.MarkFlagRequired("user")
 
+	// Add-members flags
+	chatAddMembersCmd.Flags().String("file", "", "File with one email or users/<id> per line (required)")
+	chatAddMembersCmd.Flags().String("role", "ROLE_MEMBER", "Member role: ROLE_MEMBER or ROLE_MANAGER")
+	chatAddMembersCmd.Flags().Int("concurrency", 4, "Number of memberships to create in parallel")
+	chatAddMembersCmd.Flags().Float64("rate", 2, "Maximum memberships per second across all workers (0 = unlimited)")
+	chatAddMembersCmd.MarkFlagRequired("file")
+
 	// Update member flags
 	chatUpdateMemberCmd.Flags().String("role", "", "New role: ROLE_MEMBER or ROLE_MANAGER (required)")
 	chatUpdateMemberCmd.MarkFlagRequired("role")
@@ -510,6 +852,50 @@ func init() {
 	chatFindSpaceCmd.Flags().String("type", "", "Filter by space type: SPACE, GROUP_CHAT, or DIRECT_MESSAGE")
 	chatFindSpaceCmd.Flags().Bool("refresh", false, "Rebuild cache before searching")
 	chatFindSpaceCmd.MarkFlagRequired("name")
+
+	// User-spaces flags
+	chatUserSpacesCmd.Flags().String("user", "", "Member email address to look up (required)")
+	chatUserSpacesCmd.Flags().Bool("refresh", false, "Rebuild cache for all space types before looking up")
+
+	// Find-duplicates flags
+	chatFindDuplicatesCmd.Flags().String("type", "", "Only check spaces of this type: SPACE, GROUP_CHAT, or DIRECT_MESSAGE")
+	chatFindDuplicatesCmd.Flags().Bool("refresh", false, "Rebuild cache before checking")
+
+	// Activity flags
+	chatActivityCmd.Flags().Int("days", 7, "Number of days of history to summarize")
+	chatActivityCmd.Flags().Bool("humans-only", false, "Ignore messages sent by bots/apps")
+	chatActivityCmd.Flags().Int("top", 10, "Number of top senders to return (0 = all)")
+	chatActivityCmd.Flags().Bool("resolve-senders", false, "Resolve sender display names via space membership")
+
+	// Digest flags
+	chatDigestCmd.Flags().String("since", "24h", "Window to summarize: duration (24h, 7d) or RFC3339 timestamp")
+	chatDigestCmd.Flags().String("post-to", "", "Space or thread to post the digest to (required unless --dry-run)")
+	chatDigestCmd.Flags().Int("top", 5, "Number of top threads and senders to list")
+	chatDigestCmd.Flags().Bool("humans-only", false, "Ignore messages sent by bots/apps")
+	chatDigestCmd.Flags().Bool("resolve-senders", true, "Resolve sender display names via space membership")
+	chatDigestCmd.Flags().Bool("dry-run", false, "Print the digest without posting it")
+	chatDigestCmd.Flags().Bool("include-snoozed", false, "Digest the space even if it is snoozed with 'gws chat snooze'")
+
+	// Broadcast flags
+	chatBroadcastCmd.Flags().String("spaces", "", "Comma-separated space IDs or names")
+	chatBroadcastCmd.Flags().String("all-type", "", "Send to every space of this type: SPACE, GROUP_CHAT, DIRECT_MESSAGE")
+	chatBroadcastCmd.Flags().String("text", "", "Message text (required)")
+	chatBroadcastCmd.Flags().Int("concurrency", 4, "Number of spaces to send to in parallel")
+	chatBroadcastCmd.Flags().Float64("rate", 1, "Maximum messages per second across all workers (0 = unlimited)")
+	chatBroadcastCmd.MarkFlagRequired("text")
+
+	// Unread-counts flags
+	chatUnreadCountsCmd.Flags().String("type", "", "Only check spaces of this type: SPACE, GROUP_CHAT, DIRECT_MESSAGE (default: all)")
+	chatUnreadCountsCmd.Flags().Int64("cap", 100, "Stop counting a space's unread messages at this number")
+	chatUnreadCountsCmd.Flags().Int("top", 0, "Only return the N spaces with the most unread messages (0 = all)")
+	chatUnreadCountsCmd.Flags().Int("concurrency", 4, "Number of spaces to check in parallel")
+	chatUnreadCountsCmd.Flags().Float64("rate", 5, "Maximum spaces started per second across all workers (0 = unlimited)")
+	chatUnreadCountsCmd.Flags().Bool("include-snoozed", false, "Also check spaces snoozed with 'gws chat snooze'")
+
+	// Snooze flags
+	chatSnoozeCmd.Flags().String("until", "", "When the snooze ends: duration (8h, 3d), date, 'YYYY-MM-DD HH:MM', or RFC3339 (required)")
+	chatSnoozeCmd.MarkFlagRequired("until")
+	chatUserSpacesCmd.MarkFlagRequired("user")
 }
 
 // ensureSpaceName normalizes a space identifier to its full resource name.
@@ -1398,18 +1784,52 @@ func runChatUpdate(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
-	factory, err := client.NewFactory(ctx)
-	if err != nil {
-		return p.PrintError(err)
+	messageName := args[0]
+	text, _ := cmd.Flags().GetString("text")
+	sinceStr, _ := cmd.Flags().GetString("if-unchanged-since")
+
+	var since time.Time
+	if sinceStr != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			return usageErrorf("invalid --if-unchanged-since %q: must be RFC3339 (e.g. 2026-10-17T09:30:00Z)", sinceStr)
+		}
 	}
 
-	svc, err := factory.Chat()
-	if err != nil {
-		return p.PrintError(err)
+	svc := chatServiceForTest
+	if svc == nil {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
 	}
 
-	messageName := args[0]
-	text, _ := cmd.Flags().GetString("text")
+	if sinceStr != "" {
+		current, err := svc.Spaces.Messages.Get(messageName).Context(ctx).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get message: %w", err))
+		}
+		changed, lastUpdate, err := chatMessageChangedSince(current, since)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		if changed {
+			if err := p.Print(map[string]interface{}{
+				"status":             "conflict",
+				"name":               current.Name,
+				"last_update_time":   lastUpdate,
+				"if_unchanged_since": sinceStr,
+			}); err != nil {
+				return err
+			}
+			return &printer.AlreadyPrintedError{Err: fmt.Errorf("message %s was modified at %s, after %s", messageName, lastUpdate, sinceStr)}
+		}
+	}
 
 	msg := &chat.Message{
 		Text: text,
@@ -1420,12 +1840,34 @@ func runChatUpdate(cmd *cobra.Command, args []string) error {
 		return p.PrintError(fmt.Errorf("failed to update message: %w", err))
 	}
 
-	return p.Print(map[string]interface{}{
+	result := map[string]interface{}{
 		"status":      "updated",
 		"name":        updated.Name,
 		"text":        updated.Text,
 		"create_time": updated.CreateTime,
-	})
+	}
+	if updated.LastUpdateTime != "" {
+		result["last_update_time"] = updated.LastUpdateTime
+	}
+	return p.Print(result)
+}
+
+// chatMessageChangedSince reports whether msg was edited after since. A
+// message that was never edited has no lastUpdateTime, so its create time
+// is used. It also returns the timestamp it compared.
+func chatMessageChangedSince(msg *chat.Message, since time.Time) (bool, string, error) {
+	stamp := msg.LastUpdateTime
+	if stamp == "" {
+		stamp = msg.CreateTime
+	}
+	if stamp == "" {
+		return false, "", fmt.Errorf("message %s has no update or create time", msg.Name)
+	}
+	t, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return false, stamp, fmt.Errorf("failed to parse message time %q: %w", stamp, err)
+	}
+	return t.After(since), stamp, nil
 }
 
 func runChatDelete(cmd *cobra.Command, args []string) error {
@@ -1618,6 +2060,9 @@ func mapSpaceToOutput(space *chat.Space) map[string]interface{} {
 	if space.SpaceDetails != nil && space.SpaceDetails.Description != "" {
 		result["description"] = space.SpaceDetails.Description
 	}
+	if space.SpaceDetails != nil && space.SpaceDetails.Guidelines != "" {
+		result["guidelines"] = space.SpaceDetails.Guidelines
+	}
 	if space.CreateTime != "" {
 		result["create_time"] = space.CreateTime
 	}
@@ -1717,23 +2162,48 @@ func runChatDeleteSpace(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// mergeSpaceDetails overlays the non-empty description and guidelines on
+// the current details. The API only accepts space_details as a whole, so
+// the untouched field must be resent to avoid clearing it.
+func mergeSpaceDetails(current *chat.SpaceDetails, description, guidelines string) *chat.SpaceDetails {
+	details := &chat.SpaceDetails{}
+	if current != nil {
+		details.Description = current.Description
+		details.Guidelines = current.Guidelines
+	}
+	if description != "" {
+		details.Description = description
+	}
+	if guidelines != "" {
+		details.Guidelines = guidelines
+	}
+	return details
+}
+
 func runChatUpdateSpace(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
-	factory, err := client.NewFactory(ctx)
-	if err != nil {
-		return p.PrintError(err)
-	}
-
-	svc, err := factory.Chat()
-	if err != nil {
-		return p.PrintError(err)
-	}
-
 	spaceName := ensureSpaceName(args[0])
 	displayName, _ := cmd.Flags().GetString("display-name")
 	description, _ := cmd.Flags().GetString("description")
+	guidelines, _ := cmd.Flags().GetString("guidelines")
+
+	if displayName == "" && description == "" && guidelines == "" {
+		return usageErrorf("at least one of --display-name, --description, or --guidelines is required")
+	}
+
+	svc := chatServiceForTest
+	if svc == nil {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
 
 	space := &chat.Space{}
 	var masks []string
@@ -1742,15 +2212,19 @@ func runChatUpdateSpace(cmd *cobra.Command, args []string) error {
 		space.DisplayName = displayName
 		masks = append(masks, "display_name")
 	}
-	if description != "" {
-		space.SpaceDetails = &chat.SpaceDetails{Description: description}
+	if description != "" || guidelines != "" {
+		var current *chat.SpaceDetails
+		if description == "" || guidelines == "" {
+			existing, err := svc.Spaces.Get(spaceName).Context(ctx).Do()
+			if err != nil {
+				return p.PrintError(fmt.Errorf("failed to get space: %w", err))
+			}
+			current = existing.SpaceDetails
+		}
+		space.SpaceDetails = mergeSpaceDetails(current, description, guidelines)
 		masks = append(masks, "space_details")
 	}
 
-	if len(masks) == 0 {
-		return usageErrorf("at least one of --display-name or --description is required")
-	}
-
 	updated, err := svc.Spaces.Patch(spaceName, space).UpdateMask(strings.Join(masks, ",")).Context(ctx).Do()
 	if err != nil {
 		return p.PrintError(fmt.Errorf("failed to update space: %w", err))
@@ -1855,19 +2329,10 @@ func runChatSetupSpace(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
-	factory, err := client.NewFactory(ctx)
-	if err != nil {
-		return p.PrintError(err)
-	}
-
-	svc, err := factory.Chat()
-	if err != nil {
-		return p.PrintError(err)
-	}
-
 	displayName, _ := cmd.Flags().GetString("display-name")
 	spaceType, _ := cmd.Flags().GetString("type")
 	membersStr, _ := cmd.Flags().GetString("members")
+	welcome, _ := cmd.Flags().GetString("welcome")
 
 	// Validate type
 	switch spaceType {
@@ -1888,6 +2353,18 @@ func runChatSetupSpace(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	svc := chatServiceForTest
+	if svc == nil {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
 	req := &chat.SetUpSpaceRequest{}
 
 	// API rejects displayName for DM and GROUP_CHAT types
@@ -1919,6 +2396,21 @@ func runChatSetupSpace(cmd *cobra.Command, args []string) error {
 
 	result := mapSpaceToOutput(space)
 	result["status"] = "created"
+
+	if welcome != "" {
+		msg, err := svc.Spaces.Messages.Create(space.Name, &chat.Message{Text: welcome}).Context(ctx).Do()
+		if err != nil {
+			// The space exists either way; report it so the caller can retry
+			// the post without creating a second space.
+			result["welcome_error"] = err.Error()
+			if perr := p.Print(result); perr != nil {
+				return perr
+			}
+			return &printer.AlreadyPrintedError{Err: fmt.Errorf("failed to post welcome message: %w", err)}
+		}
+		result["welcome_message"] = msg.Name
+	}
+
 	return p.Print(result)
 }
 
@@ -1982,6 +2474,128 @@ func runChatAddMember(cmd *cobra.Command, args []string) error {
 	return p.Print(result)
 }
 
+// parseMemberList reads one user per line: an email address, which becomes
+// users/<email>, or a users/<id> resource name. Blank lines and # comments
+// are skipped and repeated users are returned once.
+func parseMemberList(r io.Reader) ([]string, error) {
+	var users []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user := line
+		switch {
+		case strings.HasPrefix(line, "users/"):
+		case strings.Contains(line, "@") && !strings.ContainsAny(line, " \t,;"):
+			user = "users/" + line
+		default:
+			return nil, fmt.Errorf("line %d: %q is not an email address or users/<id>", lineNo, line)
+		}
+		key := strings.ToLower(user)
+		if !seen[key] {
+			seen[key] = true
+			users = append(users, user)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func runChatAddMembers(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceName := ensureSpaceName(args[0])
+	file, _ := cmd.Flags().GetString("file")
+	role, _ := cmd.Flags().GetString("role")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+
+	role = strings.ToUpper(role)
+	if role != "ROLE_MEMBER" && role != "ROLE_MANAGER" {
+		return usageErrorf("invalid --role %q: must be ROLE_MEMBER or ROLE_MANAGER", role)
+	}
+	if concurrency < 1 {
+		return usageErrorf("--concurrency must be at least 1, got %d", concurrency)
+	}
+	if rate < 0 {
+		return usageErrorf("--rate must not be negative")
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to open member file: %w", err))
+	}
+	users, err := parseMemberList(f)
+	f.Close()
+	if err != nil {
+		return usageErrorf("invalid member file %s: %v", file, err)
+	}
+	if len(users) == 0 {
+		return usageErrorf("member file %s lists no users", file)
+	}
+
+	svc := chatServiceForTest
+	if svc == nil {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	var interval time.Duration
+	if rate > 0 {
+		interval = time.Duration(float64(time.Second) / rate)
+	}
+
+	rows := make([]map[string]interface{}, len(users))
+	forEachRateLimited(ctx, len(users), concurrency, interval, func(ctx context.Context, i int) {
+		row := map[string]interface{}{"user": strings.TrimPrefix(users[i], "users/")}
+		membership := &chat.Membership{
+			Member: &chat.User{Name: users[i], Type: "HUMAN"},
+			Role:   role,
+		}
+		created, err := svc.Spaces.Members.Create(spaceName, membership).Context(ctx).Do()
+		var apiErr *googleapi.Error
+		switch {
+		case err == nil:
+			row["status"] = "added"
+			row["name"] = created.Name
+		case errors.As(err, &apiErr) && apiErr.Code == 409:
+			row["status"] = "already_member"
+		default:
+			row["status"] = "failed"
+			row["error"] = err.Error()
+		}
+		rows[i] = row
+	})
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row["status"].(string)]++
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":               "completed",
+		"space":                spaceName,
+		"results":              rows,
+		"added_count":          counts["added"],
+		"already_member_count": counts["already_member"],
+		"failed_count":         counts["failed"],
+	})
+}
+
 func runChatRemoveMember(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
@@ -2247,6 +2861,11 @@ func runChatUnread(cmd *cobra.Command, args []string) error {
 	if markRead {
 		result["marked_read"] = markedRead
 	}
+	if store, err := snooze.Load(snooze.DefaultPath()); err == nil {
+		if e, ok := store.Active(spaceName, time.Now()); ok {
+			result["snoozed_until"] = e.Until.Format(time.RFC3339)
+		}
+	}
 
 	return p.Print(result)
 }
@@ -2520,6 +3139,10 @@ func runChatFindGroup(cmd *cobra.Command, args []string) error {
 
 	cachePath := spacecache.DefaultPath()
 
+	if refresh && client.IsOffline(ctx) {
+		return p.PrintError(fmt.Errorf("--refresh needs network access: %w", client.ErrOffline))
+	}
+
 	if refresh {
 		factory, err := client.NewFactory(ctx)
 		if err != nil {
@@ -2622,6 +3245,10 @@ func runChatFindSpace(cmd *cobra.Command, args []string) error {
 
 	cachePath := spacecache.DefaultPath()
 
+	if refresh && client.IsOffline(ctx) {
+		return p.PrintError(fmt.Errorf("--refresh needs network access: %w", client.ErrOffline))
+	}
+
 	if refresh {
 		var chatSvc *chat.Service
 		var peopleSvc *people.Service
@@ -2712,7 +3339,248 @@ func runChatFindSpace(cmd *cobra.Command, args []string) error {
 	return p.Print(out)
 }
 
-// senderContext resolves sender display names and self markers for a single
+func runChatUserSpaces(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	rawUser, _ := cmd.Flags().GetString("user")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	user := strings.TrimSpace(rawUser)
+	if user == "" {
+		return usageErrorf("--user must not be empty")
+	}
+
+	cachePath := spacecache.DefaultPath()
+
+	if refresh && client.IsOffline(ctx) {
+		return p.PrintError(fmt.Errorf("--refresh needs network access: %w", client.ErrOffline))
+	}
+
+	if refresh {
+		var chatSvc *chat.Service
+		var peopleSvc *people.Service
+		if chatServiceForTest != nil {
+			chatSvc = chatServiceForTest
+			peopleSvc = peopleServiceForTest
+		} else {
+			factory, err := client.NewFactory(ctx)
+			if err != nil {
+				return p.PrintError(err)
+			}
+			chatSvc, err = factory.Chat()
+			if err != nil {
+				return p.PrintError(err)
+			}
+			peopleSvc, err = factory.People()
+			if err != nil {
+				return p.PrintError(err)
+			}
+		}
+
+		cache, err := spacecache.Build(ctx, chatSvc, peopleSvc, "all", func(current, total int) {
+			fmt.Fprintf(os.Stderr, "\rScanning spaces... %d/%d", current, total)
+		})
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to build cache: %w", err))
+		}
+		fmt.Fprintln(os.Stderr)
+
+		if err := spacecache.Save(cachePath, cache); err != nil {
+			return p.PrintError(fmt.Errorf("failed to save cache: %w", err))
+		}
+	}
+
+	cache, err := spacecache.Load(cachePath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to load cache: %w", err))
+	}
+
+	if len(cache.Spaces) == 0 {
+		return p.PrintError(fmt.Errorf("no cache found — run 'gws chat build-cache' first or pass --refresh"))
+	}
+
+	spaceNames := spacecache.MemberIndex(cache)[strings.ToLower(user)]
+
+	results := make([]map[string]interface{}, 0, len(spaceNames))
+	for _, name := range spaceNames {
+		entry := cache.Spaces[name]
+		results = append(results, map[string]interface{}{
+			"space":        name,
+			"type":         entry.Type,
+			"display_name": entry.DisplayName,
+			"member_count": entry.MemberCount,
+		})
+	}
+
+	unresolved := 0
+	for _, entry := range cache.Spaces {
+		if entry.MembersUnresolved {
+			unresolved++
+		}
+	}
+
+	out := map[string]interface{}{
+		"user":   user,
+		"spaces": results,
+		"count":  len(results),
+	}
+	if unresolved > 0 {
+		out["unresolved_spaces"] = unresolved
+	}
+	return p.Print(out)
+}
+
+func runChatFindDuplicates(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceType, _ := cmd.Flags().GetString("type")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	spaceType = strings.ToUpper(strings.TrimSpace(spaceType))
+	switch spaceType {
+	case "", "SPACE", "GROUP_CHAT", "DIRECT_MESSAGE":
+	default:
+		return usageErrorf("invalid --type %q: must be SPACE, GROUP_CHAT, or DIRECT_MESSAGE", spaceType)
+	}
+
+	cachePath := spacecache.DefaultPath()
+
+	if refresh && client.IsOffline(ctx) {
+		return p.PrintError(fmt.Errorf("--refresh needs network access: %w", client.ErrOffline))
+	}
+
+	if refresh {
+		var chatSvc *chat.Service
+		var peopleSvc *people.Service
+		if chatServiceForTest != nil {
+			chatSvc = chatServiceForTest
+			peopleSvc = peopleServiceForTest
+		} else {
+			factory, err := client.NewFactory(ctx)
+			if err != nil {
+				return p.PrintError(err)
+			}
+			chatSvc, err = factory.Chat()
+			if err != nil {
+				return p.PrintError(err)
+			}
+			peopleSvc, err = factory.People()
+			if err != nil {
+				return p.PrintError(err)
+			}
+		}
+
+		buildType := spaceType
+		if buildType == "" {
+			buildType = "all"
+		}
+		cache, err := spacecache.Build(ctx, chatSvc, peopleSvc, buildType, func(current, total int) {
+			fmt.Fprintf(os.Stderr, "\rScanning spaces... %d/%d", current, total)
+		})
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to build cache: %w", err))
+		}
+		fmt.Fprintln(os.Stderr)
+
+		if err := spacecache.Save(cachePath, cache); err != nil {
+			return p.PrintError(fmt.Errorf("failed to save cache: %w", err))
+		}
+	}
+
+	cache, err := spacecache.Load(cachePath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to load cache: %w", err))
+	}
+
+	if len(cache.Spaces) == 0 {
+		return p.PrintError(fmt.Errorf("no cache found — run 'gws chat build-cache' first or pass --refresh"))
+	}
+
+	describe := func(name string) map[string]interface{} {
+		entry := cache.Spaces[name]
+		return map[string]interface{}{
+			"space":        name,
+			"type":         entry.Type,
+			"display_name": entry.DisplayName,
+		}
+	}
+
+	groupsBySig := spacecache.GroupByMembers(cache, spaceType)
+	groups := make([]map[string]interface{}, 0, len(groupsBySig))
+	for sig, names := range groupsBySig {
+		spaces := make([]map[string]interface{}, 0, len(names))
+		for _, name := range names {
+			spaces = append(spaces, describe(name))
+		}
+		members := append([]string(nil), cache.Spaces[names[0]].Members...)
+		sort.Strings(members)
+		groups = append(groups, map[string]interface{}{
+			"signature":    sig,
+			"members":      members,
+			"member_count": len(members),
+			"spaces":       spaces,
+			"count":        len(spaces),
+		})
+	}
+	// Largest groups first, then by first space name for stable output
+	sort.Slice(groups, func(i, j int) bool {
+		ci, cj := groups[i]["count"].(int), groups[j]["count"].(int)
+		if ci != cj {
+			return ci > cj
+		}
+		return groupFirstSpace(groups[i]) < groupFirstSpace(groups[j])
+	})
+
+	var lonelyNames []string
+	scanned, unresolved := 0, 0
+	for name, entry := range cache.Spaces {
+		if spaceType != "" && !strings.EqualFold(entry.Type, spaceType) {
+			continue
+		}
+		scanned++
+		if entry.MembersUnresolved {
+			unresolved++
+			continue
+		}
+		if len(entry.Members) < 2 {
+			lonelyNames = append(lonelyNames, name)
+		}
+	}
+	sort.Strings(lonelyNames)
+	lonely := make([]map[string]interface{}, 0, len(lonelyNames))
+	for _, name := range lonelyNames {
+		members := cache.Spaces[name].Members
+		if members == nil {
+			members = []string{}
+		}
+		entry := describe(name)
+		entry["members"] = members
+		entry["member_count"] = len(members)
+		lonely = append(lonely, entry)
+	}
+
+	out := map[string]interface{}{
+		"duplicate_groups":      groups,
+		"duplicate_group_count": len(groups),
+		"single_member_spaces":  lonely,
+		"single_member_count":   len(lonely),
+		"spaces_scanned":        scanned,
+	}
+	if unresolved > 0 {
+		out["unresolved_spaces"] = unresolved
+	}
+	return p.Print(out)
+}
+
+// groupFirstSpace returns the first space name of a find-duplicates group.
+func groupFirstSpace(group map[string]interface{}) string {
+	spaces := group["spaces"].([]map[string]interface{})
+	return spaces[0]["space"].(string)
+}
+
+// senderContext resolves sender display names and self markers for a single
 // space within one command invocation. Resolution is best-effort: failures
 // degrade to "no resolution" rather than failing the whole command. When
 // constructed via nilSenderContext (the default-path object), it makes no
@@ -2816,3 +3684,1498 @@ func spaceFromMessageName(name string) string {
 	}
 	return parts[0] + "/" + parts[1]
 }
+
+// chatActivity accumulates per-sender, per-day, and per-thread counts for
+// the activity summary.
+type chatActivity struct {
+	total       int
+	skipped     int
+	bySender    map[string]int
+	senderTypes map[string]string
+	byDay       map[string]int
+	threads     map[string]int
+	threadText  map[string]string
+}
+
+func newChatActivity() *chatActivity {
+	return &chatActivity{
+		bySender:    map[string]int{},
+		senderTypes: map[string]string{},
+		byDay:       map[string]int{},
+		threads:     map[string]int{},
+		threadText:  map[string]string{},
+	}
+}
+
+// add records one message. Bot messages are counted in skipped instead when
+// humansOnly is set.
+func (a *chatActivity) add(msg *chat.Message, humansOnly bool) {
+	if msg == nil {
+		return
+	}
+	sender := ""
+	senderType := ""
+	if msg.Sender != nil {
+		sender = msg.Sender.Name
+		senderType = msg.Sender.Type
+	}
+	if humansOnly && senderType == "BOT" {
+		a.skipped++
+		return
+	}
+
+	a.total++
+	a.bySender[sender]++
+	if senderType != "" {
+		a.senderTypes[sender] = senderType
+	}
+	if t, err := time.Parse(time.RFC3339Nano, msg.CreateTime); err == nil {
+		a.byDay[t.UTC().Format("2006-01-02")]++
+	}
+	if msg.Thread != nil && msg.Thread.Name != "" {
+		a.threads[msg.Thread.Name]++
+		if a.threadText[msg.Thread.Name] == "" {
+			a.threadText[msg.Thread.Name] = msg.Text
+		}
+	}
+}
+
+// topSenders returns senders ordered by message count (desc), ties broken by
+// resource name. top <= 0 returns every sender.
+func (a *chatActivity) topSenders(top int, displayNames map[string]string) []map[string]interface{} {
+	names := make([]string, 0, len(a.bySender))
+	for name := range a.bySender {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if a.bySender[names[i]] != a.bySender[names[j]] {
+			return a.bySender[names[i]] > a.bySender[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if top > 0 && len(names) > top {
+		names = names[:top]
+	}
+
+	senders := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		row := map[string]interface{}{
+			"sender": name,
+			"count":  a.bySender[name],
+		}
+		if t := a.senderTypes[name]; t != "" {
+			row["sender_type"] = t
+		}
+		if dn := displayNames[name]; dn != "" {
+			row["display_name"] = dn
+		}
+		senders = append(senders, row)
+	}
+	return senders
+}
+
+// topThreads returns threads ordered by message count (desc), ties broken by
+// resource name, each with the first line of its earliest message in the
+// window. top <= 0 returns every thread.
+func (a *chatActivity) topThreads(top int) []map[string]interface{} {
+	names := make([]string, 0, len(a.threads))
+	for name := range a.threads {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if a.threads[names[i]] != a.threads[names[j]] {
+			return a.threads[names[i]] > a.threads[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if top > 0 && len(names) > top {
+		names = names[:top]
+	}
+
+	threads := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		first, _, _ := strings.Cut(strings.TrimSpace(a.threadText[name]), "\n")
+		threads = append(threads, map[string]interface{}{
+			"thread":  name,
+			"count":   a.threads[name],
+			"snippet": truncateRunes(strings.TrimSpace(first), digestSnippetLength),
+		})
+	}
+	return threads
+}
+
+// perDay returns one entry per UTC day from since through now, inclusive,
+// so quiet days show up as zero instead of being omitted.
+func (a *chatActivity) perDay(since, now time.Time) []map[string]interface{} {
+	var days []map[string]interface{}
+	start := since.UTC().Truncate(24 * time.Hour)
+	end := now.UTC()
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		days = append(days, map[string]interface{}{
+			"date":  key,
+			"count": a.byDay[key],
+		})
+	}
+	return days
+}
+
+func runChatActivity(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceName := ensureSpaceName(args[0])
+	days, _ := cmd.Flags().GetInt("days")
+	humansOnly, _ := cmd.Flags().GetBool("humans-only")
+	top, _ := cmd.Flags().GetInt("top")
+	resolveSenders, _ := cmd.Flags().GetBool("resolve-senders")
+
+	if days <= 0 {
+		return usageErrorf("--days must be positive, got %d", days)
+	}
+	if top < 0 {
+		return usageErrorf("--top must not be negative, got %d", top)
+	}
+
+	now := time.Now()
+	if chatRecentNowForTest != nil {
+		now = chatRecentNowForTest()
+	}
+	since := now.AddDate(0, 0, -days)
+	sinceRFC := since.UTC().Format(time.RFC3339)
+
+	var (
+		svc       *chat.Service
+		peopleSvc *people.Service
+	)
+	if chatServiceForTest != nil {
+		svc = chatServiceForTest
+		peopleSvc = peopleServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+		if resolveSenders {
+			peopleSvc, _ = factory.PeopleProfile()
+		}
+	}
+
+	activity, err := collectChatActivity(ctx, svc, spaceName, since, humansOnly)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	senderCtx := nilSenderContext()
+	if resolveSenders {
+		senderCtx = resolveSendersForSpace(ctx, svc, peopleSvc, spaceName)
+	}
+
+	result := map[string]interface{}{
+		"space":           spaceName,
+		"since":           sinceRFC,
+		"days":            days,
+		"message_count":   activity.total,
+		"thread_count":    len(activity.threads),
+		"sender_count":    len(activity.bySender),
+		"top_senders":     activity.topSenders(top, senderCtx.displayNames),
+		"messages_by_day": activity.perDay(since, now),
+	}
+	if humansOnly {
+		result["bot_messages_skipped"] = activity.skipped
+	}
+	return p.Print(result)
+}
+
+// collectChatActivity pages every message created in spaceName after since
+// into a chatActivity.
+func collectChatActivity(ctx context.Context, svc *chat.Service, spaceName string, since time.Time, humansOnly bool) (*chatActivity, error) {
+	activity := newChatActivity()
+	filter := fmt.Sprintf(`createTime > "%s"`, since.UTC().Format(time.RFC3339))
+	var pageToken string
+	for {
+		call := svc.Spaces.Messages.List(spaceName).
+			PageSize(1000).
+			Filter(filter).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list messages: %w", err)
+		}
+		for _, msg := range resp.Messages {
+			activity.add(msg, humansOnly)
+		}
+		if resp.NextPageToken == "" {
+			return activity, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// digestSnippetLength caps the thread snippets quoted in a digest.
+const digestSnippetLength = 80
+
+// truncateRunes shortens s to at most n runes, marking a cut with "…".
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// formatChatDigest renders an activity summary as a Chat text message,
+// using Chat's *bold* markup and bulleted lines.
+func formatChatDigest(spaceLabel, window string, activity *chatActivity, threads, senders []map[string]interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Digest for %s (%s)*\n", spaceLabel, window)
+	if activity.total == 0 {
+		b.WriteString("No new messages.")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s from %s in %s",
+		pluralize(activity.total, "message"),
+		pluralize(len(activity.bySender), "sender"),
+		pluralize(len(activity.threads), "thread"))
+
+	if len(threads) > 0 {
+		b.WriteString("\n\n*Top threads*")
+		for _, t := range threads {
+			snippet := t["snippet"].(string)
+			if snippet == "" {
+				snippet = "(no text)"
+			}
+			fmt.Fprintf(&b, "\n• %s: %s", pluralize(t["count"].(int), "message"), snippet)
+		}
+	}
+	if len(senders) > 0 {
+		b.WriteString("\n\n*Most active*")
+		for _, s := range senders {
+			name, _ := s["display_name"].(string)
+			if name == "" {
+				name = s["sender"].(string)
+			}
+			if name == "" {
+				name = "(unknown)"
+			}
+			fmt.Fprintf(&b, "\n• %s: %d", name, s["count"].(int))
+		}
+	}
+	return b.String()
+}
+
+// pluralize formats a count with its noun, adding "s" unless n is 1.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func runChatDigest(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceName := ensureSpaceName(args[0])
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	postTo, _ := cmd.Flags().GetString("post-to")
+	top, _ := cmd.Flags().GetInt("top")
+	humansOnly, _ := cmd.Flags().GetBool("humans-only")
+	resolveSenders, _ := cmd.Flags().GetBool("resolve-senders")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	includeSnoozed, _ := cmd.Flags().GetBool("include-snoozed")
+
+	now := time.Now()
+	if chatRecentNowForTest != nil {
+		now = chatRecentNowForTest()
+	}
+	since, err := parseSinceWindow(sinceFlag, now)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	if top < 1 {
+		return usageErrorf("--top must be at least 1, got %d", top)
+	}
+	if postTo == "" && !dryRun {
+		return usageErrorf("must specify --post-to, or --dry-run to preview the digest")
+	}
+
+	// A thread target is spaces/X/threads/Y; the space is its prefix.
+	targetSpace, targetThread := "", ""
+	if postTo != "" {
+		targetSpace = ensureSpaceName(postTo)
+		if space, _, ok := strings.Cut(targetSpace, "/threads/"); ok {
+			targetSpace, targetThread = space, targetSpace
+		}
+	}
+
+	if !includeSnoozed {
+		store, err := snooze.Load(snooze.DefaultPath())
+		if err != nil {
+			return p.PrintError(err)
+		}
+		if e, ok := store.Active(spaceName, time.Now()); ok {
+			return p.Print(map[string]interface{}{
+				"status":        "skipped",
+				"space":         spaceName,
+				"snoozed_until": e.Until.Format(time.RFC3339),
+				"reason":        "space is snoozed; pass --include-snoozed to digest it anyway",
+			})
+		}
+	}
+
+	var (
+		svc       *chat.Service
+		peopleSvc *people.Service
+	)
+	if chatServiceForTest != nil {
+		svc = chatServiceForTest
+		peopleSvc = peopleServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+		if resolveSenders {
+			peopleSvc, _ = factory.PeopleProfile()
+		}
+	}
+
+	activity, err := collectChatActivity(ctx, svc, spaceName, since, humansOnly)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	senderCtx := nilSenderContext()
+	if resolveSenders {
+		senderCtx = resolveSendersForSpace(ctx, svc, peopleSvc, spaceName)
+	}
+
+	// The space's display name reads better in the digest; fall back to
+	// its resource name when it can't be fetched.
+	spaceLabel := spaceName
+	if space, err := svc.Spaces.Get(spaceName).Context(ctx).Do(); err == nil && space.DisplayName != "" {
+		spaceLabel = space.DisplayName
+	}
+	window := "last " + sinceFlag
+	if _, err := time.Parse(time.RFC3339, strings.TrimSpace(sinceFlag)); err == nil {
+		window = "since " + since.UTC().Format("2006-01-02 15:04 MST")
+	}
+
+	threads := activity.topThreads(top)
+	senders := activity.topSenders(top, senderCtx.displayNames)
+	text := formatChatDigest(spaceLabel, window, activity, threads, senders)
+
+	result := map[string]interface{}{
+		"space":         spaceName,
+		"since":         since.UTC().Format(time.RFC3339),
+		"message_count": activity.total,
+		"thread_count":  len(activity.threads),
+		"sender_count":  len(activity.bySender),
+		"top_threads":   threads,
+		"top_senders":   senders,
+		"text":          text,
+	}
+	if humansOnly {
+		result["bot_messages_skipped"] = activity.skipped
+	}
+	if dryRun {
+		result["status"] = "preview"
+		if postTo != "" {
+			result["post_to"] = postTo
+		}
+		return p.Print(result)
+	}
+
+	msg := &chat.Message{Text: text}
+	call := svc.Spaces.Messages.Create(targetSpace, msg).Context(ctx)
+	if targetThread != "" {
+		msg.Thread = &chat.Thread{Name: targetThread}
+		call = call.MessageReplyOption("REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	}
+	sent, err := call.Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to post digest: %w", err))
+	}
+
+	result["status"] = "posted"
+	result["posted_to"] = targetSpace
+	result["message"] = sent.Name
+	if sent.Thread != nil && sent.Thread.Name != "" {
+		result["thread"] = sent.Thread.Name
+	}
+	return p.Print(result)
+}
+
+// broadcastResult is the outcome of sending to one space.
+type broadcastResult struct {
+	Space string
+	Name  string
+	Err   error
+}
+
+// broadcastToSpaces calls send for every space using up to concurrency
+// workers, starting at most one send per interval (0 = no limit). Results
+// are returned in the same order as spaces.
+func broadcastToSpaces(ctx context.Context, spaces []string, concurrency int, interval time.Duration, send func(ctx context.Context, space string) (string, error)) []broadcastResult {
+	results := make([]broadcastResult, len(spaces))
+	forEachRateLimited(ctx, len(spaces), concurrency, interval, func(ctx context.Context, i int) {
+		name, err := send(ctx, spaces[i])
+		results[i] = broadcastResult{Space: spaces[i], Name: name, Err: err}
+	})
+	return results
+}
+
+// forEachRateLimited calls fn for every index in [0, n) using up to
+// concurrency workers, starting at most one call per interval (0 = no limit).
+func forEachRateLimited(ctx context.Context, n, concurrency int, interval time.Duration, fn func(ctx context.Context, i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(ctx, i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		// The first call starts immediately; later ones wait for the ticker.
+		if tick != nil && i > 0 {
+			<-tick
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// listSpacesOfType returns the names of every space of the given type.
+func listSpacesOfType(ctx context.Context, svc *chat.Service, spaceType string) ([]string, error) {
+	spaces, err := listSpaces(ctx, svc, spaceType)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(spaces))
+	for _, s := range spaces {
+		names = append(names, s.Name)
+	}
+	return names, nil
+}
+
+// listSpaces returns every space of the given type, or every space the user
+// belongs to when spaceType is empty.
+func listSpaces(ctx context.Context, svc *chat.Service, spaceType string) ([]*chat.Space, error) {
+	var spaces []*chat.Space
+	var pageToken string
+	for {
+		call := svc.Spaces.List().
+			PageSize(1000).
+			Context(ctx)
+		if spaceType != "" {
+			call = call.Filter(fmt.Sprintf(`spaceType = "%s"`, spaceType))
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range resp.Spaces {
+			if s != nil && s.Name != "" {
+				spaces = append(spaces, s)
+			}
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	return spaces, nil
+}
+
+func runChatBroadcast(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spacesFlag, _ := cmd.Flags().GetString("spaces")
+	allType, _ := cmd.Flags().GetString("all-type")
+	text, _ := cmd.Flags().GetString("text")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+
+	if (spacesFlag == "") == (allType == "") {
+		return usageErrorf("specify exactly one of --spaces or --all-type")
+	}
+	allType = strings.ToUpper(allType)
+	switch allType {
+	case "", "SPACE", "GROUP_CHAT", "DIRECT_MESSAGE":
+	default:
+		return usageErrorf("invalid --all-type %q: must be SPACE, GROUP_CHAT, or DIRECT_MESSAGE", allType)
+	}
+	if strings.TrimSpace(text) == "" {
+		return usageErrorf("--text must not be empty")
+	}
+	if concurrency < 1 {
+		return usageErrorf("--concurrency must be at least 1, got %d", concurrency)
+	}
+	if rate < 0 {
+		return usageErrorf("--rate must not be negative")
+	}
+
+	var svc *chat.Service
+	if chatServiceForTest != nil {
+		svc = chatServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	var targets []string
+	if allType != "" {
+		names, err := listSpacesOfType(ctx, svc, allType)
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to list spaces: %w", err))
+		}
+		targets = names
+	} else {
+		seen := map[string]bool{}
+		for _, s := range strings.Split(spacesFlag, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			name := ensureSpaceName(s)
+			if !seen[name] {
+				seen[name] = true
+				targets = append(targets, name)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return p.PrintError(fmt.Errorf("no spaces to send to"))
+	}
+
+	var interval time.Duration
+	if rate > 0 {
+		interval = time.Duration(float64(time.Second) / rate)
+	}
+
+	results := broadcastToSpaces(ctx, targets, concurrency, interval, func(ctx context.Context, space string) (string, error) {
+		sent, err := svc.Spaces.Messages.Create(space, &chat.Message{Text: text}).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+		return sent.Name, nil
+	})
+
+	rows := make([]map[string]interface{}, 0, len(results))
+	var sentCount, failedCount int
+	for _, r := range results {
+		row := map[string]interface{}{"space": r.Space}
+		if r.Err != nil {
+			row["status"] = "failed"
+			row["error"] = r.Err.Error()
+			failedCount++
+		} else {
+			row["status"] = "sent"
+			row["name"] = r.Name
+			sentCount++
+		}
+		rows = append(rows, row)
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":       "broadcast",
+		"results":      rows,
+		"sent_count":   sentCount,
+		"failed_count": failedCount,
+	})
+}
+
+func runChatLeave(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceName := ensureSpaceName(args[0])
+
+	var (
+		svc       *chat.Service
+		peopleSvc *people.Service
+	)
+	if chatServiceForTest != nil {
+		svc = chatServiceForTest
+		peopleSvc = peopleServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+		peopleSvc, err = factory.People()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	space, err := svc.Spaces.Get(spaceName).Context(ctx).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get space: %w", err))
+	}
+	if space.SpaceType == "DIRECT_MESSAGE" {
+		return usageErrorf("%s is a direct message; direct messages cannot be left", spaceName)
+	}
+
+	self := detectSelfResource(ctx, peopleSvc)
+	if self == "" {
+		return p.PrintError(fmt.Errorf("failed to determine the authenticated user"))
+	}
+	memberName := spaceName + "/members/" + strings.TrimPrefix(self, "users/")
+
+	if _, err := svc.Spaces.Members.Delete(memberName).Context(ctx).Do(); err != nil {
+		if space.SpaceType == "GROUP_CHAT" {
+			return p.PrintError(fmt.Errorf("failed to leave group chat (group chats may not allow leaving via the API): %w", err))
+		}
+		return p.PrintError(fmt.Errorf("failed to leave space: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":       "left",
+		"space":        spaceName,
+		"display_name": space.DisplayName,
+		"membership":   memberName,
+	})
+}
+
+func runChatMyRole(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceName := ensureSpaceName(args[0])
+
+	var (
+		svc       *chat.Service
+		peopleSvc *people.Service
+	)
+	if chatServiceForTest != nil {
+		svc = chatServiceForTest
+		peopleSvc = peopleServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+		peopleSvc, err = factory.People()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	self := detectSelfResource(ctx, peopleSvc)
+	if self == "" {
+		return p.PrintError(fmt.Errorf("failed to determine the authenticated user"))
+	}
+	memberName := spaceName + "/members/" + strings.TrimPrefix(self, "users/")
+
+	membership, err := svc.Spaces.Members.Get(memberName).Context(ctx).Do()
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == 404 {
+			return p.Print(map[string]interface{}{
+				"space":      spaceName,
+				"user":       self,
+				"member":     false,
+				"is_manager": false,
+			})
+		}
+		return p.PrintError(fmt.Errorf("failed to get membership: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"space":      spaceName,
+		"user":       self,
+		"member":     true,
+		"membership": membership.Name,
+		"role":       membership.Role,
+		"is_manager": membership.Role == "ROLE_MANAGER",
+	}
+	if membership.State != "" {
+		result["state"] = membership.State
+	}
+	if membership.CreateTime != "" {
+		result["joined"] = membership.CreateTime
+	}
+	return p.Print(result)
+}
+
+// countUnread returns the number of messages in space created after its
+// read state's last read time, counting at most limit. capped reports that
+// more unread messages exist beyond limit.
+func countUnread(ctx context.Context, svc *chat.Service, space string, limit int64) (count int64, lastReadTime string, capped bool, err error) {
+	state, err := svc.Users.Spaces.GetSpaceReadState(ensureReadStateName(space)).Context(ctx).Do()
+	if err != nil {
+		return 0, "", false, fmt.Errorf("failed to get read state: %w", err)
+	}
+	lastReadTime = state.LastReadTime
+
+	// Fetch one more than the cap so a full cap can be told apart from an overflow.
+	pageSize := limit + 1
+	if pageSize > 1000 {
+		pageSize = 1000
+	}
+	var pageToken string
+	for {
+		call := svc.Spaces.Messages.List(space).
+			PageSize(pageSize).
+			Fields("nextPageToken,messages(name)").
+			Context(ctx)
+		if lastReadTime != "" {
+			call = call.Filter(fmt.Sprintf(`createTime > "%s"`, lastReadTime))
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return 0, lastReadTime, false, fmt.Errorf("failed to list messages: %w", err)
+		}
+		count += int64(len(resp.Messages))
+		if count > limit {
+			return limit, lastReadTime, true, nil
+		}
+		if resp.NextPageToken == "" {
+			return count, lastReadTime, false, nil
+		}
+		if count == limit {
+			return limit, lastReadTime, true, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func runChatUnreadCounts(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceType, _ := cmd.Flags().GetString("type")
+	limit, _ := cmd.Flags().GetInt64("cap")
+	top, _ := cmd.Flags().GetInt("top")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	includeSnoozed, _ := cmd.Flags().GetBool("include-snoozed")
+
+	spaceType = strings.ToUpper(spaceType)
+	switch spaceType {
+	case "", "SPACE", "GROUP_CHAT", "DIRECT_MESSAGE":
+	default:
+		return usageErrorf("invalid --type %q: must be SPACE, GROUP_CHAT, or DIRECT_MESSAGE", spaceType)
+	}
+	if limit < 1 {
+		return usageErrorf("--cap must be at least 1, got %d", limit)
+	}
+	if top < 0 {
+		return usageErrorf("--top must not be negative")
+	}
+	if concurrency < 1 {
+		return usageErrorf("--concurrency must be at least 1, got %d", concurrency)
+	}
+	if rate < 0 {
+		return usageErrorf("--rate must not be negative")
+	}
+
+	var svc *chat.Service
+	if chatServiceForTest != nil {
+		svc = chatServiceForTest
+	} else {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	spaces, err := listSpaces(ctx, svc, spaceType)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to list spaces: %w", err))
+	}
+
+	var snoozed []map[string]interface{}
+	if !includeSnoozed {
+		store, err := snooze.Load(snooze.DefaultPath())
+		if err != nil {
+			return p.PrintError(err)
+		}
+		now := time.Now()
+		awake := spaces[:0]
+		for _, s := range spaces {
+			if e, ok := store.Active(s.Name, now); ok {
+				snoozed = append(snoozed, map[string]interface{}{
+					"space":        s.Name,
+					"display_name": s.DisplayName,
+					"until":        e.Until.Format(time.RFC3339),
+				})
+				continue
+			}
+			awake = append(awake, s)
+		}
+		spaces = awake
+	}
+
+	var interval time.Duration
+	if rate > 0 {
+		interval = time.Duration(float64(time.Second) / rate)
+	}
+
+	type spaceCount struct {
+		count        int64
+		lastReadTime string
+		capped       bool
+		err          error
+	}
+	counts := make([]spaceCount, len(spaces))
+	forEachRateLimited(ctx, len(spaces), concurrency, interval, func(ctx context.Context, i int) {
+		c, lastRead, capped, err := countUnread(ctx, svc, spaces[i].Name, limit)
+		counts[i] = spaceCount{count: c, lastReadTime: lastRead, capped: capped, err: err}
+	})
+
+	rows := make([]map[string]interface{}, 0)
+	var totalUnread int64
+	var failed []map[string]interface{}
+	for i, s := range spaces {
+		c := counts[i]
+		if c.err != nil {
+			failed = append(failed, map[string]interface{}{
+				"space": s.Name,
+				"error": c.err.Error(),
+			})
+			continue
+		}
+		if c.count == 0 {
+			continue
+		}
+		totalUnread += c.count
+		row := map[string]interface{}{
+			"space":        s.Name,
+			"display_name": s.DisplayName,
+			"type":         s.SpaceType,
+			"unread_count": c.count,
+			"capped":       c.capped,
+		}
+		if c.lastReadTime != "" {
+			row["last_read_time"] = c.lastReadTime
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(a, b int) bool {
+		return rows[a]["unread_count"].(int64) > rows[b]["unread_count"].(int64)
+	})
+	if top > 0 && len(rows) > top {
+		rows = rows[:top]
+	}
+
+	result := map[string]interface{}{
+		"spaces":         rows,
+		"count":          len(rows),
+		"spaces_checked": len(spaces),
+		"total_unread":   totalUnread,
+	}
+	if len(failed) > 0 {
+		result["failed"] = failed
+	}
+	if len(snoozed) > 0 {
+		result["snoozed"] = snoozed
+	}
+	return p.Print(result)
+}
+
+// chatWebBaseURL is the root of Google Chat web links.
+const chatWebBaseURL = "https://chat.google.com/room/"
+
+// chatSpaceURL returns the web link for a space resource name or bare ID.
+func chatSpaceURL(space string) string {
+	return chatWebBaseURL + strings.TrimPrefix(ensureSpaceName(space), "spaces/")
+}
+
+// chatMessageURL returns the web permalink for a message resource name.
+// Server-assigned message IDs have the form "<thread>.<message>"; ok is
+// false when name isn't in that form (e.g. a client-assigned ID).
+func chatMessageURL(name string) (url string, ok bool) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 || parts[0] != "spaces" || parts[2] != "messages" {
+		return "", false
+	}
+	thread, message, found := strings.Cut(parts[3], ".")
+	if !found || thread == "" || message == "" {
+		return "", false
+	}
+	return chatWebBaseURL + parts[1] + "/" + thread + "/" + message, true
+}
+
+func runChatLink(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	name := args[0]
+	if spaceFromMessageName(name) == "" {
+		return usageErrorf("expected a message name like spaces/<space>/messages/<message>, got %q", name)
+	}
+
+	url, ok := chatMessageURL(name)
+	if !ok {
+		var svc *chat.Service
+		if chatServiceForTest != nil {
+			svc = chatServiceForTest
+		} else {
+			factory, err := client.NewFactory(ctx)
+			if err != nil {
+				return p.PrintError(err)
+			}
+			svc, err = factory.Chat()
+			if err != nil {
+				return p.PrintError(err)
+			}
+		}
+
+		msg, err := svc.Spaces.Messages.Get(name).Context(ctx).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get message: %w", err))
+		}
+		url, ok = chatMessageURL(msg.Name)
+		if !ok {
+			return p.PrintError(fmt.Errorf("cannot build a link for message %s", msg.Name))
+		}
+		name = msg.Name
+	}
+
+	return p.Print(map[string]interface{}{
+		"name":  name,
+		"space": spaceFromMessageName(name),
+		"url":   url,
+	})
+}
+
+func runChatSpaceLink(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	space := ensureSpaceName(args[0])
+	if strings.Count(space, "/") != 1 || space == "spaces/" {
+		return usageErrorf("expected a space like spaces/<space> or <space>, got %q", args[0])
+	}
+
+	return p.Print(map[string]interface{}{
+		"space": space,
+		"url":   chatSpaceURL(space),
+	})
+}
+
+// chatPermissionSetting maps MANAGERS_ONLY or ALL onto a space permission
+// setting. Owners and managers are always allowed; ALL adds members.
+func chatPermissionSetting(who string) (*chat.PermissionSetting, error) {
+	switch strings.ToUpper(strings.TrimSpace(who)) {
+	case "MANAGERS_ONLY", "MANAGERS":
+		return &chat.PermissionSetting{
+			ManagersAllowed:          true,
+			AssistantManagersAllowed: true,
+			ForceSendFields:          []string{"MembersAllowed"},
+		}, nil
+	case "ALL":
+		return &chat.PermissionSetting{
+			ManagersAllowed:          true,
+			AssistantManagersAllowed: true,
+			MembersAllowed:           true,
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid value %q: must be MANAGERS_ONLY or ALL", who)
+}
+
+// describeChatPermission is the inverse of chatPermissionSetting; settings
+// that match neither value are reported as CUSTOM.
+func describeChatPermission(setting *chat.PermissionSetting) string {
+	switch {
+	case setting == nil:
+		return ""
+	case setting.MembersAllowed:
+		return "ALL"
+	case setting.ManagersAllowed:
+		return "MANAGERS_ONLY"
+	}
+	return "CUSTOM"
+}
+
+func runChatSetPermissions(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spaceName := ensureSpaceName(args[0])
+	whoCanPost, _ := cmd.Flags().GetString("who-can-post")
+	whoCanReply, _ := cmd.Flags().GetString("who-can-reply")
+
+	if whoCanPost == "" && whoCanReply == "" {
+		return usageErrorf("at least one of --who-can-post or --who-can-reply is required")
+	}
+
+	settings := &chat.PermissionSettings{}
+	var masks []string
+	if whoCanPost != "" {
+		setting, err := chatPermissionSetting(whoCanPost)
+		if err != nil {
+			return usageErrorf("--who-can-post: %v", err)
+		}
+		settings.PostMessages = setting
+		masks = append(masks, "permission_settings.post_messages")
+	}
+	if whoCanReply != "" {
+		setting, err := chatPermissionSetting(whoCanReply)
+		if err != nil {
+			return usageErrorf("--who-can-reply: %v", err)
+		}
+		settings.ReplyMessages = setting
+		masks = append(masks, "permission_settings.reply_messages")
+	}
+
+	svc := chatServiceForTest
+	if svc == nil {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	existing, err := svc.Spaces.Get(spaceName).Context(ctx).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get space: %w", err))
+	}
+	if existing.SpaceType != "SPACE" {
+		return usageErrorf("permissions can only be set on spaces of type SPACE, %s is %s", spaceName, existing.SpaceType)
+	}
+
+	updated, err := svc.Spaces.Patch(spaceName, &chat.Space{PermissionSettings: settings}).
+		UpdateMask(strings.Join(masks, ",")).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to update space permissions: %w", err))
+	}
+
+	result := mapSpaceToOutput(updated)
+	result["status"] = "updated"
+	applied := settings
+	if updated.PermissionSettings != nil {
+		applied = updated.PermissionSettings
+	}
+	if who := describeChatPermission(applied.PostMessages); who != "" {
+		result["who_can_post"] = who
+	}
+	if who := describeChatPermission(applied.ReplyMessages); who != "" {
+		result["who_can_reply"] = who
+	}
+	return p.Print(result)
+}
+
+// parseSnoozeUntil parses --until as a duration from now ("8h", "3d"), a
+// date (the start of that day, local time), "YYYY-MM-DD HH:MM", or an
+// RFC3339 timestamp. The result must be in the future.
+func parseSnoozeUntil(value string, now time.Time) (time.Time, error) {
+	v := strings.TrimSpace(value)
+	var until time.Time
+	if base, ok := strings.CutSuffix(v, "d"); ok && base != "" {
+		if dur, err := time.ParseDuration(base + "h"); err == nil {
+			until = now.Add(dur * 24)
+		}
+	}
+	if until.IsZero() {
+		if dur, err := time.ParseDuration(v); err == nil {
+			until = now.Add(dur)
+		} else if t, err := parseTime(v); err == nil {
+			until = t
+		} else {
+			return time.Time{}, fmt.Errorf("--until must be a duration (8h, 3d), a date, 'YYYY-MM-DD HH:MM', or RFC3339 timestamp, got %q", value)
+		}
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("--until must be in the future, got %q", value)
+	}
+	return until, nil
+}
+
+func runChatSnooze(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	untilFlag, _ := cmd.Flags().GetString("until")
+	now := time.Now()
+	until, err := parseSnoozeUntil(untilFlag, now)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	return runChatSnoozeWithStore(snooze.DefaultPath(), ensureSpaceName(args[0]), until, now, p)
+}
+
+func runChatSnoozeWithStore(path, spaceName string, until, now time.Time, p printer.Printer) error {
+	store, err := snooze.Load(path)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	store.Prune(now)
+	store.Spaces[spaceName] = snooze.Entry{Until: until, Created: now}
+	if err := snooze.Save(path, store); err != nil {
+		return p.PrintError(fmt.Errorf("failed to save snooze: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status": "snoozed",
+		"space":  spaceName,
+		"until":  until.Format(time.RFC3339),
+	})
+}
+
+func runChatUnsnooze(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	return runChatUnsnoozeWithStore(snooze.DefaultPath(), ensureSpaceName(args[0]), time.Now(), p)
+}
+
+func runChatUnsnoozeWithStore(path, spaceName string, now time.Time, p printer.Printer) error {
+	store, err := snooze.Load(path)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	_, wasSnoozed := store.Active(spaceName, now)
+	delete(store.Spaces, spaceName)
+	store.Prune(now)
+	if err := snooze.Save(path, store); err != nil {
+		return p.PrintError(fmt.Errorf("failed to save snoozes: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "unsnoozed",
+		"space":       spaceName,
+		"was_snoozed": wasSnoozed,
+	})
+}
+
+func runChatSnoozed(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	return runChatSnoozedWithStore(snooze.DefaultPath(), time.Now(), p)
+}
+
+func runChatSnoozedWithStore(path string, now time.Time, p printer.Printer) error {
+	store, err := snooze.Load(path)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if store.Prune(now) > 0 {
+		if err := snooze.Save(path, store); err != nil {
+			return p.PrintError(fmt.Errorf("failed to save snoozes: %w", err))
+		}
+	}
+
+	names := store.Names()
+	sort.SliceStable(names, func(a, b int) bool {
+		return store.Spaces[names[a]].Until.Before(store.Spaces[names[b]].Until)
+	})
+	spaces := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		e := store.Spaces[name]
+		spaces = append(spaces, map[string]interface{}{
+			"space":   name,
+			"until":   e.Until.Format(time.RFC3339),
+			"created": e.Created.Format(time.RFC3339),
+		})
+	}
+
+	return p.Print(map[string]interface{}{
+		"spaces": spaces,
+		"count":  len(spaces),
+	})
+}
+
+// doctorCheck is one result of chat doctor.
+type doctorCheck struct {
+	Name   string
+	Status string // ok, warn, fail, or skip
+	Detail string
+	Remedy string
+}
+
+// chatAPIEnableURL is where the Chat API is enabled for a Cloud project.
+const chatAPIEnableURL = "https://console.cloud.google.com/apis/library/chat.googleapis.com"
+
+// loginWithServices returns the login command that adds services to those
+// granted earlier, or a plain login when all scopes were granted.
+func loginWithServices(granted []string, services ...string) string {
+	if len(granted) == 0 {
+		return "gws auth login"
+	}
+	all := append([]string{}, granted...)
+	for _, s := range services {
+		found := false
+		for _, g := range all {
+			if g == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			all = append(all, s)
+		}
+	}
+	return "gws auth login --services " + strings.Join(all, ",")
+}
+
+// diagnoseChatError explains a Chat API error and how to fix it.
+func diagnoseChatError(err error, granted []string) (detail, remedy string) {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error(), "Check your network connection and retry"
+	}
+	text := strings.ToLower(apiErr.Error())
+	switch {
+	case apiErr.Code == 401:
+		return "the API rejected the credentials", "gws auth login"
+	case apiErr.Code == 403 && (strings.Contains(text, "service_disabled") || strings.Contains(text, "accessnotconfigured") || strings.Contains(text, "has not been used") || strings.Contains(text, "is disabled")):
+		return "the Google Chat API is not enabled in the OAuth client's Cloud project",
+			"Enable it at " + chatAPIEnableURL + ", wait a few minutes, and retry"
+	case apiErr.Code == 403 && (strings.Contains(text, "insufficient") || strings.Contains(text, "scope")):
+		return "the token is missing a required Chat scope", loginWithServices(granted, "chat")
+	case apiErr.Code == 403:
+		return "permission denied: " + apiErr.Message,
+			"Check that your Workspace admin allows Google Chat for your account"
+	case apiErr.Code == 404:
+		return "the Chat API answered 404, which usually means no Chat app is configured for the Cloud project",
+			"In the Cloud console open Google Chat API > Configuration and set an app name, avatar URL, and description; user-authenticated calls need this too"
+	case apiErr.Code == 429 || apiErr.Code >= 500:
+		return fmt.Sprintf("temporary API error (HTTP %d): %s", apiErr.Code, apiErr.Message), "Retry in a minute"
+	}
+	return fmt.Sprintf("HTTP %d: %s", apiErr.Code, apiErr.Message), ""
+}
+
+func runChatDoctor(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	svc := chatServiceForTest
+	peopleSvc := peopleServiceForTest
+	var ts oauth2.TokenSource
+	if svc == nil {
+		factory, err := client.NewFactory(ctx)
+		if err != nil {
+			remedy := "gws auth login"
+			if errors.Is(err, client.ErrOffline) {
+				remedy = "Run without --offline"
+			}
+			return printChatDoctor(p, []doctorCheck{{
+				Name:   "credentials",
+				Status: "fail",
+				Detail: err.Error(),
+				Remedy: remedy,
+			}}, nil)
+		}
+		svc, err = factory.Chat()
+		if err != nil {
+			return p.PrintError(err)
+		}
+		peopleSvc, err = factory.PeopleProfile()
+		if err != nil {
+			return p.PrintError(err)
+		}
+		ts = factory.TokenSource()
+	}
+
+	checks, extra := chatDoctorChecks(ctx, ts, svc, peopleSvc, auth.LoadGrantedServices())
+	return printChatDoctor(p, checks, extra)
+}
+
+// chatDoctorChecks runs the checks that follow a successful login and
+// returns them with extra report fields (identity and space count). The
+// credentials check asks ts for a token, refreshing it if it has expired.
+func chatDoctorChecks(ctx context.Context, ts oauth2.TokenSource, svc *chat.Service, peopleSvc *people.Service, granted []string) ([]doctorCheck, map[string]interface{}) {
+	extra := map[string]interface{}{"auth_mode": "user"}
+
+	creds := doctorCheck{Name: "credentials", Status: "ok", Detail: "stored OAuth token is valid"}
+	if ts == nil {
+		creds.Status = "skip"
+		creds.Detail = "no OAuth token source to check"
+	} else if token, err := ts.Token(); err != nil {
+		creds.Status = "fail"
+		creds.Detail = "could not load or refresh the stored OAuth token: " + err.Error()
+		creds.Remedy = "gws auth login"
+	} else if !token.Valid() {
+		creds.Status = "fail"
+		creds.Detail = "the stored OAuth token has expired and could not be refreshed"
+		creds.Remedy = "gws auth login"
+	}
+
+	checks := []doctorCheck{
+		creds,
+		{Name: "auth_mode", Status: "ok", Detail: "gws calls the Chat API as the signed-in user (OAuth user credentials); Chat app (bot) authentication is not used, so app-only features such as posting cards as the app are unavailable"},
+	}
+
+	scopes := doctorCheck{Name: "scopes", Status: "ok", Detail: "all scopes were granted at login"}
+	if len(granted) > 0 {
+		scopes.Detail = "chat was granted at login"
+		hasChat := false
+		for _, s := range granted {
+			if s == "chat" {
+				hasChat = true
+			}
+		}
+		if !hasChat {
+			scopes.Status = "fail"
+			scopes.Detail = "chat was not among the services granted at login (" + strings.Join(granted, ", ") + ")"
+			scopes.Remedy = loginWithServices(granted, "chat")
+		}
+	}
+	checks = append(checks, scopes)
+
+	identity := doctorCheck{Name: "identity", Status: "ok"}
+	if peopleSvc == nil {
+		identity.Status = "skip"
+		identity.Detail = "People API client unavailable"
+	} else if me, err := peopleSvc.People.Get("people/me").PersonFields("emailAddresses,metadata").Context(ctx).Do(); err != nil {
+		identity.Status = "warn"
+		identity.Detail = "could not look up the signed-in user: " + err.Error()
+		identity.Remedy = "Commands that need your Chat user ID (my-role, unread) may fail; " + loginWithServices(granted, "people")
+	} else {
+		user := "users/" + strings.TrimPrefix(me.ResourceName, "people/")
+		identity.Detail = user
+		extra["user"] = user
+		for _, e := range me.EmailAddresses {
+			if e.Metadata != nil && e.Metadata.Primary {
+				extra["email"] = e.Value
+				identity.Detail += " (" + e.Value + ")"
+				break
+			}
+		}
+	}
+	checks = append(checks, identity)
+
+	list := doctorCheck{Name: "list_spaces", Status: "ok"}
+	resp, err := svc.Spaces.List().PageSize(100).Context(ctx).Do()
+	var firstSpace string
+	switch {
+	case err != nil:
+		list.Status = "fail"
+		list.Detail, list.Remedy = diagnoseChatError(err, granted)
+	case len(resp.Spaces) == 0:
+		list.Status = "warn"
+		list.Detail = "no spaces are visible to this account"
+		list.Remedy = "Check that you signed in with the intended account, or join or create a space in Google Chat"
+		extra["spaces"] = 0
+	default:
+		firstSpace = resp.Spaces[0].Name
+		list.Detail = fmt.Sprintf("%d spaces visible", len(resp.Spaces))
+		if resp.NextPageToken != "" {
+			list.Detail = fmt.Sprintf("at least %d spaces visible", len(resp.Spaces))
+		}
+		extra["spaces"] = len(resp.Spaces)
+	}
+	checks = append(checks, list)
+
+	messages := doctorCheck{Name: "messages", Status: "skip", Detail: "no space to test"}
+	readState := doctorCheck{Name: "read_state", Status: "skip", Detail: "no space to test"}
+	if firstSpace != "" {
+		if _, err := svc.Spaces.Messages.List(firstSpace).PageSize(1).Context(ctx).Do(); err != nil {
+			messages.Status = "warn"
+			messages.Detail, messages.Remedy = diagnoseChatError(err, granted)
+		} else {
+			messages.Status = "ok"
+			messages.Detail = "read messages in " + firstSpace
+		}
+		if _, err := svc.Users.Spaces.GetSpaceReadState(ensureReadStateName(firstSpace)).Context(ctx).Do(); err != nil {
+			readState.Status = "warn"
+			readState.Detail, readState.Remedy = diagnoseChatError(err, granted)
+		} else {
+			readState.Status = "ok"
+			readState.Detail = "read the read state of " + firstSpace
+		}
+	}
+	checks = append(checks, messages, readState)
+	return checks, extra
+}
+
+// printChatDoctor prints the report and fails when any check failed.
+func printChatDoctor(p printer.Printer, checks []doctorCheck, extra map[string]interface{}) error {
+	status := "ok"
+	rows := make([]map[string]interface{}, 0, len(checks))
+	var failed []string
+	for _, c := range checks {
+		row := map[string]interface{}{
+			"name":   c.Name,
+			"status": c.Status,
+			"detail": c.Detail,
+		}
+		if c.Remedy != "" {
+			row["remedy"] = c.Remedy
+		}
+		rows = append(rows, row)
+		switch c.Status {
+		case "fail":
+			status = "fail"
+			failed = append(failed, c.Name)
+		case "warn":
+			if status == "ok" {
+				status = "warn"
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"status": status,
+		"checks": rows,
+	}
+	for k, v := range extra {
+		result[k] = v
+	}
+	if err := p.Print(result); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return &printer.AlreadyPrintedError{Err: fmt.Errorf("chat doctor: failed checks: %s", strings.Join(failed, ", "))}
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,12 +13,20 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/omriariav/workspace-cli/internal/client"
+	"github.com/omriariav/workspace-cli/internal/config"
+	"github.com/omriariav/workspace-cli/internal/printer"
+	"github.com/omriariav/workspace-cli/internal/snooze"
 	"github.com/omriariav/workspace-cli/internal/spacecache"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/chat/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/people/v1"
 )
@@ -1270,6 +1280,119 @@ func TestChatUpdate_MockServer(t *testing.T) {
 	}
 }
 
+func runChatUpdateAgainst(t *testing.T, lastUpdate string, args ...string) (string, bool, error) {
+	t.Helper()
+	patched := false
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA/messages/msg1": func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				json.NewEncoder(w).Encode(&chat.Message{
+					Name:           "spaces/AAAA/messages/msg1",
+					Text:           "Deploy 2/5",
+					CreateTime:     "2026-10-17T09:00:00Z",
+					LastUpdateTime: lastUpdate,
+				})
+			case "PATCH":
+				patched = true
+				var msg chat.Message
+				json.NewDecoder(r.Body).Decode(&msg)
+				msg.Name = "spaces/AAAA/messages/msg1"
+				msg.CreateTime = "2026-10-17T09:00:00Z"
+				msg.LastUpdateTime = "2026-10-17T10:00:00Z"
+				json.NewEncoder(w).Encode(&msg)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		},
+	}
+
+	server := mockChatServer(t, handlers)
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "update", Args: cobra.ExactArgs(1), RunE: runChatUpdate}
+	cmd.Flags().String("text", "", "")
+	cmd.Flags().String("if-unchanged-since", "", "")
+	cmd.SetArgs(append([]string{"spaces/AAAA/messages/msg1"}, args...))
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	return out, patched, runErr
+}
+
+func TestChatUpdate_IfUnchangedSince(t *testing.T) {
+	t.Run("unchanged", func(t *testing.T) {
+		out, patched, err := runChatUpdateAgainst(t, "2026-10-17T09:15:00Z",
+			"--text", "Deploy 3/5", "--if-unchanged-since", "2026-10-17T09:30:00Z")
+		if err != nil {
+			t.Fatalf("update returned error: %v\noutput: %s", err, out)
+		}
+		if !patched {
+			t.Error("expected the message to be patched")
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("failed to parse output: %v\n%s", err, out)
+		}
+		if result["status"] != "updated" || result["text"] != "Deploy 3/5" {
+			t.Errorf("unexpected result: %v", result)
+		}
+		if result["last_update_time"] != "2026-10-17T10:00:00Z" {
+			t.Errorf("expected new last_update_time, got %v", result["last_update_time"])
+		}
+	})
+
+	t.Run("never edited falls back to create time", func(t *testing.T) {
+		_, patched, err := runChatUpdateAgainst(t, "",
+			"--text", "Deploy 3/5", "--if-unchanged-since", "2026-10-17T09:30:00Z")
+		if err != nil {
+			t.Fatalf("update returned error: %v", err)
+		}
+		if !patched {
+			t.Error("expected the message to be patched")
+		}
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		out, patched, err := runChatUpdateAgainst(t, "2026-10-17T09:45:12.345Z",
+			"--text", "Deploy 3/5", "--if-unchanged-since", "2026-10-17T09:30:00Z")
+		if err == nil {
+			t.Fatal("expected an error on conflict")
+		}
+		if patched {
+			t.Error("expected no patch on conflict")
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("failed to parse output: %v\n%s", err, out)
+		}
+		if result["status"] != "conflict" {
+			t.Errorf("expected status conflict, got %v", result["status"])
+		}
+		if result["last_update_time"] != "2026-10-17T09:45:12.345Z" {
+			t.Errorf("expected current last_update_time, got %v", result["last_update_time"])
+		}
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		_, patched, err := runChatUpdateAgainst(t, "",
+			"--text", "x", "--if-unchanged-since", "yesterday")
+		if err == nil {
+			t.Fatal("expected an error for a non-RFC3339 timestamp")
+		}
+		if patched {
+			t.Error("expected no patch for an invalid timestamp")
+		}
+	})
+}
+
 func TestChatDeleteCommand_Flags(t *testing.T) {
 	deleteCmd := findSubcommand(chatCmd, "delete")
 	if deleteCmd == nil {
@@ -1607,6 +1730,9 @@ func TestChatUpdateSpaceCommand_Flags(t *testing.T) {
 	if cmd.Flags().Lookup("description") == nil {
 		t.Error("expected --description flag")
 	}
+	if cmd.Flags().Lookup("guidelines") == nil {
+		t.Error("expected --guidelines flag")
+	}
 }
 
 func TestChatSearchSpacesCommand_Flags(t *testing.T) {
@@ -1976,6 +2102,84 @@ func TestChatUpdateSpace_MockServer(t *testing.T) {
 	}
 }
 
+func TestChatUpdateSpace_GuidelinesKeepDescription(t *testing.T) {
+	var capturedMask string
+	var patched chat.Space
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA": func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				json.NewEncoder(w).Encode(&chat.Space{
+					Name:         "spaces/AAAA",
+					SpaceType:    "SPACE",
+					SpaceDetails: &chat.SpaceDetails{Description: "Team room", Guidelines: "Old rules"},
+				})
+			case "PATCH":
+				capturedMask = r.URL.Query().Get("updateMask")
+				json.NewDecoder(r.Body).Decode(&patched)
+				patched.Name = "spaces/AAAA"
+				json.NewEncoder(w).Encode(&patched)
+			default:
+				t.Errorf("unexpected method %s", r.Method)
+			}
+		},
+	}
+
+	server := mockChatServer(t, handlers)
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "update-space", Args: cobra.ExactArgs(1), RunE: runChatUpdateSpace}
+	cmd.Flags().String("display-name", "", "")
+	cmd.Flags().String("description", "", "")
+	cmd.Flags().String("guidelines", "", "")
+	cmd.SetArgs([]string{"AAAA", "--guidelines", "Be kind"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("update-space returned error: %v\noutput: %s", runErr, out)
+	}
+
+	if capturedMask != "space_details" {
+		t.Errorf("expected updateMask 'space_details', got %q", capturedMask)
+	}
+	if patched.SpaceDetails == nil || patched.SpaceDetails.Guidelines != "Be kind" || patched.SpaceDetails.Description != "Team room" {
+		t.Errorf("expected new guidelines with preserved description, got %+v", patched.SpaceDetails)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["guidelines"] != "Be kind" || result["description"] != "Team room" || result["status"] != "updated" {
+		t.Errorf("unexpected output: %v", result)
+	}
+}
+
+func TestMergeSpaceDetails(t *testing.T) {
+	current := &chat.SpaceDetails{Description: "desc", Guidelines: "rules"}
+
+	got := mergeSpaceDetails(current, "new desc", "")
+	if got.Description != "new desc" || got.Guidelines != "rules" {
+		t.Errorf("unexpected merge: %+v", got)
+	}
+	if current.Description != "desc" {
+		t.Error("current details were modified")
+	}
+
+	got = mergeSpaceDetails(nil, "", "rules")
+	if got.Description != "" || got.Guidelines != "rules" {
+		t.Errorf("unexpected merge with nil current: %+v", got)
+	}
+}
+
 func TestChatSearchSpaces_MockServer(t *testing.T) {
 	var capturedQuery string
 	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
@@ -3295,6 +3499,29 @@ func newFindSpaceCmd() *cobra.Command {
 	return cmd
 }
 
+// newUserSpacesCmd creates a fresh user-spaces command for tests.
+func newUserSpacesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user-spaces",
+		Short: "List cached spaces a user belongs to",
+		RunE:  runChatUserSpaces,
+	}
+	cmd.Flags().String("user", "", "Member email address to look up (required)")
+	cmd.Flags().Bool("refresh", false, "Rebuild cache for all space types before looking up")
+	return cmd
+}
+
+func newFindDuplicatesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "find-duplicates",
+		Short: "Find cached spaces with identical members",
+		RunE:  runChatFindDuplicates,
+	}
+	cmd.Flags().String("type", "", "Only check spaces of this type")
+	cmd.Flags().Bool("refresh", false, "Rebuild cache before checking")
+	return cmd
+}
+
 func newChatRecentCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "recent",
@@ -4129,3 +4356,1472 @@ func mustParseTime(t *testing.T, s string) time.Time {
 	}
 	return tt
 }
+
+// TestChatUserSpaces_ReverseLookup verifies the runner lists every cached space
+// containing the user, sorted by space name, and reports unresolved spaces.
+func TestChatUserSpaces_ReverseLookup(t *testing.T) {
+	tmpHome := t.TempDir()
+	origHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", origHome)
+
+	cache := &spacecache.CacheData{
+		Spaces: map[string]spacecache.SpaceEntry{
+			"spaces/B": {Type: "SPACE", DisplayName: "Sales", Members: []string{"Alice@Example.com", "bob@example.com"}, MemberCount: 2},
+			"spaces/A": {Type: "GROUP_CHAT", Members: []string{"alice@example.com", "carol@example.com"}, MemberCount: 2},
+			"spaces/C": {Type: "SPACE", DisplayName: "Engineering", Members: []string{"bob@example.com"}, MemberCount: 1},
+			"spaces/D": {Type: "SPACE", DisplayName: "Unknown", MembersUnresolved: true},
+		},
+	}
+	if err := spacecache.Save(spacecache.DefaultPath(), cache); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	cmd := newUserSpacesCmd()
+	cmd.Flags().Set("user", "alice@example.com")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	cmd.RunE(cmd, []string{})
+	w.Close()
+	os.Stdout = oldStdout
+
+	output, _ := io.ReadAll(r)
+	var result map[string]interface{}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("failed to parse output: %v\nraw: %s", err, output)
+	}
+	if count, _ := result["count"].(float64); int(count) != 2 {
+		t.Fatalf("expected 2 spaces for alice, got %v (raw: %s)", count, output)
+	}
+	spaces, _ := result["spaces"].([]interface{})
+	first, _ := spaces[0].(map[string]interface{})
+	second, _ := spaces[1].(map[string]interface{})
+	if first["space"] != "spaces/A" || second["space"] != "spaces/B" {
+		t.Errorf("expected spaces sorted A, B; got %v, %v", first["space"], second["space"])
+	}
+	if second["display_name"] != "Sales" || second["type"] != "SPACE" {
+		t.Errorf("unexpected entry for spaces/B: %v", second)
+	}
+	if unresolved, _ := result["unresolved_spaces"].(float64); int(unresolved) != 1 {
+		t.Errorf("expected unresolved_spaces=1, got %v", result["unresolved_spaces"])
+	}
+}
+
+// TestChatUserSpaces_EmptyUser verifies a blank --user is a usage error.
+func TestChatUserSpaces_EmptyUser(t *testing.T) {
+	cmd := newUserSpacesCmd()
+	cmd.Flags().Set("user", "   ")
+
+	err := cmd.RunE(cmd, []string{})
+	if err == nil || !strings.Contains(err.Error(), "--user must not be empty") {
+		t.Errorf("expected --user validation error, got %v", err)
+	}
+}
+
+// TestChatUserSpaces_RefreshOffline verifies --refresh fails fast under
+// --offline instead of reaching the (test-injected) Chat service.
+func TestChatUserSpaces_RefreshOffline(t *testing.T) {
+	viper.Set(config.KeyOffline, true)
+	defer viper.Set(config.KeyOffline, false)
+
+	cmd := newUserSpacesCmd()
+	cmd.Flags().Set("user", "alice@example.com")
+	cmd.Flags().Set("refresh", "true")
+
+	oldStderr := os.Stderr
+	_, w, _ := os.Pipe()
+	os.Stderr = w
+	err := cmd.RunE(cmd, []string{})
+	w.Close()
+	os.Stderr = oldStderr
+
+	if !errors.Is(err, client.ErrOffline) {
+		t.Errorf("expected ErrOffline, got %v", err)
+	}
+}
+
+func newChatActivityCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "activity <space-id>",
+		Args: cobra.ExactArgs(1),
+		RunE: runChatActivity,
+	}
+	cmd.Flags().Int("days", 7, "Number of days of history to summarize")
+	cmd.Flags().Bool("humans-only", false, "Ignore messages sent by bots/apps")
+	cmd.Flags().Int("top", 10, "Number of top senders to return (0 = all)")
+	cmd.Flags().Bool("resolve-senders", false, "Resolve sender display names via space membership")
+	return cmd
+}
+
+func TestChatActivityCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(chatCmd, "activity")
+	if cmd == nil {
+		t.Fatal("chat activity command not found")
+	}
+	for _, flag := range []string{"days", "humans-only", "top", "resolve-senders"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag", flag)
+		}
+	}
+	if def := cmd.Flags().Lookup("days").DefValue; def != "7" {
+		t.Errorf("expected --days default '7', got %q", def)
+	}
+}
+
+func TestChatActivity_CountsSendersDaysAndThreads(t *testing.T) {
+	now := mustParseTime(t, "2026-04-30T12:00:00Z")
+	var capturedFilter string
+	var pages int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/spaces/AAA/messages" {
+			t.Logf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		capturedFilter = r.URL.Query().Get("filter")
+		pages++
+		if r.URL.Query().Get("pageToken") == "" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"messages": []map[string]interface{}{
+					{"name": "spaces/AAA/messages/1", "createTime": "2026-04-29T09:00:00Z", "sender": map[string]interface{}{"name": "users/1", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t1"}},
+					{"name": "spaces/AAA/messages/2", "createTime": "2026-04-29T10:00:00.5Z", "sender": map[string]interface{}{"name": "users/1", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t1"}},
+				},
+				"nextPageToken": "p2",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"messages": []map[string]interface{}{
+				{"name": "spaces/AAA/messages/3", "createTime": "2026-04-30T08:00:00Z", "sender": map[string]interface{}{"name": "users/2", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t2"}},
+				{"name": "spaces/AAA/messages/4", "createTime": "2026-04-30T08:05:00Z", "sender": map[string]interface{}{"name": "users/bot", "type": "BOT"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t3"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+
+	oldChatSvc := chatServiceForTest
+	oldPeopleSvc := peopleServiceForTest
+	oldNow := chatRecentNowForTest
+	chatServiceForTest = svc
+	peopleServiceForTest = nil
+	chatRecentNowForTest = func() time.Time { return now }
+	defer func() {
+		chatServiceForTest = oldChatSvc
+		peopleServiceForTest = oldPeopleSvc
+		chatRecentNowForTest = oldNow
+	}()
+
+	cmd := newChatActivityCmd()
+	cmd.SetArgs([]string{"AAA", "--days", "3", "--humans-only"})
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("chat activity returned error: %v\noutput:\n%s", runErr, out)
+	}
+
+	var result struct {
+		Space        string `json:"space"`
+		Since        string `json:"since"`
+		MessageCount int    `json:"message_count"`
+		ThreadCount  int    `json:"thread_count"`
+		BotsSkipped  int    `json:"bot_messages_skipped"`
+		TopSenders   []struct {
+			Sender string `json:"sender"`
+			Count  int    `json:"count"`
+		} `json:"top_senders"`
+		ByDay []struct {
+			Date  string `json:"date"`
+			Count int    `json:"count"`
+		} `json:"messages_by_day"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to decode output: %v\noutput:\n%s", err, out)
+	}
+
+	if pages != 2 {
+		t.Errorf("expected 2 pages fetched, got %d", pages)
+	}
+	if want := `createTime > "2026-04-27T12:00:00Z"`; capturedFilter != want {
+		t.Errorf("filter = %q, want %q", capturedFilter, want)
+	}
+	if result.Space != "spaces/AAA" {
+		t.Errorf("space = %q, want spaces/AAA", result.Space)
+	}
+	if result.MessageCount != 3 || result.BotsSkipped != 1 {
+		t.Errorf("message_count/bot_messages_skipped = %d/%d, want 3/1", result.MessageCount, result.BotsSkipped)
+	}
+	if result.ThreadCount != 2 {
+		t.Errorf("thread_count = %d, want 2", result.ThreadCount)
+	}
+	if len(result.TopSenders) != 2 || result.TopSenders[0].Sender != "users/1" || result.TopSenders[0].Count != 2 {
+		t.Errorf("unexpected top_senders: %+v", result.TopSenders)
+	}
+
+	wantDays := map[string]int{"2026-04-27": 0, "2026-04-28": 0, "2026-04-29": 2, "2026-04-30": 1}
+	if len(result.ByDay) != len(wantDays) {
+		t.Fatalf("expected %d days, got %+v", len(wantDays), result.ByDay)
+	}
+	for _, d := range result.ByDay {
+		if want, ok := wantDays[d.Date]; !ok || d.Count != want {
+			t.Errorf("day %s count = %d, want %d", d.Date, d.Count, want)
+		}
+	}
+}
+
+func TestChatActivity_InvalidDays(t *testing.T) {
+	cmd := newChatActivityCmd()
+	cmd.SetArgs([]string{"AAA", "--days", "0"})
+	_, err := captureStdout(t, cmd.Execute)
+	if err == nil {
+		t.Fatal("expected error for --days 0")
+	}
+	var ue *usageError
+	if !errors.As(err, &ue) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
+
+func newChatDigestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "digest <space-id>",
+		Args: cobra.ExactArgs(1),
+		RunE: runChatDigest,
+	}
+	cmd.Flags().String("since", "24h", "Window to summarize")
+	cmd.Flags().String("post-to", "", "Space or thread to post the digest to")
+	cmd.Flags().Int("top", 5, "Number of top threads and senders to list")
+	cmd.Flags().Bool("humans-only", false, "Ignore messages sent by bots/apps")
+	cmd.Flags().Bool("resolve-senders", true, "Resolve sender display names")
+	cmd.Flags().Bool("dry-run", false, "Print the digest without posting it")
+	cmd.Flags().Bool("include-snoozed", false, "Digest the space even if it is snoozed")
+	return cmd
+}
+
+// mockChatDigestServer serves a space with four messages in two threads and
+// records the digest message posted to any space.
+func mockChatDigestServer(t *testing.T, posted *chat.Message, postedURL *string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/spaces/AAA":
+			json.NewEncoder(w).Encode(map[string]interface{}{"name": "spaces/AAA", "displayName": "Eng Team"})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/spaces/AAA/members":
+			json.NewEncoder(w).Encode(map[string]interface{}{"memberships": []map[string]interface{}{
+				{"member": map[string]interface{}{"name": "users/1", "displayName": "Ada"}},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/spaces/AAA/messages":
+			json.NewEncoder(w).Encode(map[string]interface{}{"messages": []map[string]interface{}{
+				{"name": "spaces/AAA/messages/1", "text": "Release plan\nDetails inside", "createTime": "2026-04-30T09:00:00Z", "sender": map[string]interface{}{"name": "users/1", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t1"}},
+				{"name": "spaces/AAA/messages/2", "text": "+1", "createTime": "2026-04-30T09:05:00Z", "sender": map[string]interface{}{"name": "users/2", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t1"}},
+				{"name": "spaces/AAA/messages/3", "text": "Ship it", "createTime": "2026-04-30T09:10:00Z", "sender": map[string]interface{}{"name": "users/1", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t1"}},
+				{"name": "spaces/AAA/messages/4", "text": "Lunch?", "createTime": "2026-04-30T10:00:00Z", "sender": map[string]interface{}{"name": "users/2", "type": "HUMAN"}, "thread": map[string]interface{}{"name": "spaces/AAA/threads/t2"}},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/messages"):
+			*postedURL = r.URL.String()
+			json.NewDecoder(r.Body).Decode(posted)
+			json.NewEncoder(w).Encode(map[string]interface{}{"name": "spaces/BBB/messages/new", "thread": map[string]interface{}{"name": "spaces/BBB/threads/ccc"}})
+		default:
+			t.Logf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func useChatDigestServer(t *testing.T, server *httptest.Server) {
+	t.Helper()
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc, oldPeopleSvc, oldNow := chatServiceForTest, peopleServiceForTest, chatRecentNowForTest
+	chatServiceForTest = svc
+	peopleServiceForTest = nil
+	chatRecentNowForTest = func() time.Time { return mustParseTime(t, "2026-04-30T12:00:00Z") }
+	t.Cleanup(func() {
+		chatServiceForTest, peopleServiceForTest, chatRecentNowForTest = oldChatSvc, oldPeopleSvc, oldNow
+	})
+}
+
+func TestChatDigest_DryRunDoesNotPost(t *testing.T) {
+	var posted chat.Message
+	var postedURL string
+	server := mockChatDigestServer(t, &posted, &postedURL)
+	defer server.Close()
+	useChatDigestServer(t, server)
+
+	cmd := newChatDigestCmd()
+	cmd.SetArgs([]string{"AAA", "--since", "24h", "--dry-run"})
+	out, err := captureStdout(t, cmd.Execute)
+	if err != nil {
+		t.Fatalf("chat digest returned error: %v\noutput:\n%s", err, out)
+	}
+	if postedURL != "" {
+		t.Errorf("expected no post with --dry-run, got %s", postedURL)
+	}
+
+	var result struct {
+		Status       string `json:"status"`
+		MessageCount int    `json:"message_count"`
+		ThreadCount  int    `json:"thread_count"`
+		Text         string `json:"text"`
+		TopThreads   []struct {
+			Thread  string `json:"thread"`
+			Count   int    `json:"count"`
+			Snippet string `json:"snippet"`
+		} `json:"top_threads"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to decode output: %v\noutput:\n%s", err, out)
+	}
+	if result.Status != "preview" || result.MessageCount != 4 || result.ThreadCount != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.TopThreads) != 2 || result.TopThreads[0].Thread != "spaces/AAA/threads/t1" || result.TopThreads[0].Count != 3 || result.TopThreads[0].Snippet != "Release plan" {
+		t.Errorf("unexpected top_threads: %+v", result.TopThreads)
+	}
+	for _, want := range []string{"*Digest for Eng Team (last 24h)*", "4 messages from 2 senders in 2 threads", "• 3 messages: Release plan", "• Ada: 2", "• users/2: 2"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("digest text missing %q:\n%s", want, result.Text)
+		}
+	}
+}
+
+func TestChatDigest_PostsToThread(t *testing.T) {
+	var posted chat.Message
+	var postedURL string
+	server := mockChatDigestServer(t, &posted, &postedURL)
+	defer server.Close()
+	useChatDigestServer(t, server)
+
+	cmd := newChatDigestCmd()
+	cmd.SetArgs([]string{"AAA", "--post-to", "spaces/BBB/threads/ccc", "--resolve-senders=false"})
+	out, err := captureStdout(t, cmd.Execute)
+	if err != nil {
+		t.Fatalf("chat digest returned error: %v\noutput:\n%s", err, out)
+	}
+	if !strings.HasPrefix(postedURL, "/v1/spaces/BBB/messages?") || !strings.Contains(postedURL, "messageReplyOption=REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD") {
+		t.Errorf("unexpected post URL %s", postedURL)
+	}
+	if posted.Thread == nil || posted.Thread.Name != "spaces/BBB/threads/ccc" || !strings.Contains(posted.Text, "Top threads") {
+		t.Errorf("unexpected posted message: %+v", posted)
+	}
+	if strings.Contains(posted.Text, "Ada") {
+		t.Errorf("expected unresolved sender names with --resolve-senders=false:\n%s", posted.Text)
+	}
+	if !strings.Contains(out, `"status": "posted"`) || !strings.Contains(out, `"message": "spaces/BBB/messages/new"`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestChatDigest_SkipsSnoozedSpace(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	store := &snooze.Data{Spaces: map[string]snooze.Entry{
+		"spaces/AAA": {Until: time.Now().Add(time.Hour)},
+	}}
+	if err := snooze.Save(snooze.DefaultPath(), store); err != nil {
+		t.Fatal(err)
+	}
+
+	var posted chat.Message
+	var postedURL string
+	server := mockChatDigestServer(t, &posted, &postedURL)
+	defer server.Close()
+	useChatDigestServer(t, server)
+
+	cmd := newChatDigestCmd()
+	cmd.SetArgs([]string{"AAA", "--post-to", "spaces/BBB"})
+	out, err := captureStdout(t, cmd.Execute)
+	if err != nil {
+		t.Fatalf("chat digest returned error: %v\noutput:\n%s", err, out)
+	}
+	if postedURL != "" {
+		t.Errorf("expected no digest posted for a snoozed space, got %s", postedURL)
+	}
+	if !strings.Contains(out, `"status": "skipped"`) || !strings.Contains(out, `"snoozed_until"`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	cmd = newChatDigestCmd()
+	cmd.SetArgs([]string{"AAA", "--post-to", "spaces/BBB", "--include-snoozed"})
+	if out, err := captureStdout(t, cmd.Execute); err != nil || !strings.Contains(out, `"status": "posted"`) {
+		t.Errorf("expected --include-snoozed to post, got %v\n%s", err, out)
+	}
+}
+
+func TestChatDigest_RequiresTargetOrDryRun(t *testing.T) {
+	for _, args := range [][]string{
+		{"AAA"},
+		{"AAA", "--dry-run", "--since", "soon"},
+		{"AAA", "--dry-run", "--top", "0"},
+	} {
+		cmd := newChatDigestCmd()
+		cmd.SetArgs(args)
+		_, err := captureStdout(t, cmd.Execute)
+		var ue *usageError
+		if !errors.As(err, &ue) {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
+
+func TestFormatChatDigest_NoMessages(t *testing.T) {
+	text := formatChatDigest("Eng", "last 24h", newChatActivity(), nil, nil)
+	if text != "*Digest for Eng (last 24h)*\nNo new messages." {
+		t.Errorf("unexpected empty digest: %q", text)
+	}
+}
+
+func TestChatBroadcastCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(chatCmd, "broadcast")
+	if cmd == nil {
+		t.Fatal("chat broadcast command not found")
+	}
+	for _, flag := range []string{"spaces", "all-type", "text", "concurrency", "rate"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag", flag)
+		}
+	}
+}
+
+func TestBroadcastToSpaces_OrderAndErrors(t *testing.T) {
+	spaces := []string{"spaces/A", "spaces/B", "spaces/C", "spaces/D"}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	results := broadcastToSpaces(context.Background(), spaces, 2, 0, func(ctx context.Context, space string) (string, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if space == "spaces/C" {
+			return "", errors.New("permission denied")
+		}
+		return space + "/messages/1", nil
+	})
+
+	if len(results) != len(spaces) {
+		t.Fatalf("expected %d results, got %d", len(spaces), len(results))
+	}
+	for i, r := range results {
+		if r.Space != spaces[i] {
+			t.Errorf("result %d space = %s, want %s", i, r.Space, spaces[i])
+		}
+	}
+	if results[2].Err == nil || results[0].Err != nil || results[0].Name != "spaces/A/messages/1" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent sends, saw %d", maxInFlight)
+	}
+}
+
+func TestBroadcastToSpaces_RateLimit(t *testing.T) {
+	start := time.Now()
+	broadcastToSpaces(context.Background(), []string{"a", "b", "c"}, 3, 20*time.Millisecond, func(ctx context.Context, space string) (string, error) {
+		return "", nil
+	})
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected 3 sends at 20ms intervals to take >= 40ms, took %v", elapsed)
+	}
+}
+
+func TestChatBroadcast_ReportsPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/v1/spaces/BAD/messages" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"not a member"}}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name": strings.TrimPrefix(r.URL.Path, "/v1/") + "/m1",
+		})
+	}))
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "broadcast", RunE: runChatBroadcast}
+	cmd.Flags().String("spaces", "", "")
+	cmd.Flags().String("all-type", "", "")
+	cmd.Flags().String("text", "", "")
+	cmd.Flags().Int("concurrency", 4, "")
+	cmd.Flags().Float64("rate", 1, "")
+	cmd.SetArgs([]string{"--spaces", "OK1,BAD,spaces/OK2,OK1", "--text", "hello", "--rate", "0"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("broadcast returned error: %v\noutput: %s", runErr, out)
+	}
+
+	var result struct {
+		SentCount   int                      `json:"sent_count"`
+		FailedCount int                      `json:"failed_count"`
+		Results     []map[string]interface{} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to decode output: %v\noutput: %s", err, out)
+	}
+	if result.SentCount != 2 || result.FailedCount != 1 || len(result.Results) != 3 {
+		t.Fatalf("expected 2 sent / 1 failed over 3 deduplicated spaces, got %s", out)
+	}
+	if result.Results[1]["space"] != "spaces/BAD" || result.Results[1]["status"] != "failed" {
+		t.Errorf("expected spaces/BAD to fail, got %v", result.Results[1])
+	}
+	if result.Results[2]["name"] != "spaces/OK2/messages/m1" {
+		t.Errorf("unexpected message name: %v", result.Results[2]["name"])
+	}
+}
+
+func TestChatBroadcast_RequiresOneTarget(t *testing.T) {
+	cmd := &cobra.Command{Use: "broadcast", RunE: runChatBroadcast}
+	cmd.Flags().String("spaces", "", "")
+	cmd.Flags().String("all-type", "", "")
+	cmd.Flags().String("text", "", "")
+	cmd.Flags().Int("concurrency", 4, "")
+	cmd.Flags().Float64("rate", 1, "")
+	cmd.SetArgs([]string{"--spaces", "A", "--all-type", "SPACE", "--text", "hi"})
+
+	_, err := captureStdout(t, cmd.Execute)
+	if err == nil || !strings.Contains(err.Error(), "exactly one of --spaces or --all-type") {
+		t.Errorf("expected target validation error, got %v", err)
+	}
+}
+
+// runChatLeaveAgainst runs chat leave with services pointed at the given
+// chat handlers and a People server reporting people/111 as the caller.
+func runChatLeaveAgainst(t *testing.T, handlers map[string]func(w http.ResponseWriter, r *http.Request), space string) (string, error) {
+	t.Helper()
+	chatServer := mockChatServer(t, handlers)
+	defer chatServer.Close()
+	peopleServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"resourceName": "people/111"})
+	}))
+	defer peopleServer.Close()
+
+	chatSvc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(chatServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	peopleSvc, err := people.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(peopleServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldChatSvc, oldPeopleSvc := chatServiceForTest, peopleServiceForTest
+	chatServiceForTest, peopleServiceForTest = chatSvc, peopleSvc
+	defer func() { chatServiceForTest, peopleServiceForTest = oldChatSvc, oldPeopleSvc }()
+
+	cmd := &cobra.Command{Use: "leave", Args: cobra.ExactArgs(1), RunE: runChatLeave}
+	cmd.SetArgs([]string{space})
+	return captureStdout(t, cmd.Execute)
+}
+
+func TestChatLeave_DeletesOwnMembership(t *testing.T) {
+	var deleted string
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/AAAA", DisplayName: "Old project", SpaceType: "SPACE"})
+		},
+		"/v1/spaces/AAAA/members/111": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "DELETE" {
+				t.Errorf("expected DELETE, got %s", r.Method)
+			}
+			deleted = r.URL.Path
+			json.NewEncoder(w).Encode(&chat.Membership{Name: "spaces/AAAA/members/111"})
+		},
+	}
+
+	out, err := runChatLeaveAgainst(t, handlers, "AAAA")
+	if err != nil {
+		t.Fatalf("leave returned error: %v\noutput: %s", err, out)
+	}
+	if deleted != "/v1/spaces/AAAA/members/111" {
+		t.Errorf("expected own membership to be deleted, got %q", deleted)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["status"] != "left" || result["membership"] != "spaces/AAAA/members/111" || result["display_name"] != "Old project" {
+		t.Errorf("unexpected output: %v", result)
+	}
+}
+
+func TestChatLeave_RejectsDirectMessage(t *testing.T) {
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/DM": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/DM", SpaceType: "DIRECT_MESSAGE"})
+		},
+	}
+
+	_, err := runChatLeaveAgainst(t, handlers, "spaces/DM")
+	if err == nil || !strings.Contains(err.Error(), "direct messages cannot be left") {
+		t.Errorf("expected direct message error, got %v", err)
+	}
+}
+
+func TestChatUnreadCounts_CountsCapsAndSorts(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	server := mockChatServer(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("filter"); got != `spaceType = "SPACE"` {
+				t.Errorf("unexpected spaces filter: %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"spaces": []map[string]interface{}{
+					{"name": "spaces/QUIET", "displayName": "Quiet", "spaceType": "SPACE"},
+					{"name": "spaces/SOME", "displayName": "Some", "spaceType": "SPACE"},
+					{"name": "spaces/BUSY", "displayName": "Busy", "spaceType": "SPACE"},
+				},
+			})
+		},
+		"/v1/users/me/spaces/QUIET/spaceReadState": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"lastReadTime": "2026-10-01T00:00:00Z"})
+		},
+		"/v1/users/me/spaces/SOME/spaceReadState": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"lastReadTime": "2026-10-02T00:00:00Z"})
+		},
+		"/v1/users/me/spaces/BUSY/spaceReadState": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"lastReadTime": "2026-10-03T00:00:00Z"})
+		},
+		"/v1/spaces/QUIET/messages": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		},
+		"/v1/spaces/SOME/messages": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("filter"); got != `createTime > "2026-10-02T00:00:00Z"` {
+				t.Errorf("unexpected messages filter: %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"messages": []map[string]interface{}{{"name": "spaces/SOME/messages/1"}, {"name": "spaces/SOME/messages/2"}},
+			})
+		},
+		"/v1/spaces/BUSY/messages": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("pageSize"); got != "4" {
+				t.Errorf("expected pageSize cap+1 = 4, got %s", got)
+			}
+			msgs := make([]map[string]interface{}, 4)
+			for i := range msgs {
+				msgs[i] = map[string]interface{}{"name": fmt.Sprintf("spaces/BUSY/messages/%d", i)}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"messages": msgs, "nextPageToken": "more"})
+		},
+	})
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "unread-counts", RunE: runChatUnreadCounts}
+	cmd.Flags().String("type", "", "")
+	cmd.Flags().Int64("cap", 100, "")
+	cmd.Flags().Int("top", 0, "")
+	cmd.Flags().Int("concurrency", 4, "")
+	cmd.Flags().Float64("rate", 5, "")
+	cmd.Flags().Bool("include-snoozed", false, "")
+	cmd.SetArgs([]string{"--type", "space", "--cap", "3", "--rate", "0"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+
+	var result struct {
+		Spaces        []map[string]interface{} `json:"spaces"`
+		Count         int                      `json:"count"`
+		SpacesChecked int                      `json:"spaces_checked"`
+		TotalUnread   int                      `json:"total_unread"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result.SpacesChecked != 3 || result.Count != 2 || result.TotalUnread != 5 {
+		t.Fatalf("unexpected totals: %+v", result)
+	}
+	if result.Spaces[0]["space"] != "spaces/BUSY" || result.Spaces[0]["unread_count"] != float64(3) || result.Spaces[0]["capped"] != true {
+		t.Errorf("expected capped BUSY first, got %v", result.Spaces[0])
+	}
+	if result.Spaces[1]["space"] != "spaces/SOME" || result.Spaces[1]["unread_count"] != float64(2) || result.Spaces[1]["capped"] != false {
+		t.Errorf("expected SOME second, got %v", result.Spaces[1])
+	}
+}
+
+func TestChatUnreadCounts_SkipsSnoozedSpaces(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	store := &snooze.Data{Spaces: map[string]snooze.Entry{
+		"spaces/NOISY": {Until: time.Now().Add(time.Hour)},
+		"spaces/DONE":  {Until: time.Now().Add(-time.Hour)},
+	}}
+	if err := snooze.Save(snooze.DefaultPath(), store); err != nil {
+		t.Fatal(err)
+	}
+
+	var noisyChecked bool
+	server := mockChatServer(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"spaces": []map[string]interface{}{
+					{"name": "spaces/NOISY", "displayName": "Noisy", "spaceType": "SPACE"},
+					{"name": "spaces/DONE", "displayName": "Done", "spaceType": "SPACE"},
+				},
+			})
+		},
+		"/v1/users/me/spaces/NOISY/spaceReadState": func(w http.ResponseWriter, r *http.Request) {
+			noisyChecked = true
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		},
+		"/v1/users/me/spaces/DONE/spaceReadState": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		},
+		"/v1/spaces/NOISY/messages": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"messages": []map[string]interface{}{{"name": "spaces/NOISY/messages/1"}}})
+		},
+		"/v1/spaces/DONE/messages": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"messages": []map[string]interface{}{{"name": "spaces/DONE/messages/1"}}})
+		},
+	})
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	run := func(args ...string) map[string]interface{} {
+		cmd := &cobra.Command{Use: "unread-counts", RunE: runChatUnreadCounts}
+		cmd.Flags().String("type", "", "")
+		cmd.Flags().Int64("cap", 100, "")
+		cmd.Flags().Int("top", 0, "")
+		cmd.Flags().Int("concurrency", 4, "")
+		cmd.Flags().Float64("rate", 5, "")
+		cmd.Flags().Bool("include-snoozed", false, "")
+		cmd.SetArgs(append([]string{"--rate", "0"}, args...))
+		out, runErr := captureStdout(t, cmd.Execute)
+		if runErr != nil {
+			t.Fatalf("unexpected error: %v", runErr)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("failed to parse output: %v\n%s", err, out)
+		}
+		return result
+	}
+
+	result := run()
+	if noisyChecked {
+		t.Error("expected the snoozed space not to be checked")
+	}
+	if result["spaces_checked"] != float64(1) || result["total_unread"] != float64(1) {
+		t.Errorf("expected only the expired snooze's space to be counted, got %v", result)
+	}
+	snoozed, _ := result["snoozed"].([]interface{})
+	if len(snoozed) != 1 || snoozed[0].(map[string]interface{})["space"] != "spaces/NOISY" {
+		t.Errorf("expected NOISY reported as snoozed, got %v", result["snoozed"])
+	}
+
+	result = run("--include-snoozed")
+	if !noisyChecked || result["spaces_checked"] != float64(2) || result["snoozed"] != nil {
+		t.Errorf("expected --include-snoozed to check every space, got %v", result)
+	}
+}
+
+func TestParseSnoozeUntil(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"8h", now.Add(8 * time.Hour)},
+		{"3d", now.Add(72 * time.Hour)},
+		{"2026-10-20", time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)},
+		{"2026-10-18 09:30", time.Date(2026, 10, 18, 9, 30, 0, 0, time.Local)},
+		{"2026-10-18T09:30:00Z", time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSnoozeUntil(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSnoozeUntil(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "soon", "-2h", "2026-10-17", "2020-01-01"} {
+		if _, err := parseSnoozeUntil(bad, now); err == nil {
+			t.Errorf("parseSnoozeUntil(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestChatSnoozeLifecycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozes.json")
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := runChatSnoozeWithStore(path, "spaces/AAAA", now.Add(48*time.Hour), now, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("snooze: %v", err)
+	}
+	if err := runChatSnoozeWithStore(path, "spaces/BBBB", now.Add(time.Hour), now, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("snooze: %v", err)
+	}
+
+	buf.Reset()
+	if err := runChatSnoozedWithStore(path, now, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("snoozed: %v", err)
+	}
+	var listed struct {
+		Count  int                 `json:"count"`
+		Spaces []map[string]string `json:"spaces"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &listed); err != nil {
+		t.Fatalf("bad output: %v", err)
+	}
+	if listed.Count != 2 || listed.Spaces[0]["space"] != "spaces/BBBB" || listed.Spaces[1]["until"] != "2026-10-19T12:00:00Z" {
+		t.Errorf("expected snoozes soonest first, got %s", buf.String())
+	}
+
+	// Two hours later BBBB's snooze has expired and is pruned.
+	buf.Reset()
+	if err := runChatUnsnoozeWithStore(path, "spaces/AAAA", now.Add(2*time.Hour), printer.New(&buf, "json")); err != nil {
+		t.Fatalf("unsnooze: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"was_snoozed": true`) {
+		t.Errorf("expected was_snoozed true, got %s", buf.String())
+	}
+	store, err := snooze.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Spaces) != 0 {
+		t.Errorf("expected an empty store, got %v", store.Spaces)
+	}
+}
+
+func TestChatMessageURL(t *testing.T) {
+	url, ok := chatMessageURL("spaces/AAAA/messages/r9sgTEtHmEU.r9sgTEtHmEU")
+	if !ok || url != "https://chat.google.com/room/AAAA/r9sgTEtHmEU/r9sgTEtHmEU" {
+		t.Errorf("unexpected link: %q %v", url, ok)
+	}
+	url, ok = chatMessageURL("spaces/AAAA/messages/THREAD1.REPLY2")
+	if !ok || url != "https://chat.google.com/room/AAAA/THREAD1/REPLY2" {
+		t.Errorf("unexpected reply link: %q %v", url, ok)
+	}
+	if _, ok := chatMessageURL("spaces/AAAA/messages/client-deploy-42"); ok {
+		t.Error("expected client-assigned ID to need a lookup")
+	}
+	if got := chatSpaceURL("AAAA"); got != "https://chat.google.com/room/AAAA" {
+		t.Errorf("unexpected space link: %q", got)
+	}
+}
+
+func TestChatLink_ResolvesClientAssignedID(t *testing.T) {
+	server := mockChatServer(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA/messages/client-deploy-42": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&chat.Message{Name: "spaces/AAAA/messages/TTT.MMM"})
+		},
+	})
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "link", Args: cobra.ExactArgs(1), RunE: runChatLink}
+	cmd.SetArgs([]string{"spaces/AAAA/messages/client-deploy-42"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["url"] != "https://chat.google.com/room/AAAA/TTT/MMM" || result["name"] != "spaces/AAAA/messages/TTT.MMM" {
+		t.Errorf("unexpected output: %v", result)
+	}
+}
+
+func TestChatSetPermissions_AnnouncementSpace(t *testing.T) {
+	var mask string
+	var patched map[string]interface{}
+	server := mockChatServer(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA": func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/AAAA", DisplayName: "Announcements", SpaceType: "SPACE"})
+			case "PATCH":
+				mask = r.URL.Query().Get("updateMask")
+				json.NewDecoder(r.Body).Decode(&patched)
+				json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/AAAA", DisplayName: "Announcements", SpaceType: "SPACE"})
+			}
+		},
+	})
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "set-permissions", Args: cobra.ExactArgs(1), RunE: runChatSetPermissions}
+	cmd.Flags().String("who-can-post", "", "")
+	cmd.Flags().String("who-can-reply", "", "")
+	cmd.SetArgs([]string{"AAAA", "--who-can-post", "managers_only"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	if mask != "permission_settings.post_messages" {
+		t.Errorf("unexpected update mask: %q", mask)
+	}
+	post, _ := patched["permissionSettings"].(map[string]interface{})["postMessages"].(map[string]interface{})
+	if post["managersAllowed"] != true || post["membersAllowed"] != false {
+		t.Errorf("expected managers-only post setting with membersAllowed sent as false, got %v", post)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["status"] != "updated" || result["who_can_post"] != "MANAGERS_ONLY" {
+		t.Errorf("unexpected output: %v", result)
+	}
+}
+
+func TestChatSetPermissions_RejectsGroupChat(t *testing.T) {
+	server := mockChatServer(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/GRP": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "GET" {
+				t.Errorf("group chat should not be patched")
+			}
+			json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/GRP", SpaceType: "GROUP_CHAT"})
+		},
+	})
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "set-permissions", Args: cobra.ExactArgs(1), RunE: runChatSetPermissions}
+	cmd.Flags().String("who-can-post", "", "")
+	cmd.Flags().String("who-can-reply", "", "")
+	cmd.SetArgs([]string{"spaces/GRP", "--who-can-post", "ALL"})
+
+	_, runErr := captureStdout(t, cmd.Execute)
+	if runErr == nil || !strings.Contains(runErr.Error(), "type SPACE") {
+		t.Errorf("expected space type error, got %v", runErr)
+	}
+}
+
+func TestChatSetupSpace_PostsWelcome(t *testing.T) {
+	var posted chat.Message
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces:setup": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/NEW1", DisplayName: "Launch", SpaceType: "SPACE"})
+		},
+		"/v1/spaces/NEW1/messages": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&posted)
+			json.NewEncoder(w).Encode(&chat.Message{Name: "spaces/NEW1/messages/M1"})
+		},
+	}
+
+	server := mockChatServer(t, handlers)
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "setup-space", RunE: runChatSetupSpace}
+	cmd.Flags().String("display-name", "", "")
+	cmd.Flags().String("type", "SPACE", "")
+	cmd.Flags().String("members", "", "")
+	cmd.Flags().String("welcome", "", "")
+	cmd.SetArgs([]string{"--display-name", "Launch", "--members", "users/111", "--welcome", "Welcome aboard!"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("setup-space returned error: %v\noutput: %s", runErr, out)
+	}
+	if posted.Text != "Welcome aboard!" {
+		t.Errorf("expected welcome text to be posted, got %q", posted.Text)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["name"] != "spaces/NEW1" || result["welcome_message"] != "spaces/NEW1/messages/M1" || result["status"] != "created" {
+		t.Errorf("unexpected output: %v", result)
+	}
+}
+
+func TestChatSetupSpace_WelcomeFailureStillReportsSpace(t *testing.T) {
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces:setup": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&chat.Space{Name: "spaces/NEW2", DisplayName: "Launch", SpaceType: "SPACE"})
+		},
+		"/v1/spaces/NEW2/messages": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"denied"}}`))
+		},
+	}
+
+	server := mockChatServer(t, handlers)
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	cmd := &cobra.Command{Use: "setup-space", RunE: runChatSetupSpace}
+	cmd.Flags().String("display-name", "", "")
+	cmd.Flags().String("type", "SPACE", "")
+	cmd.Flags().String("members", "", "")
+	cmd.Flags().String("welcome", "", "")
+	cmd.SetArgs([]string{"--display-name", "Launch", "--welcome", "hi"})
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr == nil {
+		t.Fatal("expected an error when the welcome post fails")
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["name"] != "spaces/NEW2" || result["welcome_error"] == nil {
+		t.Errorf("expected the created space and welcome_error, got %v", result)
+	}
+}
+
+func runChatMyRoleAgainst(t *testing.T, handlers map[string]func(w http.ResponseWriter, r *http.Request), space string) (string, error) {
+	t.Helper()
+	chatServer := mockChatServer(t, handlers)
+	defer chatServer.Close()
+	peopleServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"resourceName": "people/111"})
+	}))
+	defer peopleServer.Close()
+
+	chatSvc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(chatServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	peopleSvc, err := people.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(peopleServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldChatSvc, oldPeopleSvc := chatServiceForTest, peopleServiceForTest
+	chatServiceForTest, peopleServiceForTest = chatSvc, peopleSvc
+	defer func() { chatServiceForTest, peopleServiceForTest = oldChatSvc, oldPeopleSvc }()
+
+	cmd := &cobra.Command{Use: "my-role", Args: cobra.ExactArgs(1), RunE: runChatMyRole}
+	cmd.SetArgs([]string{space})
+	return captureStdout(t, cmd.Execute)
+}
+
+func TestChatMyRole_Manager(t *testing.T) {
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA/members/111": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&chat.Membership{
+				Name:       "spaces/AAAA/members/111",
+				Role:       "ROLE_MANAGER",
+				State:      "JOINED",
+				CreateTime: "2026-01-05T10:00:00Z",
+			})
+		},
+	}
+
+	out, err := runChatMyRoleAgainst(t, handlers, "AAAA")
+	if err != nil {
+		t.Fatalf("my-role returned error: %v\noutput: %s", err, out)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["member"] != true || result["is_manager"] != true || result["role"] != "ROLE_MANAGER" {
+		t.Errorf("unexpected role output: %v", result)
+	}
+	if result["joined"] != "2026-01-05T10:00:00Z" || result["user"] != "users/111" {
+		t.Errorf("unexpected membership details: %v", result)
+	}
+}
+
+func TestChatMyRole_NotAMember(t *testing.T) {
+	out, err := runChatMyRoleAgainst(t, map[string]func(w http.ResponseWriter, r *http.Request){}, "spaces/BBBB")
+	if err != nil {
+		t.Fatalf("my-role should not fail for non-members: %v\noutput: %s", err, out)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, out)
+	}
+	if result["member"] != false || result["is_manager"] != false {
+		t.Errorf("expected member=false, got %v", result)
+	}
+}
+
+func TestParseMemberList(t *testing.T) {
+	users, err := parseMemberList(strings.NewReader("# onboarding\nana@example.com\n\n  users/123  \nANA@example.com\nbo@example.com\n"))
+	if err != nil {
+		t.Fatalf("parseMemberList: %v", err)
+	}
+	want := []string{"users/ana@example.com", "users/123", "users/bo@example.com"}
+	if strings.Join(users, ",") != strings.Join(want, ",") {
+		t.Errorf("users = %v, want %v", users, want)
+	}
+
+	if _, err := parseMemberList(strings.NewReader("ana@example.com\nBo Smith\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected a line 2 error, got %v", err)
+	}
+}
+
+func TestChatAddMembers_ReportsPerUser(t *testing.T) {
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces/AAAA/members": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", r.Method)
+			}
+			var m chat.Membership
+			json.NewDecoder(r.Body).Decode(&m)
+			switch m.Member.Name {
+			case "users/old@example.com":
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"error":{"code":409,"message":"already a member"}}`))
+			case "users/ext@other.com":
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":{"code":403,"message":"external users not allowed"}}`))
+			default:
+				json.NewEncoder(w).Encode(&chat.Membership{Name: "spaces/AAAA/members/" + strings.TrimPrefix(m.Member.Name, "users/")})
+			}
+		},
+	}
+	server := mockChatServer(t, handlers)
+	defer server.Close()
+
+	svc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create chat service: %v", err)
+	}
+	oldChatSvc := chatServiceForTest
+	chatServiceForTest = svc
+	defer func() { chatServiceForTest = oldChatSvc }()
+
+	file := filepath.Join(t.TempDir(), "members.txt")
+	if err := os.WriteFile(file, []byte("new@example.com\nold@example.com\next@other.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{Use: "add-members", Args: cobra.ExactArgs(1), RunE: runChatAddMembers}
+	cmd.Flags().String("file", "", "")
+	cmd.Flags().String("role", "ROLE_MEMBER", "")
+	cmd.Flags().Int("concurrency", 4, "")
+	cmd.Flags().Float64("rate", 2, "")
+	cmd.SetArgs([]string{"AAAA", "--file", file, "--rate", "0"})
+
+	out, runErr := captureStdout(t, cmd.Execute)
+	if runErr != nil {
+		t.Fatalf("add-members returned error: %v\noutput: %s", runErr, out)
+	}
+
+	var result struct {
+		Added   int                      `json:"added_count"`
+		Already int                      `json:"already_member_count"`
+		Failed  int                      `json:"failed_count"`
+		Results []map[string]interface{} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to decode output: %v\noutput: %s", err, out)
+	}
+	if result.Added != 1 || result.Already != 1 || result.Failed != 1 || len(result.Results) != 3 {
+		t.Fatalf("expected 1 added / 1 already member / 1 failed, got %s", out)
+	}
+	if result.Results[0]["user"] != "new@example.com" || result.Results[0]["name"] != "spaces/AAAA/members/new@example.com" {
+		t.Errorf("unexpected first result: %v", result.Results[0])
+	}
+	if result.Results[1]["status"] != "already_member" || result.Results[2]["status"] != "failed" {
+		t.Errorf("unexpected statuses: %v", result.Results)
+	}
+}
+
+// TestChatFindDuplicates_GroupsIdenticalMemberSets verifies spaces with the
+// same members are grouped (largest group first), single-member spaces are
+// listed, and unresolved spaces are only counted.
+func TestChatFindDuplicates_GroupsIdenticalMemberSets(t *testing.T) {
+	tmpHome := t.TempDir()
+	origHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", origHome)
+
+	cache := &spacecache.CacheData{
+		Spaces: map[string]spacecache.SpaceEntry{
+			"spaces/A": {Type: "GROUP_CHAT", Members: []string{"alice@example.com", "bob@example.com"}, MemberCount: 2},
+			"spaces/B": {Type: "GROUP_CHAT", Members: []string{"Bob@Example.com", "alice@example.com"}, MemberCount: 2},
+			"spaces/C": {Type: "GROUP_CHAT", Members: []string{"bob@example.com", "alice@example.com"}, MemberCount: 2},
+			"spaces/D": {Type: "SPACE", DisplayName: "Pair", Members: []string{"carol@example.com", "dan@example.com"}, MemberCount: 2},
+			"spaces/E": {Type: "SPACE", DisplayName: "Pair again", Members: []string{"dan@example.com", "carol@example.com"}, MemberCount: 2},
+			"spaces/F": {Type: "SPACE", DisplayName: "Just me", Members: []string{"alice@example.com"}, MemberCount: 1},
+			"spaces/G": {Type: "GROUP_CHAT", MembersUnresolved: true},
+		},
+	}
+	if err := spacecache.Save(spacecache.DefaultPath(), cache); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	cmd := newFindDuplicatesCmd()
+	output, err := captureStdout(t, func() error { return cmd.RunE(cmd, []string{}) })
+	if err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+
+	var result struct {
+		Groups []struct {
+			Signature string   `json:"signature"`
+			Members   []string `json:"members"`
+			Count     int      `json:"count"`
+			Spaces    []struct {
+				Space string `json:"space"`
+			} `json:"spaces"`
+		} `json:"duplicate_groups"`
+		GroupCount int `json:"duplicate_group_count"`
+		Single     []struct {
+			Space string `json:"space"`
+		} `json:"single_member_spaces"`
+		Scanned    int `json:"spaces_scanned"`
+		Unresolved int `json:"unresolved_spaces"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\nraw: %s", err, output)
+	}
+	if result.GroupCount != 2 || len(result.Groups) != 2 {
+		t.Fatalf("expected 2 duplicate groups, got %s", output)
+	}
+	first := result.Groups[0]
+	if first.Count != 3 || first.Spaces[0].Space != "spaces/A" || first.Spaces[2].Space != "spaces/C" {
+		t.Errorf("expected the 3-space group first, got %+v", first)
+	}
+	if first.Signature != spacecache.MemberSignature([]string{"alice@example.com", "bob@example.com"}) {
+		t.Errorf("unexpected signature %q", first.Signature)
+	}
+	if result.Groups[1].Count != 2 || result.Groups[1].Spaces[0].Space != "spaces/D" {
+		t.Errorf("expected spaces/D and spaces/E second, got %+v", result.Groups[1])
+	}
+	if len(result.Single) != 1 || result.Single[0].Space != "spaces/F" {
+		t.Errorf("expected spaces/F as the single-member space, got %+v", result.Single)
+	}
+	if result.Scanned != 7 || result.Unresolved != 1 {
+		t.Errorf("expected 7 scanned and 1 unresolved, got %d and %d", result.Scanned, result.Unresolved)
+	}
+
+	cmd = newFindDuplicatesCmd()
+	cmd.Flags().Set("type", "space")
+	output, _ = captureStdout(t, func() error { return cmd.RunE(cmd, []string{}) })
+	if !strings.Contains(output, `"duplicate_group_count": 1`) || !strings.Contains(output, `"spaces_scanned": 3`) {
+		t.Errorf("expected --type to scope the check to SPACEs, got %s", output)
+	}
+}
+
+func newChatDoctorServices(t *testing.T, handlers map[string]func(w http.ResponseWriter, r *http.Request)) (*chat.Service, *people.Service) {
+	t.Helper()
+	chatServer := mockChatServer(t, handlers)
+	t.Cleanup(chatServer.Close)
+	peopleServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"resourceName":   "people/111",
+			"emailAddresses": []map[string]interface{}{{"value": "ana@example.com", "metadata": map[string]interface{}{"primary": true}}},
+		})
+	}))
+	t.Cleanup(peopleServer.Close)
+
+	chatSvc, err := chat.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(chatServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	peopleSvc, err := people.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(peopleServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chatSvc, peopleSvc
+}
+
+// validDoctorToken is a token source whose token never expires.
+var validDoctorToken = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})
+
+// failingTokenSource fails every Token call, like a revoked refresh token.
+type failingTokenSource struct{ err error }
+
+func (f failingTokenSource) Token() (*oauth2.Token, error) { return nil, f.err }
+
+func doctorStatuses(checks []doctorCheck) map[string]doctorCheck {
+	byName := make(map[string]doctorCheck)
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+	return byName
+}
+
+func TestChatDoctorChecks_WarnsOnReadStateScope(t *testing.T) {
+	chatSvc, peopleSvc := newChatDoctorServices(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"spaces": []map[string]interface{}{{"name": "spaces/AAAA"}, {"name": "spaces/BBBB"}}})
+		},
+		"/v1/spaces/AAAA/messages": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		},
+		"/v1/users/me/spaces/AAAA/spaceReadState": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"Request had insufficient authentication scopes.","status":"PERMISSION_DENIED"}}`))
+		},
+	})
+
+	checks, extra := chatDoctorChecks(context.Background(), validDoctorToken, chatSvc, peopleSvc, []string{"gmail", "chat"})
+	byName := doctorStatuses(checks)
+	for name, want := range map[string]string{"credentials": "ok", "auth_mode": "ok", "scopes": "ok", "identity": "ok", "list_spaces": "ok", "messages": "ok", "read_state": "warn"} {
+		if byName[name].Status != want {
+			t.Errorf("%s: status %q, want %q (%s)", name, byName[name].Status, want, byName[name].Detail)
+		}
+	}
+	if byName["read_state"].Remedy != "gws auth login --services gmail,chat" {
+		t.Errorf("unexpected read_state remedy: %q", byName["read_state"].Remedy)
+	}
+	if extra["user"] != "users/111" || extra["email"] != "ana@example.com" || extra["spaces"] != 2 || extra["auth_mode"] != "user" {
+		t.Errorf("unexpected extra fields: %v", extra)
+	}
+
+	var buf bytes.Buffer
+	if err := printChatDoctor(printer.New(&buf, "json"), checks, extra); err != nil {
+		t.Fatalf("warnings alone should not fail: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"status": "warn"`) {
+		t.Errorf("expected overall warn, got %s", buf.String())
+	}
+}
+
+func TestChatDoctorChecks_CredentialsRefreshFails(t *testing.T) {
+	chatSvc, peopleSvc := newChatDoctorServices(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		},
+	})
+
+	ts := failingTokenSource{err: errors.New("oauth2: \"invalid_grant\" \"Token has been expired or revoked.\"")}
+	checks, _ := chatDoctorChecks(context.Background(), ts, chatSvc, peopleSvc, []string{"chat"})
+	c := doctorStatuses(checks)["credentials"]
+	if c.Status != "fail" || !strings.Contains(c.Detail, "invalid_grant") || c.Remedy != "gws auth login" {
+		t.Errorf("expected credentials to fail with the refresh error, got %+v", c)
+	}
+
+	checks, _ = chatDoctorChecks(context.Background(), nil, chatSvc, peopleSvc, []string{"chat"})
+	if c := doctorStatuses(checks)["credentials"]; c.Status != "skip" {
+		t.Errorf("expected credentials skipped without a token source, got %+v", c)
+	}
+}
+
+func TestChatDoctorChecks_APINotEnabled(t *testing.T) {
+	chatSvc, peopleSvc := newChatDoctorServices(t, map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/spaces": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"Google Chat API has not been used in project 123 before or it is disabled.","status":"PERMISSION_DENIED","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"SERVICE_DISABLED"}]}}`))
+		},
+	})
+
+	checks, extra := chatDoctorChecks(context.Background(), validDoctorToken, chatSvc, peopleSvc, []string{"gmail"})
+	byName := doctorStatuses(checks)
+	if c := byName["scopes"]; c.Status != "fail" || c.Remedy != "gws auth login --services gmail,chat" {
+		t.Errorf("expected missing chat scope to fail, got %+v", c)
+	}
+	if c := byName["list_spaces"]; c.Status != "fail" || !strings.Contains(c.Detail, "not enabled") || !strings.Contains(c.Remedy, chatAPIEnableURL) {
+		t.Errorf("expected Chat API not enabled, got %+v", c)
+	}
+	if byName["messages"].Status != "skip" || byName["read_state"].Status != "skip" {
+		t.Errorf("expected space checks skipped, got %+v %+v", byName["messages"], byName["read_state"])
+	}
+
+	var buf bytes.Buffer
+	err := printChatDoctor(printer.New(&buf, "json"), checks, extra)
+	if err == nil || !strings.Contains(err.Error(), "scopes, list_spaces") {
+		t.Errorf("expected failed checks error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), `"status": "fail"`) {
+		t.Errorf("expected overall fail, got %s", buf.String())
+	}
+}
+
+func TestDiagnoseChatError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		detail string
+		remedy string
+	}{
+		{"not found", &googleapi.Error{Code: 404, Message: "Google Chat app not found."}, "no Chat app is configured", "Configuration"},
+		{"unauthorized", &googleapi.Error{Code: 401}, "rejected the credentials", "gws auth login"},
+		{"scope", &googleapi.Error{Code: 403, Message: "Request had insufficient authentication scopes."}, "missing a required Chat scope", "gws auth login"},
+		{"transient", &googleapi.Error{Code: 503, Message: "backend error"}, "temporary API error", "Retry"},
+		{"network", errors.New("dial tcp: no route to host"), "no route to host", "network"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail, remedy := diagnoseChatError(tt.err, nil)
+			if !strings.Contains(detail, tt.detail) || !strings.Contains(remedy, tt.remedy) {
+				t.Errorf("got (%q, %q), want detail containing %q and remedy containing %q", detail, remedy, tt.detail, tt.remedy)
+			}
+		})
+	}
+}
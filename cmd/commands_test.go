@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/omriariav/workspace-cli/internal/config"
 	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
 )
@@ -31,6 +33,34 @@ func TestRootCommand_Flags(t *testing.T) {
 	}
 }
 
+func TestInitConfig_Profile(t *testing.T) {
+	origProfile := profile
+	t.Cleanup(func() {
+		profile = origProfile
+		config.SetProfile("")
+	})
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GWS_PROFILE", "personal")
+
+	profile = ""
+	initConfig()
+	if got := config.GetProfile(); got != "personal" {
+		t.Errorf("expected GWS_PROFILE to select 'personal', got %q", got)
+	}
+
+	profile = "work"
+	initConfig()
+	if got := config.GetProfile(); got != "work" {
+		t.Errorf("expected --profile to win over GWS_PROFILE, got %q", got)
+	}
+
+	profile = "../work"
+	initConfig()
+	if err := rootCmd.PersistentPreRunE(rootCmd, nil); err == nil || !strings.Contains(err.Error(), "invalid profile") {
+		t.Errorf("expected an invalid profile to be rejected, got %v", err)
+	}
+}
+
 func TestGetPrinter_QuietMode(t *testing.T) {
 	// Save and restore quiet state
 	origQuiet := quiet
@@ -62,7 +92,7 @@ func TestRootCommand_HasSubcommands(t *testing.T) {
 	}
 
 	// Check for expected subcommands
-	expected := []string{"auth", "gmail", "calendar", "tasks", "drive", "docs", "sheets", "slides", "chat", "forms", "search", "contacts", "groups", "keep"}
+	expected := []string{"auth", "gmail", "calendar", "tasks", "drive", "docs", "sheets", "slides", "chat", "forms", "search", "contacts", "groups", "keep", "run"}
 	for _, name := range expected {
 		found := false
 		for _, cmd := range subcommands {
@@ -122,6 +152,7 @@ func TestGmailCommands(t *testing.T) {
 		{"labels", "labels", false},
 		{"label", "label <message-id>", true},
 		{"archive", "archive <message-id>", true},
+		{"mark", "mark [message-id]", true},
 		{"trash", "trash <message-id>", true},
 		{"archive-thread", "archive-thread <thread-id>", true},
 		{"thread", "thread [thread-id]", true},
@@ -147,6 +178,11 @@ func TestGmailCommands(t *testing.T) {
 		{"delete-draft", "delete-draft", false},
 		{"attachment", "attachment", false},
 		{"links", "links <message-id>", true},
+		{"import", "import", false},
+		{"vacation", "vacation", false},
+		{"count", "count", false},
+		{"stats", "stats", false},
+		{"large", "large", false},
 	}
 
 	for _, tt := range tests {
@@ -231,6 +267,9 @@ func TestCalendarCommands(t *testing.T) {
 		{"freebusy"},
 		{"colors"},
 		{"settings"},
+		{"import-events"},
+		{"set-ooo"},
+		{"set-focus-time"},
 	}
 
 	for _, tt := range tests {
@@ -289,6 +328,8 @@ func TestTasksCommands(t *testing.T) {
 		{"delete"},
 		{"move"},
 		{"clear"},
+		{"export"},
+		{"import"},
 	}
 
 	for _, tt := range tests {
@@ -436,6 +477,15 @@ func TestSheetsCommands(t *testing.T) {
 		{"read"},
 		{"create"},
 		{"write"},
+		{"import-csv"},
+		{"export-csv"},
+		{"capture-template"},
+		{"apply-template"},
+		{"number-format"},
+		{"set-metadata"},
+		{"search-metadata"},
+		{"append-mapped"},
+		{"list-merges"},
 		{"append"},
 		{"add-sheet"},
 		{"delete-sheet"},
@@ -456,9 +506,42 @@ func TestSheetsCommands(t *testing.T) {
 		{"add-chart"},
 		{"list-charts"},
 		{"delete-chart"},
+		{"update-chart"},
 		{"add-conditional-format"},
 		{"list-conditional-formats"},
 		{"delete-conditional-format"},
+		{"add-range-dropdown"},
+		{"add-validation"},
+		{"clear-validation"},
+		{"format-as-table"},
+		{"link-range"},
+		{"formulas"},
+		{"lock-header"},
+		{"protect-named"},
+		{"protect"},
+		{"list-protections"},
+		{"unprotect"},
+		{"a1"},
+		{"freeze-values"},
+		{"to-html"},
+		{"set-default-format"},
+		{"retype"},
+		{"clean"},
+		{"status-colors"},
+		{"dump"},
+		{"copy-spreadsheet"},
+		{"snapshot"},
+		{"diff"},
+		{"upsert"},
+		{"to-env"},
+		{"write-typed"},
+		{"stamp"},
+		{"set-borders"},
+		{"filter-read"},
+		{"trace"},
+		{"refresh"},
+		{"add-pivot"},
+		{"comments"},
 	}
 
 	for _, tt := range tests {
@@ -491,6 +574,7 @@ func TestSlidesCommands(t *testing.T) {
 		{"delete-object"},
 		{"delete-text"},
 		{"update-text-style"},
+		{"add-hyperlink"},
 		{"update-transform"},
 		{"create-table"},
 		{"insert-table-rows"},
@@ -506,6 +590,31 @@ func TestSlidesCommands(t *testing.T) {
 		{"group"},
 		{"ungroup"},
 		{"thumbnail"},
+		{"contact-sheet"},
+		{"add-footer"},
+		{"set-alt-text"},
+		{"replace-shapes-with-image"},
+		{"replace-image"},
+		{"batch"},
+		{"replace-shapes-with-chart"},
+		{"merge"},
+		{"clone-as"},
+		{"set-font"},
+		{"set-defaults"},
+		{"fonts"},
+		{"update-line"},
+		{"toggle-slide-numbers"},
+		{"set-all-backgrounds"},
+		{"grid-layout"},
+		{"comments"},
+		{"resolve-comment"},
+		{"delete-comment"},
+		{"export-pdf"},
+		{"add-data-table"},
+		{"set-body"},
+		{"set-slide"},
+		{"skip"},
+		{"add-bullets"},
 	}
 
 	for _, tt := range tests {
@@ -545,6 +654,7 @@ func TestChatCommands(t *testing.T) {
 		{"setup-space"},
 		{"get-member"},
 		{"add-member"},
+		{"add-members"},
 		{"remove-member"},
 		{"update-member"},
 		{"read-state"},
@@ -559,6 +669,21 @@ func TestChatCommands(t *testing.T) {
 		{"build-cache"},
 		{"find-group"},
 		{"find-space"},
+		{"user-spaces"},
+		{"find-duplicates"},
+		{"activity"},
+		{"digest"},
+		{"broadcast"},
+		{"leave"},
+		{"unread-counts"},
+		{"link"},
+		{"space-link"},
+		{"set-permissions"},
+		{"my-role"},
+		{"snooze"},
+		{"unsnooze"},
+		{"snoozed"},
+		{"doctor"},
 		{"spaces"},
 	}
 
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// maxProbedArgs bounds the argument-arity probe. A command that still
+// accepts this many positional arguments is reported as unbounded.
+const maxProbedArgs = 16
+
+// dumpCommands describes the whole command tree below root: global flags
+// once, then every available command in depth-first order.
+func dumpCommands(root *cobra.Command) map[string]interface{} {
+	commands := []map[string]interface{}{}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			if !sub.IsAvailableCommand() {
+				continue
+			}
+			commands = append(commands, describeCommand(sub))
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	return map[string]interface{}{
+		"name":         root.Name(),
+		"version":      Version,
+		"global_flags": describeFlags(root.PersistentFlags()),
+		"commands":     commands,
+		"count":        len(commands),
+	}
+}
+
+// describeCommand returns one command's path, usage, flags, and argument
+// arity. Group commands (no Run) have no args entry.
+func describeCommand(c *cobra.Command) map[string]interface{} {
+	entry := map[string]interface{}{
+		"path":     c.CommandPath(),
+		"name":     c.Name(),
+		"use":      c.Use,
+		"short":    c.Short,
+		"runnable": c.Runnable(),
+		"flags":    describeFlags(c.NonInheritedFlags()),
+	}
+	if len(c.Aliases) > 0 {
+		entry["aliases"] = c.Aliases
+	}
+	if c.Runnable() {
+		min, max := commandArity(c)
+		args := map[string]interface{}{"min": min, "max": nil}
+		if max >= 0 {
+			args["max"] = max
+		}
+		entry["args"] = args
+	}
+	var subs []string
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() {
+			subs = append(subs, sub.Name())
+		}
+	}
+	if len(subs) > 0 {
+		entry["subcommands"] = subs
+	}
+	return entry
+}
+
+// commandArity probes the command's positional-argument validator with
+// 0..maxProbedArgs placeholder arguments, since cobra.PositionalArgs are
+// opaque functions. It returns the smallest and largest accepted counts;
+// max is -1 when the largest probe is still accepted.
+func commandArity(c *cobra.Command) (min, max int) {
+	min, max = -1, -1
+	for n := 0; n <= maxProbedArgs; n++ {
+		args := make([]string, n)
+		for i := range args {
+			args[i] = "x"
+		}
+		if c.ValidateArgs(args) != nil {
+			continue
+		}
+		if min < 0 {
+			min = n
+		}
+		max = n
+	}
+	if min < 0 {
+		return 0, 0
+	}
+	if max == maxProbedArgs {
+		max = -1
+	}
+	return min, max
+}
+
+// describeFlags lists the visible flags of fs in name order, skipping the
+// auto-generated --help.
+func describeFlags(fs *pflag.FlagSet) []map[string]interface{} {
+	flags := []map[string]interface{}{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		required := false
+		if ann, ok := f.Annotations[cobra.BashCompOneRequiredFlag]; ok && len(ann) > 0 && ann[0] == "true" {
+			required = true
+		}
+		flag := map[string]interface{}{
+			"name":     f.Name,
+			"type":     f.Value.Type(),
+			"default":  flagDefault(f),
+			"required": required,
+			"usage":    f.Usage,
+		}
+		if f.Shorthand != "" {
+			flag["shorthand"] = f.Shorthand
+		}
+		flags = append(flags, flag)
+	})
+	return flags
+}
+
+// flagDefault converts a flag's string default to its JSON type: booleans
+// and numbers become JSON values, slice flags become arrays.
+func flagDefault(f *pflag.Flag) interface{} {
+	def := f.DefValue
+	typ := f.Value.Type()
+	switch {
+	case typ == "bool":
+		if b, err := strconv.ParseBool(def); err == nil {
+			return b
+		}
+	case strings.HasPrefix(typ, "int") || strings.HasPrefix(typ, "uint"):
+		if n, err := strconv.ParseInt(def, 10, 64); err == nil {
+			return n
+		}
+	case strings.HasPrefix(typ, "float"):
+		if x, err := strconv.ParseFloat(def, 64); err == nil {
+			return x
+		}
+	case strings.HasSuffix(typ, "Slice") || strings.HasSuffix(typ, "Array"):
+		inner := strings.TrimSuffix(strings.TrimPrefix(def, "["), "]")
+		if inner == "" {
+			return []string{}
+		}
+		return strings.Split(inner, ",")
+	}
+	return def
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDumpCommands_DescribesTree(t *testing.T) {
+	dump := dumpCommands(rootCmd)
+	commands, _ := dump["commands"].([]map[string]interface{})
+	if len(commands) == 0 || dump["count"] != len(commands) {
+		t.Fatalf("unexpected command count: %v", dump["count"])
+	}
+
+	byPath := make(map[string]map[string]interface{})
+	for _, c := range commands {
+		byPath[c["path"].(string)] = c
+	}
+
+	sheets, ok := byPath["gws sheets"]
+	if !ok {
+		t.Fatal("expected gws sheets in the dump")
+	}
+	if _, ok := sheets["args"]; ok || sheets["runnable"] != false {
+		t.Errorf("group command should have no args entry: %v", sheets)
+	}
+
+	read := byPath["gws sheets read"]
+	if !reflect.DeepEqual(read["args"], map[string]interface{}{"min": 2, "max": 2}) {
+		t.Errorf("unexpected sheets read arity: %v", read["args"])
+	}
+
+	diff := byPath["gws sheets diff"]
+	flags := make(map[string]map[string]interface{})
+	for _, f := range diff["flags"].([]map[string]interface{}) {
+		flags[f["name"].(string)] = f
+	}
+	if flags["a"]["required"] != true || flags["a"]["type"] != "string" {
+		t.Errorf("unexpected --a: %v", flags["a"])
+	}
+	if flags["max-diffs"]["default"] != int64(1000) || flags["max-diffs"]["required"] != false {
+		t.Errorf("unexpected --max-diffs: %v", flags["max-diffs"])
+	}
+	if flags["fail-on-diff"]["default"] != false {
+		t.Errorf("unexpected --fail-on-diff: %v", flags["fail-on-diff"])
+	}
+	if _, ok := flags["format"]; ok {
+		t.Error("global flags should not be repeated per command")
+	}
+
+	global := make(map[string]bool)
+	for _, f := range dump["global_flags"].([]map[string]interface{}) {
+		global[f["name"].(string)] = true
+	}
+	if !global["format"] || !global["quiet"] {
+		t.Errorf("expected --format and --quiet in global flags: %v", global)
+	}
+}
+
+func TestCommandArity(t *testing.T) {
+	tests := []struct {
+		args     cobra.PositionalArgs
+		min, max int
+	}{
+		{cobra.NoArgs, 0, 0},
+		{cobra.ExactArgs(2), 2, 2},
+		{cobra.MaximumNArgs(1), 0, 1},
+		{cobra.RangeArgs(1, 3), 1, 3},
+		{cobra.MinimumNArgs(1), 1, -1},
+		{cobra.ArbitraryArgs, 0, -1},
+	}
+	for _, tt := range tests {
+		c := &cobra.Command{Use: "x", Args: tt.args, Run: func(*cobra.Command, []string) {}}
+		min, max := commandArity(c)
+		if min != tt.min || max != tt.max {
+			t.Errorf("commandArity = %d, %d; want %d, %d", min, max, tt.min, tt.max)
+		}
+	}
+}
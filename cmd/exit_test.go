@@ -8,8 +8,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/omriariav/workspace-cli/internal/client"
 	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/googleapi"
 )
 
@@ -70,6 +72,20 @@ func TestExitCodeForError_WrappedGoogleAPIError(t *testing.T) {
 	}
 }
 
+func TestExitCodeForError_ReauthRequired(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &client.ReauthError{Reason: "not authenticated"})
+	if got := exitCodeForError(err); got != ExitReauth {
+		t.Errorf("expected ExitReauth (5), got %d", got)
+	}
+}
+
+func TestExitCodeForError_RefreshFailureMidCommand(t *testing.T) {
+	err := fmt.Errorf("Get \"https://gmail.googleapis.com/...\": %w", &oauth2.RetrieveError{ErrorCode: "invalid_grant"})
+	if got := exitCodeForError(err); got != ExitReauth {
+		t.Errorf("expected ExitReauth (5), got %d", got)
+	}
+}
+
 // --- resolveExitError: full dispatch contract ----------------------------
 
 func TestResolveExitError_NilReturnsOK(t *testing.T) {
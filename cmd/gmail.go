@@ -8,18 +8,22 @@ import (
 	"encoding/hex"
 	"fmt"
 	"mime"
+	"net/mail"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/omriariav/workspace-cli/internal/client"
 	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/html"
 	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
 )
 
 var gmailCmd = &cobra.Command{
@@ -51,7 +55,8 @@ var gmailSendCmd = &cobra.Command{
 Examples:
   gws gmail send --to user@example.com --subject "Hello" --body "Hi there"
   gws gmail send --to user@example.com --subject "Report" --body "See attached" --attachment "/tmp/report.pdf"
-  gws gmail send --to user@example.com --subject "Files" --body "Multiple files" --attachment /tmp/a.pdf --attachment /tmp/b.png`,
+  gws gmail send --to user@example.com --subject "Files" --body "Multiple files" --attachment /tmp/a.pdf --attachment /tmp/b.png
+  gws gmail send --to user@example.com --subject "Ticket" --body "On it" --from support@example.com`,
 	RunE: runGmailSend,
 }
 
@@ -89,6 +94,24 @@ Examples:
 	RunE: runGmailArchive,
 }
 
+var gmailMarkCmd = &cobra.Command{
+	Use:   "mark [message-id]",
+	Short: "Mark messages read/unread or starred/unstarred",
+	Long: `Marks a single message, or every message matching --query, as read, unread,
+starred, or unstarred by updating the UNREAD and STARRED labels.
+
+With --query, only messages whose state would actually change are matched,
+so the reported count is the number of messages changed. Query matches are
+updated with batchModify in chunks of 1000, up to --max messages.
+
+Examples:
+  gws gmail mark 18abc123 --read
+  gws gmail mark 18abc123 --star --unread
+  gws gmail mark --query "from:alerts@example.com newer_than:7d" --read`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGmailMark,
+}
+
 var gmailTrashCmd = &cobra.Command{
 	Use:   "trash <message-id>",
 	Short: "Trash a message",
@@ -138,7 +161,8 @@ and In-Reply-To/References headers from the original message.
 Examples:
   gws gmail reply 18abc123 --body "Thanks, got it!"
   gws gmail reply 18abc123 --body "Adding someone" --cc extra@example.com
-  gws gmail reply 18abc123 --body "Sounds good" --all`,
+  gws gmail reply 18abc123 --body "Sounds good" --all
+  gws gmail reply 18abc123 --body "Resolved" --from support@example.com`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGmailReply,
 }
@@ -148,12 +172,14 @@ var gmailForwardCmd = &cobra.Command{
 	Short: "Forward a message",
 	Long: `Forwards an existing email message to new recipients.
 
-Preserves the original message content and attachments.
+Preserves the original message content and attachments. The original From,
+Date, Subject, To, and Cc headers are quoted above the original body, and
+--note (or --body) is placed above them.
 Adds a "Fwd:" prefix to the subject if not already present.
 
 Examples:
   gws gmail forward 18abc123 --to "user@example.com"
-  gws gmail forward 18abc123 --to "user1@example.com,user2@example.com" --body "FYI"
+  gws gmail forward 18abc123 --to "user1@example.com,user2@example.com" --note "FYI"
   gws gmail forward 18abc123 --to "user@example.com" --cc "manager@example.com"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGmailForward,
@@ -322,6 +348,115 @@ var gmailLinksCmd = &cobra.Command{
 	RunE:  runGmailLinks,
 }
 
+var gmailImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import an RFC 822 message into the mailbox without sending it",
+	Long: `Adds an existing message (.eml file) to the mailbox as if it had been
+received, without sending it. Useful for migrating mail from other systems.
+
+Imported messages get only the labels passed with --labels; include INBOX to
+have them show up in the inbox. By default the message date comes from its
+Date header (--date-source dateHeader); use receivedTime to stamp it with the
+import time.
+
+Examples:
+  gws gmail import --file message.eml
+  gws gmail import --file message.eml --labels INBOX,Imported --never-mark-spam`,
+	Args: cobra.NoArgs,
+	RunE: runGmailImport,
+}
+
+var gmailVacationCmd = &cobra.Command{
+	Use:   "vacation",
+	Short: "View and manage the vacation auto-reply",
+}
+
+var gmailVacationGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the vacation auto-reply settings",
+	Long: `Shows the current vacation (out-of-office) auto-reply settings.
+
+Examples:
+  gws gmail vacation get`,
+	Args: cobra.NoArgs,
+	RunE: runGmailVacationGet,
+}
+
+var gmailVacationSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Enable, update, or disable the vacation auto-reply",
+	Long: `Updates the vacation (out-of-office) auto-reply. Only the flags you pass are
+changed; the rest of the current settings are kept. The auto-reply is enabled
+unless --disable is set.
+
+--start and --end accept RFC3339, "YYYY-MM-DD HH:MM", or "YYYY-MM-DD". A
+date-only --end covers that whole day. Pass an empty value to clear a date.
+
+Examples:
+  gws gmail vacation set --subject "OOO" --body "Back on Monday" --start 2026-10-20 --end 2026-10-24
+  gws gmail vacation set --body "Away this week" --restrict-contacts
+  gws gmail vacation set --disable`,
+	Args: cobra.NoArgs,
+	RunE: runGmailVacationSet,
+}
+
+var gmailCountCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Count messages matching a query",
+	Long: `Counts the messages matching a Gmail search query without fetching them.
+Only message IDs are listed (500 per page), so this is fast even for large
+result sets. Counting stops at --cap and the result is flagged as capped.
+
+--estimate skips paging and returns Gmail's resultSizeEstimate from a single
+call instead. It is approximate but instant for very large mailboxes.
+
+Examples:
+  gws gmail count --query "label:unread"
+  gws gmail count --query "in:inbox older_than:30d" --cap 50000
+  gws gmail count --query "in:anywhere" --estimate`,
+	Args: cobra.NoArgs,
+	RunE: runGmailCount,
+}
+
+var gmailStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Count messages matching a query, per label",
+	Long: `Counts the messages matching a Gmail search query and breaks the total down
+by label. Each label is counted with its own ID-only listing (--concurrency in
+parallel), so no message is fetched. Labels with no matches are left out; the
+rest are sorted by count, largest first. Counts stop at --cap per label.
+
+A message with several labels is counted under each of them, so the label
+counts can add up to more than the total.
+
+Examples:
+  gws gmail stats --query "label:unread"
+  gws gmail stats --query "newer_than:7d" --type user`,
+	Args: cobra.NoArgs,
+	RunE: runGmailStats,
+}
+
+var gmailLargeCmd = &cobra.Command{
+	Use:   "large",
+	Short: "List the largest messages, biggest first",
+	Long: `Finds messages larger than --min-size and lists them by size, largest first,
+with sender, subject, date, and a human-readable size. Useful for finding the
+attachments that use up mailbox quota.
+
+Matching message IDs are listed 500 per page, then each message's size and
+headers are fetched (--concurrency in parallel). At most --cap matches are
+scanned; the result is flagged as capped when more exist.
+
+--min-size accepts bytes or a K, M, or G suffix (powers of 1024).
+
+Examples:
+  gws gmail large
+  gws gmail large --min-size 10M --max 20
+  gws gmail large --min-size 5M --query "older_than:1y"`,
+	Args: cobra.NoArgs,
+	RunE: runGmailLarge,
+}
+
 func init() {
 	rootCmd.AddCommand(gmailCmd)
 	gmailCmd.AddCommand(gmailListCmd)
@@ -330,6 +465,7 @@ func init() {
 	gmailCmd.AddCommand(gmailLabelsCmd)
 	gmailCmd.AddCommand(gmailLabelCmd)
 	gmailCmd.AddCommand(gmailArchiveCmd)
+	gmailCmd.AddCommand(gmailMarkCmd)
 	gmailCmd.AddCommand(gmailArchiveThreadCmd)
 	gmailCmd.AddCommand(gmailTrashCmd)
 	gmailCmd.AddCommand(gmailThreadCmd)
@@ -354,6 +490,7 @@ func init() {
 	gmailSendCmd.Flags().String("thread-id", "", "Thread ID to reply in")
 	gmailSendCmd.Flags().String("reply-to-message-id", "", "Message ID to reply to (sets In-Reply-To/References headers)")
 	gmailSendCmd.Flags().StringArray("attachment", nil, "File path to attach (repeatable: --attachment a.pdf --attachment b.pdf)")
+	gmailSendCmd.Flags().String("from", "", "Send-as address to use as the From header (must be a verified alias)")
 	gmailSendCmd.MarkFlagRequired("to")
 	gmailSendCmd.MarkFlagRequired("subject")
 	gmailSendCmd.MarkFlagRequired("body")
@@ -363,11 +500,13 @@ func init() {
 	gmailReplyCmd.Flags().String("cc", "", "CC recipients (comma-separated)")
 	gmailReplyCmd.Flags().String("bcc", "", "BCC recipients (comma-separated)")
 	gmailReplyCmd.Flags().Bool("all", false, "Reply to all recipients")
+	gmailReplyCmd.Flags().String("from", "", "Send-as address to use as the From header (must be a verified alias)")
 	gmailReplyCmd.MarkFlagRequired("body")
 
 	// Forward flags
 	gmailForwardCmd.Flags().String("to", "", "Recipient email addresses (comma-separated, required)")
 	gmailForwardCmd.Flags().String("body", "", "Optional note above the forwarded content")
+	gmailForwardCmd.Flags().String("note", "", "Optional note above the forwarded content (same as --body)")
 	gmailForwardCmd.Flags().String("cc", "", "CC recipients (comma-separated)")
 	gmailForwardCmd.Flags().String("bcc", "", "BCC recipients (comma-separated)")
 	gmailForwardCmd.MarkFlagRequired("to")
@@ -376,6 +515,14 @@ func init() {
 	gmailLabelCmd.Flags().String("add", "", "Label names to add (comma-separated)")
 	gmailLabelCmd.Flags().String("remove", "", "Label names to remove (comma-separated)")
 
+	// Mark flags
+	gmailMarkCmd.Flags().Bool("read", false, "Mark as read (remove UNREAD)")
+	gmailMarkCmd.Flags().Bool("unread", false, "Mark as unread (add UNREAD)")
+	gmailMarkCmd.Flags().Bool("star", false, "Star (add STARRED)")
+	gmailMarkCmd.Flags().Bool("unstar", false, "Unstar (remove STARRED)")
+	gmailMarkCmd.Flags().String("query", "", "Gmail search query selecting messages to mark (instead of a message ID)")
+	gmailMarkCmd.Flags().Int64("max", 500, "Maximum number of messages to mark with --query")
+
 	// New commands
 	gmailCmd.AddCommand(gmailUntrashCmd)
 	gmailCmd.AddCommand(gmailDeleteCmd)
@@ -396,6 +543,13 @@ func init() {
 	gmailCmd.AddCommand(gmailDeleteDraftCmd)
 	gmailCmd.AddCommand(gmailAttachmentCmd)
 	gmailCmd.AddCommand(gmailLinksCmd)
+	gmailCmd.AddCommand(gmailImportCmd)
+	gmailCmd.AddCommand(gmailVacationCmd)
+	gmailVacationCmd.AddCommand(gmailVacationGetCmd)
+	gmailVacationCmd.AddCommand(gmailVacationSetCmd)
+	gmailCmd.AddCommand(gmailCountCmd)
+	gmailCmd.AddCommand(gmailStatsCmd)
+	gmailCmd.AddCommand(gmailLargeCmd)
 
 	// Batch modify flags
 	gmailBatchModifyCmd.Flags().String("ids", "", "Comma-separated message IDs (required)")
@@ -470,6 +624,43 @@ func init() {
 	gmailAttachmentCmd.MarkFlagRequired("message-id")
 	gmailAttachmentCmd.MarkFlagRequired("id")
 	gmailAttachmentCmd.MarkFlagRequired("output")
+
+	// Import flags
+	gmailImportCmd.Flags().String("file", "", "Path to the RFC 822 message (.eml) to import (required)")
+	gmailImportCmd.Flags().String("labels", "", "Label names to apply (comma-separated, e.g. INBOX,Imported)")
+	gmailImportCmd.Flags().Bool("never-mark-spam", false, "Never mark the imported message as spam")
+	gmailImportCmd.Flags().String("date-source", "dateHeader", "Internal date source: dateHeader or receivedTime")
+	gmailImportCmd.MarkFlagRequired("file")
+
+	// Vacation set flags
+	gmailVacationSetCmd.Flags().String("subject", "", "Auto-reply subject")
+	gmailVacationSetCmd.Flags().String("body", "", "Auto-reply body (plain text)")
+	gmailVacationSetCmd.Flags().String("start", "", "Start sending auto-replies at this time")
+	gmailVacationSetCmd.Flags().String("end", "", "Stop sending auto-replies after this time")
+	gmailVacationSetCmd.Flags().Bool("restrict-contacts", false, "Only reply to senders in your contacts")
+	gmailVacationSetCmd.Flags().Bool("restrict-domain", false, "Only reply to senders in your domain (Workspace accounts)")
+	gmailVacationSetCmd.Flags().Bool("disable", false, "Turn the auto-reply off")
+
+	// Count flags
+	gmailCountCmd.Flags().String("query", "", "Gmail search query (default: all mail except spam and trash)")
+	gmailCountCmd.Flags().Int64("cap", 10000, "Stop counting at this number (0 = no limit)")
+	gmailCountCmd.Flags().Bool("estimate", false, "Return Gmail's approximate result size from a single call")
+	gmailCountCmd.Flags().Bool("include-spam-trash", false, "Include messages in spam and trash")
+
+	// Stats flags
+	gmailStatsCmd.Flags().String("query", "", "Gmail search query (default: all mail except spam and trash)")
+	gmailStatsCmd.Flags().Int64("cap", 10000, "Stop counting each label at this number (0 = no limit)")
+	gmailStatsCmd.Flags().String("type", "", "Only break down by labels of this type: user or system (default: all)")
+	gmailStatsCmd.Flags().Int("concurrency", 4, "Number of labels to count in parallel")
+	gmailStatsCmd.Flags().Bool("include-spam-trash", false, "Include messages in spam and trash")
+
+	// Large flags
+	gmailLargeCmd.Flags().String("min-size", "5M", "Only messages larger than this (bytes, or with a K, M, or G suffix)")
+	gmailLargeCmd.Flags().Int("max", 50, "Maximum number of messages to return")
+	gmailLargeCmd.Flags().String("query", "", "Additional Gmail search query to narrow the search")
+	gmailLargeCmd.Flags().Int64("cap", 2000, "Stop scanning after this many matches (0 = no limit)")
+	gmailLargeCmd.Flags().Int("concurrency", 8, "Number of messages to fetch in parallel")
+	gmailLargeCmd.Flags().Bool("include-spam-trash", false, "Include messages in spam and trash")
 }
 
 func runGmailList(cmd *cobra.Command, args []string) error {
@@ -823,7 +1014,7 @@ func encodeRFC2047(s string) string {
 // attachments are provided, it builds a multipart/mixed MIME message.
 func buildMIMEMessage(headers map[string]string, body string, attachmentPaths []string) ([]byte, error) {
 	// Order matters for headers; use a slice for deterministic output.
-	headerOrder := []string{"To", "Cc", "Bcc", "Subject", "In-Reply-To", "References"}
+	headerOrder := []string{"From", "To", "Cc", "Bcc", "Subject", "In-Reply-To", "References"}
 
 	// Headers that may contain non-ASCII and need RFC 2047 encoding.
 	encodeHeaders := map[string]bool{"Subject": true}
@@ -959,6 +1150,15 @@ func runGmailSend(cmd *cobra.Command, args []string) error {
 	bcc, _ := cmd.Flags().GetString("bcc")
 	threadID, _ := cmd.Flags().GetString("thread-id")
 	replyToMsgID, _ := cmd.Flags().GetString("reply-to-message-id")
+	from, _ := cmd.Flags().GetString("from")
+
+	var fromHeader string
+	if from != "" {
+		fromHeader, err = resolveSendAs(svc, from)
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
 
 	// If replying, fetch the original message's Message-ID and References headers
 	var inReplyTo, origReferences string
@@ -989,6 +1189,9 @@ func runGmailSend(cmd *cobra.Command, args []string) error {
 		"To":      to,
 		"Subject": subject,
 	}
+	if fromHeader != "" {
+		msgHeaders["From"] = fromHeader
+	}
 	if cc != "" {
 		msgHeaders["Cc"] = cc
 	}
@@ -1066,6 +1269,37 @@ func runGmailLabels(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// resolveSendAs validates that addr is one of the account's send-as
+// addresses and returns the From header value to use. Aliases that are
+// not yet verified are rejected up front; otherwise Gmail silently
+// rewrites the From header or fails with an opaque error.
+func resolveSendAs(svc *gmail.Service, addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	resp, err := svc.Users.Settings.SendAs.List("me").Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to list send-as addresses: %w", err)
+	}
+
+	var known []string
+	for _, sa := range resp.SendAs {
+		known = append(known, sa.SendAsEmail)
+		if !strings.EqualFold(sa.SendAsEmail, addr) {
+			continue
+		}
+		// The primary address has no verification status; aliases must
+		// be "accepted" before Gmail will send as them.
+		if !sa.IsPrimary && sa.VerificationStatus != "accepted" {
+			return "", fmt.Errorf("send-as address %s is not verified (status: %s)", sa.SendAsEmail, sa.VerificationStatus)
+		}
+		if sa.DisplayName == "" {
+			return sa.SendAsEmail, nil
+		}
+		return (&mail.Address{Name: sa.DisplayName, Address: sa.SendAsEmail}).String(), nil
+	}
+
+	return "", fmt.Errorf("%s is not a send-as address for this account (available: %s)", addr, strings.Join(known, ", "))
+}
+
 // fetchLabelMap fetches all Gmail labels and returns a case-insensitive name-to-ID map.
 func fetchLabelMap(svc *gmail.Service) (map[string]string, error) {
 	resp, err := svc.Users.Labels.List("me").Do()
@@ -1198,6 +1432,195 @@ func runGmailArchive(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// markLabelChanges maps mark flags to the system labels to add and remove.
+func markLabelChanges(read, unread, star, unstar bool) (add, remove []string) {
+	if read {
+		remove = append(remove, "UNREAD")
+	}
+	if unread {
+		add = append(add, "UNREAD")
+	}
+	if star {
+		add = append(add, "STARRED")
+	}
+	if unstar {
+		remove = append(remove, "STARRED")
+	}
+	return add, remove
+}
+
+// markQueryFilter narrows a search to messages that at least one of the
+// label changes would affect, using Gmail's {a b} OR-group syntax.
+func markQueryFilter(add, remove []string) string {
+	var terms []string
+	for _, l := range add {
+		terms = append(terms, "-is:"+strings.ToLower(l))
+	}
+	for _, l := range remove {
+		terms = append(terms, "is:"+strings.ToLower(l))
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "{" + strings.Join(terms, " ") + "}"
+}
+
+// labelsChanged reports whether applying add/remove to current alters it.
+func labelsChanged(current, add, remove []string) bool {
+	has := make(map[string]bool, len(current))
+	for _, l := range current {
+		has[l] = true
+	}
+	for _, l := range add {
+		if !has[l] {
+			return true
+		}
+	}
+	for _, l := range remove {
+		if has[l] {
+			return true
+		}
+	}
+	return false
+}
+
+// listMessageIDs returns up to max message IDs matching query.
+func listMessageIDs(svc *gmail.Service, query string, max int64) ([]string, error) {
+	var ids []string
+	var pageToken string
+	for int64(len(ids)) < max {
+		perPage := max - int64(len(ids))
+		if perPage > 500 {
+			perPage = 500
+		}
+		call := svc.Users.Messages.List("me").Q(query).MaxResults(perPage)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list messages: %w", err)
+		}
+		for _, m := range resp.Messages {
+			ids = append(ids, m.Id)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	if int64(len(ids)) > max {
+		ids = ids[:max]
+	}
+	return ids, nil
+}
+
+// batchModifyChunked applies the label changes to ids in chunks of 1000,
+// the batchModify limit.
+func batchModifyChunked(svc *gmail.Service, ids, add, remove []string) error {
+	const chunk = 1000
+	for start := 0; start < len(ids); start += chunk {
+		end := start + chunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+		err := svc.Users.Messages.BatchModify("me", &gmail.BatchModifyMessagesRequest{
+			Ids:            ids[start:end],
+			AddLabelIds:    add,
+			RemoveLabelIds: remove,
+		}).Do()
+		if err != nil {
+			return fmt.Errorf("failed to batch modify messages: %w", err)
+		}
+	}
+	return nil
+}
+
+func runGmailMark(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	read, _ := cmd.Flags().GetBool("read")
+	unread, _ := cmd.Flags().GetBool("unread")
+	star, _ := cmd.Flags().GetBool("star")
+	unstar, _ := cmd.Flags().GetBool("unstar")
+	query, _ := cmd.Flags().GetString("query")
+	max, _ := cmd.Flags().GetInt64("max")
+
+	if (len(args) == 0) == (query == "") {
+		return usageErrorf("specify exactly one of <message-id> or --query")
+	}
+	if read && unread {
+		return usageErrorf("--read and --unread are mutually exclusive")
+	}
+	if star && unstar {
+		return usageErrorf("--star and --unstar are mutually exclusive")
+	}
+	if !read && !unread && !star && !unstar {
+		return usageErrorf("at least one of --read, --unread, --star, or --unstar is required")
+	}
+	if max < 1 {
+		return usageErrorf("--max must be at least 1")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	add, remove := markLabelChanges(read, unread, star, unstar)
+
+	if query != "" {
+		fullQuery := "(" + query + ") " + markQueryFilter(add, remove)
+		ids, err := listMessageIDs(svc, fullQuery, max)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		if err := batchModifyChunked(svc, ids, add, remove); err != nil {
+			return p.PrintError(err)
+		}
+		return p.Print(map[string]interface{}{
+			"status":        "marked",
+			"query":         fullQuery,
+			"changed":       len(ids),
+			"add_labels":    add,
+			"remove_labels": remove,
+		})
+	}
+
+	messageID := args[0]
+	current, err := svc.Users.Messages.Get("me", messageID).Format("minimal").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get message: %w", err))
+	}
+
+	labels := current.LabelIds
+	changed := 0
+	if labelsChanged(current.LabelIds, add, remove) {
+		msg, err := svc.Users.Messages.Modify("me", messageID, &gmail.ModifyMessageRequest{
+			AddLabelIds:    add,
+			RemoveLabelIds: remove,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to mark message: %w", err))
+		}
+		labels = msg.LabelIds
+		changed = 1
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":     "marked",
+		"message_id": messageID,
+		"changed":    changed,
+		"labels":     labels,
+	})
+}
+
 func runGmailArchiveThread(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
@@ -1492,6 +1915,15 @@ func runGmailReply(cmd *cobra.Command, args []string) error {
 	cc, _ := cmd.Flags().GetString("cc")
 	bcc, _ := cmd.Flags().GetString("bcc")
 	replyAll, _ := cmd.Flags().GetBool("all")
+	from, _ := cmd.Flags().GetString("from")
+
+	var fromHeader string
+	if from != "" {
+		fromHeader, err = resolveSendAs(svc, from)
+		if err != nil {
+			return p.PrintError(err)
+		}
+	}
 
 	// Fetch the original message
 	origMsg, err := svc.Users.Messages.Get("me", messageID).Format("full").Do()
@@ -1570,6 +2002,9 @@ func runGmailReply(cmd *cobra.Command, args []string) error {
 
 	// Build RFC 2822 message with threading headers
 	var msgBuilder strings.Builder
+	if fromHeader != "" {
+		msgBuilder.WriteString(fmt.Sprintf("From: %s\r\n", fromHeader))
+	}
 	msgBuilder.WriteString(fmt.Sprintf("To: %s\r\n", replyTo))
 	if cc != "" {
 		msgBuilder.WriteString(fmt.Sprintf("Cc: %s\r\n", cc))
@@ -1628,9 +2063,17 @@ func runGmailForward(cmd *cobra.Command, args []string) error {
 	messageID := args[0]
 	to, _ := cmd.Flags().GetString("to")
 	body, _ := cmd.Flags().GetString("body")
+	note, _ := cmd.Flags().GetString("note")
 	cc, _ := cmd.Flags().GetString("cc")
 	bcc, _ := cmd.Flags().GetString("bcc")
 
+	if body != "" && note != "" {
+		return usageErrorf("--note and --body are the same option; use only one")
+	}
+	if note != "" {
+		body = note
+	}
+
 	// Fetch the original message with full payload
 	origMsg, err := svc.Users.Messages.Get("me", messageID).Format("full").Do()
 	if err != nil {
@@ -1638,40 +2081,21 @@ func runGmailForward(cmd *cobra.Command, args []string) error {
 	}
 
 	// Extract headers from original
-	var origSubject, origFrom, origTo, origDate string
+	origHeaders := map[string]string{}
 	for _, header := range origMsg.Payload.Headers {
 		switch header.Name {
-		case "Subject":
-			origSubject = header.Value
-		case "From":
-			origFrom = header.Value
-		case "To":
-			origTo = header.Value
-		case "Date":
-			origDate = header.Value
+		case "Subject", "From", "To", "Cc", "Date":
+			origHeaders[header.Name] = header.Value
 		}
 	}
 
 	// Build forwarded subject
-	fwdSubject := origSubject
+	fwdSubject := origHeaders["Subject"]
 	if !strings.HasPrefix(strings.ToLower(fwdSubject), "fwd:") {
 		fwdSubject = "Fwd: " + fwdSubject
 	}
 
-	// Build forwarded body with original content
-	origBody := extractBody(origMsg.Payload)
-	var fwdBody strings.Builder
-	if body != "" {
-		fwdBody.WriteString(body)
-		fwdBody.WriteString("\r\n\r\n")
-	}
-	fwdBody.WriteString("---------- Forwarded message ---------\r\n")
-	fwdBody.WriteString(fmt.Sprintf("From: %s\r\n", origFrom))
-	fwdBody.WriteString(fmt.Sprintf("Date: %s\r\n", origDate))
-	fwdBody.WriteString(fmt.Sprintf("Subject: %s\r\n", origSubject))
-	fwdBody.WriteString(fmt.Sprintf("To: %s\r\n", origTo))
-	fwdBody.WriteString("\r\n")
-	fwdBody.WriteString(origBody)
+	fwdBody := buildForwardBody(body, origHeaders, extractBody(origMsg.Payload))
 
 	// Collect original attachments to temporary files for buildMIMEMessage
 	var attachmentPaths []string
@@ -1741,7 +2165,7 @@ func runGmailForward(cmd *cobra.Command, args []string) error {
 		msgHeaders["Bcc"] = bcc
 	}
 
-	rawBytes, err := buildMIMEMessage(msgHeaders, fwdBody.String(), attachmentPaths)
+	rawBytes, err := buildMIMEMessage(msgHeaders, fwdBody, attachmentPaths)
 	if err != nil {
 		return p.PrintError(err)
 	}
@@ -1763,9 +2187,32 @@ func runGmailForward(cmd *cobra.Command, args []string) error {
 		"thread_id":    sent.ThreadId,
 		"forwarded_to": to,
 		"original_id":  messageID,
+		"attachments":  len(attachmentPaths),
 	})
 }
 
+// buildForwardBody returns the plain-text body of a forward: the optional
+// note, then the Gmail-style "Forwarded message" block quoting the
+// original headers, then the original body. Cc is quoted only when present.
+func buildForwardBody(note string, origHeaders map[string]string, origBody string) string {
+	var b strings.Builder
+	if note != "" {
+		b.WriteString(note)
+		b.WriteString("\r\n\r\n")
+	}
+	b.WriteString("---------- Forwarded message ---------\r\n")
+	b.WriteString(fmt.Sprintf("From: %s\r\n", origHeaders["From"]))
+	b.WriteString(fmt.Sprintf("Date: %s\r\n", origHeaders["Date"]))
+	b.WriteString(fmt.Sprintf("Subject: %s\r\n", origHeaders["Subject"]))
+	b.WriteString(fmt.Sprintf("To: %s\r\n", origHeaders["To"]))
+	if cc := origHeaders["Cc"]; cc != "" {
+		b.WriteString(fmt.Sprintf("Cc: %s\r\n", cc))
+	}
+	b.WriteString("\r\n")
+	b.WriteString(origBody)
+	return b.String()
+}
+
 // extractAttachmentParts recursively finds attachment parts in a message payload.
 // Includes both referenced attachments (with AttachmentId) and inline attachments
 // (with body data but no AttachmentId, common for small files).
@@ -2764,3 +3211,664 @@ func parseGoogleDocsURL(href string) map[string]interface{} {
 	}
 	return result
 }
+
+// vacationInfo renders vacation settings for output. Times are RFC3339 UTC
+// and omitted when unset.
+func vacationInfo(v *gmail.VacationSettings) map[string]interface{} {
+	info := map[string]interface{}{
+		"enabled":              v.EnableAutoReply,
+		"subject":              v.ResponseSubject,
+		"restrict_to_contacts": v.RestrictToContacts,
+		"restrict_to_domain":   v.RestrictToDomain,
+	}
+	if v.ResponseBodyPlainText != "" {
+		info["body"] = v.ResponseBodyPlainText
+	} else if v.ResponseBodyHtml != "" {
+		info["body_html"] = v.ResponseBodyHtml
+	}
+	if v.StartTime != 0 {
+		info["start"] = time.UnixMilli(v.StartTime).UTC().Format(time.RFC3339)
+	}
+	if v.EndTime != 0 {
+		info["end"] = time.UnixMilli(v.EndTime).UTC().Format(time.RFC3339)
+	}
+	return info
+}
+
+// vacationOptions holds the vacation set flags; the *Set fields record
+// which flags were passed so unset ones keep their current value.
+type vacationOptions struct {
+	Subject, Body                          string
+	StartTime, EndTime                     int64 // epoch ms; 0 clears
+	SubjectSet, BodySet, StartSet, EndSet  bool
+	RestrictContacts, RestrictDomain       bool
+	RestrictContactsSet, RestrictDomainSet bool
+	Disable                                bool
+}
+
+// parseVacationTime converts a --start/--end value to epoch milliseconds.
+// An empty value clears the time. A date-only end is extended to the end
+// of that day so the auto-reply covers it.
+func parseVacationTime(s string, isEnd bool) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := parseTime(s)
+	if err != nil {
+		return 0, err
+	}
+	if isEnd && len(s) == len("2006-01-02") {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.UnixMilli(), nil
+}
+
+// buildVacationSettings merges opts over the current settings and
+// validates the resulting date range, which may pair a new --start with
+// the existing end time or vice versa.
+func buildVacationSettings(current *gmail.VacationSettings, opts vacationOptions) (*gmail.VacationSettings, error) {
+	v := *current
+	v.ServerResponse = googleapi.ServerResponse{}
+	v.EnableAutoReply = !opts.Disable
+	v.ForceSendFields = []string{"EnableAutoReply"}
+
+	if opts.SubjectSet {
+		v.ResponseSubject = opts.Subject
+	}
+	if opts.BodySet {
+		v.ResponseBodyPlainText = opts.Body
+		v.ResponseBodyHtml = ""
+	}
+	if opts.StartSet {
+		v.StartTime = opts.StartTime
+	}
+	if opts.EndSet {
+		v.EndTime = opts.EndTime
+	}
+	if opts.RestrictContactsSet {
+		v.RestrictToContacts = opts.RestrictContacts
+	}
+	if opts.RestrictDomainSet {
+		v.RestrictToDomain = opts.RestrictDomain
+	}
+
+	if v.StartTime != 0 && v.EndTime != 0 && v.EndTime <= v.StartTime {
+		return nil, fmt.Errorf("--end must be after --start")
+	}
+	return &v, nil
+}
+
+func runGmailVacationGet(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runGmailVacationGetWithService(svc, p)
+}
+
+func runGmailVacationGetWithService(svc *gmail.Service, p printer.Printer) error {
+	v, err := svc.Users.Settings.GetVacation("me").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get vacation settings: %w", err))
+	}
+	return p.Print(vacationInfo(v))
+}
+
+func runGmailVacationSet(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	flags := cmd.Flags()
+	var opts vacationOptions
+	opts.Subject, _ = flags.GetString("subject")
+	opts.Body, _ = flags.GetString("body")
+	opts.RestrictContacts, _ = flags.GetBool("restrict-contacts")
+	opts.RestrictDomain, _ = flags.GetBool("restrict-domain")
+	opts.Disable, _ = flags.GetBool("disable")
+	opts.SubjectSet = flags.Changed("subject")
+	opts.BodySet = flags.Changed("body")
+	opts.StartSet = flags.Changed("start")
+	opts.EndSet = flags.Changed("end")
+	opts.RestrictContactsSet = flags.Changed("restrict-contacts")
+	opts.RestrictDomainSet = flags.Changed("restrict-domain")
+
+	start, _ := flags.GetString("start")
+	end, _ := flags.GetString("end")
+	var err error
+	if opts.StartTime, err = parseVacationTime(start, false); err != nil {
+		return usageErrorf("invalid --start: %s", err)
+	}
+	if opts.EndTime, err = parseVacationTime(end, true); err != nil {
+		return usageErrorf("invalid --end: %s", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runGmailVacationSetWithService(svc, opts, p)
+}
+
+func runGmailVacationSetWithService(svc *gmail.Service, opts vacationOptions, p printer.Printer) error {
+	current, err := svc.Users.Settings.GetVacation("me").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get vacation settings: %w", err))
+	}
+
+	settings, err := buildVacationSettings(current, opts)
+	if err != nil {
+		return usageErrorf("%s", err)
+	}
+
+	updated, err := svc.Users.Settings.UpdateVacation("me", settings).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to update vacation settings: %w", err))
+	}
+
+	result := vacationInfo(updated)
+	result["status"] = "updated"
+	return p.Print(result)
+}
+
+// gmailImportOptions configures runGmailImportWithService.
+type gmailImportOptions struct {
+	Raw           []byte
+	Labels        []string
+	DateSource    string
+	NeverMarkSpam bool
+}
+
+func runGmailImport(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	filePath, _ := cmd.Flags().GetString("file")
+	labelsStr, _ := cmd.Flags().GetString("labels")
+	dateSource, _ := cmd.Flags().GetString("date-source")
+	neverMarkSpam, _ := cmd.Flags().GetBool("never-mark-spam")
+
+	if dateSource != "dateHeader" && dateSource != "receivedTime" {
+		return usageErrorf("invalid --date-source %q: must be dateHeader or receivedTime", dateSource)
+	}
+
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read file: %w", err))
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return usageErrorf("--file %s is empty", filePath)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	opts := gmailImportOptions{
+		Raw:           raw,
+		DateSource:    dateSource,
+		NeverMarkSpam: neverMarkSpam,
+	}
+	if labelsStr != "" {
+		opts.Labels = strings.Split(labelsStr, ",")
+	}
+	return runGmailImportWithService(svc, opts, p)
+}
+
+func runGmailImportWithService(svc *gmail.Service, opts gmailImportOptions, p printer.Printer) error {
+	msg := &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString(opts.Raw),
+	}
+	if len(opts.Labels) > 0 {
+		labelIDs, err := resolveLabelNames(svc, opts.Labels)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		msg.LabelIds = labelIDs
+	}
+
+	imported, err := svc.Users.Messages.Import("me", msg).
+		InternalDateSource(opts.DateSource).
+		NeverMarkSpam(opts.NeverMarkSpam).
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to import message: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":    "imported",
+		"id":        imported.Id,
+		"thread_id": imported.ThreadId,
+		"labels":    imported.LabelIds,
+	})
+}
+
+// gmailCountOptions configures runGmailCountWithService and
+// runGmailStatsWithService.
+type gmailCountOptions struct {
+	Query            string
+	Cap              int64
+	Estimate         bool
+	IncludeSpamTrash bool
+	LabelType        string
+	Concurrency      int
+}
+
+// countGmailMessages counts messages matching query (and labelID, if set)
+// by paging message IDs only. It stops once more than limit messages are
+// seen (limit 0 = no limit) and reports the count as capped.
+func countGmailMessages(ctx context.Context, svc *gmail.Service, query, labelID string, includeSpamTrash bool, limit int64) (count int64, capped bool, err error) {
+	const pageSize int64 = 500
+	pageToken := ""
+	for {
+		perPage := pageSize
+		if limit > 0 && limit+1-count < perPage {
+			perPage = limit + 1 - count
+		}
+		call := svc.Users.Messages.List("me").
+			MaxResults(perPage).
+			IncludeSpamTrash(includeSpamTrash).
+			Fields("messages/id", "nextPageToken").
+			Context(ctx)
+		if query != "" {
+			call = call.Q(query)
+		}
+		if labelID != "" {
+			call = call.LabelIds(labelID)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return count, false, err
+		}
+		count += int64(len(resp.Messages))
+		if limit > 0 && count > limit {
+			return limit, true, nil
+		}
+		if resp.NextPageToken == "" {
+			return count, false, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func runGmailCount(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	opts := gmailCountOptions{}
+	opts.Query, _ = cmd.Flags().GetString("query")
+	opts.Cap, _ = cmd.Flags().GetInt64("cap")
+	opts.Estimate, _ = cmd.Flags().GetBool("estimate")
+	opts.IncludeSpamTrash, _ = cmd.Flags().GetBool("include-spam-trash")
+	if opts.Cap < 0 {
+		return usageErrorf("--cap must not be negative")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runGmailCountWithService(svc, opts, p)
+}
+
+func runGmailCountWithService(svc *gmail.Service, opts gmailCountOptions, p printer.Printer) error {
+	ctx := context.Background()
+	if opts.Estimate {
+		call := svc.Users.Messages.List("me").
+			MaxResults(1).
+			IncludeSpamTrash(opts.IncludeSpamTrash).
+			Fields("resultSizeEstimate").
+			Context(ctx)
+		if opts.Query != "" {
+			call = call.Q(opts.Query)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to count messages: %w", err))
+		}
+		return p.Print(map[string]interface{}{
+			"query":    opts.Query,
+			"count":    resp.ResultSizeEstimate,
+			"estimate": true,
+		})
+	}
+
+	count, capped, err := countGmailMessages(ctx, svc, opts.Query, "", opts.IncludeSpamTrash, opts.Cap)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to count messages: %w", err))
+	}
+	return p.Print(map[string]interface{}{
+		"query":    opts.Query,
+		"count":    count,
+		"capped":   capped,
+		"estimate": false,
+	})
+}
+
+func runGmailStats(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	opts := gmailCountOptions{}
+	opts.Query, _ = cmd.Flags().GetString("query")
+	opts.Cap, _ = cmd.Flags().GetInt64("cap")
+	opts.LabelType, _ = cmd.Flags().GetString("type")
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	opts.IncludeSpamTrash, _ = cmd.Flags().GetBool("include-spam-trash")
+
+	if opts.Cap < 0 {
+		return usageErrorf("--cap must not be negative")
+	}
+	if opts.Concurrency < 1 {
+		return usageErrorf("--concurrency must be at least 1, got %d", opts.Concurrency)
+	}
+	opts.LabelType = strings.ToLower(opts.LabelType)
+	if opts.LabelType != "" && opts.LabelType != "user" && opts.LabelType != "system" {
+		return usageErrorf("invalid --type %q: must be user or system", opts.LabelType)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runGmailStatsWithService(svc, opts, p)
+}
+
+func runGmailStatsWithService(svc *gmail.Service, opts gmailCountOptions, p printer.Printer) error {
+	ctx := context.Background()
+	labelsResp, err := svc.Users.Labels.List("me").Context(ctx).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to list labels: %w", err))
+	}
+	var labels []*gmail.Label
+	for _, l := range labelsResp.Labels {
+		if opts.LabelType == "" || strings.EqualFold(l.Type, opts.LabelType) {
+			labels = append(labels, l)
+		}
+	}
+
+	total, totalCapped, err := countGmailMessages(ctx, svc, opts.Query, "", opts.IncludeSpamTrash, opts.Cap)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to count messages: %w", err))
+	}
+
+	type labelCount struct {
+		count  int64
+		capped bool
+		err    error
+	}
+	counts := make([]labelCount, len(labels))
+	if total > 0 {
+		forEachRateLimited(ctx, len(labels), opts.Concurrency, 0, func(ctx context.Context, i int) {
+			c, capped, err := countGmailMessages(ctx, svc, opts.Query, labels[i].Id, opts.IncludeSpamTrash, opts.Cap)
+			counts[i] = labelCount{count: c, capped: capped, err: err}
+		})
+	}
+
+	rows := make([]map[string]interface{}, 0)
+	var failed []map[string]interface{}
+	for i, l := range labels {
+		c := counts[i]
+		if c.err != nil {
+			failed = append(failed, map[string]interface{}{"label": l.Name, "error": c.err.Error()})
+			continue
+		}
+		if c.count == 0 {
+			continue
+		}
+		rows = append(rows, map[string]interface{}{
+			"id":     l.Id,
+			"name":   l.Name,
+			"type":   strings.ToLower(l.Type),
+			"count":  c.count,
+			"capped": c.capped,
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i]["count"].(int64) > rows[j]["count"].(int64)
+	})
+
+	result := map[string]interface{}{
+		"query":          opts.Query,
+		"total":          total,
+		"capped":         totalCapped,
+		"labels":         rows,
+		"labels_checked": len(labels),
+	}
+	if len(failed) > 0 {
+		result["failed"] = failed
+	}
+	return p.Print(result)
+}
+
+// byteSizeUnits maps --min-size suffixes to their multipliers.
+var byteSizeUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"K":  1 << 10,
+	"KB": 1 << 10,
+	"M":  1 << 20,
+	"MB": 1 << 20,
+	"G":  1 << 30,
+	"GB": 1 << 30,
+}
+
+// parseByteSize parses a size such as "5M", "500K", "1.5G", or "2048" into
+// bytes. Suffixes are case-insensitive powers of 1024.
+func parseByteSize(s string) (int64, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(s))
+	i := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(trimmed)
+	}
+	unit, ok := byteSizeUnits[strings.TrimSpace(trimmed[i:])]
+	n, err := strconv.ParseFloat(trimmed[:i], 64)
+	if !ok || err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q: use bytes or a K, M, or G suffix, e.g. 5M", s)
+	}
+	return int64(n * float64(unit)), nil
+}
+
+// formatByteSize renders bytes as a short human-readable size, e.g. "4.2 MB".
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 3; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+type gmailLargeOptions struct {
+	MinSize          int64
+	Max              int
+	Query            string
+	Cap              int64
+	Concurrency      int
+	IncludeSpamTrash bool
+}
+
+func runGmailLarge(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	opts := gmailLargeOptions{}
+	minSize, _ := cmd.Flags().GetString("min-size")
+	opts.Max, _ = cmd.Flags().GetInt("max")
+	opts.Query, _ = cmd.Flags().GetString("query")
+	opts.Cap, _ = cmd.Flags().GetInt64("cap")
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	opts.IncludeSpamTrash, _ = cmd.Flags().GetBool("include-spam-trash")
+
+	var err error
+	if opts.MinSize, err = parseByteSize(minSize); err != nil {
+		return usageErrorf("--min-size: %v", err)
+	}
+	if opts.Max < 1 {
+		return usageErrorf("--max must be at least 1, got %d", opts.Max)
+	}
+	if opts.Cap < 0 {
+		return usageErrorf("--cap must not be negative")
+	}
+	if opts.Concurrency < 1 {
+		return usageErrorf("--concurrency must be at least 1, got %d", opts.Concurrency)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Gmail()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runGmailLargeWithService(svc, opts, p)
+}
+
+func runGmailLargeWithService(svc *gmail.Service, opts gmailLargeOptions, p printer.Printer) error {
+	ctx := context.Background()
+	query := fmt.Sprintf("larger:%d", opts.MinSize)
+	if opts.Query != "" {
+		query += " " + opts.Query
+	}
+
+	// List matching IDs only; sizes come from the per-message fetch below.
+	var ids []string
+	capped := false
+	pageToken := ""
+	for {
+		perPage := int64(500)
+		if opts.Cap > 0 && opts.Cap+1-int64(len(ids)) < perPage {
+			perPage = opts.Cap + 1 - int64(len(ids))
+		}
+		call := svc.Users.Messages.List("me").
+			Q(query).
+			MaxResults(perPage).
+			IncludeSpamTrash(opts.IncludeSpamTrash).
+			Fields("messages/id", "nextPageToken").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to search messages: %w", err))
+		}
+		for _, m := range resp.Messages {
+			ids = append(ids, m.Id)
+		}
+		if opts.Cap > 0 && int64(len(ids)) > opts.Cap {
+			ids, capped = ids[:opts.Cap], true
+			break
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	msgs := make([]*gmail.Message, len(ids))
+	errs := make([]error, len(ids))
+	forEachRateLimited(ctx, len(ids), opts.Concurrency, 0, func(ctx context.Context, i int) {
+		msgs[i], errs[i] = svc.Users.Messages.Get("me", ids[i]).
+			Format("metadata").
+			MetadataHeaders("From", "Subject", "Date").
+			Fields("id", "threadId", "sizeEstimate", "payload/headers").
+			Context(ctx).
+			Do()
+	})
+
+	var found []*gmail.Message
+	var failed []map[string]interface{}
+	for i, m := range msgs {
+		if errs[i] != nil {
+			failed = append(failed, map[string]interface{}{"id": ids[i], "error": errs[i].Error()})
+			continue
+		}
+		found = append(found, m)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].SizeEstimate > found[j].SizeEstimate
+	})
+	if len(found) > opts.Max {
+		found = found[:opts.Max]
+	}
+
+	rows := make([]map[string]interface{}, 0, len(found))
+	for _, m := range found {
+		row := map[string]interface{}{
+			"id":        m.Id,
+			"thread_id": m.ThreadId,
+			"size":      m.SizeEstimate,
+			"size_text": formatByteSize(m.SizeEstimate),
+		}
+		if m.Payload != nil {
+			for _, h := range m.Payload.Headers {
+				switch h.Name {
+				case "From":
+					row["from"] = h.Value
+				case "Subject":
+					row["subject"] = h.Value
+				case "Date":
+					row["date"] = h.Value
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	result := map[string]interface{}{
+		"query":    query,
+		"messages": rows,
+		"count":    len(rows),
+		"scanned":  len(ids),
+		"capped":   capped,
+	}
+	if len(failed) > 0 {
+		result["failed"] = failed
+	}
+	return p.Print(result)
+}
@@ -9,10 +9,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/omriariav/workspace-cli/internal/printer"
+	"github.com/spf13/cobra"
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/option"
 )
@@ -720,7 +723,7 @@ func TestGmailReplyCommand_Flags(t *testing.T) {
 func TestGmailForwardCommand_Flags(t *testing.T) {
 	cmd := gmailForwardCmd
 
-	expectedFlags := []string{"to", "body", "cc", "bcc"}
+	expectedFlags := []string{"to", "body", "note", "cc", "bcc"}
 	for _, flag := range expectedFlags {
 		if cmd.Flags().Lookup(flag) == nil {
 			t.Errorf("expected --%s flag to exist", flag)
@@ -728,6 +731,35 @@ func TestGmailForwardCommand_Flags(t *testing.T) {
 	}
 }
 
+func TestBuildForwardBody(t *testing.T) {
+	orig := map[string]string{
+		"From":    "alice@example.com",
+		"Date":    "Mon, 12 Apr 2026 10:00:00 +0000",
+		"Subject": "Plan",
+		"To":      "bob@example.com",
+		"Cc":      "carol@example.com",
+	}
+	got := buildForwardBody("FYI", orig, "Original body")
+	want := "FYI\r\n\r\n" +
+		"---------- Forwarded message ---------\r\n" +
+		"From: alice@example.com\r\n" +
+		"Date: Mon, 12 Apr 2026 10:00:00 +0000\r\n" +
+		"Subject: Plan\r\n" +
+		"To: bob@example.com\r\n" +
+		"Cc: carol@example.com\r\n" +
+		"\r\n" +
+		"Original body"
+	if got != want {
+		t.Errorf("unexpected forward body:\n%q\nwant:\n%q", got, want)
+	}
+
+	delete(orig, "Cc")
+	got = buildForwardBody("", orig, "Original body")
+	if strings.HasPrefix(got, "\r\n") || strings.Contains(got, "Cc:") {
+		t.Errorf("expected no note and no Cc line, got %q", got)
+	}
+}
+
 // TestExtractAttachmentParts tests attachment discovery including inline parts
 func TestExtractAttachmentParts(t *testing.T) {
 	payload := &gmail.MessagePart{
@@ -865,41 +897,21 @@ func TestGmailForward_MockServer(t *testing.T) {
 	}
 
 	// Extract headers
-	var origSubject, origFrom, origTo, origDate string
+	origHeaders := map[string]string{}
 	for _, header := range origMsg.Payload.Headers {
-		switch header.Name {
-		case "Subject":
-			origSubject = header.Value
-		case "From":
-			origFrom = header.Value
-		case "To":
-			origTo = header.Value
-		case "Date":
-			origDate = header.Value
-		}
+		origHeaders[header.Name] = header.Value
 	}
 
-	// Build forwarded subject
-	fwdSubject := "Fwd: " + origSubject
-
-	// Build forwarded body
-	origBody := extractBody(origMsg.Payload)
-	var fwdBody strings.Builder
-	fwdBody.WriteString("FYI see below\r\n\r\n")
-	fwdBody.WriteString("---------- Forwarded message ---------\r\n")
-	fwdBody.WriteString(fmt.Sprintf("From: %s\r\n", origFrom))
-	fwdBody.WriteString(fmt.Sprintf("Date: %s\r\n", origDate))
-	fwdBody.WriteString(fmt.Sprintf("Subject: %s\r\n", origSubject))
-	fwdBody.WriteString(fmt.Sprintf("To: %s\r\n", origTo))
-	fwdBody.WriteString("\r\n")
-	fwdBody.WriteString(origBody)
+	// Build forwarded subject and body
+	fwdSubject := "Fwd: " + origHeaders["Subject"]
+	fwdBody := buildForwardBody("FYI see below", origHeaders, extractBody(origMsg.Payload))
 
 	msgHeaders := map[string]string{
 		"To":      "recipient@example.com",
 		"Subject": fwdSubject,
 	}
 
-	rawBytes, err := buildMIMEMessage(msgHeaders, fwdBody.String(), nil)
+	rawBytes, err := buildMIMEMessage(msgHeaders, fwdBody, nil)
 	if err != nil {
 		t.Fatalf("failed to build MIME message: %v", err)
 	}
@@ -1027,6 +1039,83 @@ func TestGmailReply_MockServer(t *testing.T) {
 	}
 }
 
+func TestGmailSendAndReply_FromFlag(t *testing.T) {
+	for _, cmd := range []*cobra.Command{gmailSendCmd, gmailReplyCmd} {
+		if cmd.Flags().Lookup("from") == nil {
+			t.Errorf("expected --from flag on %s", cmd.Name())
+		}
+	}
+}
+
+func TestResolveSendAs_MockServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/gmail/v1/users/me/settings/sendAs" && r.Method == "GET" {
+			json.NewEncoder(w).Encode(&gmail.ListSendAsResponse{
+				SendAs: []*gmail.SendAs{
+					{SendAsEmail: "me@example.com", IsPrimary: true},
+					{SendAsEmail: "support@example.com", DisplayName: "Support Team", VerificationStatus: "accepted"},
+					{SendAsEmail: "noreply@example.com", VerificationStatus: "pending"},
+				},
+			})
+			return
+		}
+		t.Logf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		addr    string
+		want    string
+		wantErr string
+	}{
+		{"primary", "me@example.com", "me@example.com", ""},
+		{"verified alias with display name", "Support@Example.com", `"Support Team" <support@example.com>`, ""},
+		{"unverified alias", "noreply@example.com", "", "not verified"},
+		{"unknown address", "other@example.com", "", "not a send-as address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSendAs(svc, tt.addr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildMIMEMessage_FromHeader(t *testing.T) {
+	headers := map[string]string{
+		"From":    "support@example.com",
+		"To":      "user@example.com",
+		"Subject": "Hi",
+	}
+	msg, err := buildMIMEMessage(headers, "body", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(msg), "From: support@example.com\r\nTo: user@example.com\r\n") {
+		t.Errorf("expected From header before To, got %q", string(msg))
+	}
+}
+
 // TestGmailReplyAll_MockServer tests the reply-all workflow with recipient deduplication
 func TestGmailReplyAll_MockServer(t *testing.T) {
 	var sentRaw string
@@ -3301,3 +3390,535 @@ func TestGmailLinks_RunnerLevel(t *testing.T) {
 		t.Errorf("second link href: %v", second["href"])
 	}
 }
+
+func TestMarkLabelChanges(t *testing.T) {
+	add, remove := markLabelChanges(true, false, true, false)
+	if strings.Join(add, ",") != "STARRED" || strings.Join(remove, ",") != "UNREAD" {
+		t.Errorf("--read --star: got add=%v remove=%v", add, remove)
+	}
+	add, remove = markLabelChanges(false, true, false, true)
+	if strings.Join(add, ",") != "UNREAD" || strings.Join(remove, ",") != "STARRED" {
+		t.Errorf("--unread --unstar: got add=%v remove=%v", add, remove)
+	}
+}
+
+func TestMarkQueryFilter(t *testing.T) {
+	if got := markQueryFilter(nil, []string{"UNREAD"}); got != "is:unread" {
+		t.Errorf("--read: got %q", got)
+	}
+	if got := markQueryFilter([]string{"STARRED"}, []string{"UNREAD"}); got != "{-is:starred is:unread}" {
+		t.Errorf("--read --star: got %q", got)
+	}
+}
+
+func TestLabelsChanged(t *testing.T) {
+	if labelsChanged([]string{"INBOX"}, nil, []string{"UNREAD"}) {
+		t.Error("removing an absent label must not count as a change")
+	}
+	if !labelsChanged([]string{"INBOX", "UNREAD"}, nil, []string{"UNREAD"}) {
+		t.Error("removing a present label must count as a change")
+	}
+	if !labelsChanged([]string{"INBOX"}, []string{"STARRED"}, nil) {
+		t.Error("adding a missing label must count as a change")
+	}
+}
+
+func TestGmailMark_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		flags map[string]string
+		want  string
+	}{
+		{"no target", nil, map[string]string{"read": "true"}, "exactly one of <message-id> or --query"},
+		{"both targets", []string{"m1"}, map[string]string{"read": "true", "query": "in:inbox"}, "exactly one of <message-id> or --query"},
+		{"no action", []string{"m1"}, nil, "at least one of --read"},
+		{"read and unread", []string{"m1"}, map[string]string{"read": "true", "unread": "true"}, "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := findSubcommand(gmailCmd, "mark")
+			for _, f := range []string{"read", "unread", "star", "unstar"} {
+				cmd.Flags().Set(f, "false")
+			}
+			cmd.Flags().Set("query", "")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGmailMark_QueryListsAndBatchModifies(t *testing.T) {
+	var gotQuery string
+	var batchReq gmail.BatchModifyMessagesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/gmail/v1/users/me/messages" && r.Method == "GET":
+			gotQuery = r.URL.Query().Get("q")
+			if r.URL.Query().Get("pageToken") == "" {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"messages":      []map[string]string{{"id": "m1"}, {"id": "m2"}},
+					"nextPageToken": "p2",
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"messages": []map[string]string{{"id": "m3"}},
+			})
+		case r.URL.Path == "/gmail/v1/users/me/messages/batchModify" && r.Method == "POST":
+			json.NewDecoder(r.Body).Decode(&batchReq)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	add, remove := markLabelChanges(true, false, false, false)
+	query := "(from:alerts@example.com) " + markQueryFilter(add, remove)
+	ids, err := listMessageIDs(svc, query, 10)
+	if err != nil {
+		t.Fatalf("listMessageIDs failed: %v", err)
+	}
+	if gotQuery != "(from:alerts@example.com) is:unread" {
+		t.Errorf("unexpected query sent: %q", gotQuery)
+	}
+	if strings.Join(ids, ",") != "m1,m2,m3" {
+		t.Errorf("expected ids across pages, got %v", ids)
+	}
+
+	if err := batchModifyChunked(svc, ids, add, remove); err != nil {
+		t.Fatalf("batchModifyChunked failed: %v", err)
+	}
+	if len(batchReq.Ids) != 3 || strings.Join(batchReq.RemoveLabelIds, ",") != "UNREAD" {
+		t.Errorf("unexpected batchModify request: %+v", batchReq)
+	}
+}
+
+func TestGmailVacationCommands(t *testing.T) {
+	for _, name := range []string{"get", "set"} {
+		if findSubcommand(gmailVacationCmd, name) == nil {
+			t.Errorf("vacation subcommand '%s' not found", name)
+		}
+	}
+	for _, flag := range []string{"subject", "body", "start", "end", "restrict-contacts", "restrict-domain", "disable"} {
+		if gmailVacationSetCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+}
+
+func TestParseVacationTime(t *testing.T) {
+	start, err := parseVacationTime("2026-10-20T09:00:00Z", false)
+	if err != nil || start != time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC).UnixMilli() {
+		t.Errorf("unexpected start %d (err %v)", start, err)
+	}
+
+	dayStart, _ := parseVacationTime("2026-10-24", false)
+	dayEnd, err := parseVacationTime("2026-10-24", true)
+	if err != nil || dayEnd-dayStart != 24*time.Hour.Milliseconds() {
+		t.Errorf("expected date-only end to cover the whole day, got %d..%d (err %v)", dayStart, dayEnd, err)
+	}
+
+	if ms, err := parseVacationTime("", true); err != nil || ms != 0 {
+		t.Errorf("expected empty value to clear, got %d (err %v)", ms, err)
+	}
+	if _, err := parseVacationTime("next week", false); err == nil {
+		t.Error("expected error for unparseable time")
+	}
+}
+
+func TestBuildVacationSettings(t *testing.T) {
+	current := &gmail.VacationSettings{
+		ResponseSubject:       "Old",
+		ResponseBodyPlainText: "Old body",
+		StartTime:             1000,
+		EndTime:               5000,
+		RestrictToContacts:    true,
+	}
+
+	t.Run("keeps unset fields", func(t *testing.T) {
+		v, err := buildVacationSettings(current, vacationOptions{Subject: "OOO", SubjectSet: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !v.EnableAutoReply || v.ResponseSubject != "OOO" || v.ResponseBodyPlainText != "Old body" || !v.RestrictToContacts {
+			t.Errorf("unexpected settings: %+v", v)
+		}
+		if current.ResponseSubject != "Old" {
+			t.Error("current settings were modified")
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		v, err := buildVacationSettings(current, vacationOptions{Disable: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v.EnableAutoReply {
+			t.Error("expected auto-reply disabled")
+		}
+		body, _ := json.Marshal(v)
+		if !strings.Contains(string(body), `"enableAutoReply":false`) {
+			t.Errorf("expected enableAutoReply to be sent explicitly, got %s", body)
+		}
+	})
+
+	t.Run("end before existing start", func(t *testing.T) {
+		_, err := buildVacationSettings(current, vacationOptions{EndTime: 500, EndSet: true})
+		if err == nil || !strings.Contains(err.Error(), "--end must be after --start") {
+			t.Errorf("expected range error, got %v", err)
+		}
+	})
+
+	t.Run("clearing start skips range check", func(t *testing.T) {
+		v, err := buildVacationSettings(current, vacationOptions{StartSet: true, EndTime: 500, EndSet: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v.StartTime != 0 || v.EndTime != 500 {
+			t.Errorf("unexpected times: %d..%d", v.StartTime, v.EndTime)
+		}
+	})
+}
+
+func TestGmailVacationSet_MergesAndUpdates(t *testing.T) {
+	var sent gmail.VacationSettings
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gmail/v1/users/me/settings/vacation" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(&gmail.VacationSettings{
+				ResponseSubject:       "Away",
+				ResponseBodyPlainText: "Old body",
+				RestrictToDomain:      true,
+			})
+		case "PUT":
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			json.NewEncoder(w).Encode(&sent)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	start := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC).UnixMilli()
+	end := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC).UnixMilli()
+	opts := vacationOptions{
+		Body: "Back Monday", BodySet: true,
+		StartTime: start, StartSet: true,
+		EndTime: end, EndSet: true,
+	}
+
+	var buf bytes.Buffer
+	if err := runGmailVacationSetWithService(svc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runGmailVacationSetWithService: %v", err)
+	}
+
+	if !sent.EnableAutoReply || sent.ResponseSubject != "Away" || sent.ResponseBodyPlainText != "Back Monday" || !sent.RestrictToDomain {
+		t.Errorf("unexpected update body: %+v", sent)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["status"] != "updated" || out["enabled"] != true {
+		t.Errorf("unexpected output: %v", out)
+	}
+	if out["start"] != "2026-10-20T00:00:00Z" || out["end"] != "2026-10-25T00:00:00Z" {
+		t.Errorf("unexpected range: %v..%v", out["start"], out["end"])
+	}
+}
+
+func TestGmailImportCommand_Flags(t *testing.T) {
+	for _, flag := range []string{"file", "labels", "never-mark-spam", "date-source"} {
+		if gmailImportCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+	if def := gmailImportCmd.Flags().Lookup("date-source").DefValue; def != "dateHeader" {
+		t.Errorf("expected --date-source default 'dateHeader', got %q", def)
+	}
+}
+
+func TestGmailImport_ResolvesLabelsAndParams(t *testing.T) {
+	raw := []byte("From: a@example.com\r\nTo: b@example.com\r\nSubject: Old mail\r\n\r\nHello\r\n")
+	var sent gmail.Message
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/gmail/v1/users/me/labels" && r.Method == "GET":
+			json.NewEncoder(w).Encode(&gmail.ListLabelsResponse{Labels: []*gmail.Label{
+				{Id: "INBOX", Name: "INBOX"},
+				{Id: "Label_7", Name: "Imported"},
+			}})
+		case r.URL.Path == "/gmail/v1/users/me/messages/import" && r.Method == "POST":
+			query = r.URL.Query()
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&gmail.Message{Id: "m1", ThreadId: "t1", LabelIds: sent.LabelIds})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := gmailImportOptions{Raw: raw, Labels: []string{"inbox", " Imported"}, DateSource: "receivedTime", NeverMarkSpam: true}
+	if err := runGmailImportWithService(svc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runGmailImportWithService: %v", err)
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(sent.Raw)
+	if err != nil || string(decoded) != string(raw) {
+		t.Errorf("raw message not round-tripped: %q (err %v)", decoded, err)
+	}
+	if strings.Join(sent.LabelIds, ",") != "INBOX,Label_7" {
+		t.Errorf("unexpected label IDs: %v", sent.LabelIds)
+	}
+	if query["internalDateSource"][0] != "receivedTime" || query["neverMarkSpam"][0] != "true" {
+		t.Errorf("unexpected query params: %v", query)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["status"] != "imported" || out["id"] != "m1" || out["thread_id"] != "t1" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestGmailCount_PagesIDsAndCaps(t *testing.T) {
+	var pageSizes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gmail/v1/users/me/messages" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "label:unread" {
+			t.Errorf("unexpected query: %q", q.Get("q"))
+		}
+		if !strings.Contains(q.Get("fields"), "messages/id") {
+			t.Errorf("expected an ID-only field mask, got %q", q.Get("fields"))
+		}
+		pageSizes = append(pageSizes, q.Get("maxResults"))
+		n, _ := strconv.Atoi(q.Get("maxResults"))
+		if n > 3 {
+			n = 3
+		}
+		msgs := make([]*gmail.Message, n)
+		for i := range msgs {
+			msgs[i] = &gmail.Message{Id: fmt.Sprintf("m%d", i)}
+		}
+		next := "more"
+		if q.Get("pageToken") == "more" {
+			next = ""
+		}
+		json.NewEncoder(w).Encode(&gmail.ListMessagesResponse{Messages: msgs, NextPageToken: next})
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runGmailCountWithService(svc, gmailCountOptions{Query: "label:unread"}, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runGmailCountWithService: %v", err)
+	}
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["count"] != float64(6) || out["capped"] != false {
+		t.Errorf("expected 6 uncapped, got %v", out)
+	}
+
+	pageSizes = nil
+	buf.Reset()
+	if err := runGmailCountWithService(svc, gmailCountOptions{Query: "label:unread", Cap: 4}, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runGmailCountWithService: %v", err)
+	}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["count"] != float64(4) || out["capped"] != true {
+		t.Errorf("expected capped at 4, got %v", out)
+	}
+	if len(pageSizes) != 2 || pageSizes[0] != "5" || pageSizes[1] != "2" {
+		t.Errorf("expected page sizes clamped to cap+1, got %v", pageSizes)
+	}
+}
+
+func TestGmailStats_BreaksDownByLabel(t *testing.T) {
+	counts := map[string]int{"": 3, "INBOX": 3, "Label_1": 1, "Label_2": 0}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gmail/v1/users/me/labels":
+			json.NewEncoder(w).Encode(&gmail.ListLabelsResponse{Labels: []*gmail.Label{
+				{Id: "INBOX", Name: "INBOX", Type: "system"},
+				{Id: "Label_1", Name: "Receipts", Type: "user"},
+				{Id: "Label_2", Name: "Travel", Type: "user"},
+			}})
+		case "/gmail/v1/users/me/messages":
+			n := counts[r.URL.Query().Get("labelIds")]
+			msgs := make([]*gmail.Message, n)
+			for i := range msgs {
+				msgs[i] = &gmail.Message{Id: fmt.Sprintf("m%d", i)}
+			}
+			json.NewEncoder(w).Encode(&gmail.ListMessagesResponse{Messages: msgs})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := gmailCountOptions{Query: "label:unread", Concurrency: 2}
+	if err := runGmailStatsWithService(svc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runGmailStatsWithService: %v", err)
+	}
+	var out struct {
+		Total         int                      `json:"total"`
+		Labels        []map[string]interface{} `json:"labels"`
+		LabelsChecked int                      `json:"labels_checked"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Total != 3 || out.LabelsChecked != 3 || len(out.Labels) != 2 {
+		t.Fatalf("unexpected stats: %+v", out)
+	}
+	if out.Labels[0]["name"] != "INBOX" || out.Labels[1]["name"] != "Receipts" || out.Labels[1]["type"] != "user" {
+		t.Errorf("unexpected label order: %v", out.Labels)
+	}
+}
+
+func TestGmailLarge_SortsBySizeDescending(t *testing.T) {
+	sizes := map[string]int64{"m1": 6 << 20, "m2": 40 << 20, "m3": 12 << 20}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/gmail/v1/users/me/messages":
+			if q := r.URL.Query().Get("q"); q != "larger:5242880 older_than:1y" {
+				t.Errorf("unexpected query: %q", q)
+			}
+			json.NewEncoder(w).Encode(&gmail.ListMessagesResponse{Messages: []*gmail.Message{{Id: "m1"}, {Id: "m2"}, {Id: "m3"}}})
+		case strings.HasPrefix(r.URL.Path, "/gmail/v1/users/me/messages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/gmail/v1/users/me/messages/")
+			json.NewEncoder(w).Encode(&gmail.Message{
+				Id:           id,
+				ThreadId:     "t-" + id,
+				SizeEstimate: sizes[id],
+				Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{
+					{Name: "From", Value: "sender@example.com"},
+					{Name: "Subject", Value: "Files " + id},
+				}},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := gmail.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create gmail service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := gmailLargeOptions{MinSize: 5 << 20, Max: 2, Query: "older_than:1y", Concurrency: 2}
+	if err := runGmailLargeWithService(svc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runGmailLargeWithService: %v", err)
+	}
+	var out struct {
+		Messages []map[string]interface{} `json:"messages"`
+		Count    int                      `json:"count"`
+		Scanned  int                      `json:"scanned"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Count != 2 || out.Scanned != 3 {
+		t.Fatalf("expected top 2 of 3, got %+v", out)
+	}
+	if out.Messages[0]["id"] != "m2" || out.Messages[1]["id"] != "m3" {
+		t.Errorf("expected largest first, got %v", out.Messages)
+	}
+	if out.Messages[0]["size_text"] != "40.0 MB" || out.Messages[0]["subject"] != "Files m2" {
+		t.Errorf("unexpected row: %v", out.Messages[0])
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"5M", 5 << 20},
+		{"500k", 500 << 10},
+		{"1.5G", 3 << 29},
+		{"2048", 2048},
+		{"10 MB", 10 << 20},
+	}
+	for _, tt := range tests {
+		if got, err := parseByteSize(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "M", "5X", "-1M"} {
+		if _, err := parseByteSize(bad); err == nil {
+			t.Errorf("parseByteSize(%q): expected error", bad)
+		}
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		1536:            "1.5 KB",
+		5 << 20:         "5.0 MB",
+		3 << 30:         "3.0 GB",
+		(25 << 20) / 10: "2.5 MB",
+	}
+	for in, want := range tests {
+		if got := formatByteSize(in); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// outputFile is the global --output-file path; empty means stdout.
+var outputFile string
+
+// resultFile writes command results to an --output-file path. The file and
+// its parent directories are created on the first write, so a command that
+// fails before printing anything leaves no file behind.
+type resultFile struct {
+	path    string
+	f       *os.File
+	written int64
+}
+
+func (r *resultFile) Write(b []byte) (int, error) {
+	if r.f == nil {
+		if dir := filepath.Dir(r.path); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return 0, fmt.Errorf("failed to create output directory: %w", err)
+			}
+		}
+		f, err := os.Create(r.path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create output file: %w", err)
+		}
+		r.f = f
+	}
+	n, err := r.f.Write(b)
+	r.written += int64(n)
+	return n, err
+}
+
+// resultFiles holds the --output-file writers opened since the last
+// finishResultFiles, by path, so repeated GetPrinter calls in one command
+// append to the same file instead of truncating it.
+var resultFiles = map[string]*resultFile{}
+
+// resultWriter returns where command results go: the --output-file writer
+// when the flag is set, os.Stdout otherwise.
+func resultWriter() io.Writer {
+	if outputFile == "" {
+		return os.Stdout
+	}
+	r, ok := resultFiles[outputFile]
+	if !ok {
+		r = &resultFile{path: outputFile}
+		resultFiles[outputFile] = r
+	}
+	return r
+}
+
+// finishResultFiles closes every open --output-file writer except the paths
+// in keep, and reports the bytes written to each on errW unless --quiet is
+// set. Writers that were never written to are dropped without a file.
+func finishResultFiles(errW io.Writer, keep map[string]bool) error {
+	paths := make([]string, 0, len(resultFiles))
+	for path := range resultFiles {
+		if !keep[path] {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var firstErr error
+	for _, path := range paths {
+		r := resultFiles[path]
+		delete(resultFiles, path)
+		if r.f == nil {
+			continue
+		}
+		if err := r.f.Close(); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			continue
+		}
+		if !quiet {
+			fmt.Fprintf(errW, "Wrote %d bytes to %s\n", r.written, path)
+		}
+	}
+	return firstErr
+}
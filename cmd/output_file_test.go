@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/omriariav/workspace-cli/internal/printer"
+)
+
+// withOutputFile sets --output-file (and --quiet) for one test and restores
+// both, dropping any writers the test left open.
+func withOutputFile(t *testing.T, path string, q bool) {
+	t.Helper()
+	origFile, origQuiet := outputFile, quiet
+	outputFile, quiet = path, q
+	t.Cleanup(func() {
+		finishResultFiles(&bytes.Buffer{}, nil)
+		outputFile, quiet = origFile, origQuiet
+	})
+}
+
+func TestOutputFile_WritesResultAndCreatesDirs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dir", "out.json")
+	withOutputFile(t, path, false)
+
+	if err := GetPrinter().Print(map[string]interface{}{"status": "ok"}); err != nil {
+		t.Fatalf("print: %v", err)
+	}
+	var stderr bytes.Buffer
+	if err := finishResultFiles(&stderr, nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected output file: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, data)
+	}
+	if got["status"] != "ok" {
+		t.Errorf("unexpected output: %v", got)
+	}
+	want := fmt.Sprintf("Wrote %d bytes to %s", len(data), path)
+	if !strings.Contains(stderr.String(), want) {
+		t.Errorf("expected %q on stderr, got %q", want, stderr.String())
+	}
+}
+
+func TestOutputFile_AppendsAcrossPrinters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	withOutputFile(t, path, false)
+
+	GetPrinter().Print(map[string]interface{}{"n": 1})
+	GetPrinter().Print(map[string]interface{}{"n": 2})
+	finishResultFiles(&bytes.Buffer{}, nil)
+
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), `"n"`) != 2 {
+		t.Errorf("expected both results in the file, got %s", data)
+	}
+}
+
+func TestOutputFile_NotCreatedWithoutOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "out.json")
+	withOutputFile(t, path, false)
+
+	GetPrinter()
+	var stderr bytes.Buffer
+	if err := finishResultFiles(&stderr, nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no file when nothing was printed, got err=%v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("expected no report, got %q", stderr.String())
+	}
+}
+
+func TestOutputFile_QuietStillWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.json")
+	withOutputFile(t, path, true)
+
+	p := GetPrinter()
+	if _, ok := p.(*printer.NullPrinter); ok {
+		t.Fatal("expected a real printer when --output-file is set under --quiet")
+	}
+	p.Print(map[string]interface{}{"status": "ok"})
+	var stderr bytes.Buffer
+	finishResultFiles(&stderr, nil)
+
+	if data, err := os.ReadFile(path); err != nil || len(data) == 0 {
+		t.Errorf("expected result in file, got %q (err=%v)", data, err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("expected no byte report under --quiet, got %q", stderr.String())
+	}
+}
+
+func TestOutputFile_KeepLeavesWriterOpen(t *testing.T) {
+	dir := t.TempDir()
+	outer, inner := filepath.Join(dir, "outer.json"), filepath.Join(dir, "inner.json")
+	withOutputFile(t, outer, false)
+	GetPrinter().Print(map[string]interface{}{"step": "outer"})
+
+	outputFile = inner
+	GetPrinter().Print(map[string]interface{}{"step": "inner"})
+	var stderr bytes.Buffer
+	finishResultFiles(&stderr, map[string]bool{outer: true})
+
+	if strings.Contains(stderr.String(), outer) || !strings.Contains(stderr.String(), inner) {
+		t.Errorf("expected only the inner file reported, got %q", stderr.String())
+	}
+	if _, ok := resultFiles[outer]; !ok {
+		t.Error("expected the kept writer to stay open")
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -73,15 +72,16 @@ func parseParams(cmd *cobra.Command) (map[string]interface{}, error) {
 	return m, nil
 }
 
-// printRaw marshals v to stdout using SDK JSON tags directly so the output
-// preserves the Google API shape. Honors the global --quiet flag: when
-// quiet is set, output is suppressed (matching GetPrinter's NullPrinter
-// contract so scripts can run raw commands quietly for side effects).
+// printRaw marshals v to stdout (or --output-file) using SDK JSON tags
+// directly so the output preserves the Google API shape. Honors the global
+// --quiet flag: when quiet is set, output is suppressed (matching
+// GetPrinter's NullPrinter contract so scripts can run raw commands quietly
+// for side effects).
 func printRaw(v interface{}) error {
-	if quiet {
+	if quiet && outputFile == "" {
 		return nil
 	}
-	return writeRaw(os.Stdout, v)
+	return writeRaw(resultWriter(), v)
 }
 
 func writeRaw(w io.Writer, v interface{}) error {
@@ -9,18 +9,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/omriariav/workspace-cli/internal/client"
 	"github.com/omriariav/workspace-cli/internal/config"
 	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/omriariav/workspace-cli/internal/updatecheck"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/googleapi"
 )
 
 var (
-	cfgFile string
-	format  string
-	quiet   bool
+	cfgFile     string
+	profile     string
+	format      string
+	quiet       bool
+	interactive bool
 )
 
 var rootCmd = &cobra.Command{
@@ -39,16 +43,33 @@ and Custom Search.`,
 	// since arg/flag validation runs before PersistentPreRun.
 	SilenceErrors: true,
 	SilenceUsage:  true,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		emitVersionNotice(cmd, os.Stderr, quiet, os.Getenv("GWS_NO_UPDATE_CHECK") != "")
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.ValidateProfile(config.GetProfile()); err != nil {
+			return usageErrorf("%v", err)
+		}
+		client.ReauthHandler = nil
+		if interactive {
+			client.ReauthHandler = interactiveReauth
+		}
+		emitVersionNotice(cmd, os.Stderr, quiet, inScript || os.Getenv("GWS_NO_UPDATE_CHECK") != "" || config.IsOffline())
+		return nil
+	},
+	// The root only runs for --dump-commands; otherwise it shows help, as
+	// it did before it had a RunE.
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dump, _ := cmd.Flags().GetBool("dump-commands"); dump {
+			return GetPrinter().Print(dumpCommands(cmd))
+		}
+		return cmd.Help()
 	},
 }
 
 // emitVersionNotice writes a low-noise line when a newer release is
 // available. All errors are swallowed so unrelated commands stay healthy.
-// Suppressed by --quiet, by GWS_NO_UPDATE_CHECK (passed in as suppressEnv),
-// and on the version command itself (which has its own --check path) and
-// shell completion subcommands.
+// Suppressed by --quiet, by GWS_NO_UPDATE_CHECK, --offline, or `gws run`
+// steps (passed in as suppressEnv), and on the version command itself
+// (which has its own --check path), the root itself (help and
+// --dump-commands), and shell completion subcommands.
 func emitVersionNotice(cmd *cobra.Command, w io.Writer, quietFlag, suppressEnv bool) {
 	if quietFlag || suppressEnv {
 		return
@@ -56,7 +77,7 @@ func emitVersionNotice(cmd *cobra.Command, w io.Writer, quietFlag, suppressEnv b
 	if cmd == nil {
 		return
 	}
-	if cmd == versionCmd || (cmd.Parent() != nil && cmd.Parent().Name() == "completion") {
+	if cmd == versionCmd || !cmd.HasParent() || (cmd.Parent() != nil && cmd.Parent().Name() == "completion") {
 		return
 	}
 
@@ -80,12 +101,14 @@ func emitVersionNotice(cmd *cobra.Command, w io.Writer, quietFlag, suppressEnv b
 //	2 — CLI usage error (wrong args, unknown flag)
 //	3 — auth failure (HTTP 401 / 403)
 //	4 — transient / retryable (HTTP 429, 5xx)
+//	5 — re-authentication required (no stored token, or it was revoked/expired)
 const (
 	ExitOK        = 0
 	ExitError     = 1
 	ExitUsage     = 2
 	ExitAuth      = 3
 	ExitTransient = 4
+	ExitReauth    = 5
 )
 
 // Execute runs the root command and exits with the appropriate code.
@@ -101,7 +124,11 @@ func Execute() error {
 // Errors from PrintError are mapped via exitCodeForError; Cobra usage
 // errors are printed to errW as plain text and exit ExitUsage.
 func executeAndResolve(errW io.Writer) int {
-	return resolveExitError(rootCmd.Execute(), errW)
+	err := rootCmd.Execute()
+	if fileErr := finishResultFiles(errW, nil); fileErr != nil && err == nil {
+		err = fileErr
+	}
+	return resolveExitError(err, errW)
 }
 
 // resolveExitError maps a Cobra execution error to an exit code. Pure
@@ -178,8 +205,14 @@ var cobraUsageErrorPrefixes = []string{
 }
 
 // exitCodeForError maps a Go error to the appropriate CLI exit code by
-// inspecting the underlying googleapi.Error HTTP status.
+// inspecting the underlying googleapi.Error HTTP status. Unusable stored
+// credentials — caught up front by client.NewFactory, or as an OAuth refresh
+// error mid-command — map to ExitReauth.
 func exitCodeForError(err error) int {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.Is(err, client.ErrReauthRequired) || errors.As(err, &retrieveErr) {
+		return ExitReauth
+	}
 	var apiErr *googleapi.Error
 	if errors.As(err, &apiErr) {
 		switch {
@@ -198,11 +231,27 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ~/.config/gws/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&format, "format", "json", "output format: json, text, or yaml")
 	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "suppress output (useful for scripted actions)")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "write the result to this file instead of stdout, creating parent directories")
+	rootCmd.PersistentFlags().Bool("offline", false, "disable network access; only cache-backed commands succeed")
+	rootCmd.PersistentFlags().String("token-store", "", "where OAuth tokens are kept: file (default, token.json in the config dir) or keyring (OS secret store)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "named profile with its own config, token, and local stores under ~/.config/gws/profiles/<name> (env: GWS_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&interactive, "interactive", false, "if stored credentials are missing or revoked, run the login flow and retry")
+	rootCmd.Flags().Bool("dump-commands", false, "print every command with its flags and argument counts as JSON, for tools and agents")
 
 	viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
+	viper.BindPFlag(config.KeyOffline, rootCmd.PersistentFlags().Lookup("offline"))
+	viper.BindPFlag(config.KeyTokenStore, rootCmd.PersistentFlags().Lookup("token-store"))
 }
 
 func initConfig() {
+	// The profile decides the config directory, so it is resolved before
+	// anything is read from it.
+	if profile != "" {
+		config.SetProfile(profile)
+	} else {
+		config.SetProfile(os.Getenv("GWS_PROFILE"))
+	}
+
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -229,10 +278,11 @@ func GetFormat() string {
 }
 
 // GetPrinter returns a Printer based on current flags.
-// Returns NullPrinter when --quiet is set, otherwise the format-appropriate printer.
+// Returns NullPrinter when --quiet is set, otherwise the format-appropriate
+// printer. An explicit --output-file is still written under --quiet.
 func GetPrinter() printer.Printer {
-	if quiet {
+	if quiet && outputFile == "" {
 		return printer.NewNullPrinter()
 	}
-	return printer.New(os.Stdout, GetFormat())
+	return printer.New(resultWriter(), GetFormat())
 }
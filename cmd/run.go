@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/omriariav/workspace-cli/internal/client"
+	"github.com/omriariav/workspace-cli/internal/printer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// inScript is set while `gws run` executes its steps, so per-step hooks
+// (like the passive update notice) stay quiet.
+var inScript bool
+
+var runCmd = &cobra.Command{
+	Use:   "run [script]",
+	Short: "Run a file of gws commands in one process",
+	Long: `Reads newline-separated gws commands from a file and runs them in order in
+a single process, authenticating once and reusing the API clients across steps.
+
+Each line is one command without the leading "gws" (it is accepted and
+ignored if present). Arguments are split like a shell: single quotes are
+literal, double quotes allow \" and \\ escapes. Blank lines and lines starting
+with # are skipped. Each step starts from default flags, except that the
+run's global flags --config, --offline, --token-store, --profile, and
+--interactive carry over; --format, --quiet, and --output-file are reset.
+
+By default the run stops at the first failing step (--stop-on-error); later
+steps are reported as skipped. With --continue, every step runs. Output is a
+JSON array with one result per step. The command exits non-zero if any step
+failed.
+
+Examples:
+  gws run recipe.txt
+  gws run --from-file recipe.txt --continue
+
+  # recipe.txt
+  sheets create --title "Q3 Report"
+  sheets write <id> "Sheet1!A1" --values-json '[["Region","Revenue"],["EMEA",120]]'
+  sheets add-chart <id> --sheet Sheet1 --type BAR --data "A1:B2"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScript,
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().String("from-file", "", "Script file to run (alternative to the positional argument)")
+	runCmd.Flags().Bool("stop-on-error", false, "Stop at the first failing step (default behavior)")
+	runCmd.Flags().Bool("continue", false, "Keep running after a step fails")
+}
+
+// scriptStep is one parsed command line of a run script.
+type scriptStep struct {
+	Line    int
+	Command string
+	Args    []string
+}
+
+// parseScript reads a run script into steps, skipping blank lines and
+// # comments and dropping an optional leading "gws".
+func parseScript(r io.Reader) ([]scriptStep, error) {
+	var steps []scriptStep
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		args, err := splitCommandLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if len(args) > 0 && args[0] == "gws" {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if args[0] == "run" {
+			return nil, fmt.Errorf("line %d: nested run is not supported", lineNo)
+		}
+		steps = append(steps, scriptStep{Line: lineNo, Command: text, Args: args})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// splitCommandLine splits a line into arguments with shell-like quoting:
+// single quotes are literal, double quotes allow \" and \\ escapes, and a
+// backslash outside quotes escapes the next character.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+				cur.WriteRune(runes[i])
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				cur.WriteRune(runes[i])
+			}
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// resetFlagSet restores every flag in fs to its default and clears Changed,
+// so a step never sees flag values left over from an earlier step. Slice
+// flags in this tree all default to empty, so Replace(nil) restores them.
+func resetFlagSet(fs *pflag.FlagSet) {
+	fs.VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// resetCommandFlags resets the local and persistent flags of every command
+// below root.
+func resetCommandFlags(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		resetFlagSet(c.Flags())
+		resetFlagSet(c.PersistentFlags())
+		resetCommandFlags(c)
+	}
+}
+
+type flagSnapshot struct {
+	value   string
+	changed bool
+}
+
+func snapshotFlags(fs *pflag.FlagSet) map[string]flagSnapshot {
+	snap := make(map[string]flagSnapshot)
+	fs.VisitAll(func(f *pflag.Flag) {
+		snap[f.Name] = flagSnapshot{value: f.Value.String(), changed: f.Changed}
+	})
+	return snap
+}
+
+func restoreFlags(fs *pflag.FlagSet, snap map[string]flagSnapshot) {
+	fs.VisitAll(func(f *pflag.Flag) {
+		if s, ok := snap[f.Name]; ok {
+			_ = f.Value.Set(s.value)
+			f.Changed = s.changed
+		}
+	})
+}
+
+// captureStepOutput runs fn with os.Stdout redirected to a buffer and
+// returns what fn wrote.
+func captureStepOutput(fn func() error) (string, error) {
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, r)
+	}()
+
+	runErr := fn()
+
+	_ = w.Close()
+	os.Stdout = orig
+	wg.Wait()
+	_ = r.Close()
+	return buf.String(), runErr
+}
+
+// stepOutput returns the step's stdout as decoded JSON when it parses,
+// otherwise as trimmed text. Empty output yields nil.
+func stepOutput(out string) interface{} {
+	trimmed := strings.TrimSpace(out)
+	if trimmed == "" {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(trimmed), &v); err == nil {
+		return v
+	}
+	return trimmed
+}
+
+// runScriptSteps executes steps through rootCmd and returns one result per
+// step, plus the first step error. After a failure the remaining steps are
+// reported as skipped unless continueOnError is set.
+func runScriptSteps(steps []scriptStep, continueOnError bool) ([]map[string]interface{}, error) {
+	rootSnap := snapshotFlags(rootCmd.PersistentFlags())
+	// The run's own --output-file stays open across steps; files opened by
+	// a step's --output-file are closed when that step ends.
+	runFiles := map[string]bool{}
+	for path := range resultFiles {
+		runFiles[path] = true
+	}
+	inScript = true
+	defer func() {
+		inScript = false
+		rootCmd.SetArgs(nil)
+		resetCommandFlags(rootCmd)
+		restoreFlags(rootCmd.PersistentFlags(), rootSnap)
+	}()
+
+	results := make([]map[string]interface{}, 0, len(steps))
+	var firstErr error
+	for i, step := range steps {
+		result := map[string]interface{}{
+			"step":    i + 1,
+			"line":    step.Line,
+			"command": step.Command,
+		}
+		if firstErr != nil && !continueOnError {
+			result["status"] = "skipped"
+			results = append(results, result)
+			continue
+		}
+
+		// Each step starts from default flags. Every root persistent flag
+		// the run was given (--config, --offline, --token-store, --profile,
+		// --interactive) carries over, except the output flags reset below.
+		resetCommandFlags(rootCmd)
+		restoreFlags(rootCmd.PersistentFlags(), rootSnap)
+		for _, name := range []string{"format", "quiet", "output-file"} {
+			if f := rootCmd.PersistentFlags().Lookup(name); f != nil {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			}
+		}
+
+		rootCmd.SetArgs(step.Args)
+		out, err := captureStepOutput(func() error {
+			_, err := rootCmd.ExecuteC()
+			if fileErr := finishResultFiles(os.Stderr, runFiles); fileErr != nil && err == nil {
+				err = fileErr
+			}
+			return err
+		})
+		if output := stepOutput(out); output != nil {
+			result["output"] = output
+		}
+		if err != nil {
+			result["status"] = "error"
+			result["error"] = err.Error()
+			result["exit_code"] = resolveExitError(err, os.Stderr)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("step %d (line %d) failed: %w", i+1, step.Line, err)
+			}
+		} else {
+			result["status"] = "ok"
+			result["exit_code"] = ExitOK
+		}
+		results = append(results, result)
+	}
+	return results, firstErr
+}
+
+func runScript(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	stopOnError, _ := cmd.Flags().GetBool("stop-on-error")
+	continueOnError, _ := cmd.Flags().GetBool("continue")
+
+	if stopOnError && continueOnError {
+		return usageErrorf("--stop-on-error and --continue are mutually exclusive")
+	}
+	path := fromFile
+	if len(args) == 1 {
+		if fromFile != "" {
+			return usageErrorf("pass the script as an argument or with --from-file, not both")
+		}
+		path = args[0]
+	}
+	if path == "" {
+		return usageErrorf("a script file is required (argument or --from-file)")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to open script: %w", err))
+	}
+	defer f.Close()
+
+	steps, err := parseScript(f)
+	if err != nil {
+		return usageErrorf("invalid script %s: %v", path, err)
+	}
+	if len(steps) == 0 {
+		return usageErrorf("script %s has no commands", path)
+	}
+
+	disable := client.EnableSharing()
+	defer disable()
+
+	results, stepErr := runScriptSteps(steps, continueOnError)
+	if err := p.Print(results); err != nil {
+		return err
+	}
+	if stepErr != nil {
+		return &printer.AlreadyPrintedError{Err: stepErr}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{`sheets read abc "Sheet1!A1:B2"`, []string{"sheets", "read", "abc", "Sheet1!A1:B2"}},
+		{`sheets write id --values '[["a b","c"]]'`, []string{"sheets", "write", "id", "--values", `[["a b","c"]]`}},
+		{`gmail send --subject "say \"hi\""`, []string{"gmail", "send", "--subject", `say "hi"`}},
+		{`docs append id --text a\ b`, []string{"docs", "append", "id", "--text", "a b"}},
+		{`tasks list --title ""`, []string{"tasks", "list", "--title", ""}},
+	}
+	for _, tt := range tests {
+		got, err := splitCommandLine(tt.line)
+		if err != nil {
+			t.Errorf("splitCommandLine(%q): %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+
+	if _, err := splitCommandLine(`sheets read "abc`); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func TestParseScript(t *testing.T) {
+	script := "# build the report\n\ngws sheets a1 --to-index B3\n  sheets a1 --to-a1 1,2  \n"
+	steps, err := parseScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("parseScript: %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(steps))
+	}
+	if steps[0].Line != 3 || !reflect.DeepEqual(steps[0].Args, []string{"sheets", "a1", "--to-index", "B3"}) {
+		t.Errorf("unexpected first step: %+v", steps[0])
+	}
+	if steps[1].Line != 4 || steps[1].Command != "sheets a1 --to-a1 1,2" {
+		t.Errorf("unexpected second step: %+v", steps[1])
+	}
+
+	if _, err := parseScript(strings.NewReader("run other.txt\n")); err == nil || !strings.Contains(err.Error(), "nested run") {
+		t.Errorf("expected nested run error, got %v", err)
+	}
+}
+
+func TestRunScriptSteps_ResetsFlagsBetweenSteps(t *testing.T) {
+	steps := []scriptStep{
+		{Line: 1, Command: "sheets a1 --to-index B3", Args: []string{"sheets", "a1", "--to-index", "B3"}},
+		{Line: 2, Command: "sheets a1 --to-a1 1,2", Args: []string{"sheets", "a1", "--to-a1", "1,2"}},
+	}
+	results, err := runScriptSteps(steps, false)
+	if err != nil {
+		t.Fatalf("runScriptSteps: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r["status"] != "ok" {
+			t.Errorf("step %v: expected ok, got %v (%v)", r["step"], r["status"], r["error"])
+		}
+	}
+	out, _ := results[1]["output"].(map[string]interface{})
+	if out["ref"] != "B3" {
+		t.Errorf("expected second step to convert to B3, got %v", results[1]["output"])
+	}
+}
+
+func TestRunScriptSteps_StopAndContinue(t *testing.T) {
+	steps := []scriptStep{
+		{Line: 1, Command: "sheets a1", Args: []string{"sheets", "a1"}},
+		{Line: 2, Command: "sheets a1 --to-index C1", Args: []string{"sheets", "a1", "--to-index", "C1"}},
+	}
+
+	results, err := runScriptSteps(steps, false)
+	if err == nil || !strings.Contains(err.Error(), "step 1 (line 1) failed") {
+		t.Fatalf("expected step 1 failure, got %v", err)
+	}
+	if results[0]["status"] != "error" || results[0]["exit_code"] != ExitUsage {
+		t.Errorf("unexpected first result: %v", results[0])
+	}
+	if results[1]["status"] != "skipped" {
+		t.Errorf("expected second step skipped, got %v", results[1]["status"])
+	}
+
+	results, err = runScriptSteps(steps, true)
+	if err == nil {
+		t.Fatal("expected an error to be reported with --continue")
+	}
+	if results[1]["status"] != "ok" {
+		t.Errorf("expected second step to run with --continue, got %v", results[1])
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/omriariav/workspace-cli/internal/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -86,6 +87,9 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	// Make request
 	ctx := context.Background()
+	if client.IsOffline(ctx) {
+		return p.PrintError(client.ErrOffline)
+	}
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"?"+params.Encode(), nil)
 	if err != nil {
 		return p.PrintError(fmt.Errorf("failed to create request: %w", err))
@@ -5,10 +5,23 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/omriariav/workspace-cli/internal/client"
+	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -35,7 +48,23 @@ Range format examples:
   Sheet1!A1:D10    - Specific range in Sheet1
   Sheet1!A:D       - Columns A through D in Sheet1
   Sheet1           - All data in Sheet1
-  A1:D10           - Range in first sheet`,
+  A1:D10           - Range in first sheet
+
+For very large ranges, --page-rows fetches the range in windows of that many
+rows, stopping at the sheet's row count. --start-row resumes from a given
+1-based row, and --stream prints each window as soon as it arrives instead of
+collecting the whole range in memory.
+
+--value-render FORMULA returns formulas instead of their results, and
+UNFORMATTED_VALUE returns raw numbers. --date-render picks how dates come
+back when values are not formatted: SERIAL_NUMBER (the API default) or
+FORMATTED_STRING. With --headers, the header row is taken verbatim from the
+first row returned under the chosen rendering.
+
+Examples:
+  gws sheets read <id> "Sheet1!A1:D10"
+  gws sheets read <id> "Sheet1!A:D" --value-render FORMULA
+  gws sheets read <id> Sheet1 --value-render UNFORMATTED_VALUE --date-render FORMATTED_STRING`,
 	Args: cobra.ExactArgs(2),
 	RunE: runSheetsRead,
 }
@@ -225,7 +254,8 @@ Range format examples:
   Sheet1!A1:D10    - Format range in Sheet1
   A1:D10           - Format range in first sheet
 
-Note: Unbounded ranges like "A:A" (whole column) or "1:1" (whole row) are not supported.`,
+Note: Unbounded ranges like "A:A" (whole column) or "1:1" (whole row) are not supported.
+For number, date, and currency formats use number-format.`,
 	Args: cobra.ExactArgs(2),
 	RunE: runSheetsFormat,
 }
@@ -344,9 +374,36 @@ var sheetsAddFilterViewCmd = &cobra.Command{
 var sheetsAddChartCmd = &cobra.Command{
 	Use:   "add-chart <spreadsheet-id>",
 	Short: "Add a chart to a spreadsheet",
-	Long:  "Adds an embedded chart (bar, line, area, column, scatter, pie, combo) to a spreadsheet.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSheetsAddChart,
+	Long: `Adds an embedded chart (bar, line, area, column, scatter, pie, combo) to a spreadsheet.
+
+--x-axis-title and --y-axis-title label the bottom and left axes.
+--series-colors takes one hex color per series, in order; when it is set,
+the first column of --data becomes the labels and each remaining column
+its own series. --legend-position accepts BOTTOM, TOP, LEFT, RIGHT, or NONE
+(LABELED is also valid for pie charts).
+
+Examples:
+  gws sheets add-chart <id> --type COLUMN --data "Sheet1!A1:C10" --title "Revenue"
+  gws sheets add-chart <id> --type LINE --data "Sheet1!A1:C10" --x-axis-title Month --y-axis-title USD --series-colors "#F00,#0F0" --legend-position BOTTOM_LEGEND`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsAddChart,
+}
+
+var sheetsUpdateChartCmd = &cobra.Command{
+	Use:   "update-chart <spreadsheet-id>",
+	Short: "Update a chart's title, axis titles, series colors, or legend",
+	Long: `Fetches an existing chart's spec, applies the given changes, and writes it
+back. Only the flags you pass are changed; everything else in the chart is
+kept as is. Pass an empty string to clear a title.
+
+Axis titles and series colors apply to bar, line, area, column, scatter, and
+combo charts. Series colors are applied to the chart's series in order.
+
+Examples:
+  gws sheets update-chart <id> --chart-id 123456 --y-axis-title "Revenue (USD)"
+  gws sheets update-chart <id> --chart-id 123456 --series-colors "#1A73E8,#EA4335" --legend-position NONE`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsUpdateChart,
 }
 
 var sheetsListChartsCmd = &cobra.Command{
@@ -400,6 +457,814 @@ var sheetsDeleteConditionalFormatCmd = &cobra.Command{
 	RunE:  runSheetsDeleteConditionalFormat,
 }
 
+var sheetsAddRangeDropdownCmd = &cobra.Command{
+	Use:   "add-range-dropdown <spreadsheet-id> <range>",
+	Short: "Add a dropdown whose options come from another range",
+	Long: `Adds a ONE_OF_RANGE data validation rule so the dropdown options in
+<range> are read live from the cells in --source.
+
+The source reference is absolute ($A$1:$A$20) by default so every cell in
+the target range points at the same options. Use --relative to keep a
+relative reference that shifts with each validated cell.
+
+Examples:
+  gws sheets add-range-dropdown <id> "Form!B2:B100" --source "Lists!A1:A20"
+  gws sheets add-range-dropdown <id> "Form!C2:C100" --source "Lists!B1:B10" --allow-invalid`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsAddRangeDropdown,
+}
+
+var sheetsAddValidationCmd = &cobra.Command{
+	Use:   "add-validation <spreadsheet-id> <range>",
+	Short: "Add a data validation rule to a range",
+	Long: `Sets a data validation rule on every cell of <range>, replacing any rule
+already there. --type picks the condition:
+
+  ONE_OF_LIST      Value must be one of --values (comma-separated)
+  NUMBER_BETWEEN   Number between --min and --max, inclusive
+  TEXT_CONTAINS    Text must contain --text
+  CUSTOM_FORMULA   --formula must evaluate to TRUE (relative to the
+                   range's first cell)
+
+By default invalid input is accepted with a warning; --strict rejects it.
+--show-dropdown shows the list as an in-cell dropdown (ONE_OF_LIST only).
+For options read live from other cells, use add-range-dropdown.
+
+Examples:
+  gws sheets add-validation <id> "Form!B2:B100" --type ONE_OF_LIST --values "Open,In Progress,Done" --show-dropdown --strict
+  gws sheets add-validation <id> "Form!C2:C100" --type NUMBER_BETWEEN --min 0 --max 100
+  gws sheets add-validation <id> "Form!D2:D100" --type TEXT_CONTAINS --text "@"
+  gws sheets add-validation <id> "Form!E2:E100" --type CUSTOM_FORMULA --formula "=E2>=TODAY()" --strict`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsAddValidation,
+}
+
+var sheetsClearValidationCmd = &cobra.Command{
+	Use:   "clear-validation <spreadsheet-id> <range>",
+	Short: "Remove data validation from a range",
+	Long: `Removes every data validation rule (including dropdowns) from the cells of
+<range>. Cell values are left as they are.
+
+Examples:
+  gws sheets clear-validation <id> "Form!B2:B100"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsClearValidation,
+}
+
+var sheetsFormatAsTableCmd = &cobra.Command{
+	Use:   "format-as-table <spreadsheet-id> <range>",
+	Short: "Give a range a table look in one batch update",
+	Long: `Formats the first row of <range> as a header, optionally adds alternating
+row banding, and freezes the sheet through the header row — all in a single
+batch update.
+
+Banding fails if the range already overlaps a banded range.
+
+Examples:
+  gws sheets format-as-table <id> "Sheet1!A1:E50" --header-bold --banded
+  gws sheets format-as-table <id> "Sheet1!A1:E50" --header-bold --header-bg "#4285F4" --header-color "#FFFFFF" --banded`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsFormatAsTable,
+}
+
+var sheetsLinkRangeCmd = &cobra.Command{
+	Use:   "link-range <spreadsheet-id>",
+	Short: "Link a range from another spreadsheet with IMPORTRANGE",
+	Long: `Writes an =IMPORTRANGE(...) formula into --dst-cell so it shows a live copy
+of --src-range from the --src-id spreadsheet. With --query the import is
+wrapped in =QUERY(IMPORTRANGE(...), "<query>"); columns in the query are
+referenced as Col1, Col2, ... rather than by letter.
+
+The first time the formula loads, Sheets asks someone with access to both
+files to click "Allow access" in the destination cell.
+
+Examples:
+  gws sheets link-range <dst-id> --dst-cell A1 --src-id <src-id> --src-range "Sheet1!A1:D"
+  gws sheets link-range <dst-id> --dst-cell "Summary!B2" --src-id <src-id> --src-range "Data!A:C" --query "select Col1, Col3 where Col2 > 10"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsLinkRange,
+}
+
+var sheetsFormulasCmd = &cobra.Command{
+	Use:   "formulas <spreadsheet-id> [sheet]",
+	Short: "List every formula in a spreadsheet",
+	Long: `Reads cells with the FORMULA value render option and returns only those
+whose value starts with "=", as {cell, formula} grouped by sheet. Without
+[sheet], every sheet in the spreadsheet is scanned.
+
+--contains keeps only formulas containing the given text (case-insensitive),
+e.g. a function name or a referenced range.
+
+Examples:
+  gws sheets formulas <id>
+  gws sheets formulas <id> "Summary"
+  gws sheets formulas <id> --contains VLOOKUP
+  gws sheets formulas <id> --contains "Data!A"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSheetsFormulas,
+}
+
+var sheetsLockHeaderCmd = &cobra.Command{
+	Use:   "lock-header <spreadsheet-id>",
+	Short: "Freeze and protect the header rows of a sheet",
+	Long: `Freezes the top --rows rows of --sheet and adds a warning-only protected
+range over them, in a single batch update. Editors can still change the
+header after confirming a warning, which prevents accidental edits without
+locking anyone out.
+
+Examples:
+  gws sheets lock-header <id> --sheet "Sheet1"
+  gws sheets lock-header <id> --sheet "Data" --rows 2 --description "Column headers"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsLockHeader,
+}
+
+var sheetsProtectNamedCmd = &cobra.Command{
+	Use:   "protect-named <spreadsheet-id>",
+	Short: "Protect the cells of a named range",
+	Long: `Adds a protected range backed by a named range, so the protection follows
+the named range when it is moved or resized. --named-range takes the range's
+name or ID. Only --editors (and you) can change the protected cells; with
+--warning-only, anyone can edit after confirming a warning.
+
+Examples:
+  gws sheets protect-named <id> --named-range Inputs --editors a@x.com
+  gws sheets protect-named <id> --named-range Inputs --editors a@x.com,b@x.com --description "Model inputs"
+  gws sheets protect-named <id> --named-range Totals --warning-only`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsProtectNamed,
+}
+
+var sheetsProtectCmd = &cobra.Command{
+	Use:   "protect <spreadsheet-id> [range]",
+	Short: "Protect a range or a whole sheet",
+	Long: `Adds a protected range over a range in A1 notation, or over a whole sheet
+with --sheet. Only --editors (and you) can change the protected cells; with
+--warning-only, anyone can edit after confirming a warning.
+
+Examples:
+  gws sheets protect <id> "OKRs!E2:G50" --editors a@x.com --description "Score formulas"
+  gws sheets protect <id> --sheet Config --warning-only
+  gws sheets protect <id> "Sheet1!A1:D1"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSheetsProtect,
+}
+
+var sheetsListProtectionsCmd = &cobra.Command{
+	Use:   "list-protections <spreadsheet-id>",
+	Short: "List protected ranges",
+	Long: `Lists the protected ranges and sheets of a spreadsheet with their IDs,
+editors, and descriptions. Use --sheet to list one sheet only.
+
+Examples:
+  gws sheets list-protections <id>
+  gws sheets list-protections <id> --sheet OKRs`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsListProtections,
+}
+
+var sheetsUnprotectCmd = &cobra.Command{
+	Use:   "unprotect <spreadsheet-id>",
+	Short: "Remove a protected range",
+	Long: `Removes a protected range or sheet protection by ID. Find IDs with
+list-protections.
+
+Examples:
+  gws sheets unprotect <id> --protection-id 123456`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsUnprotect,
+}
+
+var sheetsA1Cmd = &cobra.Command{
+	Use:   "a1",
+	Short: "Convert between A1 notation and column/row indices",
+	Long: `Converts a cell reference to 0-based column/row indices (--to-index) or
+indices back to a cell reference (--to-a1). Indices are 0-based, matching the
+GridRange coordinates used by the Sheets API. A bare column (e.g. "AA") or a
+single index converts just the column. Works offline; no API call is made.
+
+Examples:
+  gws sheets a1 --to-index B3
+  gws sheets a1 --to-index AA
+  gws sheets a1 --to-a1 "2,3"
+  gws sheets a1 --to-a1 27`,
+	Args: cobra.NoArgs,
+	RunE: runSheetsA1,
+}
+
+var sheetsFreezeValuesCmd = &cobra.Command{
+	Use:   "freeze-values <spreadsheet-id> <range>",
+	Short: "Replace formulas in a range with their current values",
+	Long: `Converts every formula in a range to its current computed value, in place
+("paste values only"). Use it to snapshot volatile formulas such as NOW(),
+RAND(), or IMPORTRANGE. Formatting is left untouched. This cannot be undone
+from the CLI; use "gws sheets formulas" first to see what will be replaced.
+
+Range format examples:
+  Sheet1!A1:D10    - Freeze values in A1 through D10 of Sheet1
+  B2:B100          - Freeze values in the first sheet
+
+Examples:
+  gws sheets freeze-values <id> "Sheet1!A1:D10"
+  gws sheets freeze-values <id> "Report!B2:F200"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsFreezeValues,
+}
+
+var sheetsToHTMLCmd = &cobra.Command{
+	Use:   "to-html <spreadsheet-id> <range>",
+	Short: "Export a range as an HTML table",
+	Long: `Writes the formatted values of a range to an HTML <table>, ready to paste
+into an email or web page. With --with-styles, each cell carries inline styles
+for bold, italic, underline, strikethrough, text and background colors, and
+horizontal alignment, taken from the cell's effective format.
+
+Examples:
+  gws sheets to-html <id> "Sheet1!A1:D20" --output table.html
+  gws sheets to-html <id> "Report!A1:F50" --output report.html --with-styles --header`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsToHTML,
+}
+
+var sheetsSetDefaultFormatCmd = &cobra.Command{
+	Use:   "set-default-format <spreadsheet-id>",
+	Short: "Set the number format of an entire column",
+	Long: `Applies a number format to a whole column with no end row, so rows appended
+later inherit it. Use --skip-rows to leave header rows unformatted.
+
+--type is inferred from the pattern when omitted (e.g. "yyyy-mm-dd" is DATE,
+"0.00%" is PERCENT, "$#,##0.00" is CURRENCY). Valid types: NUMBER, CURRENCY,
+PERCENT, DATE, TIME, DATE_TIME, SCIENTIFIC, TEXT.
+
+Examples:
+  gws sheets set-default-format <id> --sheet "Orders" --col B --number-format "yyyy-mm-dd" --skip-rows 1
+  gws sheets set-default-format <id> --sheet "Orders" --col E --number-format "$#,##0.00"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsSetDefaultFormat,
+}
+
+var sheetsCommentsCmd = &cobra.Command{
+	Use:     "comments",
+	Aliases: []string{"comment"},
+	Short:   "List and add review comments",
+	Long: `Threaded review comments on a spreadsheet, read and written through the
+Drive Comments API. These are distinct from cell notes.`,
+}
+
+var sheetsCommentsListCmd = &cobra.Command{
+	Use:   "list <spreadsheet-id>",
+	Short: "List review comments",
+	Long: `Lists the review comments on a spreadsheet with their author, text, resolved
+state, anchor, and replies. Resolved comments are skipped unless
+--include-resolved is set.
+
+Examples:
+  gws sheets comments list <id>
+  gws sheets comments list <id> --include-resolved --max 50`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsCommentsList,
+}
+
+var sheetsCommentsAddCmd = &cobra.Command{
+	Use:   "add <spreadsheet-id>",
+	Short: "Add a review comment",
+	Long: `Adds a review comment to a spreadsheet. --anchor records the cell or range
+the comment is about in A1 notation; it is stored on the comment and returned
+by "comments list". The Sheets UI does not attach comments created through the
+Drive API to a cell, so they appear as spreadsheet-level comments there.
+
+Examples:
+  gws sheets comment add <id> --anchor "Sheet1!B2" --text "please verify"
+  gws sheets comments add <id> --text "Numbers look off this week"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsCommentsAdd,
+}
+
+var sheetsRetypeCmd = &cobra.Command{
+	Use:   "retype <spreadsheet-id> <range>",
+	Short: "Convert text cells to real numbers or dates",
+	Long: `Reparses text values in a range as numbers or dates and writes them back
+with USER_ENTERED input, so Sheets stores them as typed values. Useful after a
+CSV import left a column as text.
+
+Numbers may carry thousands separators, currency symbols ($ € £ ¥), a
+trailing % (stored as a fraction), or accounting-style parentheses for
+negatives. Dates accept ISO (2026-10-17, with optional time), month names
+(Oct 17, 2026; 17-Oct-2026), and numeric M/D/YYYY, or D/M/YYYY with
+--day-first.
+
+Only converted cells are written. Formulas, empty cells, and values that are
+already typed are left alone; text that doesn't parse is counted as skipped.
+
+Examples:
+  gws sheets retype <id> "Import!C2:C500" --as number
+  gws sheets retype <id> "Import!A2:A500" --as date --day-first`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsRetype,
+}
+
+var sheetsCleanCmd = &cobra.Command{
+	Use:   "clean <spreadsheet-id> <range>",
+	Short: "Tidy up freshly imported values",
+	Long: `Applies common cleanups to the text cells of a range in one pass and writes
+the changed cells back with USER_ENTERED input. Pick any combination:
+
+  --trim             Remove leading and trailing whitespace
+  --collapse-spaces  Collapse runs of spaces and tabs inside a value to one
+                     space (line breaks are kept)
+  --to-number        Convert numeric text to numbers, as 'retype --as number'
+  --to-date          Convert date text to dates, as 'retype --as date'
+
+Cleanups run in that order, so " $1,200 " becomes 1200 with --trim
+--to-number. Formulas, empty cells, and values that are already typed are
+left alone, and only cells whose value changes are written.
+
+Examples:
+  gws sheets clean <id> "Import!A2:F500" --trim --collapse-spaces
+  gws sheets clean <id> "Import!A2:F500" --trim --to-number --to-date --day-first`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsClean,
+}
+
+var sheetsStatusColorsCmd = &cobra.Command{
+	Use:   "status-colors <spreadsheet-id> <range>",
+	Short: "Color rows by the value of a status column",
+	Long: `Reads a range and sets the background color of each row (within the
+range's columns) from the value in its status column, the usual tracker
+sheet pattern without writing conditional-format rules.
+
+--map lists status=#RRGGBB pairs, comma-separated. Statuses match the
+displayed cell value, ignoring case and surrounding spaces. Rows whose
+status is not mapped (including the header row, unless mapped) are left
+as they are. Consecutive rows with the same color are painted with one
+RepeatCell request, all in a single batchUpdate.
+
+The range must have explicit rows and columns, e.g. Tasks!A2:H200.
+
+Examples:
+  gws sheets status-colors <id> "Tasks!A2:H200" --status-col E --map "Done=#B7E1CD,Blocked=#F4C7C3"
+  gws sheets status-colors <id> "Tasks!A2:H200" --status-col E --map "Done=#00FF00,Blocked=#FF0000,In Progress=#FFFF00"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsStatusColors,
+}
+
+var sheetsDumpCmd = &cobra.Command{
+	Use:   "dump <spreadsheet-id>",
+	Short: "Read every tab of a spreadsheet at once",
+	Long: `Lists all sheets and reads each tab's used range in a single BatchGet call.
+Without --output, prints a map of sheet name to 2D values. With --output,
+writes one CSV file per sheet into that directory instead.
+
+Chart sheets are skipped. As a safeguard, the dump is refused when the tabs'
+grid sizes add up to more than --max-cells (the grid size is an upper bound
+on the used range); pass --max-cells 0 to disable the check.
+
+Examples:
+  gws sheets dump <id>
+  gws sheets dump <id> --output ./snapshot
+  gws sheets dump <id> --value-render UNFORMATTED_VALUE --max-cells 5000000`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsDump,
+}
+
+var sheetsCopySpreadsheetCmd = &cobra.Command{
+	Use:   "copy-spreadsheet <source-id>",
+	Short: "Copy a spreadsheet, e.g. to instantiate a template",
+	Long: `Copies a spreadsheet with the Drive API and returns the new spreadsheet's
+ID and URL. The copy keeps every tab, formula, format, and chart.
+
+--replace key=value (repeatable) then runs a find-and-replace across every
+tab of the copy, so placeholders in a template can be filled in the same
+command. Keys are matched case-sensitively anywhere in a cell's text.
+
+Examples:
+  gws sheets copy-spreadsheet <template-id> --title "Q3 Report"
+  gws sheets copy-spreadsheet <template-id> --title "Acme invoice" --folder <folder-id> \
+    --replace "{{client}}=Acme Corp" --replace "{{date}}=2026-10-17"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsCopySpreadsheet,
+}
+
+var sheetsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <spreadsheet-id>",
+	Short: "Copy a spreadsheet to a timestamped backup",
+	Long: `Copies a spreadsheet with the Drive API under a name built from
+--name-template, for lightweight versioned backups run from cron.
+
+The template may use {title} (the source's title), {date} (YYYY-MM-DD), and
+{time} (HHMMSS), in local time. With --keep N, older snapshots in the same
+folder whose names match the template are moved to trash, keeping the N most
+recent including the new one.
+
+Examples:
+  gws sheets snapshot <id> --folder <folder-id>
+  gws sheets snapshot <id> --folder <folder-id> --name-template "backup-{date}" --keep 14`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsSnapshot,
+}
+
+var sheetsDiffCmd = &cobra.Command{
+	Use:   "diff [spreadsheet-id]",
+	Short: "Compare two ranges and report cell differences",
+	Long: `Reads two ranges and reports every cell whose value differs, matched by
+position from each range's top-left corner. When one range has more rows,
+the extra rows are reported as added (only in --b) or removed (only in --a).
+
+Each of --a and --b is a range in the spreadsheet given as the argument, or
+a full "<spreadsheet-id>!<range>" spec, so ranges in two different
+spreadsheets can be compared. Values are compared as displayed
+(--value-render FORMATTED_VALUE) unless another render option is chosen.
+
+With --fail-on-diff the command exits 1 when the ranges differ, for use as
+a CI assertion.
+
+Examples:
+  gws sheets diff <id> --a "Sheet1!A1:D10" --b "Sheet2!A1:D10"
+  gws sheets diff --a "<id-1>!Data!A:F" --b "<id-2>!Data!A:F" --fail-on-diff
+  gws sheets diff <id> --a "Expected!A1:C50" --b "Actual!A1:C50" --value-render UNFORMATTED_VALUE`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSheetsDiff,
+}
+
+var sheetsUpsertCmd = &cobra.Command{
+	Use:   "upsert <spreadsheet-id> <range>",
+	Short: "Update rows by key column, appending unmatched records",
+	Long: `Syncs records into a table: each record whose key matches an existing row
+is written over that row, and every other record is appended after the table.
+
+--records is a JSON file holding an array of rows. Each row is either an array
+of values aligned to the first column of the range, or an object whose keys
+are header names from the range's first row. --key-col is the sheet column
+letter holding the key (it must fall inside the range). Keys are compared as
+displayed text; when a key appears more than once in the sheet, the first row
+wins, and when it appears more than once in the records, the last record wins.
+
+Examples:
+  gws sheets upsert <id> "Customers!A:D" --key-col A --records customers.json
+  gws sheets upsert <id> "Inventory!B1:F" --key-col C --records stock.json
+
+  # customers.json
+  [["C-001","Acme","EMEA",1200],{"ID":"C-002","Name":"Globex"}]`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsUpsert,
+}
+
+var sheetsToEnvCmd = &cobra.Command{
+	Use:   "to-env <spreadsheet-id>",
+	Short: "Read a key/value range as KEY=value lines",
+	Long: `Reads a two-column range (key, value) and prints one KEY=value line per row,
+ready for eval or a .env file. Values are single-quoted when they contain
+anything a shell would interpret, so eval sets them verbatim.
+
+Keys become valid variable names: characters other than letters, digits, and
+underscores turn into "_", and a key starting with a digit gets a leading
+"_". --prefix is prepended and --upper upper-cases the result. Rows with an
+empty key are skipped; extra columns are ignored.
+
+Without --output the lines go straight to stdout regardless of --format.
+With --output they are written to that file and a JSON summary is printed.
+
+Examples:
+  eval "$(gws sheets to-env <id> --range "Config!A:B")"
+  gws sheets to-env <id> --range "Config!A2:B" --prefix APP_ --upper --output .env
+  gws sheets to-env <id> --range "Config!A:B" --skip-header --export`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsToEnv,
+}
+
+var sheetsWriteTypedCmd = &cobra.Command{
+	Use:   "write-typed <spreadsheet-id> <range>",
+	Short: "Write values and their number format in one update",
+	Long: `Writes a 2D JSON array starting at the range's top-left cell and applies a
+number format to the written cells in the same batch update, so the values
+are never visible unformatted and no second call can race the first.
+
+Values keep their JSON types: numbers are stored as numbers, true/false as
+booleans, strings starting with "=" as formulas, other strings as text, and
+null clears the cell. Quote-free numbers are therefore required for the
+number format to take effect ("1200" is text, 1200 is a number).
+
+When the range has an end cell, the data must fit inside it. --type is
+inferred from the pattern when omitted, as in set-default-format.
+
+Examples:
+  gws sheets write-typed <id> "Summary!B2" --json '[[1200.5, 980], [310, 45.25]]' --number-format "$#,##0.00"
+  gws sheets write-typed <id> "Rates!C2:C4" --json '[[0.05],[0.075],[0.1]]' --number-format "0.0%"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsWriteTyped,
+}
+
+var sheetsStampCmd = &cobra.Command{
+	Use:   "stamp <spreadsheet-id>",
+	Short: "Write the current time as a fixed date value",
+	Long: `Writes the current date and time into --cell as a typed date value with a
+date/time number format, in one batch update. Unlike =NOW(), the value never
+recalculates, so it records when the command ran.
+
+The time is taken in the spreadsheet's time zone, matching what =NOW() would
+show; --utc writes UTC instead.
+
+Examples:
+  gws sheets stamp <id> --cell "Log!B2"
+  gws sheets stamp <id> --cell A1 --format "yyyy-mm-dd hh:mm" --utc
+  gws sheets stamp <id> --cell "Runs!C10" --format "dd/mm/yyyy"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsStamp,
+}
+
+var sheetsSetBordersCmd = &cobra.Command{
+	Use:   "set-borders <spreadsheet-id> <range>",
+	Short: "Apply borders to a range",
+	Long: `Applies borders to a range with a single UpdateBordersRequest. Choose the
+sides with --top, --bottom, --left, --right, --inner-horizontal, and
+--inner-vertical, or the shortcuts --outer (all four edges), --inner (both
+inner directions), and --all (every side). Sides not selected are left
+unchanged.
+
+--style is one of SOLID, SOLID_MEDIUM, SOLID_THICK, DASHED, DOTTED, DOUBLE,
+or NONE (removes the selected borders). --width 2 or 3 selects
+SOLID_MEDIUM or SOLID_THICK when the style is SOLID; the API derives the
+width from the style otherwise.
+
+Examples:
+  gws sheets set-borders <id> "Sheet1!A1:D10" --all
+  gws sheets set-borders <id> "Report!A1:F20" --outer --style SOLID --width 2 --color "#333333"
+  gws sheets set-borders <id> "Report!A1:F20" --inner-horizontal --style DOTTED
+  gws sheets set-borders <id> "Sheet1!A1:D10" --all --style NONE`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsSetBorders,
+}
+
+var sheetsFilterReadCmd = &cobra.Command{
+	Use:   "filter-read <spreadsheet-id> <range>",
+	Short: "Read only the rows that match a condition",
+	Long: `Reads a range and returns only the rows matching --where. The filter runs
+locally, so no filter or helper columns are added to the sheet.
+
+Grammar (AND binds tighter than OR; keywords are case-insensitive):
+  expr       := and-expr { OR and-expr }
+  and-expr   := term { AND term }
+  term       := condition | "(" expr ")"
+  condition  := column operator value
+  column     := column letter (A, AB) or, with --header, a header name;
+                use [Unit Price] for names with spaces
+  operator   := =  !=  >  >=  <  <=  ~ (contains, case-insensitive)
+  value      := number, bare word, or "quoted string"
+
+A numeric value is compared numerically and matches only numeric cells
+(text and empty cells match only !=). Other values are compared as text
+(case-sensitive); empty cells are "".
+Values are read unformatted by default so numbers compare as numbers.
+
+With --header the first row names the columns and each matching row is
+returned as an object keyed by header.
+
+Examples:
+  gws sheets filter-read <id> "Orders!A1:D500" --where "B>100 AND C=active"
+  gws sheets filter-read <id> "Orders!A:D" --header --where "Status=open OR [Unit Price]>=9.5"
+  gws sheets filter-read <id> "Tasks!A2:F" --where "(D=high OR D=urgent) AND F~alice"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsFilterRead,
+}
+
+var sheetsTraceCmd = &cobra.Command{
+	Use:   "trace <spreadsheet-id>",
+	Short: "Show the cells a formula depends on",
+	Long: `Reads the formula in --cell and follows the cells and ranges it refers
+to, emitting a dependency tree. Referenced cells that hold formulas are
+traced in turn; inside a referenced range, only the cells holding formulas
+are listed. Formulas are read with FORMULA rendering, one batch per level.
+
+References are parsed from the formula text: A1 cells and ranges, whole
+columns and rows (A:C, 2:5), and sheet-qualified forms ('Q1 Data'!B2:B9).
+Unqualified references belong to the formula's own sheet. Open-ended
+ranges (A:A, 2:2, A2:B) are listed but not expanded. Named ranges,
+INDIRECT, and IMPORTRANGE targets are not resolved.
+
+A cell reached twice is listed again with "seen": true instead of being
+traced again, which also stops circular references. --depth limits how
+many formula hops are followed.
+
+Examples:
+  gws sheets trace <id> --cell D5
+  gws sheets trace <id> --cell "Summary!B12" --depth 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsTrace,
+}
+
+var sheetsRefreshCmd = &cobra.Command{
+	Use:   "refresh <spreadsheet-id>",
+	Short: "Refresh connected data sources",
+	Long: `Re-runs the queries behind the spreadsheet's connected data sources
+(BigQuery or Looker) with a RefreshDataSourceRequest, refreshing every
+data source sheet, pivot table, chart, and formula built on them. Ordinary
+charts and pivot tables over cell ranges recalculate on their own and are
+not affected.
+
+A spreadsheet without connected data sources is left untouched and
+reported as nothing_to_refresh. Each refreshed object is reported with its
+execution state; objects that failed to refresh carry the error.
+
+Examples:
+  gws sheets refresh <id>
+  gws sheets refresh <id> --data-source 1234567 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsRefresh,
+}
+
+var sheetsAddPivotCmd = &cobra.Command{
+	Use:   "add-pivot <spreadsheet-id>",
+	Short: "Create a pivot table from a range",
+	Long: `Creates a pivot table over --source and places it with its top-left corner
+at --anchor. --rows and --cols take comma-separated column letters of the
+source to group by. --values takes comma-separated COLUMN:FUNCTION pairs to
+summarize; the function defaults to SUM and may be SUM, COUNT, COUNTA,
+COUNTUNIQUE, AVERAGE, MAX, MIN, or MEDIAN.
+
+The source's first row is its header row. An anchor without a sheet name
+is placed on the first sheet; anything already at the anchor is replaced.
+
+Examples:
+  gws sheets add-pivot <id> --source "Export!A1:F500" --rows B --values E:SUM --anchor "Pivot!A1"
+  gws sheets add-pivot <id> --source "Export!A1:F500" --rows B,C --cols D --values E:SUM,E:COUNT --anchor "Pivot!A1"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsAddPivot,
+}
+
+var sheetsImportCSVCmd = &cobra.Command{
+	Use:   "import-csv <spreadsheet-id> <range>",
+	Short: "Load a CSV file into a range",
+	Long: `Reads a CSV file and writes its rows to a range, starting at the range's
+top-left cell. Rows may have different numbers of fields.
+
+--value-input USER_ENTERED (the default) parses numbers, dates, and formulas
+as if typed into Sheets; RAW stores every field as text. --clear-first
+clears the range argument before writing, so pass a whole sheet or a wide
+range (e.g. "Data" or "Data!A:Z") to replace an earlier, larger import.
+
+Examples:
+  gws sheets import-csv <id> "Data!A1" --file export.csv
+  gws sheets import-csv <id> "Data" --file export.csv --clear-first
+  gws sheets import-csv <id> "Data!A2" --file export.tsv --delimiter tab --skip-header --value-input RAW`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsImportCSV,
+}
+
+var sheetsExportCSVCmd = &cobra.Command{
+	Use:   "export-csv <spreadsheet-id> [range]",
+	Short: "Write a range or every sheet to CSV files",
+	Long: `Reads a range and writes it as a plain CSV file, without the JSON wrapper
+that "read --output-format csv" adds. --output - writes the CSV to stdout so
+it can be piped into other tools; nothing else is printed in that case.
+
+--all-sheets exports every tab instead of a range, one <title>.csv file per
+sheet in the --output directory (default: the current directory). Chart
+sheets are skipped.
+
+Examples:
+  gws sheets export-csv <id> "Sheet1!A1:D100" --output out.csv
+  gws sheets export-csv <id> "Data" --output - | cut -d, -f2 | sort | uniq -c
+  gws sheets export-csv <id> "Data" --output data.tsv --delimiter tab
+  gws sheets export-csv <id> --all-sheets --output ./export`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSheetsExportCSV,
+}
+
+var sheetsCaptureTemplateCmd = &cobra.Command{
+	Use:   "capture-template <spreadsheet-id>",
+	Short: "Save a sheet's formatting as a reusable JSON template",
+	Long: `Reads a sheet's layout and formatting into a JSON template file: frozen rows
+and columns, column widths, the header rows' heights and cell formats, each
+column's number format (taken from the first row below the header), and the
+conditional formatting rules. Cell values are not captured.
+
+--header-rows defaults to the sheet's frozen row count, or 1 when no rows are
+frozen. Apply the template to any sheet with apply-template.
+
+Examples:
+  gws sheets capture-template <id> --sheet "Report" --output report-tmpl.json
+  gws sheets capture-template <id> --sheet "Data" --header-rows 2 --output tmpl.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsCaptureTemplate,
+}
+
+var sheetsApplyTemplateCmd = &cobra.Command{
+	Use:   "apply-template <spreadsheet-id>",
+	Short: "Apply a captured formatting template to a sheet",
+	Long: `Replays a template written by capture-template onto a sheet in one batch
+update: frozen rows and columns, column widths, header row heights and
+formats, column number formats (from below the header to the end of the
+sheet), and conditional formatting rules. Cell values are left as they are.
+
+The sheet's existing conditional formatting rules are replaced by the
+template's so that applying the same template twice gives the same result;
+--keep-rules adds the template's rules after the existing ones instead.
+Template columns beyond the sheet's last column are skipped.
+
+Examples:
+  gws sheets apply-template <id> --sheet "Q3 Report" --file report-tmpl.json
+  gws sheets apply-template <other-id> --sheet "Data" --file tmpl.json --keep-rules`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsApplyTemplate,
+}
+
+var sheetsNumberFormatCmd = &cobra.Command{
+	Use:   "number-format <spreadsheet-id> <range>",
+	Short: "Set the number, date, or currency format of cells",
+	Long: `Sets the number format of every cell in a range, leaving values and other
+formatting as they are.
+
+--type is inferred from --pattern when omitted (e.g. "yyyy-mm-dd" is DATE,
+"0.00%" is PERCENT, "$#,##0.00" is CURRENCY). With --type alone, Sheets uses
+the spreadsheet locale's default pattern for that type. Valid types: NUMBER,
+CURRENCY, PERCENT, DATE, TIME, DATE_TIME, SCIENTIFIC, TEXT.
+
+To format a whole column including rows added later, use set-default-format.
+
+Examples:
+  gws sheets number-format <id> "Sheet1!B2:B100" --pattern "$#,##0.00"
+  gws sheets number-format <id> "Orders!D2:D50" --type DATE --pattern "yyyy-mm-dd"
+  gws sheets number-format <id> "Rates!C2:C20" --type PERCENT`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSheetsNumberFormat,
+}
+
+var sheetsSetMetadataCmd = &cobra.Command{
+	Use:   "set-metadata <spreadsheet-id>",
+	Short: "Attach a developer metadata key/value",
+	Long: `Creates a developer metadata entry: a key/value pair attached to the whole
+spreadsheet, a sheet, a row, or a column. Row and column metadata moves with
+its row or column when rows are inserted, deleted, or sorted, so tooling can
+find the row again with search-metadata instead of relying on A1 positions.
+
+Locations:
+  spreadsheet   the whole spreadsheet (default)
+  sheet         a sheet (--sheet)
+  row           one row of a sheet (--sheet, --row, 1-based)
+  column        one column of a sheet (--sheet, --col)
+
+DOCUMENT visibility (default) is readable by anyone who can open the
+spreadsheet; PROJECT visibility is only readable by the Cloud project that
+created it. Setting the same key again adds another entry.
+
+Examples:
+  gws sheets set-metadata <id> --key sync-source --value crm
+  gws sheets set-metadata <id> --key record-id --value 4821 --location row --sheet Orders --row 12
+  gws sheets set-metadata <id> --key field --value email --location column --sheet Contacts --col C --visibility PROJECT`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsSetMetadata,
+}
+
+var sheetsSearchMetadataCmd = &cobra.Command{
+	Use:   "search-metadata <spreadsheet-id>",
+	Short: "Find developer metadata by key or value",
+	Long: `Searches a spreadsheet's developer metadata and returns each match with its
+location: the sheet, and the 1-based row or column letter for row and column
+metadata. Visible metadata is DOCUMENT metadata plus PROJECT metadata created
+by this gws OAuth client's project.
+
+Examples:
+  gws sheets search-metadata <id> --key record-id
+  gws sheets search-metadata <id> --key record-id --value 4821
+  gws sheets search-metadata <id> --key field --location column`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsSearchMetadata,
+}
+
+var sheetsAppendMappedCmd = &cobra.Command{
+	Use:   "append-mapped <spreadsheet-id>",
+	Short: "Append JSON records as rows, matching keys to header names",
+	Long: `Appends records to a sheet by column name instead of position. The sheet's
+first row is read as the header, each record's keys are matched to header
+names, and the rows are appended after the table so that every value lands
+in its column even when columns have been reordered.
+
+Keys that match no header are ignored and reported in ignored_keys; columns
+missing from a record are left blank. Header names are compared exactly,
+after trimming spaces; when a name appears twice the first column wins.
+Values are entered as if typed (USER_ENTERED), like append.
+
+Examples:
+  gws sheets append-mapped <id> --sheet Contacts --json '[{"Name":"Ana","City":"Lisbon"}]'
+  gws sheets append-mapped <id> --sheet "Orders 2026" --json "$(cat orders.json)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsAppendMapped,
+}
+
+var sheetsListMergesCmd = &cobra.Command{
+	Use:   "list-merges <spreadsheet-id>",
+	Short: "List merged cell ranges",
+	Long: `Lists merged cell ranges in A1 notation with their row and column spans,
+in sheet order and then top to bottom, left to right. Lists every sheet
+unless --sheet is given. Each range can be passed straight to unmerge.
+
+Examples:
+  gws sheets list-merges <id>
+  gws sheets list-merges <id> --sheet Sheet1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSheetsListMerges,
+}
+
 func init() {
 	rootCmd.AddCommand(sheetsCmd)
 	sheetsCmd.AddCommand(sheetsInfoCmd)
@@ -456,6 +1321,11 @@ func init() {
 	// Read flags
 	sheetsReadCmd.Flags().String("output-format", "json", "Output format: json or csv")
 	sheetsReadCmd.Flags().Bool("headers", true, "Treat first row as headers (for json output)")
+	sheetsReadCmd.Flags().Int64("page-rows", 0, "Fetch the range in windows of this many rows (0 reads it in one call)")
+	sheetsReadCmd.Flags().Int64("start-row", 0, "1-based row to start paging from (default: first row of the range)")
+	sheetsReadCmd.Flags().Bool("stream", false, "With --page-rows, print each window as it arrives (only --stream keeps memory bounded; otherwise all windows are collected first)")
+	sheetsReadCmd.Flags().String("value-render", "FORMATTED_VALUE", "Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, FORMULA")
+	sheetsReadCmd.Flags().String("date-render", "", "Date render option for unformatted values: SERIAL_NUMBER, FORMATTED_STRING (default: API default, SERIAL_NUMBER)")
 
 	// Create flags
 	sheetsCreateCmd.Flags().String("title", "", "Spreadsheet title (required)")
@@ -582,9 +1452,17 @@ func init() {
 	sheetsAddChartCmd.Flags().String("data", "", "Data range (e.g., Sheet1!A1:B10) (required)")
 	sheetsAddChartCmd.Flags().String("title", "", "Chart title")
 	sheetsAddChartCmd.Flags().String("sheet", "", "Sheet to place chart on (defaults to new sheet)")
+	addChartStyleFlags(sheetsAddChartCmd)
 	sheetsAddChartCmd.MarkFlagRequired("type")
 	sheetsAddChartCmd.MarkFlagRequired("data")
 
+	// Update-chart command
+	sheetsCmd.AddCommand(sheetsUpdateChartCmd)
+	sheetsUpdateChartCmd.Flags().Int64("chart-id", 0, "Chart ID to update (required)")
+	sheetsUpdateChartCmd.Flags().String("title", "", "Chart title")
+	addChartStyleFlags(sheetsUpdateChartCmd)
+	sheetsUpdateChartCmd.MarkFlagRequired("chart-id")
+
 	// List-charts command
 	sheetsCmd.AddCommand(sheetsListChartsCmd)
 
@@ -614,6 +1492,303 @@ func init() {
 	sheetsDeleteConditionalFormatCmd.Flags().Int64("index", 0, "0-based index of the rule to delete (required)")
 	sheetsDeleteConditionalFormatCmd.MarkFlagRequired("sheet")
 	sheetsDeleteConditionalFormatCmd.MarkFlagRequired("index")
+
+	// Add-range-dropdown command
+	sheetsCmd.AddCommand(sheetsAddRangeDropdownCmd)
+	sheetsAddRangeDropdownCmd.Flags().String("source", "", "Range holding the dropdown options (e.g., Lists!A1:A20) (required)")
+	sheetsAddRangeDropdownCmd.Flags().Bool("relative", false, "Keep the source reference relative instead of absolute")
+	sheetsAddRangeDropdownCmd.Flags().Bool("allow-invalid", false, "Show a warning instead of rejecting values not in the source range")
+	sheetsAddRangeDropdownCmd.MarkFlagRequired("source")
+
+	// Add validation command
+	sheetsCmd.AddCommand(sheetsAddValidationCmd)
+	sheetsAddValidationCmd.Flags().String("type", "", "Condition type: ONE_OF_LIST, NUMBER_BETWEEN, TEXT_CONTAINS, CUSTOM_FORMULA (required)")
+	sheetsAddValidationCmd.Flags().StringSlice("values", nil, "Allowed values, comma-separated (ONE_OF_LIST)")
+	sheetsAddValidationCmd.Flags().Float64("min", 0, "Minimum value, inclusive (NUMBER_BETWEEN)")
+	sheetsAddValidationCmd.Flags().Float64("max", 0, "Maximum value, inclusive (NUMBER_BETWEEN)")
+	sheetsAddValidationCmd.Flags().String("text", "", "Text the value must contain (TEXT_CONTAINS)")
+	sheetsAddValidationCmd.Flags().String("formula", "", "Formula that must be TRUE, e.g. =B2>0 (CUSTOM_FORMULA)")
+	sheetsAddValidationCmd.Flags().Bool("strict", false, "Reject invalid input instead of showing a warning")
+	sheetsAddValidationCmd.Flags().Bool("show-dropdown", false, "Show the allowed values as an in-cell dropdown (ONE_OF_LIST)")
+	sheetsAddValidationCmd.MarkFlagRequired("type")
+
+	// Clear validation command
+	sheetsCmd.AddCommand(sheetsClearValidationCmd)
+
+	// Format-as-table command
+	sheetsCmd.AddCommand(sheetsFormatAsTableCmd)
+	sheetsFormatAsTableCmd.Flags().Bool("header-bold", false, "Make the header row bold")
+	sheetsFormatAsTableCmd.Flags().String("header-bg", "", "Header background color (hex, e.g., #4285F4)")
+	sheetsFormatAsTableCmd.Flags().String("header-color", "", "Header text color (hex, e.g., #FFFFFF)")
+	sheetsFormatAsTableCmd.Flags().Bool("banded", false, "Add alternating row colors below the header")
+	sheetsFormatAsTableCmd.Flags().String("band-color", "#F3F3F3", "Color of every other row when --banded (hex)")
+	sheetsFormatAsTableCmd.Flags().Bool("no-freeze", false, "Do not freeze rows through the header row")
+
+	// Link-range command
+	sheetsCmd.AddCommand(sheetsLinkRangeCmd)
+	sheetsLinkRangeCmd.Flags().String("dst-cell", "", "Destination cell for the formula (e.g., A1 or Summary!B2) (required)")
+	sheetsLinkRangeCmd.Flags().String("src-id", "", "Source spreadsheet ID (required)")
+	sheetsLinkRangeCmd.Flags().String("src-range", "", "Source range in A1 notation (e.g., Sheet1!A1:D) (required)")
+	sheetsLinkRangeCmd.Flags().String("query", "", "Wrap the import in QUERY with this query string (use Col1, Col2, ...)")
+	sheetsLinkRangeCmd.MarkFlagRequired("dst-cell")
+	sheetsLinkRangeCmd.MarkFlagRequired("src-id")
+	sheetsLinkRangeCmd.MarkFlagRequired("src-range")
+
+	// Formulas command
+	sheetsCmd.AddCommand(sheetsFormulasCmd)
+	sheetsFormulasCmd.Flags().String("contains", "", "Only list formulas containing this text (case-insensitive)")
+
+	// Lock-header command
+	sheetsCmd.AddCommand(sheetsLockHeaderCmd)
+	sheetsLockHeaderCmd.Flags().String("sheet", "", "Sheet name (required)")
+	sheetsLockHeaderCmd.Flags().Int64("rows", 1, "Number of header rows to freeze and protect")
+	sheetsLockHeaderCmd.Flags().String("description", "Header row", "Description shown on the protected range")
+	sheetsLockHeaderCmd.MarkFlagRequired("sheet")
+
+	// Protect-named command
+	sheetsCmd.AddCommand(sheetsProtectNamedCmd)
+	sheetsProtectNamedCmd.Flags().String("named-range", "", "Name or ID of the named range to protect (required)")
+	sheetsProtectNamedCmd.Flags().String("editors", "", "Comma-separated emails of users allowed to edit the range")
+	sheetsProtectNamedCmd.Flags().Bool("warning-only", false, "Warn before edits instead of restricting editors")
+	sheetsProtectNamedCmd.Flags().String("description", "", "Description shown on the protected range (default: the range name)")
+	sheetsProtectNamedCmd.MarkFlagRequired("named-range")
+
+	// Protect command
+	sheetsCmd.AddCommand(sheetsProtectCmd)
+	sheetsProtectCmd.Flags().String("sheet", "", "Protect this whole sheet instead of a range")
+	sheetsProtectCmd.Flags().String("editors", "", "Comma-separated emails of users allowed to edit the range")
+	sheetsProtectCmd.Flags().Bool("warning-only", false, "Warn before edits instead of restricting editors")
+	sheetsProtectCmd.Flags().String("description", "", "Description shown on the protected range")
+
+	// List-protections command
+	sheetsCmd.AddCommand(sheetsListProtectionsCmd)
+	sheetsListProtectionsCmd.Flags().String("sheet", "", "Only list protections on this sheet")
+
+	// Unprotect command
+	sheetsCmd.AddCommand(sheetsUnprotectCmd)
+	sheetsUnprotectCmd.Flags().Int64("protection-id", 0, "Protected range ID (from list-protections) (required)")
+	sheetsUnprotectCmd.MarkFlagRequired("protection-id")
+
+	// A1 command
+	sheetsCmd.AddCommand(sheetsA1Cmd)
+	sheetsA1Cmd.Flags().String("to-index", "", "Cell or column in A1 notation to convert to 0-based indices (e.g., B3, AA)")
+	sheetsA1Cmd.Flags().String("to-a1", "", "0-based \"col,row\" indices (or a single column index) to convert to A1 notation")
+
+	// Freeze-values command
+	sheetsCmd.AddCommand(sheetsFreezeValuesCmd)
+
+	// To-html command
+	sheetsCmd.AddCommand(sheetsToHTMLCmd)
+	sheetsToHTMLCmd.Flags().String("output", "", "Output HTML file path (required)")
+	sheetsToHTMLCmd.Flags().Bool("with-styles", false, "Inline each cell's bold/italic, colors, and alignment")
+	sheetsToHTMLCmd.Flags().Bool("header", false, "Render the first row as <th> header cells")
+	sheetsToHTMLCmd.MarkFlagRequired("output")
+
+	// Set-default-format command
+	sheetsCmd.AddCommand(sheetsSetDefaultFormatCmd)
+	sheetsSetDefaultFormatCmd.Flags().String("sheet", "", "Sheet name (required)")
+	sheetsSetDefaultFormatCmd.Flags().String("col", "", "Column letter (e.g., B, AA) (required)")
+	sheetsSetDefaultFormatCmd.Flags().String("number-format", "", "Number format pattern, e.g. yyyy-mm-dd or #,##0.00 (required)")
+	sheetsSetDefaultFormatCmd.Flags().String("type", "", "Number format type (default: inferred from the pattern)")
+	sheetsSetDefaultFormatCmd.Flags().Int64("skip-rows", 0, "Number of top rows (e.g. headers) to leave unformatted")
+	sheetsSetDefaultFormatCmd.MarkFlagRequired("sheet")
+	sheetsSetDefaultFormatCmd.MarkFlagRequired("col")
+	sheetsSetDefaultFormatCmd.MarkFlagRequired("number-format")
+
+	// Retype command
+	sheetsCmd.AddCommand(sheetsRetypeCmd)
+	sheetsRetypeCmd.Flags().String("as", "", "Target type: number or date (required)")
+	sheetsRetypeCmd.Flags().Bool("day-first", false, "Read numeric dates as D/M/YYYY instead of M/D/YYYY")
+	sheetsRetypeCmd.MarkFlagRequired("as")
+
+	// Clean command
+	sheetsCmd.AddCommand(sheetsCleanCmd)
+	sheetsCleanCmd.Flags().Bool("trim", false, "Remove leading and trailing whitespace")
+	sheetsCleanCmd.Flags().Bool("collapse-spaces", false, "Collapse runs of spaces and tabs to a single space")
+	sheetsCleanCmd.Flags().Bool("to-number", false, "Convert numeric text to numbers")
+	sheetsCleanCmd.Flags().Bool("to-date", false, "Convert date text to dates")
+	sheetsCleanCmd.Flags().Bool("day-first", false, "Read numeric dates as D/M/YYYY instead of M/D/YYYY (with --to-date)")
+
+	// Status colors command
+	sheetsCmd.AddCommand(sheetsStatusColorsCmd)
+	sheetsStatusColorsCmd.Flags().String("status-col", "", "Column letter holding each row's status, e.g. E (required)")
+	sheetsStatusColorsCmd.Flags().String("map", "", "Comma-separated status=#RRGGBB pairs, e.g. \"Done=#00FF00,Blocked=#FF0000\" (required)")
+	sheetsStatusColorsCmd.MarkFlagRequired("status-col")
+	sheetsStatusColorsCmd.MarkFlagRequired("map")
+
+	// Comments commands
+	sheetsCmd.AddCommand(sheetsCommentsCmd)
+	sheetsCommentsCmd.AddCommand(sheetsCommentsListCmd)
+	sheetsCommentsCmd.AddCommand(sheetsCommentsAddCmd)
+	sheetsCommentsListCmd.Flags().Int64("max", 100, "Maximum number of comments to fetch")
+	sheetsCommentsListCmd.Flags().Bool("include-resolved", false, "Include resolved comments")
+	sheetsCommentsAddCmd.Flags().String("text", "", "Comment text (required)")
+	sheetsCommentsAddCmd.Flags().String("anchor", "", "Cell or range the comment refers to, in A1 notation (e.g., Sheet1!B2)")
+	sheetsCommentsAddCmd.MarkFlagRequired("text")
+
+	// Dump command
+	sheetsCmd.AddCommand(sheetsDumpCmd)
+	sheetsDumpCmd.Flags().String("output", "", "Directory to write one CSV file per sheet (default: print JSON)")
+	sheetsDumpCmd.Flags().Int64("max-cells", 1000000, "Refuse to dump when the sheets' grid sizes total more cells than this (0 = no limit)")
+	sheetsDumpCmd.Flags().String("value-render", "FORMATTED_VALUE", "Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, FORMULA")
+
+	// Copy-spreadsheet command
+	sheetsCmd.AddCommand(sheetsCopySpreadsheetCmd)
+	sheetsCopySpreadsheetCmd.Flags().String("title", "", "Title of the copy (default: \"Copy of <source title>\")")
+	sheetsCopySpreadsheetCmd.Flags().String("folder", "", "Drive folder ID to put the copy in (default: the source's folder)")
+	sheetsCopySpreadsheetCmd.Flags().StringArray("replace", nil, "Replace text in the copy, as key=value (can be repeated)")
+
+	// Snapshot command
+	sheetsCmd.AddCommand(sheetsSnapshotCmd)
+	sheetsSnapshotCmd.Flags().String("folder", "", "Drive folder ID for snapshots (default: the source's folder)")
+	sheetsSnapshotCmd.Flags().String("name-template", "{title} snapshot {date} {time}", "Snapshot name; supports {title}, {date}, and {time}")
+	sheetsSnapshotCmd.Flags().Int("keep", 0, "Keep only the N most recent matching snapshots, trashing older ones (0 = keep all)")
+
+	// Diff command
+	sheetsCmd.AddCommand(sheetsDiffCmd)
+	sheetsDiffCmd.Flags().String("a", "", "First range: <range> or <spreadsheet-id>!<range> (required)")
+	sheetsDiffCmd.Flags().String("b", "", "Second range: <range> or <spreadsheet-id>!<range> (required)")
+	sheetsDiffCmd.Flags().String("value-render", "FORMATTED_VALUE", "Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, FORMULA")
+	sheetsDiffCmd.Flags().Int("max-diffs", 1000, "Maximum number of changed cells to list (0 = all)")
+	sheetsDiffCmd.Flags().Bool("fail-on-diff", false, "Exit with code 1 when the ranges differ")
+	sheetsDiffCmd.MarkFlagRequired("a")
+	sheetsDiffCmd.MarkFlagRequired("b")
+
+	// Upsert command
+	sheetsCmd.AddCommand(sheetsUpsertCmd)
+	sheetsUpsertCmd.Flags().String("key-col", "", "Column letter holding the key, e.g. A (required)")
+	sheetsUpsertCmd.Flags().String("records", "", "JSON file with an array of rows (arrays or header-keyed objects) (required)")
+	sheetsUpsertCmd.MarkFlagRequired("key-col")
+	sheetsUpsertCmd.MarkFlagRequired("records")
+
+	// To-env command
+	sheetsCmd.AddCommand(sheetsToEnvCmd)
+	sheetsToEnvCmd.Flags().String("range", "", "Two-column key/value range, e.g. Config!A:B (required)")
+	sheetsToEnvCmd.Flags().String("prefix", "", "Prefix added to every key")
+	sheetsToEnvCmd.Flags().Bool("upper", false, "Upper-case keys")
+	sheetsToEnvCmd.Flags().Bool("skip-header", false, "Skip the first row of the range")
+	sheetsToEnvCmd.Flags().Bool("export", false, "Prefix each line with \"export \"")
+	sheetsToEnvCmd.Flags().String("output", "", "Write the lines to this file instead of stdout")
+	sheetsToEnvCmd.MarkFlagRequired("range")
+
+	// Write-typed command
+	sheetsCmd.AddCommand(sheetsWriteTypedCmd)
+	sheetsWriteTypedCmd.Flags().String("json", "", "Values as a JSON 2D array of rows (required)")
+	sheetsWriteTypedCmd.Flags().String("number-format", "", "Number format pattern, e.g. $#,##0.00 (required)")
+	sheetsWriteTypedCmd.Flags().String("type", "", "Number format type (default: inferred from the pattern)")
+	sheetsWriteTypedCmd.MarkFlagRequired("json")
+	sheetsWriteTypedCmd.MarkFlagRequired("number-format")
+
+	// Stamp command
+	sheetsCmd.AddCommand(sheetsStampCmd)
+	sheetsStampCmd.Flags().String("cell", "", "Cell to write, e.g. A1 or \"Log!B2\" (required)")
+	sheetsStampCmd.Flags().String("format", "yyyy-mm-dd hh:mm:ss", "Date/time number format pattern")
+	sheetsStampCmd.Flags().Bool("utc", false, "Use UTC instead of the spreadsheet's time zone")
+	sheetsStampCmd.MarkFlagRequired("cell")
+
+	// Set-borders command
+	sheetsCmd.AddCommand(sheetsSetBordersCmd)
+	for _, side := range borderSides {
+		sheetsSetBordersCmd.Flags().Bool(side, false, "Apply the "+strings.ReplaceAll(side, "-", " ")+" border")
+	}
+	sheetsSetBordersCmd.Flags().Bool("outer", false, "Apply the top, bottom, left, and right borders")
+	sheetsSetBordersCmd.Flags().Bool("inner", false, "Apply the inner horizontal and inner vertical borders")
+	sheetsSetBordersCmd.Flags().Bool("all", false, "Apply every border")
+	sheetsSetBordersCmd.Flags().String("color", "#000000", "Border color (hex)")
+	sheetsSetBordersCmd.Flags().String("style", "SOLID", "Border style: SOLID, SOLID_MEDIUM, SOLID_THICK, DASHED, DOTTED, DOUBLE, NONE")
+	sheetsSetBordersCmd.Flags().Int64("width", 1, "Line width 1-3 (SOLID only)")
+
+	// Filter-read command
+	sheetsCmd.AddCommand(sheetsFilterReadCmd)
+	sheetsFilterReadCmd.Flags().String("where", "", "Row condition, e.g. \"B>100 AND C=active\" (required)")
+	sheetsFilterReadCmd.Flags().Bool("header", false, "Treat the first row as headers and return rows as objects")
+	sheetsFilterReadCmd.Flags().String("value-render", "UNFORMATTED_VALUE", "Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, FORMULA")
+	sheetsFilterReadCmd.MarkFlagRequired("where")
+
+	// Trace command
+	sheetsCmd.AddCommand(sheetsTraceCmd)
+	sheetsTraceCmd.Flags().String("cell", "", "Cell to trace, e.g. D5 or \"Summary!B12\" (required)")
+	sheetsTraceCmd.Flags().Int("depth", 10, "Maximum number of formula hops to follow")
+	sheetsTraceCmd.MarkFlagRequired("cell")
+
+	// Refresh command
+	sheetsCmd.AddCommand(sheetsRefreshCmd)
+	sheetsRefreshCmd.Flags().String("data-source", "", "Only refresh objects of this data source ID")
+	sheetsRefreshCmd.Flags().Bool("force", false, "Refresh even when the data is current, cancelling a running refresh")
+
+	// Add-pivot command
+	sheetsCmd.AddCommand(sheetsAddPivotCmd)
+	sheetsAddPivotCmd.Flags().String("source", "", "Source data range including the header row (e.g., Export!A1:F500) (required)")
+	sheetsAddPivotCmd.Flags().String("rows", "", "Comma-separated source columns to group rows by (e.g., B,C)")
+	sheetsAddPivotCmd.Flags().String("cols", "", "Comma-separated source columns to group columns by")
+	sheetsAddPivotCmd.Flags().String("values", "", "Comma-separated COLUMN:FUNCTION values to summarize (e.g., E:SUM,F:AVERAGE)")
+	sheetsAddPivotCmd.Flags().String("anchor", "", "Top-left cell of the pivot table (e.g., Pivot!A1) (required)")
+	sheetsAddPivotCmd.MarkFlagRequired("source")
+	sheetsAddPivotCmd.MarkFlagRequired("anchor")
+
+	// Import-csv command
+	sheetsCmd.AddCommand(sheetsImportCSVCmd)
+	sheetsImportCSVCmd.Flags().String("file", "", "Path to the CSV file (required)")
+	sheetsImportCSVCmd.Flags().String("delimiter", ",", "Field delimiter: a single character, or \\t / tab for tab-separated files")
+	sheetsImportCSVCmd.Flags().Bool("skip-header", false, "Skip the file's first row")
+	sheetsImportCSVCmd.Flags().Bool("clear-first", false, "Clear the range before writing")
+	sheetsImportCSVCmd.Flags().String("value-input", "USER_ENTERED", "Value input option: RAW, USER_ENTERED")
+	sheetsImportCSVCmd.MarkFlagRequired("file")
+
+	// Export-csv command
+	sheetsCmd.AddCommand(sheetsExportCSVCmd)
+	sheetsExportCSVCmd.Flags().String("output", "", "CSV file to write, - for stdout, or a directory with --all-sheets")
+	sheetsExportCSVCmd.Flags().String("delimiter", ",", "Field delimiter: a single character, or \\t / tab for tab-separated output")
+	sheetsExportCSVCmd.Flags().Bool("all-sheets", false, "Export every sheet to <title>.csv instead of a range")
+
+	// Capture-template command
+	sheetsCmd.AddCommand(sheetsCaptureTemplateCmd)
+	sheetsCaptureTemplateCmd.Flags().String("sheet", "", "Sheet to capture (required)")
+	sheetsCaptureTemplateCmd.Flags().String("output", "", "Template file to write (required)")
+	sheetsCaptureTemplateCmd.Flags().Int64("header-rows", 0, "Number of header rows (default: frozen rows, or 1)")
+	sheetsCaptureTemplateCmd.MarkFlagRequired("sheet")
+	sheetsCaptureTemplateCmd.MarkFlagRequired("output")
+
+	// Apply-template command
+	sheetsCmd.AddCommand(sheetsApplyTemplateCmd)
+	sheetsApplyTemplateCmd.Flags().String("sheet", "", "Sheet to format (required)")
+	sheetsApplyTemplateCmd.Flags().String("file", "", "Template file written by capture-template (required)")
+	sheetsApplyTemplateCmd.Flags().Bool("keep-rules", false, "Keep the sheet's conditional formatting rules and add the template's after them")
+	sheetsApplyTemplateCmd.MarkFlagRequired("sheet")
+	sheetsApplyTemplateCmd.MarkFlagRequired("file")
+
+	// Number-format command
+	sheetsCmd.AddCommand(sheetsNumberFormatCmd)
+	sheetsNumberFormatCmd.Flags().String("type", "", "Number format type (default: inferred from --pattern)")
+	sheetsNumberFormatCmd.Flags().String("pattern", "", "Number format pattern, e.g. $#,##0.00 or yyyy-mm-dd")
+
+	// Set-metadata command
+	sheetsCmd.AddCommand(sheetsSetMetadataCmd)
+	sheetsSetMetadataCmd.Flags().String("key", "", "Metadata key (required)")
+	sheetsSetMetadataCmd.Flags().String("value", "", "Metadata value")
+	sheetsSetMetadataCmd.Flags().String("location", "spreadsheet", "Where to attach it: spreadsheet, sheet, row, or column")
+	sheetsSetMetadataCmd.Flags().String("sheet", "", "Sheet name (required for sheet, row, and column)")
+	sheetsSetMetadataCmd.Flags().Int64("row", 0, "Row number, 1-based (for --location row)")
+	sheetsSetMetadataCmd.Flags().String("col", "", "Column letter, e.g. C (for --location column)")
+	sheetsSetMetadataCmd.Flags().String("visibility", "DOCUMENT", "DOCUMENT or PROJECT")
+	sheetsSetMetadataCmd.MarkFlagRequired("key")
+
+	// Search-metadata command
+	sheetsCmd.AddCommand(sheetsSearchMetadataCmd)
+	sheetsSearchMetadataCmd.Flags().String("key", "", "Metadata key to match")
+	sheetsSearchMetadataCmd.Flags().String("value", "", "Metadata value to match")
+	sheetsSearchMetadataCmd.Flags().String("location", "", "Only match metadata at this location type: spreadsheet, sheet, row, or column")
+
+	// Append-mapped command
+	sheetsCmd.AddCommand(sheetsAppendMappedCmd)
+	sheetsAppendMappedCmd.Flags().String("sheet", "", "Sheet whose first row holds the header (required)")
+	sheetsAppendMappedCmd.Flags().String("json", "", `JSON array of objects, e.g. '[{"Name":"Ana"}]' (required)`)
+	sheetsAppendMappedCmd.MarkFlagRequired("sheet")
+	sheetsAppendMappedCmd.MarkFlagRequired("json")
+
+	// List-merges command
+	sheetsCmd.AddCommand(sheetsListMergesCmd)
+	sheetsListMergesCmd.Flags().String("sheet", "", "Only list merges in this sheet")
 }
 
 func runSheetsInfo(cmd *cobra.Command, args []string) error {
@@ -666,6 +1841,32 @@ func runSheetsRead(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
+	pageRows, _ := cmd.Flags().GetInt64("page-rows")
+	startRow, _ := cmd.Flags().GetInt64("start-row")
+	stream, _ := cmd.Flags().GetBool("stream")
+	if pageRows < 0 {
+		return usageErrorf("--page-rows must be 0 or greater")
+	}
+	if cmd.Flags().Changed("start-row") && startRow < 1 {
+		return usageErrorf("--start-row must be 1 or greater")
+	}
+	if pageRows == 0 && (startRow > 0 || stream) {
+		return usageErrorf("--start-row and --stream require --page-rows")
+	}
+	valueRender, _ := cmd.Flags().GetString("value-render")
+	dateRender, _ := cmd.Flags().GetString("date-render")
+	render := renderOptions{Value: strings.ToUpper(valueRender), Date: strings.ToUpper(dateRender)}
+	switch render.Value {
+	case "FORMATTED_VALUE", "UNFORMATTED_VALUE", "FORMULA":
+	default:
+		return usageErrorf("invalid --value-render %q: must be FORMATTED_VALUE, UNFORMATTED_VALUE, or FORMULA", valueRender)
+	}
+	switch render.Date {
+	case "", "SERIAL_NUMBER", "FORMATTED_STRING":
+	default:
+		return usageErrorf("invalid --date-render %q: must be SERIAL_NUMBER or FORMATTED_STRING", dateRender)
+	}
+
 	factory, err := client.NewFactory(ctx)
 	if err != nil {
 		return p.PrintError(err)
@@ -681,7 +1882,42 @@ func runSheetsRead(cmd *cobra.Command, args []string) error {
 	outputFormat, _ := cmd.Flags().GetString("output-format")
 	useHeaders, _ := cmd.Flags().GetBool("headers")
 
-	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr).Do()
+	if pageRows > 0 {
+		return runSheetsReadPaged(p, svc, spreadsheetID, rangeStr, pagedReadOptions{
+			PageRows: pageRows,
+			StartRow: startRow,
+			Stream:   stream,
+			CSV:      outputFormat == "csv",
+			Headers:  useHeaders && outputFormat != "csv",
+			Render:   render,
+		})
+	}
+
+	return runSheetsReadWithService(svc, spreadsheetID, rangeStr, outputFormat, useHeaders, render, p)
+}
+
+// renderOptions are the value and date render options for a values read.
+// Empty fields leave the API defaults.
+type renderOptions struct {
+	Value string
+	Date  string
+}
+
+// apply sets the render options on a values get call.
+func (r renderOptions) apply(call *sheets.SpreadsheetsValuesGetCall) *sheets.SpreadsheetsValuesGetCall {
+	if r.Value != "" {
+		call = call.ValueRenderOption(r.Value)
+	}
+	if r.Date != "" {
+		call = call.DateTimeRenderOption(r.Date)
+	}
+	return call
+}
+
+// runSheetsReadWithService reads a range in one call and prints it as CSV,
+// header-keyed rows, or raw values.
+func runSheetsReadWithService(svc *sheets.Service, spreadsheetID, rangeStr, outputFormat string, useHeaders bool, render renderOptions, p printer.Printer) error {
+	resp, err := render.apply(svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr)).Do()
 	if err != nil {
 		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
 	}
@@ -696,21 +1932,9 @@ func runSheetsRead(cmd *cobra.Command, args []string) error {
 
 	// CSV output
 	if outputFormat == "csv" {
-		var builder strings.Builder
-		writer := csv.NewWriter(&builder)
-
-		for _, row := range resp.Values {
-			record := make([]string, len(row))
-			for i, cell := range row {
-				record[i] = fmt.Sprintf("%v", cell)
-			}
-			_ = writer.Write(record)
-		}
-		writer.Flush()
-
 		return p.Print(map[string]interface{}{
 			"range": resp.Range,
-			"csv":   builder.String(),
+			"csv":   rowsToCSV(resp.Values),
 			"rows":  len(resp.Values),
 		})
 	}
@@ -723,16 +1947,7 @@ func runSheetsRead(cmd *cobra.Command, args []string) error {
 			headers[i] = fmt.Sprintf("%v", cell)
 		}
 
-		data := make([]map[string]interface{}, 0, len(resp.Values)-1)
-		for _, row := range resp.Values[1:] {
-			rowMap := make(map[string]interface{})
-			for i, cell := range row {
-				if i < len(headers) {
-					rowMap[headers[i]] = cell
-				}
-			}
-			data = append(data, rowMap)
-		}
+		data := rowsToHeaderMaps(headers, resp.Values[1:])
 
 		return p.Print(map[string]interface{}{
 			"range":   resp.Range,
@@ -1493,6 +2708,9 @@ func parseRange(svc *sheets.Service, spreadsheetID, rangeStr string) (int64, *sh
 
 	if idx := strings.Index(rangeStr, "!"); idx != -1 {
 		sheetName := rangeStr[:idx]
+		if len(sheetName) >= 2 && strings.HasPrefix(sheetName, "'") && strings.HasSuffix(sheetName, "'") {
+			sheetName = strings.ReplaceAll(sheetName[1:len(sheetName)-1], "''", "'")
+		}
 		cellRange = rangeStr[idx+1:]
 		// Look up sheet ID by name
 		var err error
@@ -1848,6 +3066,16 @@ func parseSheetsHexColor(hex string) (*sheets.Color, error) {
 	}, nil
 }
 
+// sheetsColorToHex renders a Sheets Color as #RRGGBB, the inverse of
+// parseSheetsHexColor. Returns "" for nil.
+func sheetsColorToHex(c *sheets.Color) string {
+	if c == nil {
+		return ""
+	}
+	channel := func(v float64) int64 { return int64(math.Round(v * 255)) }
+	return fmt.Sprintf("#%02X%02X%02X", channel(c.Red), channel(c.Green), channel(c.Blue))
+}
+
 func runSheetsFormat(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
@@ -2599,6 +3827,11 @@ func runSheetsAddChart(cmd *cobra.Command, args []string) error {
 		return usageErrorf("unknown chart type: %s (valid: BAR, LINE, AREA, COLUMN, SCATTER, PIE, COMBO)", chartType)
 	}
 
+	style, err := chartStyleFromFlags(cmd)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
 	_, gridRange, err := parseRange(svc, spreadsheetID, dataRange)
 	if err != nil {
 		return p.PrintError(fmt.Errorf("failed to parse data range: %w", err))
@@ -2632,6 +3865,23 @@ func runSheetsAddChart(cmd *cobra.Command, args []string) error {
 			Domain: &sheets.ChartData{SourceRange: &sheets.ChartSourceRange{Sources: []*sheets.GridRange{domainRange}}},
 			Series: &sheets.ChartData{SourceRange: &sheets.ChartSourceRange{Sources: []*sheets.GridRange{seriesRange}}},
 		}
+	} else if len(style.seriesColors) > 0 {
+		// Per-series colors need one series per column: first column =
+		// labels (domain), each remaining column = its own series.
+		if gridRange.EndColumnIndex-gridRange.StartColumnIndex < 2 {
+			return usageErrorf("--series-colors requires at least 2 columns (labels + data), got range with %d column(s)", gridRange.EndColumnIndex-gridRange.StartColumnIndex)
+		}
+		spec.BasicChart = &sheets.BasicChartSpec{
+			ChartType: chartType,
+			Domains: []*sheets.BasicChartDomain{
+				{Domain: chartColumnData(gridRange, gridRange.StartColumnIndex)},
+			},
+		}
+		for col := gridRange.StartColumnIndex + 1; col < gridRange.EndColumnIndex; col++ {
+			spec.BasicChart.Series = append(spec.BasicChart.Series, &sheets.BasicChartSeries{
+				Series: chartColumnData(gridRange, col),
+			})
+		}
 	} else {
 		spec.BasicChart = &sheets.BasicChartSpec{
 			ChartType: chartType,
@@ -2644,6 +3894,10 @@ func runSheetsAddChart(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err := applyChartStyle(spec, style); err != nil {
+		return usageErrorf("%v", err)
+	}
+
 	position := &sheets.EmbeddedObjectPosition{}
 	if sheetName != "" {
 		sheetID, err := getSheetID(svc, spreadsheetID, sheetName)
@@ -2694,30 +3948,263 @@ func runSheetsAddChart(cmd *cobra.Command, args []string) error {
 	})
 }
 
-func runSheetsListCharts(cmd *cobra.Command, args []string) error {
-	p := GetPrinter()
-	ctx := context.Background()
+// addChartStyleFlags registers the axis, series, and legend flags shared by
+// add-chart and update-chart.
+func addChartStyleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("x-axis-title", "", "Title for the bottom (X) axis")
+	cmd.Flags().String("y-axis-title", "", "Title for the left (Y) axis")
+	cmd.Flags().String("series-colors", "", "Comma-separated hex colors, one per series in order (e.g., #F00,#0F0)")
+	cmd.Flags().String("legend-position", "", "Legend position: BOTTOM, TOP, LEFT, RIGHT, NONE, or LABELED (pie only)")
+}
 
-	factory, err := client.NewFactory(ctx)
-	if err != nil {
-		return p.PrintError(err)
+// chartStyle holds the optional chart settings from addChartStyleFlags.
+// Axis titles are pointers so an explicit empty value clears the title.
+type chartStyle struct {
+	xAxisTitle     *string
+	yAxisTitle     *string
+	seriesColors   []*sheets.Color
+	legendPosition string
+}
+
+func (s chartStyle) isEmpty() bool {
+	return s.xAxisTitle == nil && s.yAxisTitle == nil && len(s.seriesColors) == 0 && s.legendPosition == ""
+}
+
+// chartStyleFromFlags reads and validates the flags registered by
+// addChartStyleFlags.
+func chartStyleFromFlags(cmd *cobra.Command) (chartStyle, error) {
+	var style chartStyle
+	if cmd.Flags().Changed("x-axis-title") {
+		v, _ := cmd.Flags().GetString("x-axis-title")
+		style.xAxisTitle = &v
+	}
+	if cmd.Flags().Changed("y-axis-title") {
+		v, _ := cmd.Flags().GetString("y-axis-title")
+		style.yAxisTitle = &v
+	}
+	if colors, _ := cmd.Flags().GetString("series-colors"); colors != "" {
+		parsed, err := parseSeriesColors(colors)
+		if err != nil {
+			return style, err
+		}
+		style.seriesColors = parsed
+	}
+	if legend, _ := cmd.Flags().GetString("legend-position"); legend != "" {
+		position, err := normalizeLegendPosition(legend)
+		if err != nil {
+			return style, err
+		}
+		style.legendPosition = position
 	}
+	return style, nil
+}
 
-	svc, err := factory.Sheets()
-	if err != nil {
-		return p.PrintError(err)
+// parseSeriesColors parses a comma-separated list of #RRGGBB or #RGB colors.
+func parseSeriesColors(list string) ([]*sheets.Color, error) {
+	var colors []*sheets.Color
+	for _, part := range strings.Split(list, ",") {
+		hex := strings.TrimSpace(part)
+		if len(hex) == 4 && hex[0] == '#' {
+			hex = "#" + strings.Repeat(hex[1:2], 2) + strings.Repeat(hex[2:3], 2) + strings.Repeat(hex[3:4], 2)
+		}
+		color, err := parseSheetsHexColor(hex)
+		if err != nil {
+			return nil, fmt.Errorf("--series-colors: %w", err)
+		}
+		colors = append(colors, color)
 	}
+	return colors, nil
+}
 
-	spreadsheetID := args[0]
+// normalizeLegendPosition maps "bottom" or "BOTTOM_LEGEND" style values to
+// the API's legend position enum.
+func normalizeLegendPosition(value string) (string, error) {
+	position := strings.ToUpper(strings.TrimSpace(value))
+	if !strings.HasSuffix(position, "_LEGEND") {
+		position += "_LEGEND"
+	}
+	switch position {
+	case "BOTTOM_LEGEND", "TOP_LEGEND", "LEFT_LEGEND", "RIGHT_LEGEND", "NO_LEGEND", "LABELED_LEGEND":
+		return position, nil
+	case "NONE_LEGEND":
+		return "NO_LEGEND", nil
+	}
+	return "", fmt.Errorf("unknown legend position: %s (valid: BOTTOM, TOP, LEFT, RIGHT, NONE, LABELED)", value)
+}
 
-	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.charts,sheets.properties").Do()
-	if err != nil {
-		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+// chartColumnData returns chart data for a single column of r.
+func chartColumnData(r *sheets.GridRange, column int64) *sheets.ChartData {
+	return &sheets.ChartData{SourceRange: &sheets.ChartSourceRange{Sources: []*sheets.GridRange{{
+		SheetId:          r.SheetId,
+		StartRowIndex:    r.StartRowIndex,
+		EndRowIndex:      r.EndRowIndex,
+		StartColumnIndex: column,
+		EndColumnIndex:   column + 1,
+	}}}}
+}
+
+// applyChartStyle patches spec in place with the settings in style. Axis
+// titles and series colors only exist on basic charts; pie charts accept a
+// legend position only.
+func applyChartStyle(spec *sheets.ChartSpec, style chartStyle) error {
+	if spec.PieChart != nil {
+		if style.xAxisTitle != nil || style.yAxisTitle != nil || len(style.seriesColors) > 0 {
+			return fmt.Errorf("axis titles and series colors are not supported on pie charts")
+		}
+		if style.legendPosition != "" {
+			spec.PieChart.LegendPosition = style.legendPosition
+		}
+		return nil
 	}
 
-	var charts []map[string]interface{}
-	for _, sheet := range spreadsheet.Sheets {
-		for _, chart := range sheet.Charts {
+	basic := spec.BasicChart
+	if basic == nil {
+		return fmt.Errorf("only basic (bar, line, area, column, scatter, combo) and pie charts can be styled")
+	}
+	if style.legendPosition == "LABELED_LEGEND" {
+		return fmt.Errorf("LABELED legend position is only valid for pie charts")
+	}
+	if len(style.seriesColors) > len(basic.Series) {
+		return fmt.Errorf("got %d series colors but the chart has %d series", len(style.seriesColors), len(basic.Series))
+	}
+
+	if style.xAxisTitle != nil {
+		setChartAxisTitle(basic, "BOTTOM_AXIS", *style.xAxisTitle)
+	}
+	if style.yAxisTitle != nil {
+		setChartAxisTitle(basic, "LEFT_AXIS", *style.yAxisTitle)
+	}
+	for i, color := range style.seriesColors {
+		basic.Series[i].ColorStyle = &sheets.ColorStyle{RgbColor: color}
+		basic.Series[i].Color = nil
+	}
+	if style.legendPosition != "" {
+		basic.LegendPosition = style.legendPosition
+	}
+	return nil
+}
+
+// setChartAxisTitle sets the title of the axis at position, adding the axis
+// if the chart doesn't define it yet.
+func setChartAxisTitle(basic *sheets.BasicChartSpec, position, title string) {
+	for _, axis := range basic.Axis {
+		if axis.Position == position {
+			axis.Title = title
+			if title == "" {
+				axis.ForceSendFields = append(axis.ForceSendFields, "Title")
+			}
+			return
+		}
+	}
+	basic.Axis = append(basic.Axis, &sheets.BasicChartAxis{Position: position, Title: title})
+}
+
+func runSheetsUpdateChart(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spreadsheetID := args[0]
+	chartID, _ := cmd.Flags().GetInt64("chart-id")
+
+	style, err := chartStyleFromFlags(cmd)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	titleChanged := cmd.Flags().Changed("title")
+	if !titleChanged && style.isEmpty() {
+		return usageErrorf("nothing to update: pass --title, --x-axis-title, --y-axis-title, --series-colors, or --legend-position")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.charts").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+
+	var spec *sheets.ChartSpec
+	for _, sheet := range spreadsheet.Sheets {
+		for _, chart := range sheet.Charts {
+			if chart.ChartId == chartID {
+				spec = chart.Spec
+			}
+		}
+	}
+	if spec == nil {
+		return p.PrintError(fmt.Errorf("chart %d not found in spreadsheet", chartID))
+	}
+
+	if titleChanged {
+		spec.Title, _ = cmd.Flags().GetString("title")
+		if spec.Title == "" {
+			spec.ForceSendFields = append(spec.ForceSendFields, "Title")
+		}
+	}
+	if err := applyChartStyle(spec, style); err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				UpdateChartSpec: &sheets.UpdateChartSpecRequest{
+					ChartId: chartID,
+					Spec:    spec,
+				},
+			},
+		},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to update chart: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":      "updated",
+		"spreadsheet": spreadsheetID,
+		"chart_id":    chartID,
+		"title":       spec.Title,
+	}
+	if spec.BasicChart != nil {
+		result["type"] = spec.BasicChart.ChartType
+		result["series"] = len(spec.BasicChart.Series)
+	} else if spec.PieChart != nil {
+		result["type"] = "PIE"
+	}
+	return p.Print(result)
+}
+
+func runSheetsListCharts(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheetID := args[0]
+
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.charts,sheets.properties").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+
+	var charts []map[string]interface{}
+	for _, sheet := range spreadsheet.Sheets {
+		for _, chart := range sheet.Charts {
 			chartInfo := map[string]interface{}{
 				"chart_id": chart.ChartId,
 				"sheet":    sheet.Properties.Title,
@@ -3074,3 +4561,6196 @@ func runSheetsDeleteConditionalFormat(cmd *cobra.Command, args []string) error {
 		"index":       index,
 	})
 }
+
+// columnIndexToLetter converts a 0-based column index to its letter form (0=A, 25=Z, 26=AA).
+func columnIndexToLetter(index int64) string {
+	letters := ""
+	for n := index + 1; n > 0; n = (n - 1) / 26 {
+		letters = string(rune('A'+(n-1)%26)) + letters
+	}
+	return letters
+}
+
+// quoteSheetName wraps a sheet name in single quotes when A1 notation
+// requires it (anything beyond letters, digits, and underscores).
+func quoteSheetName(name string) string {
+	for _, r := range name {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+		}
+	}
+	return name
+}
+
+// formatA1Range renders a grid range back to A1 notation. When absolute is
+// true, every row and column is anchored with $. sheetName may be empty.
+func formatA1Range(sheetName string, gr *sheets.GridRange, absolute bool) string {
+	anchor := ""
+	if absolute {
+		anchor = "$"
+	}
+	cell := func(col, row int64) string {
+		return fmt.Sprintf("%s%s%s%d", anchor, columnIndexToLetter(col), anchor, row+1)
+	}
+	ref := cell(gr.StartColumnIndex, gr.StartRowIndex) + ":" + cell(gr.EndColumnIndex-1, gr.EndRowIndex-1)
+	if sheetName == "" {
+		return ref
+	}
+	return quoteSheetName(sheetName) + "!" + ref
+}
+
+// buildRangeDropdownRule builds a ONE_OF_RANGE validation rule pointing at ref.
+func buildRangeDropdownRule(ref string, strict bool) *sheets.DataValidationRule {
+	return &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type:   "ONE_OF_RANGE",
+			Values: []*sheets.ConditionValue{{UserEnteredValue: "=" + ref}},
+		},
+		ShowCustomUi:    true,
+		Strict:          strict,
+		ForceSendFields: []string{"Strict"},
+	}
+}
+
+func runSheetsAddRangeDropdown(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	source, _ := cmd.Flags().GetString("source")
+	relative, _ := cmd.Flags().GetBool("relative")
+	allowInvalid, _ := cmd.Flags().GetBool("allow-invalid")
+
+	return runSheetsAddRangeDropdownWithService(svc, args[0], args[1], source, relative, allowInvalid, p)
+}
+
+func runSheetsAddRangeDropdownWithService(svc *sheets.Service, spreadsheetID, rangeStr, source string, relative, allowInvalid bool, p printer.Printer) error {
+	_, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	// Resolve the source through parseRange so a typo in the sheet name or
+	// cell range fails here rather than producing a dropdown with no options.
+	_, sourceRange, err := parseRange(svc, spreadsheetID, source)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("invalid --source: %w", err))
+	}
+	// formatA1Range quotes the name itself, so take it unquoted.
+	sourceSheet, _, _, err := splitA1Range(source)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("invalid --source: %w", err))
+	}
+	sourceRef := formatA1Range(sourceSheet, sourceRange, !relative)
+
+	requests := []*sheets.Request{
+		{
+			SetDataValidation: &sheets.SetDataValidationRequest{
+				Range: gridRange,
+				Rule:  buildRangeDropdownRule(sourceRef, !allowInvalid),
+			},
+		},
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to add range dropdown: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":         "added",
+		"spreadsheet":    spreadsheetID,
+		"range":          rangeStr,
+		"source":         sourceRef,
+		"condition_type": "ONE_OF_RANGE",
+		"strict":         !allowInvalid,
+	})
+}
+
+// validationOptions describes the rule built by add-validation.
+type validationOptions struct {
+	Type         string
+	Values       []string
+	Min, Max     *float64
+	Text         string
+	Formula      string
+	Strict       bool
+	ShowDropdown bool
+}
+
+// buildValidationRule turns add-validation flags into a DataValidationRule,
+// checking that each condition type gets exactly the inputs it uses.
+func buildValidationRule(opts validationOptions) (*sheets.DataValidationRule, error) {
+	var values []string
+	switch opts.Type {
+	case "ONE_OF_LIST":
+		for _, v := range opts.Values {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("--type ONE_OF_LIST requires --values")
+		}
+	case "NUMBER_BETWEEN":
+		if opts.Min == nil || opts.Max == nil {
+			return nil, fmt.Errorf("--type NUMBER_BETWEEN requires --min and --max")
+		}
+		if *opts.Min > *opts.Max {
+			return nil, fmt.Errorf("--min (%g) must not be greater than --max (%g)", *opts.Min, *opts.Max)
+		}
+		values = []string{strconv.FormatFloat(*opts.Min, 'f', -1, 64), strconv.FormatFloat(*opts.Max, 'f', -1, 64)}
+	case "TEXT_CONTAINS":
+		if opts.Text == "" {
+			return nil, fmt.Errorf("--type TEXT_CONTAINS requires --text")
+		}
+		values = []string{opts.Text}
+	case "CUSTOM_FORMULA":
+		formula := strings.TrimSpace(opts.Formula)
+		if formula == "" {
+			return nil, fmt.Errorf("--type CUSTOM_FORMULA requires --formula")
+		}
+		if !strings.HasPrefix(formula, "=") {
+			formula = "=" + formula
+		}
+		values = []string{formula}
+	default:
+		return nil, fmt.Errorf("invalid --type %q: must be ONE_OF_LIST, NUMBER_BETWEEN, TEXT_CONTAINS, or CUSTOM_FORMULA", opts.Type)
+	}
+
+	for _, input := range []struct {
+		flag string
+		set  bool
+		typ  string
+	}{
+		{"--values", len(opts.Values) > 0, "ONE_OF_LIST"},
+		{"--min", opts.Min != nil, "NUMBER_BETWEEN"},
+		{"--max", opts.Max != nil, "NUMBER_BETWEEN"},
+		{"--text", opts.Text != "", "TEXT_CONTAINS"},
+		{"--formula", opts.Formula != "", "CUSTOM_FORMULA"},
+		{"--show-dropdown", opts.ShowDropdown, "ONE_OF_LIST"},
+	} {
+		if input.set && input.typ != opts.Type {
+			return nil, fmt.Errorf("%s only applies to --type %s", input.flag, input.typ)
+		}
+	}
+
+	condition := &sheets.BooleanCondition{Type: opts.Type}
+	for _, v := range values {
+		condition.Values = append(condition.Values, &sheets.ConditionValue{UserEnteredValue: v})
+	}
+	return &sheets.DataValidationRule{
+		Condition:       condition,
+		ShowCustomUi:    opts.ShowDropdown,
+		Strict:          opts.Strict,
+		ForceSendFields: []string{"Strict", "ShowCustomUi"},
+	}, nil
+}
+
+func runSheetsAddValidation(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	var opts validationOptions
+	typ, _ := cmd.Flags().GetString("type")
+	opts.Type = strings.ToUpper(strings.TrimSpace(typ))
+	opts.Values, _ = cmd.Flags().GetStringSlice("values")
+	if cmd.Flags().Changed("min") {
+		v, _ := cmd.Flags().GetFloat64("min")
+		opts.Min = &v
+	}
+	if cmd.Flags().Changed("max") {
+		v, _ := cmd.Flags().GetFloat64("max")
+		opts.Max = &v
+	}
+	opts.Text, _ = cmd.Flags().GetString("text")
+	opts.Formula, _ = cmd.Flags().GetString("formula")
+	opts.Strict, _ = cmd.Flags().GetBool("strict")
+	opts.ShowDropdown, _ = cmd.Flags().GetBool("show-dropdown")
+
+	rule, err := buildValidationRule(opts)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsAddValidationWithService(svc, args[0], args[1], rule, p)
+}
+
+func runSheetsAddValidationWithService(svc *sheets.Service, spreadsheetID, rangeStr string, rule *sheets.DataValidationRule, p printer.Printer) error {
+	_, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{SetDataValidation: &sheets.SetDataValidationRequest{Range: gridRange, Rule: rule}},
+		},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to add validation: %w", err))
+	}
+
+	values := make([]string, 0, len(rule.Condition.Values))
+	for _, v := range rule.Condition.Values {
+		values = append(values, v.UserEnteredValue)
+	}
+	return p.Print(map[string]interface{}{
+		"status":         "added",
+		"spreadsheet":    spreadsheetID,
+		"range":          rangeStr,
+		"condition_type": rule.Condition.Type,
+		"values":         values,
+		"strict":         rule.Strict,
+		"show_dropdown":  rule.ShowCustomUi,
+	})
+}
+
+func runSheetsClearValidation(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsClearValidationWithService(svc, args[0], args[1], p)
+}
+
+// runSheetsClearValidationWithService sends SetDataValidation without a
+// rule, which removes validation from every cell in the range.
+func runSheetsClearValidationWithService(svc *sheets.Service, spreadsheetID, rangeStr string, p printer.Printer) error {
+	_, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{SetDataValidation: &sheets.SetDataValidationRequest{Range: gridRange}},
+		},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to clear validation: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "cleared",
+		"spreadsheet": spreadsheetID,
+		"range":       rangeStr,
+	})
+}
+
+// formulaString quotes s as a Sheets formula string literal.
+func formulaString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// buildImportRangeFormula returns an IMPORTRANGE formula for the source
+// range, wrapped in QUERY when query is non-empty.
+func buildImportRangeFormula(srcID, srcRange, query string) string {
+	importRange := fmt.Sprintf("IMPORTRANGE(%s,%s)", formulaString(srcID), formulaString(srcRange))
+	if query == "" {
+		return "=" + importRange
+	}
+	return fmt.Sprintf("=QUERY(%s,%s)", importRange, formulaString(query))
+}
+
+func runSheetsLinkRange(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	spreadsheetID := args[0]
+	dstCell, _ := cmd.Flags().GetString("dst-cell")
+	srcID, _ := cmd.Flags().GetString("src-id")
+	srcRange, _ := cmd.Flags().GetString("src-range")
+	query, _ := cmd.Flags().GetString("query")
+
+	dstCell = strings.TrimSpace(dstCell)
+	cellRef := dstCell
+	if idx := strings.LastIndex(dstCell, "!"); idx != -1 {
+		cellRef = dstCell[idx+1:]
+	}
+	if strings.Contains(cellRef, ":") {
+		return usageErrorf("--dst-cell must be a single cell, got %q", dstCell)
+	}
+	if _, _, err := parseCellRef(cellRef); err != nil {
+		return usageErrorf("invalid --dst-cell %q: %v", dstCell, err)
+	}
+	srcID = strings.TrimSpace(srcID)
+	srcRange = strings.TrimSpace(srcRange)
+	if srcID == "" || srcRange == "" {
+		return usageErrorf("--src-id and --src-range must not be empty")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	formula := buildImportRangeFormula(srcID, srcRange, query)
+	valueRange := &sheets.ValueRange{
+		Values: [][]interface{}{{formula}},
+	}
+
+	resp, err := svc.Spreadsheets.Values.Update(spreadsheetID, dstCell, valueRange).
+		ValueInputOption("USER_ENTERED").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to write link formula: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "linked",
+		"spreadsheet": resp.SpreadsheetId,
+		"range":       resp.UpdatedRange,
+		"formula":     formula,
+		"source_id":   srcID,
+		"note":        "On first load, open the destination cell and click \"Allow access\" to authorize the import.",
+	})
+}
+
+// a1Corner is one corner of an A1 range. Col is empty for row-only references
+// (e.g. "5:10") and Row is 0 for column-only references (e.g. "A:D").
+type a1Corner struct {
+	Col string
+	Row int64
+}
+
+// splitA1Range splits an A1 range into its (unquoted) sheet name and corners.
+// A bare sheet name yields zero corners; a single cell yields equal corners.
+// Without a "!", the string is a cell range if it parses as one with column
+// letters of at most three characters (like Sheets itself), else a sheet name.
+func splitA1Range(rangeStr string) (string, a1Corner, a1Corner, error) {
+	idx := strings.LastIndex(rangeStr, "!")
+	if idx == -1 {
+		if _, start, end, err := splitA1Range("!" + rangeStr); err == nil && len(start.Col) <= 3 && len(end.Col) <= 3 {
+			return "", start, end, nil
+		}
+		idx = len(rangeStr)
+		rangeStr += "!"
+	}
+	sheet, cells := rangeStr[:idx], rangeStr[idx+1:]
+	if len(sheet) >= 2 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	if cells == "" {
+		return sheet, a1Corner{}, a1Corner{}, nil
+	}
+
+	parts := strings.Split(cells, ":")
+	if len(parts) > 2 {
+		return "", a1Corner{}, a1Corner{}, fmt.Errorf("invalid range: %s", rangeStr)
+	}
+	corners := make([]a1Corner, len(parts))
+	for i, part := range parts {
+		c, err := parseA1Corner(part)
+		if err != nil {
+			return "", a1Corner{}, a1Corner{}, fmt.Errorf("invalid range %s: %w", rangeStr, err)
+		}
+		corners[i] = c
+	}
+	if len(corners) == 1 {
+		return sheet, corners[0], corners[0], nil
+	}
+	return sheet, corners[0], corners[1], nil
+}
+
+// parseA1Corner parses "B12", "B", or "12" into an a1Corner.
+func parseA1Corner(ref string) (a1Corner, error) {
+	ref = strings.ToUpper(strings.TrimSpace(strings.ReplaceAll(ref, "$", "")))
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		i++
+	}
+	c := a1Corner{Col: ref[:i]}
+	if i < len(ref) {
+		var row int64
+		if _, err := fmt.Sscanf(ref[i:], "%d", &row); err != nil || row < 1 || fmt.Sprint(row) != ref[i:] {
+			return a1Corner{}, fmt.Errorf("invalid cell reference: %s", ref)
+		}
+		c.Row = row
+	}
+	if c.Col == "" && c.Row == 0 {
+		return a1Corner{}, fmt.Errorf("invalid cell reference: %q", ref)
+	}
+	return c, nil
+}
+
+// pagedReadOptions configures runSheetsReadPaged.
+type pagedReadOptions struct {
+	PageRows int64
+	StartRow int64
+	Stream   bool
+	CSV      bool
+	Headers  bool
+	Render   renderOptions
+}
+
+// sheetPage is one row window fetched by readSheetPages. Headers repeats
+// the header row (if requested) so each window can be rendered on its own.
+type sheetPage struct {
+	Range    string
+	StartRow int64
+	EndRow   int64
+	Headers  []string
+	Values   [][]interface{}
+}
+
+// readSheetPages fetches rangeStr in windows of opts.PageRows rows, calling
+// emit for each window. The last row is the range's end row, capped at the
+// sheet's grid row count from Spreadsheets.Get. When opts.Headers is set,
+// the range's first row is fetched once and returned as headers, and paging
+// starts below it.
+func readSheetPages(svc *sheets.Service, spreadsheetID, rangeStr string, opts pagedReadOptions, emit func(sheetPage) error) ([]string, error) {
+	sheetName, start, end, err := splitA1Range(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties").Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spreadsheet: %w", err)
+	}
+	var props *sheets.SheetProperties
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+		if sheetName == "" || sheet.Properties.Title == sheetName {
+			props = sheet.Properties
+			break
+		}
+	}
+	if props == nil {
+		return nil, fmt.Errorf("sheet '%s' not found", sheetName)
+	}
+	var rowCount int64
+	if props.GridProperties != nil {
+		rowCount = props.GridProperties.RowCount
+	}
+
+	firstRow := start.Row
+	if firstRow == 0 {
+		firstRow = 1
+	}
+	lastRow := end.Row
+	if lastRow == 0 || lastRow > rowCount {
+		lastRow = rowCount
+	}
+
+	prefix := quoteSheetName(props.Title) + "!"
+	window := func(from, to int64) string {
+		return fmt.Sprintf("%s%s%d:%s%d", prefix, start.Col, from, end.Col, to)
+	}
+
+	var headers []string
+	if opts.Headers && firstRow <= lastRow {
+		resp, err := opts.Render.apply(svc.Spreadsheets.Values.Get(spreadsheetID, window(firstRow, firstRow))).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header row: %w", err)
+		}
+		if len(resp.Values) > 0 {
+			for _, cell := range resp.Values[0] {
+				headers = append(headers, fmt.Sprintf("%v", cell))
+			}
+		}
+		firstRow++
+	}
+	if opts.StartRow > firstRow {
+		firstRow = opts.StartRow
+	}
+
+	for from := firstRow; from <= lastRow; from += opts.PageRows {
+		to := from + opts.PageRows - 1
+		if to > lastRow {
+			to = lastRow
+		}
+		resp, err := opts.Render.apply(svc.Spreadsheets.Values.Get(spreadsheetID, window(from, to))).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rows %d-%d: %w", from, to, err)
+		}
+		// The API drops trailing empty rows; pad every page but the last so
+		// row positions stay aligned across pages.
+		if to < lastRow {
+			for int64(len(resp.Values)) < to-from+1 {
+				resp.Values = append(resp.Values, []interface{}{})
+			}
+		}
+		if err := emit(sheetPage{Range: resp.Range, StartRow: from, EndRow: to, Headers: headers, Values: resp.Values}); err != nil {
+			return nil, err
+		}
+	}
+	return headers, nil
+}
+
+// rowsToHeaderMaps keys each row by the given headers, dropping extra cells.
+func rowsToHeaderMaps(headers []string, rows [][]interface{}) []map[string]interface{} {
+	data := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		rowMap := make(map[string]interface{})
+		for i, cell := range row {
+			if i < len(headers) {
+				rowMap[headers[i]] = cell
+			}
+		}
+		data = append(data, rowMap)
+	}
+	return data
+}
+
+// rowsToCSV renders rows as CSV text.
+func rowsToCSV(rows [][]interface{}) string {
+	var builder strings.Builder
+	_ = writeCSVRows(&builder, rows, ',')
+	return builder.String()
+}
+
+// writeCSVRows writes rows to w as CSV with the given field delimiter.
+func writeCSVRows(w io.Writer, rows [][]interface{}, delimiter rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = fmt.Sprintf("%v", cell)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func runSheetsReadPaged(p printer.Printer, svc *sheets.Service, spreadsheetID, rangeStr string, opts pagedReadOptions) error {
+	var all [][]interface{}
+	pages := 0
+	var firstRow, lastRow int64
+
+	emit := func(page sheetPage) error {
+		pages++
+		if pages == 1 {
+			firstRow = page.StartRow
+		}
+		lastRow = page.EndRow
+		if !opts.Stream {
+			all = append(all, page.Values...)
+			return nil
+		}
+		out := map[string]interface{}{
+			"range":     page.Range,
+			"page":      pages,
+			"start_row": page.StartRow,
+			"end_row":   page.EndRow,
+			"rows":      len(page.Values),
+		}
+		switch {
+		case opts.CSV:
+			out["csv"] = rowsToCSV(page.Values)
+		case opts.Headers:
+			out["headers"] = page.Headers
+			out["data"] = rowsToHeaderMaps(page.Headers, page.Values)
+		default:
+			out["data"] = page.Values
+		}
+		return p.Print(out)
+	}
+
+	headers, err := readSheetPages(svc, spreadsheetID, rangeStr, opts, emit)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if opts.Stream {
+		return nil
+	}
+
+	out := map[string]interface{}{
+		"range":     rangeStr,
+		"pages":     pages,
+		"start_row": firstRow,
+		"end_row":   lastRow,
+	}
+	switch {
+	case opts.CSV:
+		out["csv"] = rowsToCSV(all)
+		out["rows"] = len(all)
+	case opts.Headers:
+		data := rowsToHeaderMaps(headers, all)
+		out["headers"] = headers
+		out["data"] = data
+		out["rows"] = len(data)
+	default:
+		if all == nil {
+			all = [][]interface{}{}
+		}
+		out["data"] = all
+		out["rows"] = len(all)
+	}
+	return p.Print(out)
+}
+
+// tableStyleOptions configures buildTableStyleRequests.
+type tableStyleOptions struct {
+	HeaderBold  bool
+	HeaderBg    string
+	HeaderColor string
+	Banded      bool
+	BandColor   string
+	Freeze      bool
+}
+
+// buildTableStyleRequests returns the header format, banding, and freeze
+// requests that give gridRange a table look. The first row of gridRange is
+// treated as the header.
+func buildTableStyleRequests(sheetID int64, gridRange *sheets.GridRange, opts tableStyleOptions) ([]*sheets.Request, error) {
+	if gridRange.EndRowIndex-gridRange.StartRowIndex < 1 {
+		return nil, fmt.Errorf("range must include at least a header row")
+	}
+	headerRange := &sheets.GridRange{
+		SheetId:          sheetID,
+		StartRowIndex:    gridRange.StartRowIndex,
+		EndRowIndex:      gridRange.StartRowIndex + 1,
+		StartColumnIndex: gridRange.StartColumnIndex,
+		EndColumnIndex:   gridRange.EndColumnIndex,
+	}
+
+	var requests []*sheets.Request
+
+	cellFormat := &sheets.CellFormat{}
+	var fields []string
+	var headerBg *sheets.Color
+	if opts.HeaderBold || opts.HeaderColor != "" {
+		textFormat := &sheets.TextFormat{}
+		if opts.HeaderBold {
+			textFormat.Bold = true
+			fields = append(fields, "userEnteredFormat.textFormat.bold")
+		}
+		if opts.HeaderColor != "" {
+			color, err := parseSheetsHexColor(opts.HeaderColor)
+			if err != nil {
+				return nil, err
+			}
+			textFormat.ForegroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+			fields = append(fields, "userEnteredFormat.textFormat.foregroundColorStyle")
+		}
+		cellFormat.TextFormat = textFormat
+	}
+	if opts.HeaderBg != "" {
+		color, err := parseSheetsHexColor(opts.HeaderBg)
+		if err != nil {
+			return nil, err
+		}
+		headerBg = color
+		cellFormat.BackgroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+		fields = append(fields, "userEnteredFormat.backgroundColorStyle")
+	}
+	if len(fields) > 0 {
+		requests = append(requests, &sheets.Request{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Range:  headerRange,
+				Cell:   &sheets.CellData{UserEnteredFormat: cellFormat},
+				Fields: strings.Join(fields, ","),
+			},
+		})
+	}
+
+	if opts.Banded {
+		bandColor, err := parseSheetsHexColor(opts.BandColor)
+		if err != nil {
+			return nil, err
+		}
+		rowProps := &sheets.BandingProperties{
+			FirstBandColorStyle:  &sheets.ColorStyle{RgbColor: &sheets.Color{Red: 1, Green: 1, Blue: 1}},
+			SecondBandColorStyle: &sheets.ColorStyle{RgbColor: bandColor},
+		}
+		// Banding paints its own header color over the cell background, so
+		// carry the header background into the band when one is set.
+		if headerBg != nil {
+			rowProps.HeaderColorStyle = &sheets.ColorStyle{RgbColor: headerBg}
+		}
+		requests = append(requests, &sheets.Request{
+			AddBanding: &sheets.AddBandingRequest{
+				BandedRange: &sheets.BandedRange{
+					Range:         gridRange,
+					RowProperties: rowProps,
+				},
+			},
+		})
+	}
+
+	if opts.Freeze {
+		requests = append(requests, &sheets.Request{
+			UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+				Properties: &sheets.SheetProperties{
+					SheetId: sheetID,
+					GridProperties: &sheets.GridProperties{
+						FrozenRowCount: headerRange.EndRowIndex,
+					},
+				},
+				Fields: "gridProperties.frozenRowCount",
+			},
+		})
+	}
+
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("nothing to apply")
+	}
+	return requests, nil
+}
+
+func runSheetsFormatAsTable(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	headerBold, _ := cmd.Flags().GetBool("header-bold")
+	headerBg, _ := cmd.Flags().GetString("header-bg")
+	headerColor, _ := cmd.Flags().GetString("header-color")
+	banded, _ := cmd.Flags().GetBool("banded")
+	bandColor, _ := cmd.Flags().GetString("band-color")
+	noFreeze, _ := cmd.Flags().GetBool("no-freeze")
+
+	for flag, value := range map[string]string{"header-bg": headerBg, "header-color": headerColor, "band-color": bandColor} {
+		if value == "" {
+			continue
+		}
+		if _, err := parseSheetsHexColor(value); err != nil {
+			return usageErrorf("invalid --%s: %v", flag, err)
+		}
+	}
+	if !headerBold && headerBg == "" && headerColor == "" && !banded && noFreeze {
+		return usageErrorf("nothing to apply; use --header-bold, --header-bg, --header-color, or --banded")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheetID := args[0]
+	rangeStr := args[1]
+
+	sheetID, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	requests, err := buildTableStyleRequests(sheetID, gridRange, tableStyleOptions{
+		HeaderBold:  headerBold,
+		HeaderBg:    headerBg,
+		HeaderColor: headerColor,
+		Banded:      banded,
+		BandColor:   bandColor,
+		Freeze:      !noFreeze,
+	})
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to format as table: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":      "formatted",
+		"spreadsheet": spreadsheetID,
+		"range":       rangeStr,
+		"banded":      banded,
+	}
+	if !noFreeze {
+		result["frozen_rows"] = gridRange.StartRowIndex + 1
+	}
+	return p.Print(result)
+}
+
+// extractFormulas returns {cell, formula} for every string value starting
+// with "=" in a FORMULA-rendered value range. rangeStr is the range the API
+// reported for values, used to locate the top-left cell.
+func extractFormulas(rangeStr string, values [][]interface{}, contains string) ([]map[string]interface{}, error) {
+	_, start, _, err := splitA1Range(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	var startCol, startRow int64
+	if start.Col != "" {
+		startCol = columnLetterToIndex(start.Col)
+	}
+	if start.Row > 0 {
+		startRow = start.Row - 1
+	}
+
+	needle := strings.ToLower(contains)
+	formulas := []map[string]interface{}{}
+	for r, row := range values {
+		for c, v := range row {
+			s, ok := v.(string)
+			if !ok || !strings.HasPrefix(s, "=") {
+				continue
+			}
+			if needle != "" && !strings.Contains(strings.ToLower(s), needle) {
+				continue
+			}
+			formulas = append(formulas, map[string]interface{}{
+				"cell":    fmt.Sprintf("%s%d", columnIndexToLetter(startCol+int64(c)), startRow+int64(r)+1),
+				"formula": s,
+			})
+		}
+	}
+	return formulas, nil
+}
+
+func runSheetsFormulas(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheetID := args[0]
+	contains, _ := cmd.Flags().GetString("contains")
+
+	var sheetNames []string
+	if len(args) > 1 {
+		sheetNames = []string{args[1]}
+	} else {
+		spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties.title").Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+		}
+		for _, sheet := range spreadsheet.Sheets {
+			sheetNames = append(sheetNames, sheet.Properties.Title)
+		}
+	}
+
+	ranges := make([]string, len(sheetNames))
+	for i, name := range sheetNames {
+		ranges[i] = quoteSheetName(name)
+	}
+
+	resp, err := svc.Spreadsheets.Values.BatchGet(spreadsheetID).
+		Ranges(ranges...).
+		ValueRenderOption("FORMULA").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read formulas: %w", err))
+	}
+
+	sheetResults := make([]map[string]interface{}, 0, len(resp.ValueRanges))
+	total := 0
+	for i, vr := range resp.ValueRanges {
+		formulas, err := extractFormulas(vr.Range, vr.Values, contains)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		if len(formulas) == 0 && len(args) < 2 {
+			continue
+		}
+		name := vr.Range
+		if i < len(sheetNames) {
+			name = sheetNames[i]
+		}
+		sheetResults = append(sheetResults, map[string]interface{}{
+			"sheet":    name,
+			"formulas": formulas,
+			"count":    len(formulas),
+		})
+		total += len(formulas)
+	}
+
+	result := map[string]interface{}{
+		"spreadsheet": spreadsheetID,
+		"sheets":      sheetResults,
+		"count":       total,
+	}
+	if contains != "" {
+		result["contains"] = contains
+	}
+	return p.Print(result)
+}
+
+// buildLockHeaderRequests freezes the first rows of a sheet and protects
+// them with a warning-only protected range.
+func buildLockHeaderRequests(sheetID, rows int64, description string) []*sheets.Request {
+	return []*sheets.Request{
+		{
+			UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+				Properties: &sheets.SheetProperties{
+					SheetId:        sheetID,
+					GridProperties: &sheets.GridProperties{FrozenRowCount: rows},
+				},
+				Fields: "gridProperties.frozenRowCount",
+			},
+		},
+		{
+			AddProtectedRange: &sheets.AddProtectedRangeRequest{
+				ProtectedRange: &sheets.ProtectedRange{
+					Range: &sheets.GridRange{
+						SheetId:     sheetID,
+						EndRowIndex: rows,
+					},
+					Description: description,
+					WarningOnly: true,
+				},
+			},
+		},
+	}
+}
+
+func runSheetsLockHeader(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spreadsheetID := args[0]
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	rows, _ := cmd.Flags().GetInt64("rows")
+	description, _ := cmd.Flags().GetString("description")
+
+	if rows < 1 {
+		return usageErrorf("--rows must be at least 1, got %d", rows)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	sheetID, err := getSheetID(svc, spreadsheetID, sheetName)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	resp, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: buildLockHeaderRequests(sheetID, rows, description),
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to lock header: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":      "locked",
+		"spreadsheet": spreadsheetID,
+		"sheet":       sheetName,
+		"rows":        rows,
+	}
+	if len(resp.Replies) > 1 && resp.Replies[1].AddProtectedRange != nil && resp.Replies[1].AddProtectedRange.ProtectedRange != nil {
+		result["protected_range_id"] = resp.Replies[1].AddProtectedRange.ProtectedRange.ProtectedRangeId
+	}
+	return p.Print(result)
+}
+
+// parseEditorEmails splits a comma-separated list of editor emails,
+// skipping blanks and duplicates.
+func parseEditorEmails(list string) ([]string, error) {
+	var emails []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(list, ",") {
+		email := strings.TrimSpace(part)
+		if email == "" || seen[strings.ToLower(email)] {
+			continue
+		}
+		if !strings.Contains(email, "@") {
+			return nil, fmt.Errorf("invalid editor email: %q", email)
+		}
+		seen[strings.ToLower(email)] = true
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+// findNamedRange returns the named range whose ID or name matches ref.
+// Names are matched case-insensitively, as Sheets treats them.
+func findNamedRange(namedRanges []*sheets.NamedRange, ref string) (*sheets.NamedRange, error) {
+	names := make([]string, 0, len(namedRanges))
+	for _, nr := range namedRanges {
+		if nr.NamedRangeId == ref || strings.EqualFold(nr.Name, ref) {
+			return nr, nil
+		}
+		names = append(names, nr.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("named range %q not found: the spreadsheet has no named ranges", ref)
+	}
+	return nil, fmt.Errorf("named range %q not found (available: %s)", ref, strings.Join(names, ", "))
+}
+
+func runSheetsProtectNamed(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	namedRange, _ := cmd.Flags().GetString("named-range")
+	editorList, _ := cmd.Flags().GetString("editors")
+	warningOnly, _ := cmd.Flags().GetBool("warning-only")
+	description, _ := cmd.Flags().GetString("description")
+
+	editors, err := parseEditorEmails(editorList)
+	if err != nil {
+		return usageErrorf("--editors: %v", err)
+	}
+	if warningOnly && len(editors) > 0 {
+		return usageErrorf("--editors cannot be combined with --warning-only")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsProtectNamedWithService(svc, args[0], namedRange, editors, warningOnly, description, p)
+}
+
+func runSheetsProtectNamedWithService(svc *sheets.Service, spreadsheetID, namedRange string, editors []string, warningOnly bool, description string, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("namedRanges,sheets.properties(sheetId,title)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get named ranges: %w", err))
+	}
+	nr, err := findNamedRange(spreadsheet.NamedRanges, namedRange)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if description == "" {
+		description = nr.Name
+	}
+
+	protected := &sheets.ProtectedRange{
+		NamedRangeId: nr.NamedRangeId,
+		Description:  description,
+		WarningOnly:  warningOnly,
+	}
+	if !warningOnly {
+		protected.Editors = &sheets.Editors{Users: editors}
+	}
+
+	resp, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			AddProtectedRange: &sheets.AddProtectedRangeRequest{ProtectedRange: protected},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to protect named range: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":         "protected",
+		"spreadsheet":    spreadsheetID,
+		"named_range":    nr.Name,
+		"named_range_id": nr.NamedRangeId,
+		"description":    description,
+		"warning_only":   warningOnly,
+	}
+	if !warningOnly {
+		if editors == nil {
+			editors = []string{}
+		}
+		result["editors"] = editors
+	}
+	if nr.Range != nil {
+		sheetName := ""
+		for _, s := range spreadsheet.Sheets {
+			if s.Properties != nil && s.Properties.SheetId == nr.Range.SheetId {
+				sheetName = s.Properties.Title
+			}
+		}
+		result["sheet"] = sheetName
+		// Unbounded ranges (whole rows or columns) have no single A1 form here
+		if nr.Range.EndRowIndex > 0 && nr.Range.EndColumnIndex > 0 {
+			result["range"] = formatA1Range(sheetName, nr.Range, false)
+		}
+	}
+	if len(resp.Replies) > 0 && resp.Replies[0].AddProtectedRange != nil && resp.Replies[0].AddProtectedRange.ProtectedRange != nil {
+		result["protected_range_id"] = resp.Replies[0].AddProtectedRange.ProtectedRange.ProtectedRangeId
+	}
+	return p.Print(result)
+}
+
+func runSheetsProtect(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	editorList, _ := cmd.Flags().GetString("editors")
+	warningOnly, _ := cmd.Flags().GetBool("warning-only")
+	description, _ := cmd.Flags().GetString("description")
+
+	rangeStr := ""
+	if len(args) > 1 {
+		rangeStr = args[1]
+	}
+	if (rangeStr == "") == (sheetName == "") {
+		return usageErrorf("specify either a range or --sheet")
+	}
+	editors, err := parseEditorEmails(editorList)
+	if err != nil {
+		return usageErrorf("--editors: %v", err)
+	}
+	if warningOnly && len(editors) > 0 {
+		return usageErrorf("--editors cannot be combined with --warning-only")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsProtectWithService(svc, args[0], rangeStr, sheetName, editors, warningOnly, description, p)
+}
+
+// runSheetsProtectWithService protects rangeStr, or the whole of sheetName
+// when rangeStr is empty.
+func runSheetsProtectWithService(svc *sheets.Service, spreadsheetID, rangeStr, sheetName string, editors []string, warningOnly bool, description string, p printer.Printer) error {
+	var gridRange *sheets.GridRange
+	if rangeStr != "" {
+		_, gr, err := parseRange(svc, spreadsheetID, rangeStr)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		gridRange = gr
+	} else {
+		sheetID, err := getSheetID(svc, spreadsheetID, sheetName)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		// A range with only a sheet ID covers the whole sheet; force the ID
+		// so sheet 0 is not sent as an empty range.
+		gridRange = &sheets.GridRange{SheetId: sheetID, ForceSendFields: []string{"SheetId"}}
+	}
+
+	protected := &sheets.ProtectedRange{
+		Range:       gridRange,
+		Description: description,
+		WarningOnly: warningOnly,
+	}
+	if !warningOnly {
+		protected.Editors = &sheets.Editors{Users: editors}
+	}
+
+	resp, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			AddProtectedRange: &sheets.AddProtectedRangeRequest{ProtectedRange: protected},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to protect range: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":       "protected",
+		"spreadsheet":  spreadsheetID,
+		"warning_only": warningOnly,
+	}
+	if rangeStr != "" {
+		result["range"] = rangeStr
+	} else {
+		result["sheet"] = sheetName
+	}
+	if description != "" {
+		result["description"] = description
+	}
+	if !warningOnly {
+		if editors == nil {
+			editors = []string{}
+		}
+		result["editors"] = editors
+	}
+	if len(resp.Replies) > 0 && resp.Replies[0].AddProtectedRange != nil && resp.Replies[0].AddProtectedRange.ProtectedRange != nil {
+		result["protected_range_id"] = resp.Replies[0].AddProtectedRange.ProtectedRange.ProtectedRangeId
+	}
+	return p.Print(result)
+}
+
+func runSheetsListProtections(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	return runSheetsListProtectionsWithService(svc, args[0], sheetName, p)
+}
+
+func runSheetsListProtectionsWithService(svc *sheets.Service, spreadsheetID, sheetName string, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties(sheetId,title),sheets.protectedRanges").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get protected ranges: %w", err))
+	}
+
+	protections := []map[string]interface{}{}
+	found := sheetName == ""
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties == nil || (sheetName != "" && sheet.Properties.Title != sheetName) {
+			continue
+		}
+		found = true
+		for _, pr := range sheet.ProtectedRanges {
+			protections = append(protections, protectionInfo(sheet.Properties.Title, pr))
+		}
+	}
+	if !found {
+		return p.PrintError(fmt.Errorf("sheet '%s' not found", sheetName))
+	}
+
+	result := map[string]interface{}{
+		"spreadsheet": spreadsheetID,
+		"protections": protections,
+		"count":       len(protections),
+	}
+	if sheetName != "" {
+		result["sheet"] = sheetName
+	}
+	return p.Print(result)
+}
+
+// protectionInfo summarizes a protected range for list-protections. A range
+// with no bounds at all protects the whole sheet.
+func protectionInfo(sheetName string, pr *sheets.ProtectedRange) map[string]interface{} {
+	info := map[string]interface{}{
+		"protected_range_id": pr.ProtectedRangeId,
+		"sheet":              sheetName,
+		"description":        pr.Description,
+		"warning_only":       pr.WarningOnly,
+	}
+	if pr.NamedRangeId != "" {
+		info["named_range_id"] = pr.NamedRangeId
+	}
+	if r := pr.Range; r != nil {
+		info["whole_sheet"] = r.StartRowIndex == 0 && r.EndRowIndex == 0 && r.StartColumnIndex == 0 && r.EndColumnIndex == 0
+		info["grid_range"] = map[string]interface{}{
+			"start_row":    r.StartRowIndex,
+			"end_row":      r.EndRowIndex,
+			"start_column": r.StartColumnIndex,
+			"end_column":   r.EndColumnIndex,
+		}
+		// Unbounded ranges (whole rows or columns) have no single A1 form here
+		if r.EndRowIndex > 0 && r.EndColumnIndex > 0 {
+			info["range"] = formatA1Range(sheetName, r, false)
+		}
+	}
+	if pr.Editors != nil {
+		users := pr.Editors.Users
+		if users == nil {
+			users = []string{}
+		}
+		info["editors"] = users
+	}
+	if len(pr.UnprotectedRanges) > 0 {
+		info["unprotected_ranges"] = len(pr.UnprotectedRanges)
+	}
+	return info
+}
+
+func runSheetsUnprotect(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	protectionID, _ := cmd.Flags().GetInt64("protection-id")
+	if protectionID <= 0 {
+		return usageErrorf("--protection-id must be a positive ID, got %d", protectionID)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheetID := args[0]
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{ProtectedRangeId: protectionID},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to remove protection: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":        "unprotected",
+		"spreadsheet":   spreadsheetID,
+		"protection_id": protectionID,
+	})
+}
+
+// a1ToIndex converts a cell reference or bare column (absolute markers
+// allowed) to 0-based indices. Bare columns omit the row fields.
+func a1ToIndex(ref string) (map[string]interface{}, error) {
+	c, err := parseA1Corner(ref)
+	if err != nil {
+		return nil, err
+	}
+	if c.Col == "" {
+		return nil, fmt.Errorf("invalid cell reference: %s (missing column)", ref)
+	}
+	result := map[string]interface{}{
+		"column":       c.Col,
+		"column_index": columnLetterToIndex(c.Col),
+	}
+	if c.Row > 0 {
+		result["ref"] = fmt.Sprintf("%s%d", c.Col, c.Row)
+		result["row"] = c.Row
+		result["row_index"] = c.Row - 1
+	}
+	return result, nil
+}
+
+// indexToA1 converts "col,row" 0-based indices (or a single column index)
+// to A1 notation.
+func indexToA1(indices string) (map[string]interface{}, error) {
+	parts := strings.Split(indices, ",")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("invalid indices %q: expected \"col,row\" or \"col\"", indices)
+	}
+	values := make([]int64, len(parts))
+	for i, part := range parts {
+		n, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid index %q: must be a non-negative integer", strings.TrimSpace(part))
+		}
+		values[i] = n
+	}
+	column := columnIndexToLetter(values[0])
+	result := map[string]interface{}{
+		"column":       column,
+		"column_index": values[0],
+	}
+	if len(values) == 2 {
+		result["ref"] = fmt.Sprintf("%s%d", column, values[1]+1)
+		result["row"] = values[1] + 1
+		result["row_index"] = values[1]
+	}
+	return result, nil
+}
+
+func runSheetsA1(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	toIndex, _ := cmd.Flags().GetString("to-index")
+	toA1, _ := cmd.Flags().GetString("to-a1")
+
+	if (toIndex == "") == (toA1 == "") {
+		return usageErrorf("specify exactly one of --to-index or --to-a1")
+	}
+
+	var result map[string]interface{}
+	var err error
+	if toIndex != "" {
+		result, err = a1ToIndex(toIndex)
+	} else {
+		result, err = indexToA1(toA1)
+	}
+	if err != nil {
+		return usageErrorf("%s", err)
+	}
+	return p.Print(result)
+}
+
+// buildFreezeValuesRequest pastes a range onto itself as values only,
+// replacing formulas with their computed results.
+func buildFreezeValuesRequest(gridRange *sheets.GridRange) *sheets.Request {
+	return &sheets.Request{
+		CopyPaste: &sheets.CopyPasteRequest{
+			Source:           gridRange,
+			Destination:      gridRange,
+			PasteType:        "PASTE_VALUES",
+			PasteOrientation: "NORMAL",
+		},
+	}
+}
+
+func runSheetsFreezeValues(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheetID := args[0]
+	rangeStr := args[1]
+
+	_, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{buildFreezeValuesRequest(gridRange)},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to freeze values: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "frozen",
+		"spreadsheet": spreadsheetID,
+		"range":       rangeStr,
+	})
+}
+
+// cellStyleCSS converts a cell's effective format to inline CSS. Sheets
+// defaults (black text, white background, no emphasis) are left out.
+func cellStyleCSS(f *sheets.CellFormat) string {
+	if f == nil {
+		return ""
+	}
+	var css []string
+	if tf := f.TextFormat; tf != nil {
+		if tf.Bold {
+			css = append(css, "font-weight:bold")
+		}
+		if tf.Italic {
+			css = append(css, "font-style:italic")
+		}
+		var decorations []string
+		if tf.Underline {
+			decorations = append(decorations, "underline")
+		}
+		if tf.Strikethrough {
+			decorations = append(decorations, "line-through")
+		}
+		if len(decorations) > 0 {
+			css = append(css, "text-decoration:"+strings.Join(decorations, " "))
+		}
+		fg := tf.ForegroundColor
+		if tf.ForegroundColorStyle != nil && tf.ForegroundColorStyle.RgbColor != nil {
+			fg = tf.ForegroundColorStyle.RgbColor
+		}
+		if hex := sheetsColorToHex(fg); hex != "" && hex != "#000000" {
+			css = append(css, "color:"+hex)
+		}
+	}
+	bg := f.BackgroundColor
+	if f.BackgroundColorStyle != nil && f.BackgroundColorStyle.RgbColor != nil {
+		bg = f.BackgroundColorStyle.RgbColor
+	}
+	if hex := sheetsColorToHex(bg); hex != "" && hex != "#FFFFFF" {
+		css = append(css, "background-color:"+hex)
+	}
+	switch f.HorizontalAlignment {
+	case "LEFT", "CENTER", "RIGHT":
+		css = append(css, "text-align:"+strings.ToLower(f.HorizontalAlignment))
+	}
+	return strings.Join(css, ";")
+}
+
+// renderHTMLTable renders grid rows as an HTML table, padding short rows to
+// the widest one. It returns the HTML and the column count.
+func renderHTMLTable(rows []*sheets.RowData, withStyles, header bool) (string, int) {
+	columns := 0
+	for _, row := range rows {
+		if row != nil && len(row.Values) > columns {
+			columns = len(row.Values)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<table>\n")
+	for i, row := range rows {
+		tag := "td"
+		if header && i == 0 {
+			tag = "th"
+		}
+		b.WriteString("  <tr>")
+		for c := 0; c < columns; c++ {
+			var cell *sheets.CellData
+			if row != nil && c < len(row.Values) {
+				cell = row.Values[c]
+			}
+			text, style := "", ""
+			if cell != nil {
+				text = cell.FormattedValue
+				if withStyles {
+					style = cellStyleCSS(cell.EffectiveFormat)
+				}
+			}
+			if style != "" {
+				fmt.Fprintf(&b, "<%s style=\"%s\">", tag, style)
+			} else {
+				fmt.Fprintf(&b, "<%s>", tag)
+			}
+			b.WriteString(strings.ReplaceAll(html.EscapeString(text), "\n", "<br>"))
+			fmt.Fprintf(&b, "</%s>", tag)
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return b.String(), columns
+}
+
+func runSheetsToHTML(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	spreadsheetID := args[0]
+	rangeStr := args[1]
+	outputPath, _ := cmd.Flags().GetString("output")
+	withStyles, _ := cmd.Flags().GetBool("with-styles")
+	header, _ := cmd.Flags().GetBool("header")
+
+	fields := "sheets(data(rowData(values(formattedValue))))"
+	if withStyles {
+		fields = "sheets(data(rowData(values(formattedValue,effectiveFormat(textFormat,backgroundColor,backgroundColorStyle,horizontalAlignment)))))"
+	}
+
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).
+		Ranges(rangeStr).
+		IncludeGridData(true).
+		Fields(googleapi.Field(fields)).
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	var rows []*sheets.RowData
+	if len(spreadsheet.Sheets) > 0 && len(spreadsheet.Sheets[0].Data) > 0 {
+		rows = spreadsheet.Sheets[0].Data[0].RowData
+	}
+
+	table, columns := renderHTMLTable(rows, withStyles, header)
+	if err := os.WriteFile(outputPath, []byte(table), 0644); err != nil {
+		return p.PrintError(fmt.Errorf("failed to write file: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "exported",
+		"spreadsheet": spreadsheetID,
+		"range":       rangeStr,
+		"output":      outputPath,
+		"rows":        len(rows),
+		"columns":     columns,
+		"with_styles": withStyles,
+	})
+}
+
+// numberFormatTypes lists the NumberFormat types accepted by --type.
+var numberFormatTypes = map[string]bool{
+	"NUMBER": true, "CURRENCY": true, "PERCENT": true, "DATE": true,
+	"TIME": true, "DATE_TIME": true, "SCIENTIFIC": true, "TEXT": true,
+}
+
+// numberFormatBracket matches a [...] section of a number format pattern,
+// such as a color, condition, locale code, or elapsed-time unit.
+var numberFormatBracket = regexp.MustCompile(`\[[^\]]*\]`)
+
+// inferNumberFormatType guesses the NumberFormat type from a pattern.
+// Quoted literals and bracketed sections are ignored, except elapsed-time
+// units such as [h] and currency codes such as [$USD]; "m" alone is
+// ambiguous (month or minute) and does not decide the type.
+func inferNumberFormatType(pattern string) string {
+	var b strings.Builder
+	inQuote := false
+	for _, r := range pattern {
+		if r == '"' {
+			inQuote = !inQuote
+			continue
+		}
+		if !inQuote {
+			b.WriteRune(r)
+		}
+	}
+	p := numberFormatBracket.ReplaceAllStringFunc(strings.ToLower(b.String()), func(section string) string {
+		inner := section[1 : len(section)-1]
+		switch {
+		case inner != "" && strings.Trim(inner, "hms") == "":
+			return inner
+		case strings.HasPrefix(inner, "$") && len(inner) > 1 && inner[1] != '-':
+			return "$"
+		}
+		return ""
+	})
+
+	hasDate := strings.ContainsAny(p, "yd")
+	hasTime := strings.ContainsAny(p, "hs")
+	switch {
+	case p == "@":
+		return "TEXT"
+	case hasDate && hasTime:
+		return "DATE_TIME"
+	case hasDate:
+		return "DATE"
+	case hasTime:
+		return "TIME"
+	case strings.Contains(p, "%"):
+		return "PERCENT"
+	case strings.Contains(p, "e+") || strings.Contains(p, "e-"):
+		return "SCIENTIFIC"
+	case strings.ContainsAny(p, "$€£¥₪"):
+		return "CURRENCY"
+	}
+	return "NUMBER"
+}
+
+// buildNumberFormatRequest sets the number format of every cell in
+// gridRange without touching values or other formatting.
+func buildNumberFormatRequest(gridRange *sheets.GridRange, formatType, pattern string) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range: gridRange,
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					NumberFormat: &sheets.NumberFormat{Type: formatType, Pattern: pattern},
+				},
+			},
+			Fields: "userEnteredFormat.numberFormat",
+		},
+	}
+}
+
+// buildDefaultFormatRequest applies a number format to one whole column
+// below skipRows. The range has no end row, so it also covers rows added
+// later.
+func buildDefaultFormatRequest(sheetID, colIndex, skipRows int64, formatType, pattern string) *sheets.Request {
+	return buildNumberFormatRequest(&sheets.GridRange{
+		SheetId:          sheetID,
+		StartRowIndex:    skipRows,
+		StartColumnIndex: colIndex,
+		EndColumnIndex:   colIndex + 1,
+	}, formatType, pattern)
+}
+
+func runSheetsNumberFormat(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	formatType, _ := cmd.Flags().GetString("type")
+	pattern, _ := cmd.Flags().GetString("pattern")
+
+	formatType = strings.ToUpper(strings.TrimSpace(formatType))
+	switch {
+	case formatType == "" && strings.TrimSpace(pattern) == "":
+		return usageErrorf("specify --type, --pattern, or both")
+	case formatType == "":
+		formatType = inferNumberFormatType(pattern)
+	case !numberFormatTypes[formatType]:
+		return usageErrorf("invalid --type %q: must be one of NUMBER, CURRENCY, PERCENT, DATE, TIME, DATE_TIME, SCIENTIFIC, TEXT", formatType)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsNumberFormatWithService(svc, args[0], args[1], formatType, pattern, p)
+}
+
+func runSheetsNumberFormatWithService(svc *sheets.Service, spreadsheetID, rangeStr, formatType, pattern string, p printer.Printer) error {
+	_, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{buildNumberFormatRequest(gridRange, formatType, pattern)},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set number format: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":      "formatted",
+		"spreadsheet": spreadsheetID,
+		"range":       rangeStr,
+		"type":        formatType,
+	}
+	if pattern != "" {
+		result["pattern"] = pattern
+	}
+	return p.Print(result)
+}
+
+func runSheetsSetDefaultFormat(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spreadsheetID := args[0]
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	col, _ := cmd.Flags().GetString("col")
+	pattern, _ := cmd.Flags().GetString("number-format")
+	formatType, _ := cmd.Flags().GetString("type")
+	skipRows, _ := cmd.Flags().GetInt64("skip-rows")
+
+	col = strings.ToUpper(strings.TrimSpace(col))
+	if col == "" || strings.TrimLeft(col, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return usageErrorf("invalid --col %q: expected column letters like B or AA", col)
+	}
+	if strings.TrimSpace(pattern) == "" {
+		return usageErrorf("--number-format must not be empty")
+	}
+	if skipRows < 0 {
+		return usageErrorf("--skip-rows must not be negative, got %d", skipRows)
+	}
+	if formatType == "" {
+		formatType = inferNumberFormatType(pattern)
+	} else {
+		formatType = strings.ToUpper(formatType)
+		if !numberFormatTypes[formatType] {
+			return usageErrorf("invalid --type %q: must be one of NUMBER, CURRENCY, PERCENT, DATE, TIME, DATE_TIME, SCIENTIFIC, TEXT", formatType)
+		}
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	sheetID, err := getSheetID(svc, spreadsheetID, sheetName)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{buildDefaultFormatRequest(sheetID, columnLetterToIndex(col), skipRows, formatType, pattern)},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set default format: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":        "formatted",
+		"spreadsheet":   spreadsheetID,
+		"sheet":         sheetName,
+		"column":        col,
+		"number_format": pattern,
+		"type":          formatType,
+		"skip_rows":     skipRows,
+	})
+}
+
+// sheetsCommentFields is the Drive field mask for a spreadsheet comment.
+const sheetsCommentFields = "id,content,anchor,author(displayName,emailAddress),createdTime,modifiedTime,resolved,replies(id,content,author(displayName,emailAddress),createdTime,action)"
+
+// mapSheetsComment converts a Drive comment into the output shape used by
+// the sheets comments commands.
+func mapSheetsComment(c *drive.Comment) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":       c.Id,
+		"text":     c.Content,
+		"resolved": c.Resolved,
+		"created":  c.CreatedTime,
+	}
+	if c.ModifiedTime != "" {
+		out["modified"] = c.ModifiedTime
+	}
+	if c.Anchor != "" {
+		out["anchor"] = c.Anchor
+	}
+	if c.Author != nil {
+		out["author"] = map[string]interface{}{
+			"name":  c.Author.DisplayName,
+			"email": c.Author.EmailAddress,
+		}
+	}
+	if len(c.Replies) > 0 {
+		replies := make([]map[string]interface{}, 0, len(c.Replies))
+		for _, r := range c.Replies {
+			reply := map[string]interface{}{
+				"id":      r.Id,
+				"text":    r.Content,
+				"created": r.CreatedTime,
+			}
+			if r.Action != "" {
+				reply["action"] = r.Action
+			}
+			if r.Author != nil {
+				reply["author"] = map[string]interface{}{
+					"name":  r.Author.DisplayName,
+					"email": r.Author.EmailAddress,
+				}
+			}
+			replies = append(replies, reply)
+		}
+		out["replies"] = replies
+	}
+	return out
+}
+
+func runSheetsCommentsList(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	maxResults, _ := cmd.Flags().GetInt64("max")
+	includeResolved, _ := cmd.Flags().GetBool("include-resolved")
+	if maxResults <= 0 {
+		return usageErrorf("--max must be positive")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsCommentsListWithService(svc, args[0], maxResults, includeResolved, p)
+}
+
+func runSheetsCommentsListWithService(svc *drive.Service, spreadsheetID string, maxResults int64, includeResolved bool, p printer.Printer) error {
+	var all []*drive.Comment
+	pageToken := ""
+	for {
+		call := svc.Comments.List(spreadsheetID).
+			PageSize(maxResults).
+			Fields(googleapi.Field("nextPageToken,comments(" + sheetsCommentFields + ")"))
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to list comments: %w", err))
+		}
+		all = append(all, resp.Comments...)
+		if resp.NextPageToken == "" || int64(len(all)) >= maxResults {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	if int64(len(all)) > maxResults {
+		all = all[:maxResults]
+	}
+
+	comments := make([]map[string]interface{}, 0, len(all))
+	for _, c := range all {
+		if c.Resolved && !includeResolved {
+			continue
+		}
+		comments = append(comments, mapSheetsComment(c))
+	}
+
+	return p.Print(map[string]interface{}{
+		"spreadsheet_id": spreadsheetID,
+		"comments":       comments,
+		"count":          len(comments),
+	})
+}
+
+func runSheetsCommentsAdd(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	text, _ := cmd.Flags().GetString("text")
+	anchor, _ := cmd.Flags().GetString("anchor")
+	if strings.TrimSpace(text) == "" {
+		return usageErrorf("--text must not be empty")
+	}
+	if anchor != "" {
+		if _, _, _, err := splitA1Range(anchor); err != nil {
+			return usageErrorf("invalid --anchor: %v", err)
+		}
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsCommentsAddWithService(svc, args[0], text, anchor, p)
+}
+
+func runSheetsCommentsAddWithService(svc *drive.Service, spreadsheetID, text, anchor string, p printer.Printer) error {
+	created, err := svc.Comments.Create(spreadsheetID, &drive.Comment{Content: text, Anchor: anchor}).
+		Fields(googleapi.Field(sheetsCommentFields)).
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to add comment: %w", err))
+	}
+
+	result := mapSheetsComment(created)
+	result["status"] = "created"
+	result["spreadsheet_id"] = spreadsheetID
+	return p.Print(result)
+}
+
+// retypeCell is one cell whose text was converted by retype, at 0-based
+// offsets from the top-left of the range.
+type retypeCell struct {
+	Row   int
+	Col   int
+	Value interface{}
+}
+
+// retypeSkippedSampleSize caps how many unparseable cells retype lists.
+const retypeSkippedSampleSize = 20
+
+// retypeValues converts the text cells of values (read with FORMULA
+// rendering) to the target type. It returns the converted cells and the
+// offsets of text cells that did not parse; formulas, empty cells, and
+// non-string values are counted as unchanged.
+func retypeValues(values [][]interface{}, as string, dayFirst bool) (converted []retypeCell, skipped [][2]int, unchanged int) {
+	for r, row := range values {
+		for c, v := range row {
+			str, ok := v.(string)
+			if !ok || strings.TrimSpace(str) == "" || strings.HasPrefix(str, "=") {
+				unchanged++
+				continue
+			}
+			var value interface{}
+			switch as {
+			case "number":
+				if n, ok := parseLooseNumber(str); ok {
+					value = n
+				}
+			case "date":
+				if d, ok := parseLooseDate(str, dayFirst); ok {
+					value = d
+				}
+			}
+			if value == nil {
+				skipped = append(skipped, [2]int{r, c})
+				continue
+			}
+			converted = append(converted, retypeCell{Row: r, Col: c, Value: value})
+		}
+	}
+	return converted, skipped, unchanged
+}
+
+// parseLooseNumber parses numbers as they commonly appear in exported text:
+// thousands separators, currency symbols, a trailing percent sign, and
+// parentheses for negatives.
+func parseLooseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	percent := false
+	if strings.HasSuffix(s, "%") {
+		percent = true
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = strings.TrimSpace(s[1:])
+	}
+	s = strings.Trim(s, "$€£¥ ")
+	s = strings.ReplaceAll(s, ",", "")
+	if s == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, false
+	}
+	if percent {
+		n /= 100
+	}
+	if negative {
+		n = -n
+	}
+	return n, true
+}
+
+var (
+	looseDateTimeLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+	}
+	looseDateLayouts = []string{
+		"2006-01-02",
+		"2006/01/02",
+		"Jan 2, 2006",
+		"January 2, 2006",
+		"2 Jan 2006",
+		"2 January 2006",
+		"02-Jan-2006",
+		"2-Jan-2006",
+	}
+	monthFirstDateLayouts = []string{"1/2/2006", "1/2/06", "1-2-2006", "1.2.2006"}
+	dayFirstDateLayouts   = []string{"2/1/2006", "2/1/06", "2-1-2006", "2.1.2006"}
+)
+
+// parseLooseDate parses common date and date-time spellings and returns the
+// value as "YYYY-MM-DD" (or "YYYY-MM-DD HH:MM:SS"), which Sheets reads as a
+// date under USER_ENTERED input. Numeric dates are month-first unless
+// dayFirst is set.
+func parseLooseDate(s string, dayFirst bool) (string, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range looseDateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02 15:04:05"), true
+		}
+	}
+	numeric := monthFirstDateLayouts
+	if dayFirst {
+		numeric = dayFirstDateLayouts
+	}
+	for _, layout := range append(looseDateLayouts, numeric...) {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+// rangeCellRef returns a function mapping 0-based offsets within the values
+// of a fully qualified range (as returned by the API) to A1 cell references.
+func rangeCellRef(resolvedRange string) (func(row, col int) string, error) {
+	sheetName, origin, _, err := splitA1Range(resolvedRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse range %s: %w", resolvedRange, err)
+	}
+	originCol := int64(0)
+	if origin.Col != "" {
+		originCol = columnLetterToIndex(origin.Col)
+	}
+	originRow := origin.Row
+	if originRow == 0 {
+		originRow = 1
+	}
+	return func(row, col int) string {
+		return fmt.Sprintf("%s!%s%d", quoteSheetName(sheetName), columnIndexToLetter(originCol+int64(col)), originRow+int64(row))
+	}, nil
+}
+
+func runSheetsRetype(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spreadsheetID := args[0]
+	rangeStr := args[1]
+	as, _ := cmd.Flags().GetString("as")
+	dayFirst, _ := cmd.Flags().GetBool("day-first")
+
+	as = strings.ToLower(strings.TrimSpace(as))
+	if as != "number" && as != "date" {
+		return usageErrorf("invalid --as %q: must be number or date", as)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr).
+		ValueRenderOption("FORMULA").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	// The returned range is fully qualified, so it anchors cell addresses
+	// even when the argument was a bare sheet name.
+	cellRef, err := rangeCellRef(resp.Range)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	converted, skipped, unchanged := retypeValues(resp.Values, as, dayFirst)
+
+	if len(converted) > 0 {
+		data := make([]*sheets.ValueRange, 0, len(converted))
+		for _, c := range converted {
+			data = append(data, &sheets.ValueRange{
+				Range:  cellRef(c.Row, c.Col),
+				Values: [][]interface{}{{c.Value}},
+			})
+		}
+		_, err = svc.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "USER_ENTERED",
+			Data:             data,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to write converted values: %w", err))
+		}
+	}
+
+	result := map[string]interface{}{
+		"status":      "retyped",
+		"spreadsheet": spreadsheetID,
+		"range":       resp.Range,
+		"as":          as,
+		"converted":   len(converted),
+		"skipped":     len(skipped),
+		"unchanged":   unchanged,
+	}
+	if len(skipped) > 0 {
+		sample := make([]string, 0, retypeSkippedSampleSize)
+		for _, s := range skipped {
+			if len(sample) == retypeSkippedSampleSize {
+				break
+			}
+			sample = append(sample, cellRef(s[0], s[1]))
+		}
+		result["skipped_cells"] = sample
+	}
+	return p.Print(result)
+}
+
+// cleanOptions selects the cleanups applied by sheets clean.
+type cleanOptions struct {
+	Trim           bool
+	CollapseSpaces bool
+	ToNumber       bool
+	ToDate         bool
+	DayFirst       bool
+}
+
+// operations lists the selected cleanups by flag name, in the order they run.
+func (o cleanOptions) operations() []string {
+	var ops []string
+	if o.Trim {
+		ops = append(ops, "trim")
+	}
+	if o.CollapseSpaces {
+		ops = append(ops, "collapse-spaces")
+	}
+	if o.ToNumber {
+		ops = append(ops, "to-number")
+	}
+	if o.ToDate {
+		ops = append(ops, "to-date")
+	}
+	return ops
+}
+
+// inlineSpaceRun matches a run of whitespace other than line breaks.
+var inlineSpaceRun = regexp.MustCompile(`[^\S\n]+`)
+
+// cleanSummary counts what sheets clean did to a range.
+type cleanSummary struct {
+	Numbers   int
+	Dates     int
+	Unchanged int
+}
+
+// cleanValues applies opts to the text cells of values (read with FORMULA
+// rendering) and returns the cells whose value changed: those that are
+// still text, and those converted to a number or date. Formulas, empty
+// cells, and non-string values are counted as unchanged.
+func cleanValues(values [][]interface{}, opts cleanOptions) (text, converted []retypeCell, summary cleanSummary) {
+	for r, row := range values {
+		for c, v := range row {
+			str, ok := v.(string)
+			if !ok || strings.TrimSpace(str) == "" || strings.HasPrefix(str, "=") {
+				summary.Unchanged++
+				continue
+			}
+			cleaned := str
+			if opts.Trim {
+				cleaned = strings.TrimSpace(cleaned)
+			}
+			if opts.CollapseSpaces {
+				cleaned = inlineSpaceRun.ReplaceAllString(cleaned, " ")
+			}
+			if n, ok := parseLooseNumber(cleaned); opts.ToNumber && ok {
+				converted = append(converted, retypeCell{Row: r, Col: c, Value: n})
+				summary.Numbers++
+			} else if d, ok := parseLooseDate(cleaned, opts.DayFirst); opts.ToDate && ok {
+				converted = append(converted, retypeCell{Row: r, Col: c, Value: d})
+				summary.Dates++
+			} else if cleaned != str {
+				text = append(text, retypeCell{Row: r, Col: c, Value: cleaned})
+			} else {
+				summary.Unchanged++
+			}
+		}
+	}
+	return text, converted, summary
+}
+
+func runSheetsClean(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	opts := cleanOptions{}
+	opts.Trim, _ = cmd.Flags().GetBool("trim")
+	opts.CollapseSpaces, _ = cmd.Flags().GetBool("collapse-spaces")
+	opts.ToNumber, _ = cmd.Flags().GetBool("to-number")
+	opts.ToDate, _ = cmd.Flags().GetBool("to-date")
+	opts.DayFirst, _ = cmd.Flags().GetBool("day-first")
+
+	if len(opts.operations()) == 0 {
+		return usageErrorf("specify at least one of --trim, --collapse-spaces, --to-number, or --to-date")
+	}
+	if opts.DayFirst && !opts.ToDate {
+		return usageErrorf("--day-first requires --to-date")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsCleanWithService(svc, args[0], args[1], opts, p)
+}
+
+func runSheetsCleanWithService(svc *sheets.Service, spreadsheetID, rangeStr string, opts cleanOptions, p printer.Printer) error {
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr).
+		ValueRenderOption("FORMULA").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	cellRef, err := rangeCellRef(resp.Range)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	text, converted, summary := cleanValues(resp.Values, opts)
+
+	// Cleaned text is written RAW so Sheets doesn't re-parse it (e.g. turn
+	// "00123" into 123); only deliberate conversions use USER_ENTERED.
+	for _, batch := range []struct {
+		cells []retypeCell
+		input string
+	}{{text, "RAW"}, {converted, "USER_ENTERED"}} {
+		if len(batch.cells) == 0 {
+			continue
+		}
+		data := make([]*sheets.ValueRange, 0, len(batch.cells))
+		for _, c := range batch.cells {
+			data = append(data, &sheets.ValueRange{
+				Range:  cellRef(c.Row, c.Col),
+				Values: [][]interface{}{{c.Value}},
+			})
+		}
+		_, err = svc.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: batch.input,
+			Data:             data,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to write cleaned values: %w", err))
+		}
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "cleaned",
+		"spreadsheet": spreadsheetID,
+		"range":       resp.Range,
+		"operations":  opts.operations(),
+		"changed":     len(text) + len(converted),
+		"numbers":     summary.Numbers,
+		"dates":       summary.Dates,
+		"unchanged":   summary.Unchanged,
+	})
+}
+
+// statusColor is one --map entry of status-colors.
+type statusColor struct {
+	Status string
+	Hex    string
+	Color  *sheets.Color
+}
+
+// columnLetterPattern matches a bare column letter reference like E or AB.
+var columnLetterPattern = regexp.MustCompile(`^[A-Za-z]{1,3}$`)
+
+// parseStatusColorMap parses "Done=#00FF00,Blocked=#FF0000" into ordered
+// status colors. Statuses are matched case-insensitively, so two entries
+// differing only in case are rejected.
+func parseStatusColorMap(spec string) ([]statusColor, error) {
+	var rules []statusColor
+	seen := map[string]bool{}
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		idx := strings.LastIndex(pair, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid --map entry %q: expected status=#RRGGBB", strings.TrimSpace(pair))
+		}
+		status := strings.TrimSpace(pair[:idx])
+		hex := strings.ToUpper(strings.TrimSpace(pair[idx+1:]))
+		if status == "" {
+			return nil, fmt.Errorf("invalid --map entry %q: status is empty", strings.TrimSpace(pair))
+		}
+		color, err := parseSheetsHexColor(hex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --map entry %q: %w", strings.TrimSpace(pair), err)
+		}
+		key := strings.ToLower(status)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate status %q in --map", status)
+		}
+		seen[key] = true
+		rules = append(rules, statusColor{Status: status, Hex: hex, Color: color})
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("--map has no status=#RRGGBB entries")
+	}
+	return rules, nil
+}
+
+// statusColorRequests builds the RepeatCell requests that paint each row of
+// grid by its status cell, found statusOffset columns into values' rows.
+// Consecutive rows of the same status share one request, and requests are
+// grouped by color in --map order. It also returns the number of rows
+// painted per status and the number of rows left unmatched.
+func statusColorRequests(grid *sheets.GridRange, statusOffset int, values [][]interface{}, rules []statusColor) ([]*sheets.Request, map[string]int, int) {
+	ruleIndex := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		ruleIndex[strings.ToLower(rule.Status)] = i
+	}
+
+	rowCount := int(grid.EndRowIndex - grid.StartRowIndex)
+	matches := make([]int, rowCount)
+	counts := map[string]int{}
+	unmatched := 0
+	for i := range matches {
+		matches[i] = -1
+		if i < len(values) && statusOffset < len(values[i]) {
+			status := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", values[i][statusOffset])))
+			if k, ok := ruleIndex[status]; ok {
+				matches[i] = k
+				counts[rules[k].Status]++
+				continue
+			}
+		}
+		unmatched++
+	}
+
+	var requests []*sheets.Request
+	for k, rule := range rules {
+		for i := 0; i < rowCount; i++ {
+			if matches[i] != k {
+				continue
+			}
+			end := i
+			for end < rowCount && matches[end] == k {
+				end++
+			}
+			requests = append(requests, &sheets.Request{
+				RepeatCell: &sheets.RepeatCellRequest{
+					Range: &sheets.GridRange{
+						SheetId:          grid.SheetId,
+						StartRowIndex:    grid.StartRowIndex + int64(i),
+						EndRowIndex:      grid.StartRowIndex + int64(end),
+						StartColumnIndex: grid.StartColumnIndex,
+						EndColumnIndex:   grid.EndColumnIndex,
+					},
+					Cell: &sheets.CellData{
+						UserEnteredFormat: &sheets.CellFormat{BackgroundColor: rule.Color},
+					},
+					Fields: "userEnteredFormat.backgroundColor",
+				},
+			})
+			i = end
+		}
+	}
+	return requests, counts, unmatched
+}
+
+func runSheetsStatusColors(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	statusCol, _ := cmd.Flags().GetString("status-col")
+	mapSpec, _ := cmd.Flags().GetString("map")
+
+	statusCol = strings.ToUpper(strings.TrimSpace(statusCol))
+	if !columnLetterPattern.MatchString(statusCol) {
+		return usageErrorf("invalid --status-col %q: expected a column letter like E", statusCol)
+	}
+	rules, err := parseStatusColorMap(mapSpec)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsStatusColorsWithService(svc, args[0], args[1], statusCol, rules, p)
+}
+
+func runSheetsStatusColorsWithService(svc *sheets.Service, spreadsheetID, rangeStr, statusCol string, rules []statusColor, p printer.Printer) error {
+	_, grid, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if grid.EndRowIndex <= grid.StartRowIndex {
+		return usageErrorf("range %s must have bounded rows, top row first (e.g. Tasks!A2:H200)", rangeStr)
+	}
+	statusIndex := columnLetterToIndex(statusCol)
+	if statusIndex < grid.StartColumnIndex || statusIndex >= grid.EndColumnIndex {
+		return p.PrintError(fmt.Errorf("--status-col %s is outside the range %s", statusCol, rangeStr))
+	}
+
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	requests, counts, unmatched := statusColorRequests(grid, int(statusIndex-grid.StartColumnIndex), resp.Values, rules)
+	if len(requests) > 0 {
+		_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: requests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to color rows: %w", err))
+		}
+	}
+
+	colored := 0
+	statuses := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		colored += counts[rule.Status]
+		statuses = append(statuses, map[string]interface{}{
+			"status": rule.Status,
+			"color":  rule.Hex,
+			"rows":   counts[rule.Status],
+		})
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "colored",
+		"spreadsheet": spreadsheetID,
+		"range":       rangeStr,
+		"status_col":  statusCol,
+		"colored":     colored,
+		"unmatched":   unmatched,
+		"requests":    len(requests),
+		"statuses":    statuses,
+	})
+}
+
+// gridSheetRanges returns the titles of a spreadsheet's grid sheets, a
+// whole-sheet range for each, and the total of their grid sizes in cells.
+// Chart sheets are skipped.
+func gridSheetRanges(spreadsheet *sheets.Spreadsheet) (titles, ranges []string, gridCells int64) {
+	for _, sheet := range spreadsheet.Sheets {
+		props := sheet.Properties
+		if props == nil || (props.SheetType != "" && props.SheetType != "GRID") {
+			continue
+		}
+		titles = append(titles, props.Title)
+		ranges = append(ranges, quoteSheetName(props.Title))
+		if props.GridProperties != nil {
+			gridCells += props.GridProperties.RowCount * props.GridProperties.ColumnCount
+		}
+	}
+	return titles, ranges, gridCells
+}
+
+// csvFileName turns a sheet title into a safe, unique CSV file name. used
+// tracks names already handed out so duplicates get a numeric suffix.
+func csvFileName(title string, used map[string]bool) string {
+	base := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if base == "" || base == "." || base == ".." {
+		base = "sheet"
+	}
+	name := base + ".csv"
+	for i := 2; used[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s-%d.csv", base, i)
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func runSheetsDump(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	outputDir, _ := cmd.Flags().GetString("output")
+	maxCells, _ := cmd.Flags().GetInt64("max-cells")
+	valueRender, _ := cmd.Flags().GetString("value-render")
+	if maxCells < 0 {
+		return usageErrorf("--max-cells must not be negative")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsDumpWithService(svc, args[0], outputDir, maxCells, valueRender, p)
+}
+
+func runSheetsDumpWithService(svc *sheets.Service, spreadsheetID, outputDir string, maxCells int64, valueRender string, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("properties.title,sheets.properties").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+
+	titles, ranges, gridCells := gridSheetRanges(spreadsheet)
+	if maxCells > 0 && gridCells > maxCells {
+		return usageErrorf("spreadsheet has up to %d cells across %d sheets, over --max-cells %d (raise it or pass --max-cells 0)", gridCells, len(titles), maxCells)
+	}
+
+	values := make([][][]interface{}, len(titles))
+	if len(ranges) > 0 {
+		resp, err := svc.Spreadsheets.Values.BatchGet(spreadsheetID).
+			Ranges(ranges...).
+			ValueRenderOption(valueRender).
+			Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to read sheets: %w", err))
+		}
+		for i, vr := range resp.ValueRanges {
+			if i < len(values) {
+				values[i] = vr.Values
+			}
+		}
+	}
+
+	var cells int
+	for _, rows := range values {
+		for _, row := range rows {
+			cells += len(row)
+		}
+	}
+
+	title := ""
+	if spreadsheet.Properties != nil {
+		title = spreadsheet.Properties.Title
+	}
+
+	if outputDir == "" {
+		data := make(map[string]interface{}, len(titles))
+		for i, t := range titles {
+			rows := values[i]
+			if rows == nil {
+				rows = [][]interface{}{}
+			}
+			data[t] = rows
+		}
+		return p.Print(map[string]interface{}{
+			"spreadsheet": spreadsheetID,
+			"title":       title,
+			"sheet_names": titles,
+			"sheets":      data,
+			"sheet_count": len(titles),
+			"cells":       cells,
+		})
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return p.PrintError(fmt.Errorf("failed to create output directory: %w", err))
+	}
+	used := make(map[string]bool)
+	files := make([]map[string]interface{}, 0, len(titles))
+	for i, t := range titles {
+		path := filepath.Join(outputDir, csvFileName(t, used))
+		if err := os.WriteFile(path, []byte(rowsToCSV(values[i])), 0644); err != nil {
+			return p.PrintError(fmt.Errorf("failed to write %s: %w", path, err))
+		}
+		files = append(files, map[string]interface{}{
+			"sheet": t,
+			"path":  path,
+			"rows":  len(values[i]),
+		})
+	}
+
+	return p.Print(map[string]interface{}{
+		"spreadsheet": spreadsheetID,
+		"title":       title,
+		"output":      outputDir,
+		"files":       files,
+		"sheet_count": len(titles),
+		"cells":       cells,
+	})
+}
+
+// sheetsCopyOptions configures runSheetsCopySpreadsheetWithService.
+type sheetsCopyOptions struct {
+	SourceID     string
+	Title        string
+	FolderID     string
+	Replacements [][2]string
+}
+
+// parseReplacements parses key=value pairs, splitting at the first "=".
+func parseReplacements(pairs []string) ([][2]string, error) {
+	var out [][2]string
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --replace %q: expected key=value", pair)
+		}
+		out = append(out, [2]string{key, value})
+	}
+	return out, nil
+}
+
+func runSheetsCopySpreadsheet(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	title, _ := cmd.Flags().GetString("title")
+	folderID, _ := cmd.Flags().GetString("folder")
+	pairs, _ := cmd.Flags().GetStringArray("replace")
+
+	replacements, err := parseReplacements(pairs)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	driveSvc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	sheetsSvc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsCopySpreadsheetWithService(driveSvc, sheetsSvc, sheetsCopyOptions{
+		SourceID:     args[0],
+		Title:        title,
+		FolderID:     folderID,
+		Replacements: replacements,
+	}, p)
+}
+
+func runSheetsCopySpreadsheetWithService(driveSvc *drive.Service, sheetsSvc *sheets.Service, opts sheetsCopyOptions, p printer.Printer) error {
+	source, err := driveSvc.Files.Get(opts.SourceID).
+		SupportsAllDrives(true).
+		Fields("id,name,mimeType").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get source spreadsheet: %w", err))
+	}
+	if source.MimeType != "application/vnd.google-apps.spreadsheet" {
+		return usageErrorf("%s is not a Google Sheets spreadsheet (mime type %s)", opts.SourceID, source.MimeType)
+	}
+
+	copyFile := &drive.File{}
+	if opts.Title != "" {
+		copyFile.Name = opts.Title
+	}
+	if opts.FolderID != "" {
+		copyFile.Parents = []string{opts.FolderID}
+	}
+
+	copied, err := driveSvc.Files.Copy(opts.SourceID, copyFile).
+		SupportsAllDrives(true).
+		Fields("id,name,webViewLink").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to copy spreadsheet: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":         "copied",
+		"source_id":      opts.SourceID,
+		"spreadsheet_id": copied.Id,
+		"title":          copied.Name,
+		"url":            copied.WebViewLink,
+	}
+
+	if len(opts.Replacements) > 0 {
+		requests := make([]*sheets.Request, 0, len(opts.Replacements))
+		for _, r := range opts.Replacements {
+			requests = append(requests, &sheets.Request{
+				FindReplace: &sheets.FindReplaceRequest{
+					Find:        r[0],
+					Replacement: r[1],
+					MatchCase:   true,
+					AllSheets:   true,
+				},
+			})
+		}
+		resp, err := sheetsSvc.Spreadsheets.BatchUpdate(copied.Id, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: requests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("copied to %s but failed to replace text: %w", copied.Id, err))
+		}
+
+		replaced := make([]map[string]interface{}, 0, len(opts.Replacements))
+		for i, r := range opts.Replacements {
+			var occurrences int64
+			if i < len(resp.Replies) && resp.Replies[i].FindReplace != nil {
+				occurrences = resp.Replies[i].FindReplace.OccurrencesChanged
+			}
+			replaced = append(replaced, map[string]interface{}{
+				"find":        r[0],
+				"replace":     r[1],
+				"occurrences": occurrences,
+			})
+		}
+		result["replacements"] = replaced
+	}
+
+	return p.Print(result)
+}
+
+// sheetsSnapshotOptions configures runSheetsSnapshotWithService.
+type sheetsSnapshotOptions struct {
+	SourceID     string
+	FolderID     string
+	NameTemplate string
+	Keep         int
+	Now          time.Time
+}
+
+// snapshotOfProperty is the Drive appProperties key that tags a snapshot with
+// the ID of the spreadsheet it was copied from, so --keep only ever prunes
+// snapshots of that spreadsheet.
+const snapshotOfProperty = "gwsSnapshotOf"
+
+// snapshotPlaceholder matches a {name} placeholder in a snapshot name template.
+var snapshotPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// validateSnapshotTemplate rejects empty templates and unknown placeholders.
+func validateSnapshotTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("--name-template cannot be empty")
+	}
+	for _, ph := range snapshotPlaceholder.FindAllString(template, -1) {
+		switch ph {
+		case "{title}", "{date}", "{time}":
+		default:
+			return fmt.Errorf("unknown placeholder %s in --name-template (supported: {title}, {date}, {time})", ph)
+		}
+	}
+	return nil
+}
+
+// snapshotName fills in a snapshot name template.
+func snapshotName(template, title string, now time.Time) string {
+	return strings.NewReplacer(
+		"{title}", title,
+		"{date}", now.Format("2006-01-02"),
+		"{time}", now.Format("150405"),
+	).Replace(template)
+}
+
+// snapshotNamePattern matches every name snapshotName can produce from
+// template and title, at any date and time.
+func snapshotNamePattern(template, title string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, loc := range snapshotPlaceholder.FindAllStringIndex(template, -1) {
+		b.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		switch template[loc[0]:loc[1]] {
+		case "{title}":
+			b.WriteString(regexp.QuoteMeta(title))
+		case "{date}":
+			b.WriteString(`\d{4}-\d{2}-\d{2}`)
+		case "{time}":
+			b.WriteString(`\d{6}`)
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(template[last:]))
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func runSheetsSnapshot(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	folderID, _ := cmd.Flags().GetString("folder")
+	template, _ := cmd.Flags().GetString("name-template")
+	keep, _ := cmd.Flags().GetInt("keep")
+
+	if err := validateSnapshotTemplate(template); err != nil {
+		return usageErrorf("%v", err)
+	}
+	if keep < 0 {
+		return usageErrorf("--keep must be 0 or more, got %d", keep)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	driveSvc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsSnapshotWithService(driveSvc, sheetsSnapshotOptions{
+		SourceID:     args[0],
+		FolderID:     folderID,
+		NameTemplate: template,
+		Keep:         keep,
+		Now:          time.Now(),
+	}, p)
+}
+
+func runSheetsSnapshotWithService(driveSvc *drive.Service, opts sheetsSnapshotOptions, p printer.Printer) error {
+	source, err := driveSvc.Files.Get(opts.SourceID).
+		SupportsAllDrives(true).
+		Fields("id,name,mimeType").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get source spreadsheet: %w", err))
+	}
+	if source.MimeType != "application/vnd.google-apps.spreadsheet" {
+		return usageErrorf("%s is not a Google Sheets spreadsheet (mime type %s)", opts.SourceID, source.MimeType)
+	}
+
+	copyFile := &drive.File{
+		Name:          snapshotName(opts.NameTemplate, source.Name, opts.Now),
+		AppProperties: map[string]string{snapshotOfProperty: opts.SourceID},
+	}
+	if opts.FolderID != "" {
+		copyFile.Parents = []string{opts.FolderID}
+	}
+
+	copied, err := driveSvc.Files.Copy(opts.SourceID, copyFile).
+		SupportsAllDrives(true).
+		Fields("id,name,webViewLink,parents").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to copy spreadsheet: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":         "created",
+		"source_id":      opts.SourceID,
+		"spreadsheet_id": copied.Id,
+		"title":          copied.Name,
+		"url":            copied.WebViewLink,
+	}
+	if len(copied.Parents) > 0 {
+		result["folder"] = copied.Parents[0]
+	}
+
+	if opts.Keep > 0 {
+		if len(copied.Parents) == 0 {
+			return p.PrintError(fmt.Errorf("created snapshot %s but cannot prune: its folder is unknown", copied.Id))
+		}
+		trashed, err := pruneSnapshots(driveSvc, copied.Parents[0], snapshotNamePattern(opts.NameTemplate, source.Name), opts.Keep, opts.SourceID, copied.Id)
+		if err != nil {
+			return p.PrintError(fmt.Errorf("created snapshot %s but failed to prune old snapshots: %w", copied.Id, err))
+		}
+		result["keep"] = opts.Keep
+		result["trashed"] = trashed
+	}
+
+	return p.Print(result)
+}
+
+// pruneSnapshots moves matching snapshots in folderID to trash, newest
+// first, keeping keep of them counting the just-created newID. Only files
+// tagged as snapshots of sourceID are considered, so snapshots of other
+// spreadsheets sharing the folder and name template are never touched.
+func pruneSnapshots(driveSvc *drive.Service, folderID string, pattern *regexp.Regexp, keep int, sourceID, newID string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("'%s' in parents and mimeType = 'application/vnd.google-apps.spreadsheet' and trashed = false and appProperties has { key='%s' and value='%s' }",
+		strings.ReplaceAll(folderID, "'", "\\'"), snapshotOfProperty, strings.ReplaceAll(sourceID, "'", "\\'"))
+	var older []*drive.File
+	pageToken := ""
+	for {
+		call := driveSvc.Files.List().
+			Q(query).
+			OrderBy("createdTime desc").
+			Fields("nextPageToken,files(id,name,createdTime)").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			PageSize(100)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		for _, f := range resp.Files {
+			if f.Id != sourceID && f.Id != newID && pattern.MatchString(f.Name) {
+				older = append(older, f)
+			}
+		}
+		pageToken = resp.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	trashed := []map[string]interface{}{}
+	if len(older) < keep {
+		return trashed, nil
+	}
+	for _, f := range older[keep-1:] {
+		if _, err := driveSvc.Files.Update(f.Id, &drive.File{Trashed: true}).SupportsAllDrives(true).Do(); err != nil {
+			return trashed, fmt.Errorf("failed to trash %s: %w", f.Id, err)
+		}
+		trashed = append(trashed, map[string]interface{}{
+			"id":           f.Id,
+			"name":         f.Name,
+			"created_time": f.CreatedTime,
+		})
+	}
+	return trashed, nil
+}
+
+// spreadsheetIDPrefix matches a spreadsheet ID at the start of a
+// "<spreadsheet-id>!<range>" spec. Real IDs are 44 characters; requiring 25+
+// keeps ordinary sheet names from being mistaken for one.
+var spreadsheetIDPrefix = regexp.MustCompile(`^([A-Za-z0-9_-]{25,})!(.+)$`)
+
+// splitRangeSpec resolves a diff operand into a spreadsheet ID and range.
+// A leading "<spreadsheet-id>!" wins; otherwise defaultID is used.
+func splitRangeSpec(spec, defaultID string) (string, string, error) {
+	if m := spreadsheetIDPrefix.FindStringSubmatch(spec); m != nil {
+		return m[1], m[2], nil
+	}
+	if defaultID == "" {
+		return "", "", fmt.Errorf("%q has no spreadsheet ID: pass one as the argument or use <spreadsheet-id>!<range>", spec)
+	}
+	return defaultID, spec, nil
+}
+
+// cellDiff is one cell whose value differs between two ranges, at 0-based
+// offsets from the ranges' top-left corners.
+type cellDiff struct {
+	Row, Col int
+	A, B     string
+}
+
+// diffValues compares two value grids by position. Rows present in both are
+// compared cell by cell (a missing cell equals ""); extra rows are returned
+// as removed (only in a) or added (only in b) row offsets.
+func diffValues(a, b [][]interface{}) (cells []cellDiff, removed, added []int) {
+	cellText := func(row []interface{}, col int) string {
+		if col < len(row) && row[col] != nil {
+			return fmt.Sprint(row[col])
+		}
+		return ""
+	}
+	common := len(a)
+	if len(b) < common {
+		common = len(b)
+	}
+	for r := 0; r < common; r++ {
+		cols := len(a[r])
+		if len(b[r]) > cols {
+			cols = len(b[r])
+		}
+		for c := 0; c < cols; c++ {
+			av, bv := cellText(a[r], c), cellText(b[r], c)
+			if av != bv {
+				cells = append(cells, cellDiff{Row: r, Col: c, A: av, B: bv})
+			}
+		}
+	}
+	for r := common; r < len(a); r++ {
+		removed = append(removed, r)
+	}
+	for r := common; r < len(b); r++ {
+		added = append(added, r)
+	}
+	return cells, removed, added
+}
+
+func runSheetsDiff(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	specA, _ := cmd.Flags().GetString("a")
+	specB, _ := cmd.Flags().GetString("b")
+	valueRender, _ := cmd.Flags().GetString("value-render")
+	maxDiffs, _ := cmd.Flags().GetInt("max-diffs")
+	failOnDiff, _ := cmd.Flags().GetBool("fail-on-diff")
+
+	defaultID := ""
+	if len(args) == 1 {
+		defaultID = args[0]
+	}
+	idA, rangeA, err := splitRangeSpec(specA, defaultID)
+	if err != nil {
+		return usageErrorf("--a: %v", err)
+	}
+	idB, rangeB, err := splitRangeSpec(specB, defaultID)
+	if err != nil {
+		return usageErrorf("--b: %v", err)
+	}
+	if maxDiffs < 0 {
+		return usageErrorf("--max-diffs must not be negative")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	read := func(id, rng string) (*sheets.ValueRange, error) {
+		return svc.Spreadsheets.Values.Get(id, rng).ValueRenderOption(valueRender).Do()
+	}
+	respA, err := read(idA, rangeA)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read --a %s: %w", rangeA, err))
+	}
+	respB, err := read(idB, rangeB)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read --b %s: %w", rangeB, err))
+	}
+
+	refA, err := rangeCellRef(respA.Range)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	refB, err := rangeCellRef(respB.Range)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	cells, removed, added := diffValues(respA.Values, respB.Values)
+
+	listed := cells
+	if maxDiffs > 0 && len(listed) > maxDiffs {
+		listed = listed[:maxDiffs]
+	}
+	cellRows := make([]map[string]interface{}, 0, len(listed))
+	for _, d := range listed {
+		row := map[string]interface{}{
+			"cell": refA(d.Row, d.Col),
+			"a":    d.A,
+			"b":    d.B,
+		}
+		if bCell := refB(d.Row, d.Col); bCell != row["cell"] {
+			row["b_cell"] = bCell
+		}
+		cellRows = append(cellRows, row)
+	}
+	rowsOut := func(offsets []int, values [][]interface{}, ref func(row, col int) string) []map[string]interface{} {
+		out := make([]map[string]interface{}, 0, len(offsets))
+		for _, r := range offsets {
+			out = append(out, map[string]interface{}{
+				"row":    ref(r, 0),
+				"values": values[r],
+			})
+		}
+		return out
+	}
+
+	equal := len(cells) == 0 && len(removed) == 0 && len(added) == 0
+	result := map[string]interface{}{
+		"a":             map[string]interface{}{"spreadsheet_id": idA, "range": respA.Range, "rows": len(respA.Values)},
+		"b":             map[string]interface{}{"spreadsheet_id": idB, "range": respB.Range, "rows": len(respB.Values)},
+		"equal":         equal,
+		"changed_cells": len(cells),
+		"cells":         cellRows,
+		"truncated":     len(listed) < len(cells),
+		"removed_rows":  rowsOut(removed, respA.Values, refA),
+		"added_rows":    rowsOut(added, respB.Values, refB),
+	}
+	if err := p.Print(result); err != nil {
+		return err
+	}
+	if failOnDiff && !equal {
+		return &printer.AlreadyPrintedError{Err: fmt.Errorf("ranges differ: %d changed cells, %d removed rows, %d added rows", len(cells), len(removed), len(added))}
+	}
+	return nil
+}
+
+// columnLettersPattern matches a bare column reference such as A or AB.
+var columnLettersPattern = regexp.MustCompile(`^[A-Z]{1,3}$`)
+
+// sheetsUpsertOptions holds the inputs of sheets upsert.
+type sheetsUpsertOptions struct {
+	SpreadsheetID string
+	Range         string
+	KeyCol        string
+	Records       []interface{}
+}
+
+// upsertRows converts records to rows aligned to the range's first column.
+// Object records are mapped through header, the range's first row; columns
+// a record does not mention are left nil so mergeUpsertRow keeps them.
+func upsertRows(records []interface{}, header []interface{}) ([][]interface{}, error) {
+	rows := make([][]interface{}, 0, len(records))
+	for i, rec := range records {
+		switch r := rec.(type) {
+		case []interface{}:
+			rows = append(rows, r)
+		case map[string]interface{}:
+			if len(header) == 0 {
+				return nil, fmt.Errorf("record %d is an object but the range has no header row", i+1)
+			}
+			row := make([]interface{}, len(header))
+			for name, v := range r {
+				col := -1
+				for j, h := range header {
+					if fmt.Sprint(h) == name {
+						col = j
+						break
+					}
+				}
+				if col < 0 {
+					return nil, fmt.Errorf("record %d: %q is not a header in the range", i+1, name)
+				}
+				row[col] = v
+			}
+			rows = append(rows, row)
+		default:
+			return nil, fmt.Errorf("record %d must be an array or an object", i+1)
+		}
+	}
+	return rows, nil
+}
+
+// mergeUpsertRow overlays row on base, keeping base's value wherever row
+// has no cell or a nil one.
+func mergeUpsertRow(base, row []interface{}) []interface{} {
+	n := len(row)
+	if len(base) > n {
+		n = len(base)
+	}
+	merged := make([]interface{}, n)
+	for j := range merged {
+		if j < len(row) && row[j] != nil {
+			merged[j] = row[j]
+		} else if j < len(base) {
+			merged[j] = base[j]
+		}
+	}
+	return merged
+}
+
+// upsertKey returns the key cell of row as text, or "" when it is missing.
+func upsertKey(row []interface{}, keyIdx int) string {
+	if keyIdx < len(row) && row[keyIdx] != nil {
+		return fmt.Sprint(row[keyIdx])
+	}
+	return ""
+}
+
+func runSheetsUpsert(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	keyCol, _ := cmd.Flags().GetString("key-col")
+	recordsPath, _ := cmd.Flags().GetString("records")
+
+	keyCol = strings.ToUpper(strings.TrimSpace(keyCol))
+	if !columnLettersPattern.MatchString(keyCol) {
+		return usageErrorf("invalid --key-col %q: must be a column letter such as A", keyCol)
+	}
+
+	data, err := os.ReadFile(recordsPath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read file %s: %w", recordsPath, err))
+	}
+	var records []interface{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return usageErrorf("invalid --records JSON: %v", err)
+	}
+	if len(records) == 0 {
+		return usageErrorf("--records has no records")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsUpsertWithService(svc, sheetsUpsertOptions{
+		SpreadsheetID: args[0],
+		Range:         args[1],
+		KeyCol:        keyCol,
+		Records:       records,
+	}, p)
+}
+
+// runSheetsUpsertWithService reads the range, matches records to rows by
+// key, rewrites matched rows in one batch update, and appends the rest.
+func runSheetsUpsertWithService(svc *sheets.Service, opts sheetsUpsertOptions, p printer.Printer) error {
+	resp, err := svc.Spreadsheets.Values.Get(opts.SpreadsheetID, opts.Range).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	sheetName, origin, end, err := splitA1Range(resp.Range)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to parse range %s: %w", resp.Range, err))
+	}
+	originCol := int64(0)
+	if origin.Col != "" {
+		originCol = columnLetterToIndex(origin.Col)
+	}
+	originRow := origin.Row
+	if originRow == 0 {
+		originRow = 1
+	}
+	keyIdx := int(columnLetterToIndex(opts.KeyCol) - originCol)
+	if keyIdx < 0 || (end.Col != "" && columnLetterToIndex(opts.KeyCol) > columnLetterToIndex(end.Col)) {
+		return usageErrorf("--key-col %s is outside the range %s", opts.KeyCol, resp.Range)
+	}
+
+	var header []interface{}
+	if len(resp.Values) > 0 {
+		header = resp.Values[0]
+	}
+	rows, err := upsertRows(opts.Records, header)
+	if err != nil {
+		return usageErrorf("invalid --records: %v", err)
+	}
+
+	// The first row is the header, so keys are only matched below it.
+	existing := make(map[string]int)
+	for i := 1; i < len(resp.Values); i++ {
+		if k := upsertKey(resp.Values[i], keyIdx); k != "" {
+			if _, seen := existing[k]; !seen {
+				existing[k] = i
+			}
+		}
+	}
+
+	// Records merge into the row they match, so object records only change
+	// the columns they name. Later records with the same key merge over
+	// earlier ones in place, so each key is written exactly once.
+	updates := make(map[int][]interface{})
+	var updateOrder []int
+	var inserts [][]interface{}
+	inserted := make(map[string]int)
+	for i, row := range rows {
+		k := upsertKey(row, keyIdx)
+		if k == "" {
+			return usageErrorf("record %d has no value in key column %s", i+1, opts.KeyCol)
+		}
+		if idx, ok := existing[k]; ok {
+			base, queued := updates[idx]
+			if !queued {
+				updateOrder = append(updateOrder, idx)
+				base = resp.Values[idx]
+			}
+			updates[idx] = mergeUpsertRow(base, row)
+			continue
+		}
+		if idx, ok := inserted[k]; ok {
+			inserts[idx] = mergeUpsertRow(inserts[idx], row)
+			continue
+		}
+		inserted[k] = len(inserts)
+		inserts = append(inserts, row)
+	}
+
+	result := map[string]interface{}{
+		"status":         "upserted",
+		"spreadsheet_id": opts.SpreadsheetID,
+		"range":          resp.Range,
+		"updated":        len(updateOrder),
+		"inserted":       len(inserts),
+	}
+
+	if len(updateOrder) > 0 {
+		sort.Ints(updateOrder)
+		data := make([]*sheets.ValueRange, 0, len(updateOrder))
+		updatedRows := make([]int64, 0, len(updateOrder))
+		for _, idx := range updateOrder {
+			rowNum := originRow + int64(idx)
+			data = append(data, &sheets.ValueRange{
+				Range:  fmt.Sprintf("%s!%s%d", quoteSheetName(sheetName), columnIndexToLetter(originCol), rowNum),
+				Values: [][]interface{}{updates[idx]},
+			})
+			updatedRows = append(updatedRows, rowNum)
+		}
+		_, err := svc.Spreadsheets.Values.BatchUpdate(opts.SpreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "USER_ENTERED",
+			Data:             data,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to update rows: %w", err))
+		}
+		result["updated_rows"] = updatedRows
+	}
+
+	if len(inserts) > 0 {
+		for _, row := range inserts {
+			for j, v := range row {
+				if v == nil {
+					row[j] = ""
+				}
+			}
+		}
+		appendResp, err := svc.Spreadsheets.Values.Append(opts.SpreadsheetID, resp.Range, &sheets.ValueRange{Values: inserts}).
+			ValueInputOption("USER_ENTERED").
+			InsertDataOption("INSERT_ROWS").
+			Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to append rows: %w", err))
+		}
+		if appendResp.Updates != nil {
+			result["inserted_range"] = appendResp.Updates.UpdatedRange
+		}
+	}
+
+	return p.Print(result)
+}
+
+// envKeyInvalid matches characters that are not allowed in a shell
+// variable name.
+var envKeyInvalid = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// envKey turns a sheet key into a shell variable name with prefix applied.
+func envKey(key, prefix string, upper bool) string {
+	name := envKeyInvalid.ReplaceAllString(prefix+strings.TrimSpace(key), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	if upper {
+		name = strings.ToUpper(name)
+	}
+	return name
+}
+
+// shellQuote returns value as a shell word: bare when it only has safe
+// characters, otherwise single-quoted with embedded quotes escaped.
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_./:@%+,-=") == "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// envLines converts key/value rows to KEY=value lines, skipping rows with
+// an empty key. It returns the lines and the keys in order.
+func envLines(rows [][]interface{}, prefix string, upper, export bool) ([]string, []string) {
+	var lines, keys []string
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		rawKey := strings.TrimSpace(fmt.Sprint(row[0]))
+		if rawKey == "" {
+			continue
+		}
+		key := envKey(rawKey, prefix, upper)
+		value := ""
+		if len(row) > 1 && row[1] != nil {
+			value = fmt.Sprint(row[1])
+		}
+		line := key + "=" + shellQuote(value)
+		if export {
+			line = "export " + line
+		}
+		lines = append(lines, line)
+		keys = append(keys, key)
+	}
+	return lines, keys
+}
+
+func runSheetsToEnv(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	rangeStr, _ := cmd.Flags().GetString("range")
+	prefix, _ := cmd.Flags().GetString("prefix")
+	upper, _ := cmd.Flags().GetBool("upper")
+	skipHeader, _ := cmd.Flags().GetBool("skip-header")
+	export, _ := cmd.Flags().GetBool("export")
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	if envKeyInvalid.MatchString(prefix) {
+		return usageErrorf("invalid --prefix %q: use letters, digits, and underscores", prefix)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	resp, err := svc.Spreadsheets.Values.Get(args[0], rangeStr).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	rows := resp.Values
+	if skipHeader && len(rows) > 0 {
+		rows = rows[1:]
+	}
+	lines, keys := envLines(rows, prefix, upper, export)
+	text := ""
+	if len(lines) > 0 {
+		text = strings.Join(lines, "\n") + "\n"
+	}
+
+	if outputPath == "" {
+		if quiet && outputFile == "" {
+			return nil
+		}
+		_, err := fmt.Fprint(resultWriter(), text)
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, []byte(text), 0600); err != nil {
+		return p.PrintError(fmt.Errorf("failed to write file: %w", err))
+	}
+	return p.Print(map[string]interface{}{
+		"status": "written",
+		"file":   outputPath,
+		"range":  resp.Range,
+		"count":  len(keys),
+		"keys":   keys,
+	})
+}
+
+// sheetsWriteTypedOptions holds the inputs of sheets write-typed.
+type sheetsWriteTypedOptions struct {
+	SpreadsheetID string
+	Range         string
+	Values        [][]interface{}
+	Pattern       string
+	FormatType    string
+}
+
+// typedCellValue converts a decoded JSON value to a cell value, keeping its
+// type. nil yields nil, which clears the cell.
+func typedCellValue(v interface{}) (*sheets.ExtendedValue, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return &sheets.ExtendedValue{NumberValue: &val}, nil
+	case bool:
+		return &sheets.ExtendedValue{BoolValue: &val}, nil
+	case string:
+		if strings.HasPrefix(val, "=") {
+			return &sheets.ExtendedValue{FormulaValue: &val}, nil
+		}
+		return &sheets.ExtendedValue{StringValue: &val}, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v: use numbers, strings, booleans, or null", v)
+}
+
+// buildWriteTypedRequest writes values from start with the number format
+// set on every written cell, as one UpdateCells request.
+func buildWriteTypedRequest(sheetID, startRow, startCol int64, values [][]interface{}, format *sheets.NumberFormat) (*sheets.Request, error) {
+	rows := make([]*sheets.RowData, len(values))
+	for r, row := range values {
+		cells := make([]*sheets.CellData, len(row))
+		for c, v := range row {
+			ev, err := typedCellValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %d: %w", r+1, c+1, err)
+			}
+			cells[c] = &sheets.CellData{
+				UserEnteredValue:  ev,
+				UserEnteredFormat: &sheets.CellFormat{NumberFormat: format},
+			}
+		}
+		rows[r] = &sheets.RowData{Values: cells}
+	}
+	return &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start:  &sheets.GridCoordinate{SheetId: sheetID, RowIndex: startRow, ColumnIndex: startCol},
+			Rows:   rows,
+			Fields: "userEnteredValue,userEnteredFormat.numberFormat",
+		},
+	}, nil
+}
+
+func runSheetsWriteTyped(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	raw, _ := cmd.Flags().GetString("json")
+	pattern, _ := cmd.Flags().GetString("number-format")
+	formatType, _ := cmd.Flags().GetString("type")
+
+	var values [][]interface{}
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return usageErrorf("invalid --json: %v", err)
+	}
+	if len(values) == 0 {
+		return usageErrorf("--json has no rows")
+	}
+	if pattern == "" {
+		return usageErrorf("--number-format must not be empty")
+	}
+	formatType = strings.ToUpper(formatType)
+	if formatType == "" {
+		formatType = inferNumberFormatType(pattern)
+	} else if !numberFormatTypes[formatType] {
+		return usageErrorf("invalid --type %q: must be NUMBER, CURRENCY, PERCENT, DATE, TIME, DATE_TIME, SCIENTIFIC, or TEXT", formatType)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsWriteTypedWithService(svc, sheetsWriteTypedOptions{
+		SpreadsheetID: args[0],
+		Range:         args[1],
+		Values:        values,
+		Pattern:       pattern,
+		FormatType:    formatType,
+	}, p)
+}
+
+// runSheetsWriteTypedWithService resolves the range's sheet and start cell
+// and sends the values and format in a single batch update.
+func runSheetsWriteTypedWithService(svc *sheets.Service, opts sheetsWriteTypedOptions, p printer.Printer) error {
+	sheetName, start, end, err := splitA1Range(opts.Range)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	startCol := int64(0)
+	if start.Col != "" {
+		startCol = columnLetterToIndex(start.Col)
+	}
+	startRow := int64(0)
+	if start.Row > 0 {
+		startRow = start.Row - 1
+	}
+
+	width := 0
+	for _, row := range opts.Values {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	if width == 0 {
+		return usageErrorf("--json has no values")
+	}
+	// A single cell only anchors the data; a span must contain it.
+	isSpan := strings.Contains(opts.Range[strings.LastIndex(opts.Range, "!")+1:], ":")
+	if isSpan && end.Col != "" && end.Row > 0 {
+		rangeRows := end.Row - startRow
+		rangeCols := columnLetterToIndex(end.Col) - startCol + 1
+		if int64(len(opts.Values)) > rangeRows || int64(width) > rangeCols {
+			return usageErrorf("--json is %dx%d but %s holds %dx%d cells", len(opts.Values), width, opts.Range, rangeRows, rangeCols)
+		}
+	}
+
+	spreadsheet, err := svc.Spreadsheets.Get(opts.SpreadsheetID).Fields("sheets.properties(sheetId,title)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+	if len(spreadsheet.Sheets) == 0 {
+		return p.PrintError(fmt.Errorf("spreadsheet has no sheets"))
+	}
+	sheet := spreadsheet.Sheets[0]
+	if sheetName != "" {
+		sheet = nil
+		for _, s := range spreadsheet.Sheets {
+			if s.Properties.Title == sheetName {
+				sheet = s
+				break
+			}
+		}
+		if sheet == nil {
+			return p.PrintError(fmt.Errorf("sheet '%s' not found", sheetName))
+		}
+	}
+
+	format := &sheets.NumberFormat{Type: opts.FormatType, Pattern: opts.Pattern}
+	req, err := buildWriteTypedRequest(sheet.Properties.SheetId, startRow, startCol, opts.Values, format)
+	if err != nil {
+		return usageErrorf("invalid --json: %v", err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(opts.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{req},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to write values: %w", err))
+	}
+
+	written := fmt.Sprintf("%s!%s%d:%s%d", quoteSheetName(sheet.Properties.Title),
+		columnIndexToLetter(startCol), startRow+1,
+		columnIndexToLetter(startCol+int64(width)-1), startRow+int64(len(opts.Values)))
+	return p.Print(map[string]interface{}{
+		"status":         "written",
+		"spreadsheet_id": opts.SpreadsheetID,
+		"range":          written,
+		"rows":           len(opts.Values),
+		"columns":        width,
+		"number_format":  map[string]interface{}{"type": opts.FormatType, "pattern": opts.Pattern},
+	})
+}
+
+// sheetsEpoch is day 0 of Sheets date serial numbers.
+var sheetsEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// sheetsSerialDate converts t's wall-clock time, to the second, to a Sheets
+// date serial number: days since 1899-12-30, with the time of day as the
+// fraction. Sheets serials carry no zone, so t's own zone is what is shown.
+func sheetsSerialDate(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return wall.Sub(sheetsEpoch).Seconds() / 86400
+}
+
+func runSheetsStamp(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	cell, _ := cmd.Flags().GetString("cell")
+	pattern, _ := cmd.Flags().GetString("format")
+	utc, _ := cmd.Flags().GetBool("utc")
+
+	if pattern == "" {
+		return usageErrorf("--format must not be empty")
+	}
+	if formatType := inferNumberFormatType(pattern); formatType != "DATE" && formatType != "TIME" && formatType != "DATE_TIME" {
+		return usageErrorf("invalid --format %q: must be a date or time pattern, e.g. yyyy-mm-dd hh:mm", pattern)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsStampWithService(svc, args[0], cell, pattern, utc, time.Now(), p)
+}
+
+// runSheetsStampWithService writes now, in the spreadsheet's time zone or
+// UTC, to a single cell with a date/time number format.
+func runSheetsStampWithService(svc *sheets.Service, spreadsheetID, cell, pattern string, utc bool, now time.Time, p printer.Printer) error {
+	sheetName, start, _, err := splitA1Range(cell)
+	if err != nil || start.Col == "" || start.Row == 0 || strings.Contains(cell[strings.LastIndex(cell, "!")+1:], ":") {
+		return usageErrorf("invalid --cell %q: must be a single cell such as A1 or \"Log!B2\"", cell)
+	}
+
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("properties.timeZone,sheets.properties(sheetId,title)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+	if len(spreadsheet.Sheets) == 0 {
+		return p.PrintError(fmt.Errorf("spreadsheet has no sheets"))
+	}
+	sheet := spreadsheet.Sheets[0]
+	if sheetName != "" {
+		sheet = nil
+		for _, s := range spreadsheet.Sheets {
+			if s.Properties.Title == sheetName {
+				sheet = s
+				break
+			}
+		}
+		if sheet == nil {
+			return p.PrintError(fmt.Errorf("sheet '%s' not found", sheetName))
+		}
+	}
+
+	loc := time.UTC
+	if !utc {
+		if spreadsheet.Properties == nil || spreadsheet.Properties.TimeZone == "" {
+			return p.PrintError(fmt.Errorf("spreadsheet has no time zone; pass --utc"))
+		}
+		loc, err = time.LoadLocation(spreadsheet.Properties.TimeZone)
+		if err != nil {
+			return p.PrintError(fmt.Errorf("unknown spreadsheet time zone %q (pass --utc): %w", spreadsheet.Properties.TimeZone, err))
+		}
+	}
+	stamp := now.In(loc).Truncate(time.Second)
+	serial := sheetsSerialDate(stamp)
+
+	formatType := inferNumberFormatType(pattern)
+	format := &sheets.NumberFormat{Type: formatType, Pattern: pattern}
+	row, col := start.Row-1, columnLetterToIndex(start.Col)
+	req, err := buildWriteTypedRequest(sheet.Properties.SheetId, row, col, [][]interface{}{{serial}}, format)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{req},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to write timestamp: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":         "stamped",
+		"spreadsheet_id": spreadsheetID,
+		"cell":           fmt.Sprintf("%s!%s%d", quoteSheetName(sheet.Properties.Title), start.Col, start.Row),
+		"timestamp":      stamp.Format(time.RFC3339),
+		"time_zone":      loc.String(),
+		"serial":         serial,
+		"number_format":  map[string]interface{}{"type": formatType, "pattern": pattern},
+	})
+}
+
+// borderSides lists the set-borders side flags in UpdateBordersRequest
+// field order.
+var borderSides = []string{"top", "bottom", "left", "right", "inner-horizontal", "inner-vertical"}
+
+// borderStyles lists the accepted Border.Style values.
+var borderStyles = map[string]bool{
+	"SOLID":        true,
+	"SOLID_MEDIUM": true,
+	"SOLID_THICK":  true,
+	"DASHED":       true,
+	"DOTTED":       true,
+	"DOUBLE":       true,
+	"NONE":         true,
+}
+
+// borderStyle validates --style and folds --width into it: SOLID with
+// width 2 or 3 becomes SOLID_MEDIUM or SOLID_THICK.
+func borderStyle(style string, width int64) (string, error) {
+	style = strings.ToUpper(style)
+	if !borderStyles[style] {
+		return "", fmt.Errorf("invalid --style %q: must be SOLID, SOLID_MEDIUM, SOLID_THICK, DASHED, DOTTED, DOUBLE, or NONE", style)
+	}
+	if width < 1 || width > 3 {
+		return "", fmt.Errorf("invalid --width %d: must be 1, 2, or 3", width)
+	}
+	if style == "SOLID" {
+		switch width {
+		case 2:
+			style = "SOLID_MEDIUM"
+		case 3:
+			style = "SOLID_THICK"
+		}
+	}
+	return style, nil
+}
+
+// buildBordersRequest sets border on each selected side of gridRange.
+// Unselected sides stay nil so the API leaves them unchanged.
+func buildBordersRequest(gridRange *sheets.GridRange, sides map[string]bool, border *sheets.Border) *sheets.Request {
+	pick := func(side string) *sheets.Border {
+		if sides[side] {
+			return border
+		}
+		return nil
+	}
+	return &sheets.Request{
+		UpdateBorders: &sheets.UpdateBordersRequest{
+			Range:           gridRange,
+			Top:             pick("top"),
+			Bottom:          pick("bottom"),
+			Left:            pick("left"),
+			Right:           pick("right"),
+			InnerHorizontal: pick("inner-horizontal"),
+			InnerVertical:   pick("inner-vertical"),
+		},
+	}
+}
+
+func runSheetsSetBorders(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spreadsheetID := args[0]
+	rangeStr := args[1]
+
+	all, _ := cmd.Flags().GetBool("all")
+	outer, _ := cmd.Flags().GetBool("outer")
+	inner, _ := cmd.Flags().GetBool("inner")
+	colorHex, _ := cmd.Flags().GetString("color")
+	styleFlag, _ := cmd.Flags().GetString("style")
+	width, _ := cmd.Flags().GetInt64("width")
+
+	sides := map[string]bool{}
+	var applied []string
+	for i, side := range borderSides {
+		on, _ := cmd.Flags().GetBool(side)
+		if all || on || (outer && i < 4) || (inner && i >= 4) {
+			sides[side] = true
+			applied = append(applied, side)
+		}
+	}
+	if len(applied) == 0 {
+		return usageErrorf("no sides selected; use --all, --outer, --inner, or a side flag such as --top")
+	}
+
+	style, err := borderStyle(styleFlag, width)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	border := &sheets.Border{Style: style}
+	if style != "NONE" {
+		color, err := parseSheetsHexColor(colorHex)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+		border.ColorStyle = &sheets.ColorStyle{RgbColor: color}
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, gridRange, err := parseRange(svc, spreadsheetID, rangeStr)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{buildBordersRequest(gridRange, sides, border)},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set borders: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status": "formatted",
+		"range":  rangeStr,
+		"sides":  applied,
+		"style":  style,
+	}
+	if border.ColorStyle != nil {
+		result["color"] = colorHex
+	}
+	return p.Print(result)
+}
+
+// whereExpr is a parsed filter-read condition evaluated against one row.
+type whereExpr interface {
+	match(row []interface{}) bool
+}
+
+type whereAnd struct{ left, right whereExpr }
+
+func (e whereAnd) match(row []interface{}) bool { return e.left.match(row) && e.right.match(row) }
+
+type whereOr struct{ left, right whereExpr }
+
+func (e whereOr) match(row []interface{}) bool { return e.left.match(row) || e.right.match(row) }
+
+// whereCond compares the cell at col (0-based within the range) to value.
+type whereCond struct {
+	col   int
+	op    string
+	value string
+}
+
+func (c whereCond) match(row []interface{}) bool {
+	var cell interface{} = ""
+	if c.col < len(row) {
+		cell = row[c.col]
+	}
+	text := whereCellText(cell)
+	if c.op == "~" {
+		return strings.Contains(strings.ToLower(text), strings.ToLower(c.value))
+	}
+
+	var cmp int
+	cellNum, cellIsNum := whereCellNumber(cell)
+	valueNum, err := strconv.ParseFloat(c.value, 64)
+	if err == nil && !cellIsNum {
+		// A number never equals or orders against text or an empty cell.
+		return c.op == "!="
+	}
+	if cellIsNum && err == nil {
+		switch {
+		case cellNum < valueNum:
+			cmp = -1
+		case cellNum > valueNum:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(text, c.value)
+	}
+
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// whereCellText renders a cell for text comparison; whole numbers lose
+// their trailing ".0".
+func whereCellText(cell interface{}) string {
+	if f, ok := cell.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(cell)
+}
+
+// whereCellNumber reports the cell's numeric value, parsing text cells.
+func whereCellNumber(cell interface{}) (float64, bool) {
+	switch v := cell.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// whereToken is one lexical token of a --where expression. kind is one of
+// "word", "string", "ident" ([...]), "op", "(", or ")".
+type whereToken struct {
+	kind string
+	text string
+	pos  int
+}
+
+// tokenizeWhere splits a --where expression into tokens.
+func tokenizeWhere(expr string) ([]whereToken, error) {
+	var tokens []whereToken
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, whereToken{kind: string(c), text: string(c), pos: i})
+			i++
+		case strings.HasPrefix(expr[i:], "!=") || strings.HasPrefix(expr[i:], ">=") || strings.HasPrefix(expr[i:], "<="):
+			tokens = append(tokens, whereToken{kind: "op", text: expr[i : i+2], pos: i})
+			i += 2
+		case c == '=' || c == '>' || c == '<' || c == '~':
+			tokens = append(tokens, whereToken{kind: "op", text: string(c), pos: i})
+			i++
+		case c == '!':
+			return nil, fmt.Errorf("unexpected '!' at position %d (did you mean !=?)", i+1)
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string starting at position %d", i+1)
+			}
+			tokens = append(tokens, whereToken{kind: "string", text: expr[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case c == '[':
+			end := strings.IndexByte(expr[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [ starting at position %d", i+1)
+			}
+			tokens = append(tokens, whereToken{kind: "ident", text: strings.TrimSpace(expr[i+1 : i+1+end]), pos: i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\n()=!<>~\"'[", rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, whereToken{kind: "word", text: expr[start:i], pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// whereParser is a recursive-descent parser for --where expressions.
+// resolve maps a column reference to its 0-based index within the range.
+type whereParser struct {
+	tokens  []whereToken
+	pos     int
+	resolve func(name string) (int, error)
+}
+
+// parseWhere parses expr into an evaluable condition.
+func parseWhere(expr string, resolve func(name string) (int, error)) (whereExpr, error) {
+	tokens, err := tokenizeWhere(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	p := &whereParser{tokens: tokens, resolve: resolve}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		t := p.tokens[p.pos]
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos+1)
+	}
+	return e, nil
+}
+
+func (p *whereParser) peekKeyword(kw string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == "word" && strings.EqualFold(p.tokens[p.pos].text, kw)
+}
+
+func (p *whereParser) parseOr() (whereExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = whereOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseAnd() (whereExpr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("AND") {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = whereAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseTerm() (whereExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	t := p.tokens[p.pos]
+	if t.kind == "(" {
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != ")" {
+			return nil, fmt.Errorf("missing ) for ( at position %d", t.pos+1)
+		}
+		p.pos++
+		return e, nil
+	}
+	if (t.kind != "word" && t.kind != "ident") || p.peekKeyword("AND") || p.peekKeyword("OR") {
+		return nil, fmt.Errorf("expected a column at position %d, got %q", t.pos+1, t.text)
+	}
+	col, err := p.resolve(t.text)
+	if err != nil {
+		return nil, fmt.Errorf("position %d: %w", t.pos+1, err)
+	}
+	p.pos++
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != "op" {
+		return nil, fmt.Errorf("expected an operator after %q", t.text)
+	}
+	op := p.tokens[p.pos].text
+	p.pos++
+
+	if p.pos >= len(p.tokens) || (p.tokens[p.pos].kind != "word" && p.tokens[p.pos].kind != "string") {
+		return nil, fmt.Errorf("expected a value after %s %s", t.text, op)
+	}
+	value := p.tokens[p.pos].text
+	p.pos++
+	return whereCond{col: col, op: op, value: value}, nil
+}
+
+// whereColumnResolver maps column references to 0-based indices within a
+// range whose first column is originCol. Header names (exact, then
+// case-insensitive) win over column letters.
+func whereColumnResolver(headers []string, originCol int64) func(name string) (int, error) {
+	return func(name string) (int, error) {
+		for i, h := range headers {
+			if h == name {
+				return i, nil
+			}
+		}
+		for i, h := range headers {
+			if strings.EqualFold(h, name) {
+				return i, nil
+			}
+		}
+		if columnLettersPattern.MatchString(name) {
+			idx := columnLetterToIndex(strings.ToUpper(name)) - originCol
+			if idx < 0 {
+				return 0, fmt.Errorf("column %s is outside the range", strings.ToUpper(name))
+			}
+			return int(idx), nil
+		}
+		if headers != nil {
+			return 0, fmt.Errorf("unknown column %q (not a header or column letter)", name)
+		}
+		return 0, fmt.Errorf("unknown column %q (use a column letter, or --header to refer to header names)", name)
+	}
+}
+
+func runSheetsFilterRead(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	spreadsheetID := args[0]
+	rangeStr := args[1]
+	where, _ := cmd.Flags().GetString("where")
+	header, _ := cmd.Flags().GetBool("header")
+	valueRender, _ := cmd.Flags().GetString("value-render")
+
+	// Check the syntax before any API call; columns are resolved after the
+	// read, once the headers and the range origin are known.
+	if _, err := parseWhere(where, func(string) (int, error) { return 0, nil }); err != nil {
+		return usageErrorf("invalid --where: %v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsFilterReadWithService(svc, spreadsheetID, rangeStr, where, header, valueRender, p)
+}
+
+// runSheetsFilterReadWithService reads the range and prints the rows that
+// match where, with their sheet row numbers.
+func runSheetsFilterReadWithService(svc *sheets.Service, spreadsheetID, rangeStr, where string, header bool, valueRender string, p printer.Printer) error {
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr).ValueRenderOption(valueRender).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	_, origin, _, err := splitA1Range(resp.Range)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to parse range %s: %w", resp.Range, err))
+	}
+	originCol := int64(0)
+	if origin.Col != "" {
+		originCol = columnLetterToIndex(origin.Col)
+	}
+	firstRow := origin.Row
+	if firstRow == 0 {
+		firstRow = 1
+	}
+
+	rows := resp.Values
+	var headers []string
+	if header && len(rows) > 0 {
+		for _, h := range rows[0] {
+			headers = append(headers, whereCellText(h))
+		}
+		rows = rows[1:]
+		firstRow++
+	}
+
+	expr, err := parseWhere(where, whereColumnResolver(headers, originCol))
+	if err != nil {
+		return usageErrorf("invalid --where: %v", err)
+	}
+
+	matched := []interface{}{}
+	rowNumbers := []int64{}
+	for i, row := range rows {
+		if !expr.match(row) {
+			continue
+		}
+		rowNumbers = append(rowNumbers, firstRow+int64(i))
+		if headers == nil {
+			matched = append(matched, row)
+			continue
+		}
+		obj := map[string]interface{}{}
+		for j, h := range headers {
+			if j < len(row) {
+				obj[h] = row[j]
+			} else {
+				obj[h] = ""
+			}
+		}
+		matched = append(matched, obj)
+	}
+
+	result := map[string]interface{}{
+		"range":       resp.Range,
+		"where":       where,
+		"rows":        matched,
+		"row_numbers": rowNumbers,
+		"count":       len(matched),
+		"scanned":     len(rows),
+	}
+	if headers != nil {
+		result["headers"] = headers
+	}
+	return p.Print(result)
+}
+
+// formulaRef is one A1 reference found in a formula. Sheet is empty when
+// the reference is unqualified.
+type formulaRef struct {
+	Sheet string
+	Start a1Corner
+	End   a1Corner
+}
+
+// open reports whether the reference has no fixed end (A:A, 2:2, A2:B).
+func (r formulaRef) open() bool {
+	return r.Start.Col == "" || r.Start.Row == 0 || r.End.Col == "" || r.End.Row == 0
+}
+
+// a1 renders the reference qualified with its sheet, without $ anchors.
+func (r formulaRef) a1() string {
+	corner := func(c a1Corner) string {
+		if c.Row == 0 {
+			return c.Col
+		}
+		return c.Col + strconv.FormatInt(c.Row, 10)
+	}
+	out := corner(r.Start)
+	if r.End != r.Start {
+		out += ":" + corner(r.End)
+	}
+	if r.Sheet == "" {
+		return out
+	}
+	return quoteSheetName(r.Sheet) + "!" + out
+}
+
+var (
+	formulaCellPattern = regexp.MustCompile(`^\$?[A-Za-z]{1,3}\$?[0-9]+$`)
+	formulaColPattern  = regexp.MustCompile(`^\$?[A-Za-z]{1,3}$`)
+	formulaRowPattern  = regexp.MustCompile(`^\$?[0-9]+$`)
+)
+
+// isFormulaWordChar reports whether c can appear in a function name,
+// unquoted sheet name, or reference.
+func isFormulaWordChar(c byte) bool {
+	return c == '_' || c == '.' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// extractFormulaRefs returns the A1 references in a formula, in order of
+// appearance. String literals are skipped, and a word followed by "(" is a
+// function call, so LOG10( is not read as a cell.
+func extractFormulaRefs(formula string) []formulaRef {
+	var refs []formulaRef
+	s := formula
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '"':
+			i++
+			for i < len(s) {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+		case c == '\'':
+			var name strings.Builder
+			j := i + 1
+			for j < len(s) {
+				if s[j] == '\'' {
+					if j+1 < len(s) && s[j+1] == '\'' {
+						name.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				name.WriteByte(s[j])
+				j++
+			}
+			i = j + 1
+			if i < len(s) && s[i] == '!' {
+				if ref, end, ok := readFormulaRef(s, i+1); ok {
+					ref.Sheet = name.String()
+					refs = append(refs, ref)
+					i = end
+				}
+			}
+		case isFormulaWordChar(c):
+			j := i
+			for j < len(s) && isFormulaWordChar(s[j]) {
+				j++
+			}
+			if j < len(s) && s[j] == '!' {
+				if ref, end, ok := readFormulaRef(s, j+1); ok {
+					ref.Sheet = s[i:j]
+					refs = append(refs, ref)
+					i = end
+					continue
+				}
+				i = j + 1
+				continue
+			}
+			if ref, end, ok := readFormulaRef(s, i); ok {
+				refs = append(refs, ref)
+				i = end
+				continue
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	return refs
+}
+
+// readFormulaRef reads a reference (B2, $B$2, B2:C9, A:C, 2:5, A2:C)
+// starting at s[i]. It returns the index after it, or false when the text
+// there is not a reference.
+func readFormulaRef(s string, i int) (formulaRef, int, bool) {
+	part := func(at int) (string, int) {
+		j := at
+		for j < len(s) && (s[j] == '$' || (s[j] >= 'a' && s[j] <= 'z') || (s[j] >= 'A' && s[j] <= 'Z') || (s[j] >= '0' && s[j] <= '9')) {
+			j++
+		}
+		return s[at:j], j
+	}
+	first, end := part(i)
+	second := ""
+	if end < len(s) && s[end] == ':' {
+		second, end = part(end + 1)
+		if second == "" {
+			return formulaRef{}, 0, false
+		}
+	}
+	if end < len(s) && (isFormulaWordChar(s[end]) || s[end] == '(' || s[end] == '!') {
+		return formulaRef{}, 0, false
+	}
+
+	isCell := formulaCellPattern.MatchString
+	isCol := formulaColPattern.MatchString
+	isRow := formulaRowPattern.MatchString
+	switch {
+	case second == "" && isCell(first):
+	case second != "" && isCell(first) && (isCell(second) || isCol(second)):
+	case second != "" && isCol(first) && isCol(second):
+	case second != "" && isRow(first) && isRow(second):
+	default:
+		return formulaRef{}, 0, false
+	}
+
+	start, err := parseA1Corner(first)
+	if err != nil {
+		return formulaRef{}, 0, false
+	}
+	ref := formulaRef{Start: start, End: start}
+	if second != "" {
+		if ref.End, err = parseA1Corner(second); err != nil {
+			return formulaRef{}, 0, false
+		}
+	}
+	return ref, end, true
+}
+
+// traceNode is one reference in a sheets trace tree. Cells are the formula
+// cells found inside a range reference; Refs are the references of a
+// cell's own formula.
+type traceNode struct {
+	ref       formulaRef
+	depth     int
+	formula   string
+	refs      []*traceNode
+	cells     []*traceNode
+	seen      bool
+	truncated bool
+}
+
+func (n *traceNode) toMap() map[string]interface{} {
+	out := map[string]interface{}{"ref": n.ref.a1()}
+	if n.formula != "" {
+		out["formula"] = n.formula
+	}
+	if n.ref.open() {
+		out["expanded"] = false
+	}
+	if n.seen {
+		out["seen"] = true
+	}
+	if n.truncated {
+		out["truncated"] = true
+	}
+	if len(n.refs) > 0 {
+		refs := make([]map[string]interface{}, len(n.refs))
+		for i, r := range n.refs {
+			refs[i] = r.toMap()
+		}
+		out["references"] = refs
+	}
+	if len(n.cells) > 0 {
+		cells := make([]map[string]interface{}, len(n.cells))
+		for i, c := range n.cells {
+			cells[i] = c.toMap()
+		}
+		out["formula_cells"] = cells
+	}
+	return out
+}
+
+// traceFormulaDependencies builds the dependency tree of root, reading
+// formulas with one BatchGet per level. Every returned reference is
+// sheet-qualified. precedents lists each distinct reference once.
+func traceFormulaDependencies(svc *sheets.Service, spreadsheetID string, root formulaRef, maxDepth int) (*traceNode, []string, error) {
+	rootNode := &traceNode{ref: root}
+	visited := map[string]bool{root.a1(): true}
+	var precedents []string
+	listed := map[string]bool{}
+
+	// expand records the references of a formula cell and returns the ones
+	// that still need to be read.
+	expand := func(n *traceNode, formula string) []*traceNode {
+		n.formula = formula
+		var pending []*traceNode
+		for _, ref := range extractFormulaRefs(strings.TrimPrefix(formula, "=")) {
+			if ref.Sheet == "" {
+				ref.Sheet = n.ref.Sheet
+			}
+			child := &traceNode{ref: ref, depth: n.depth + 1}
+			n.refs = append(n.refs, child)
+			key := ref.a1()
+			if !listed[key] {
+				listed[key] = true
+				precedents = append(precedents, key)
+			}
+			switch {
+			case ref.open():
+			case visited[key]:
+				child.seen = true
+			case child.depth > maxDepth:
+				child.truncated = true
+			default:
+				if ref.Start == ref.End {
+					visited[key] = true
+				}
+				pending = append(pending, child)
+			}
+		}
+		return pending
+	}
+
+	pending := []*traceNode{rootNode}
+	for len(pending) > 0 {
+		ranges := make([]string, len(pending))
+		for i, n := range pending {
+			ranges[i] = n.ref.a1()
+		}
+		resp, err := svc.Spreadsheets.Values.BatchGet(spreadsheetID).
+			Ranges(ranges...).
+			ValueRenderOption("FORMULA").
+			Do()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read formulas: %w", err)
+		}
+
+		var next []*traceNode
+		for i, n := range pending {
+			if i >= len(resp.ValueRanges) {
+				break
+			}
+			values := resp.ValueRanges[i].Values
+			if n.ref.Start == n.ref.End {
+				if len(values) > 0 && len(values[0]) > 0 {
+					if f, ok := values[0][0].(string); ok && strings.HasPrefix(f, "=") {
+						next = append(next, expand(n, f)...)
+					}
+				}
+				continue
+			}
+			startCol := columnLetterToIndex(n.ref.Start.Col)
+			for r, row := range values {
+				for c, v := range row {
+					f, ok := v.(string)
+					if !ok || !strings.HasPrefix(f, "=") {
+						continue
+					}
+					cellRef := formulaRef{Sheet: n.ref.Sheet, Start: a1Corner{
+						Col: columnIndexToLetter(startCol + int64(c)),
+						Row: n.ref.Start.Row + int64(r),
+					}}
+					cellRef.End = cellRef.Start
+					cell := &traceNode{ref: cellRef, depth: n.depth}
+					n.cells = append(n.cells, cell)
+					key := cellRef.a1()
+					if visited[key] {
+						cell.seen = true
+						cell.formula = f
+						continue
+					}
+					visited[key] = true
+					next = append(next, expand(cell, f)...)
+				}
+			}
+		}
+		pending = next
+	}
+	return rootNode, precedents, nil
+}
+
+func runSheetsTrace(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	cell, _ := cmd.Flags().GetString("cell")
+	maxDepth, _ := cmd.Flags().GetInt("depth")
+	if maxDepth < 1 {
+		return usageErrorf("--depth must be at least 1")
+	}
+	if _, start, end, err := splitA1Range(cell); err != nil || start != end || start.Col == "" || start.Row == 0 {
+		return usageErrorf("--cell must be a single cell, e.g. D5 or \"Summary!B12\"")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsTraceWithService(svc, args[0], cell, maxDepth, p)
+}
+
+// runSheetsTraceWithService traces cell, qualifying it with the first
+// sheet's name when it has none so that unqualified references in its
+// formula can be placed.
+func runSheetsTraceWithService(svc *sheets.Service, spreadsheetID, cell string, maxDepth int, p printer.Printer) error {
+	sheetName, start, _, err := splitA1Range(cell)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if sheetName == "" {
+		spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties.title").Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+		}
+		if len(spreadsheet.Sheets) == 0 || spreadsheet.Sheets[0].Properties == nil {
+			return p.PrintError(fmt.Errorf("spreadsheet %s has no sheets", spreadsheetID))
+		}
+		sheetName = spreadsheet.Sheets[0].Properties.Title
+	}
+
+	root := formulaRef{Sheet: sheetName, Start: start, End: start}
+	tree, precedents, err := traceFormulaDependencies(svc, spreadsheetID, root, maxDepth)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if precedents == nil {
+		precedents = []string{}
+	}
+
+	result := map[string]interface{}{
+		"spreadsheet": spreadsheetID,
+		"cell":        root.a1(),
+		"tree":        tree.toMap(),
+		"precedents":  precedents,
+		"count":       len(precedents),
+	}
+	if tree.formula == "" {
+		result["note"] = "the cell holds no formula"
+	}
+	return p.Print(result)
+}
+
+func runSheetsRefresh(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	dataSourceID, _ := cmd.Flags().GetString("data-source")
+	force, _ := cmd.Flags().GetBool("force")
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsRefreshWithService(svc, args[0], dataSourceID, force, p)
+}
+
+// runSheetsRefreshWithService refreshes every object of the spreadsheet's
+// data sources, or of dataSourceID only, and prints one row per object.
+func runSheetsRefreshWithService(svc *sheets.Service, spreadsheetID, dataSourceID string, force bool, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties(sheetId,title)", "dataSources(dataSourceId,spec(bigQuery(projectId),looker(instanceUri)))").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+
+	sources := make([]map[string]interface{}, 0, len(spreadsheet.DataSources))
+	found := false
+	for _, ds := range spreadsheet.DataSources {
+		if ds.DataSourceId == dataSourceID {
+			found = true
+		}
+		source := map[string]interface{}{"data_source_id": ds.DataSourceId}
+		if ds.Spec != nil && ds.Spec.BigQuery != nil {
+			source["type"] = "bigquery"
+			source["project_id"] = ds.Spec.BigQuery.ProjectId
+		} else if ds.Spec != nil && ds.Spec.Looker != nil {
+			source["type"] = "looker"
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return p.Print(map[string]interface{}{
+			"status":       "nothing_to_refresh",
+			"spreadsheet":  spreadsheetID,
+			"data_sources": sources,
+			"note":         "the spreadsheet has no connected data sources",
+		})
+	}
+	if dataSourceID != "" && !found {
+		return p.PrintError(fmt.Errorf("data source %s not found in spreadsheet %s", dataSourceID, spreadsheetID))
+	}
+
+	refresh := &sheets.RefreshDataSourceRequest{Force: force}
+	if dataSourceID != "" {
+		refresh.DataSourceId = dataSourceID
+	} else {
+		refresh.IsAll = true
+	}
+	resp, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{RefreshDataSource: refresh}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to refresh data sources: %w", err))
+	}
+
+	sheetTitles := map[int64]string{}
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties != nil {
+			sheetTitles[sheet.Properties.SheetId] = sheet.Properties.Title
+		}
+	}
+
+	objects := []map[string]interface{}{}
+	failed := 0
+	for _, reply := range resp.Replies {
+		if reply == nil || reply.RefreshDataSource == nil {
+			continue
+		}
+		for _, st := range reply.RefreshDataSource.Statuses {
+			object := describeDataSourceObject(st.Reference, sheetTitles)
+			if es := st.DataExecutionStatus; es != nil {
+				object["state"] = es.State
+				if es.LastRefreshTime != "" {
+					object["last_refresh_time"] = es.LastRefreshTime
+				}
+				if es.ErrorCode != "" || es.ErrorMessage != "" {
+					object["error_code"] = es.ErrorCode
+					object["error"] = es.ErrorMessage
+					failed++
+				}
+			}
+			objects = append(objects, object)
+		}
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":       "refreshed",
+		"spreadsheet":  spreadsheetID,
+		"data_sources": sources,
+		"objects":      objects,
+		"count":        len(objects),
+		"failed_count": failed,
+	})
+}
+
+// describeDataSourceObject names the object a refresh status refers to,
+// rendering anchor cells in A1 notation.
+func describeDataSourceObject(ref *sheets.DataSourceObjectReference, sheetTitles map[int64]string) map[string]interface{} {
+	cell := func(c *sheets.GridCoordinate) string {
+		a1 := columnIndexToLetter(c.ColumnIndex) + strconv.FormatInt(c.RowIndex+1, 10)
+		if title, ok := sheetTitles[c.SheetId]; ok {
+			return quoteSheetName(title) + "!" + a1
+		}
+		return a1
+	}
+	switch {
+	case ref == nil:
+		return map[string]interface{}{"type": "unknown"}
+	case ref.SheetId != "":
+		return map[string]interface{}{"type": "data_source_sheet", "sheet_id": ref.SheetId}
+	case ref.ChartId != 0:
+		return map[string]interface{}{"type": "chart", "chart_id": ref.ChartId}
+	case ref.DataSourceTableAnchorCell != nil:
+		return map[string]interface{}{"type": "table", "cell": cell(ref.DataSourceTableAnchorCell)}
+	case ref.DataSourcePivotTableAnchorCell != nil:
+		return map[string]interface{}{"type": "pivot_table", "cell": cell(ref.DataSourcePivotTableAnchorCell)}
+	case ref.DataSourceFormulaCell != nil:
+		return map[string]interface{}{"type": "formula", "cell": cell(ref.DataSourceFormulaCell)}
+	}
+	return map[string]interface{}{"type": "unknown"}
+}
+
+// pivotSummarizeFunctions are the --values functions add-pivot accepts.
+var pivotSummarizeFunctions = map[string]bool{
+	"SUM": true, "COUNT": true, "COUNTA": true, "COUNTUNIQUE": true,
+	"AVERAGE": true, "MAX": true, "MIN": true, "MEDIAN": true,
+}
+
+// pivotValue is one --values entry: a source column and how to summarize it.
+type pivotValue struct {
+	Column   string
+	Function string
+}
+
+// parsePivotColumns parses a comma-separated list of column letters,
+// uppercased.
+func parsePivotColumns(list, flag string) ([]string, error) {
+	var cols []string
+	for _, part := range strings.Split(list, ",") {
+		col := strings.ToUpper(strings.TrimSpace(part))
+		if col == "" {
+			continue
+		}
+		if !columnLetterPattern.MatchString(col) {
+			return nil, fmt.Errorf("invalid %s column %q: expected a column letter like B", flag, part)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// parsePivotValues parses --values as COLUMN[:FUNCTION] pairs.
+func parsePivotValues(list string) ([]pivotValue, error) {
+	var values []pivotValue
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		col, fn, found := strings.Cut(part, ":")
+		col = strings.ToUpper(strings.TrimSpace(col))
+		fn = strings.ToUpper(strings.TrimSpace(fn))
+		if !found {
+			fn = "SUM"
+		}
+		if !columnLetterPattern.MatchString(col) {
+			return nil, fmt.Errorf("invalid --values column %q: expected COLUMN:FUNCTION like E:SUM", part)
+		}
+		if !pivotSummarizeFunctions[fn] {
+			return nil, fmt.Errorf("invalid --values function %q: must be SUM, COUNT, COUNTA, COUNTUNIQUE, AVERAGE, MAX, MIN, or MEDIAN", fn)
+		}
+		values = append(values, pivotValue{Column: col, Function: fn})
+	}
+	return values, nil
+}
+
+// pivotSourceOffset returns col's offset within source, erroring when the
+// column falls outside it.
+func pivotSourceOffset(source *sheets.GridRange, col string) (int64, error) {
+	offset := columnLetterToIndex(col) - source.StartColumnIndex
+	if offset < 0 || offset >= source.EndColumnIndex-source.StartColumnIndex {
+		return 0, fmt.Errorf("column %s is outside the source range (%s:%s)", col,
+			columnIndexToLetter(source.StartColumnIndex), columnIndexToLetter(source.EndColumnIndex-1))
+	}
+	return offset, nil
+}
+
+// buildPivotTable builds a pivot table over source. Groups are sorted
+// ascending with totals shown.
+func buildPivotTable(source *sheets.GridRange, rows, cols []string, values []pivotValue) (*sheets.PivotTable, error) {
+	groups := func(columns []string) ([]*sheets.PivotGroup, error) {
+		var out []*sheets.PivotGroup
+		for _, col := range columns {
+			offset, err := pivotSourceOffset(source, col)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, &sheets.PivotGroup{
+				SourceColumnOffset: offset,
+				SortOrder:          "ASCENDING",
+				ShowTotals:         true,
+				ForceSendFields:    []string{"SourceColumnOffset"},
+			})
+		}
+		return out, nil
+	}
+
+	pt := &sheets.PivotTable{Source: source}
+	var err error
+	if pt.Rows, err = groups(rows); err != nil {
+		return nil, err
+	}
+	if pt.Columns, err = groups(cols); err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		offset, err := pivotSourceOffset(source, v.Column)
+		if err != nil {
+			return nil, err
+		}
+		pt.Values = append(pt.Values, &sheets.PivotValue{
+			SourceColumnOffset: offset,
+			SummarizeFunction:  v.Function,
+			ForceSendFields:    []string{"SourceColumnOffset"},
+		})
+	}
+	return pt, nil
+}
+
+func runSheetsAddPivot(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	source, _ := cmd.Flags().GetString("source")
+	rowList, _ := cmd.Flags().GetString("rows")
+	colList, _ := cmd.Flags().GetString("cols")
+	valueList, _ := cmd.Flags().GetString("values")
+	anchor, _ := cmd.Flags().GetString("anchor")
+
+	rows, err := parsePivotColumns(rowList, "--rows")
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	cols, err := parsePivotColumns(colList, "--cols")
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	values, err := parsePivotValues(valueList)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	if len(rows) == 0 && len(cols) == 0 && len(values) == 0 {
+		return usageErrorf("at least one of --rows, --cols, or --values is required")
+	}
+	if _, start, end, err := splitA1Range(anchor); err != nil || start != end || start.Col == "" || start.Row == 0 {
+		return usageErrorf("--anchor must be a single cell, e.g. A1 or \"Pivot!A1\"")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsAddPivotWithService(svc, args[0], source, anchor, rows, cols, values, p)
+}
+
+func runSheetsAddPivotWithService(svc *sheets.Service, spreadsheetID, source, anchor string, rows, cols []string, values []pivotValue, p printer.Printer) error {
+	_, sourceRange, err := parseRange(svc, spreadsheetID, source)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	pivot, err := buildPivotTable(sourceRange, rows, cols, values)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	anchorSheet, corner, _, err := splitA1Range(anchor)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	var anchorSheetID int64
+	if anchorSheet != "" {
+		if anchorSheetID, err = getSheetID(svc, spreadsheetID, anchorSheet); err != nil {
+			return p.PrintError(err)
+		}
+	} else {
+		spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties(sheetId,title)").Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+		}
+		if len(spreadsheet.Sheets) == 0 || spreadsheet.Sheets[0].Properties == nil {
+			return p.PrintError(fmt.Errorf("spreadsheet %s has no sheets", spreadsheetID))
+		}
+		anchorSheet = spreadsheet.Sheets[0].Properties.Title
+		anchorSheetID = spreadsheet.Sheets[0].Properties.SheetId
+	}
+	rowIndex, colIndex := corner.Row-1, columnLetterToIndex(corner.Col)
+
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Start: &sheets.GridCoordinate{
+					SheetId:     anchorSheetID,
+					RowIndex:    rowIndex,
+					ColumnIndex: colIndex,
+				},
+				Rows:   []*sheets.RowData{{Values: []*sheets.CellData{{PivotTable: pivot}}}},
+				Fields: "pivotTable",
+			},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to create pivot table: %w", err))
+	}
+
+	valueInfo := make([]map[string]interface{}, 0, len(values))
+	for _, v := range values {
+		valueInfo = append(valueInfo, map[string]interface{}{"column": v.Column, "function": v.Function})
+	}
+	if rows == nil {
+		rows = []string{}
+	}
+	if cols == nil {
+		cols = []string{}
+	}
+	return p.Print(map[string]interface{}{
+		"status":      "created",
+		"spreadsheet": spreadsheetID,
+		"source":      source,
+		"sheet_id":    anchorSheetID,
+		"anchor":      quoteSheetName(anchorSheet) + "!" + corner.Col + strconv.FormatInt(corner.Row, 10),
+		"row_index":   rowIndex,
+		"col_index":   colIndex,
+		"rows":        rows,
+		"cols":        cols,
+		"values":      valueInfo,
+	})
+}
+
+// parseCSVDelimiter parses --delimiter: a single character, or "\t" or
+// "tab" for tab-separated files.
+func parseCSVDelimiter(value string) (rune, error) {
+	switch strings.ToLower(value) {
+	case `\t`, "tab":
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid --delimiter %q: must be a single character other than a quote or newline", value)
+	}
+	return r, nil
+}
+
+// readCSVValues reads CSV records as sheet rows. Records may differ in
+// length, and a leading UTF-8 byte order mark is dropped.
+func readCSVValues(r io.Reader, delimiter rune, skipHeader bool) ([][]interface{}, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+
+	var values [][]interface{}
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		if first {
+			first = false
+			if len(record) > 0 {
+				record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			}
+			if skipHeader {
+				continue
+			}
+		}
+		row := make([]interface{}, len(record))
+		for i, field := range record {
+			row[i] = field
+		}
+		values = append(values, row)
+	}
+	return values, nil
+}
+
+func runSheetsImportCSV(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	file, _ := cmd.Flags().GetString("file")
+	delimiterFlag, _ := cmd.Flags().GetString("delimiter")
+	skipHeader, _ := cmd.Flags().GetBool("skip-header")
+	clearFirst, _ := cmd.Flags().GetBool("clear-first")
+	valueInput, _ := cmd.Flags().GetString("value-input")
+
+	valueInput = strings.ToUpper(valueInput)
+	if valueInput != "RAW" && valueInput != "USER_ENTERED" {
+		return usageErrorf("invalid --value-input %q: must be RAW or USER_ENTERED", valueInput)
+	}
+	delimiter, err := parseCSVDelimiter(delimiterFlag)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to open CSV file: %w", err))
+	}
+	values, err := readCSVValues(f, delimiter, skipHeader)
+	f.Close()
+	if err != nil {
+		return usageErrorf("invalid CSV file %s: %v", file, err)
+	}
+	if len(values) == 0 {
+		return usageErrorf("CSV file %s has no rows to import", file)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsImportCSVWithService(svc, args[0], args[1], values, valueInput, clearFirst, p)
+}
+
+func runSheetsImportCSVWithService(svc *sheets.Service, spreadsheetID, rangeStr string, values [][]interface{}, valueInput string, clearFirst bool, p printer.Printer) error {
+	if clearFirst {
+		if _, err := svc.Spreadsheets.Values.Clear(spreadsheetID, rangeStr, &sheets.ClearValuesRequest{}).Do(); err != nil {
+			return p.PrintError(fmt.Errorf("failed to clear range: %w", err))
+		}
+	}
+
+	resp, err := svc.Spreadsheets.Values.Update(spreadsheetID, rangeStr, &sheets.ValueRange{Values: values}).
+		ValueInputOption(valueInput).
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to write values: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":        "written",
+		"spreadsheet":   resp.SpreadsheetId,
+		"range":         resp.UpdatedRange,
+		"rows_updated":  resp.UpdatedRows,
+		"cells_updated": resp.UpdatedCells,
+	}
+	if clearFirst {
+		result["cleared"] = true
+	}
+	return p.Print(result)
+}
+
+func runSheetsExportCSV(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	output, _ := cmd.Flags().GetString("output")
+	delimiterFlag, _ := cmd.Flags().GetString("delimiter")
+	allSheets, _ := cmd.Flags().GetBool("all-sheets")
+
+	if allSheets && len(args) > 1 {
+		return usageErrorf("--all-sheets exports every sheet; do not pass a range")
+	}
+	if !allSheets && len(args) < 2 {
+		return usageErrorf("a range is required unless --all-sheets is set")
+	}
+	if allSheets && output == "-" {
+		return usageErrorf("--all-sheets writes one file per sheet; --output must be a directory, not -")
+	}
+	if !allSheets && output == "" {
+		return usageErrorf("--output is required: a file path, or - for stdout")
+	}
+	delimiter, err := parseCSVDelimiter(delimiterFlag)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	if allSheets {
+		if output == "" {
+			output = "."
+		}
+		return runSheetsExportAllCSVWithService(svc, args[0], output, delimiter, p)
+	}
+	return runSheetsExportCSVWithService(svc, args[0], args[1], output, delimiter, resultWriter(), p)
+}
+
+// runSheetsExportCSVWithService writes one range as CSV to the file at
+// output, or to stdout when output is "-".
+func runSheetsExportCSVWithService(svc *sheets.Service, spreadsheetID, rangeStr, output string, delimiter rune, stdout io.Writer, p printer.Printer) error {
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeStr).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read range: %w", err))
+	}
+
+	if output == "-" {
+		if err := writeCSVRows(stdout, resp.Values, delimiter); err != nil {
+			return p.PrintError(fmt.Errorf("failed to write CSV: %w", err))
+		}
+		return nil
+	}
+
+	var buf strings.Builder
+	if err := writeCSVRows(&buf, resp.Values, delimiter); err != nil {
+		return p.PrintError(fmt.Errorf("failed to write CSV: %w", err))
+	}
+	if err := os.WriteFile(output, []byte(buf.String()), 0644); err != nil {
+		return p.PrintError(fmt.Errorf("failed to write %s: %w", output, err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":      "exported",
+		"spreadsheet": spreadsheetID,
+		"range":       resp.Range,
+		"path":        output,
+		"rows":        len(resp.Values),
+	})
+}
+
+// runSheetsExportAllCSVWithService writes every grid sheet to
+// <title>.csv in outputDir.
+func runSheetsExportAllCSVWithService(svc *sheets.Service, spreadsheetID, outputDir string, delimiter rune, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("properties.title,sheets.properties").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+
+	titles, ranges, _ := gridSheetRanges(spreadsheet)
+	values := make([][][]interface{}, len(titles))
+	if len(ranges) > 0 {
+		resp, err := svc.Spreadsheets.Values.BatchGet(spreadsheetID).Ranges(ranges...).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to read sheets: %w", err))
+		}
+		for i, vr := range resp.ValueRanges {
+			if i < len(values) {
+				values[i] = vr.Values
+			}
+		}
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return p.PrintError(fmt.Errorf("failed to create output directory: %w", err))
+	}
+	used := make(map[string]bool)
+	files := make([]map[string]interface{}, 0, len(titles))
+	for i, t := range titles {
+		path := filepath.Join(outputDir, csvFileName(t, used))
+		var buf strings.Builder
+		if err := writeCSVRows(&buf, values[i], delimiter); err != nil {
+			return p.PrintError(fmt.Errorf("failed to write CSV: %w", err))
+		}
+		if err := os.WriteFile(path, []byte(buf.String()), 0644); err != nil {
+			return p.PrintError(fmt.Errorf("failed to write %s: %w", path, err))
+		}
+		files = append(files, map[string]interface{}{
+			"sheet": t,
+			"path":  path,
+			"rows":  len(values[i]),
+		})
+	}
+
+	title := ""
+	if spreadsheet.Properties != nil {
+		title = spreadsheet.Properties.Title
+	}
+	return p.Print(map[string]interface{}{
+		"status":      "exported",
+		"spreadsheet": spreadsheetID,
+		"title":       title,
+		"output":      outputDir,
+		"files":       files,
+		"sheet_count": len(titles),
+	})
+}
+
+// sheetTemplateVersion is the template format written by capture-template.
+const sheetTemplateVersion = 1
+
+// sheetTemplate is a sheet's layout and formatting, without its values.
+// Conditional format ranges are stored without a sheet ID.
+type sheetTemplate struct {
+	Version            int                             `json:"version"`
+	Sheet              string                          `json:"sheet"`
+	FrozenRows         int64                           `json:"frozen_rows"`
+	FrozenCols         int64                           `json:"frozen_cols"`
+	HeaderRows         int64                           `json:"header_rows"`
+	Columns            []templateColumn                `json:"columns"`
+	Header             []templateRow                   `json:"header"`
+	ConditionalFormats []*sheets.ConditionalFormatRule `json:"conditional_formats"`
+}
+
+// templateColumn is one column's width and the number format of its data
+// cells.
+type templateColumn struct {
+	Column       string               `json:"column"`
+	Width        int64                `json:"width,omitempty"`
+	NumberFormat *sheets.NumberFormat `json:"number_format,omitempty"`
+}
+
+// templateRow is one header row's height and per-column cell formats.
+type templateRow struct {
+	Height  int64                `json:"height,omitempty"`
+	Formats []*sheets.CellFormat `json:"formats"`
+}
+
+// findSheet returns the sheet titled name.
+func findSheet(spreadsheet *sheets.Spreadsheet, name string) (*sheets.Sheet, error) {
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties != nil && sheet.Properties.Title == name {
+			return sheet, nil
+		}
+	}
+	return nil, fmt.Errorf("sheet '%s' not found", name)
+}
+
+// buildSheetTemplate builds a template from a sheet's properties and
+// conditional formats plus grid data covering its first headerRows+1 rows.
+func buildSheetTemplate(sheet *sheets.Sheet, headerRows int64) *sheetTemplate {
+	props := sheet.Properties
+	tmpl := &sheetTemplate{
+		Version:            sheetTemplateVersion,
+		Sheet:              props.Title,
+		HeaderRows:         headerRows,
+		Columns:            []templateColumn{},
+		Header:             []templateRow{},
+		ConditionalFormats: []*sheets.ConditionalFormatRule{},
+	}
+	if props.GridProperties != nil {
+		tmpl.FrozenRows = props.GridProperties.FrozenRowCount
+		tmpl.FrozenCols = props.GridProperties.FrozenColumnCount
+	}
+
+	var data *sheets.GridData
+	if len(sheet.Data) > 0 {
+		data = sheet.Data[0]
+	}
+	if data != nil {
+		for i, meta := range data.ColumnMetadata {
+			tmpl.Columns = append(tmpl.Columns, templateColumn{
+				Column: columnIndexToLetter(data.StartColumn + int64(i)),
+				Width:  meta.PixelSize,
+			})
+		}
+		for r := int64(0); r < headerRows; r++ {
+			row := templateRow{Formats: []*sheets.CellFormat{}}
+			if r < int64(len(data.RowMetadata)) {
+				row.Height = data.RowMetadata[r].PixelSize
+			}
+			if r < int64(len(data.RowData)) {
+				for _, cell := range data.RowData[r].Values {
+					row.Formats = append(row.Formats, cell.UserEnteredFormat)
+				}
+			}
+			tmpl.Header = append(tmpl.Header, row)
+		}
+		if headerRows < int64(len(data.RowData)) {
+			for c, cell := range data.RowData[headerRows].Values {
+				if cell.UserEnteredFormat == nil || cell.UserEnteredFormat.NumberFormat == nil {
+					continue
+				}
+				for len(tmpl.Columns) <= c {
+					tmpl.Columns = append(tmpl.Columns, templateColumn{Column: columnIndexToLetter(data.StartColumn + int64(len(tmpl.Columns)))})
+				}
+				tmpl.Columns[c].NumberFormat = cell.UserEnteredFormat.NumberFormat
+			}
+		}
+	}
+
+	for _, rule := range sheet.ConditionalFormats {
+		for _, gr := range rule.Ranges {
+			gr.SheetId = 0
+			gr.ForceSendFields = nil
+		}
+		tmpl.ConditionalFormats = append(tmpl.ConditionalFormats, rule)
+	}
+	return tmpl
+}
+
+func runSheetsCaptureTemplate(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	output, _ := cmd.Flags().GetString("output")
+	headerRows, _ := cmd.Flags().GetInt64("header-rows")
+	if headerRows < 0 {
+		return usageErrorf("--header-rows must not be negative")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsCaptureTemplateWithService(svc, args[0], sheetName, output, headerRows, p)
+}
+
+func runSheetsCaptureTemplateWithService(svc *sheets.Service, spreadsheetID, sheetName, output string, headerRows int64, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+	sheet, err := findSheet(spreadsheet, sheetName)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	if headerRows == 0 {
+		headerRows = 1
+		if gp := sheet.Properties.GridProperties; gp != nil && gp.FrozenRowCount > 0 {
+			headerRows = gp.FrozenRowCount
+		}
+	}
+
+	// Read the header rows plus one data row for the column number formats.
+	rangeStr := fmt.Sprintf("%s!1:%d", quoteSheetName(sheetName), headerRows+1)
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Ranges(rangeStr).
+		Fields("sheets(properties,conditionalFormats,data(startRow,startColumn,rowMetadata/pixelSize,columnMetadata/pixelSize,rowData/values/userEnteredFormat))").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read sheet formatting: %w", err))
+	}
+	sheet, err = findSheet(resp, sheetName)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	tmpl := buildSheetTemplate(sheet, headerRows)
+	data, err := json.MarshalIndent(tmpl, "", "  ")
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to encode template: %w", err))
+	}
+	if err := os.WriteFile(output, append(data, '\n'), 0644); err != nil {
+		return p.PrintError(fmt.Errorf("failed to write %s: %w", output, err))
+	}
+
+	numberFormats := 0
+	for _, col := range tmpl.Columns {
+		if col.NumberFormat != nil {
+			numberFormats++
+		}
+	}
+	return p.Print(map[string]interface{}{
+		"status":              "captured",
+		"spreadsheet":         spreadsheetID,
+		"sheet":               sheetName,
+		"path":                output,
+		"frozen_rows":         tmpl.FrozenRows,
+		"frozen_cols":         tmpl.FrozenCols,
+		"header_rows":         tmpl.HeaderRows,
+		"columns":             len(tmpl.Columns),
+		"number_formats":      numberFormats,
+		"conditional_formats": len(tmpl.ConditionalFormats),
+	})
+}
+
+// parseSheetTemplate decodes a template file and checks its version and
+// column letters.
+func parseSheetTemplate(data []byte) (*sheetTemplate, error) {
+	var tmpl sheetTemplate
+	if err := json.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("invalid template JSON: %w", err)
+	}
+	if tmpl.Version != sheetTemplateVersion {
+		return nil, fmt.Errorf("unsupported template version %d (expected %d); recapture it with capture-template", tmpl.Version, sheetTemplateVersion)
+	}
+	if tmpl.FrozenRows < 0 || tmpl.FrozenCols < 0 || tmpl.HeaderRows < 0 {
+		return nil, fmt.Errorf("template frozen and header row counts must not be negative")
+	}
+	for _, col := range tmpl.Columns {
+		if !columnLetterPattern.MatchString(col.Column) {
+			return nil, fmt.Errorf("invalid template column %q: expected a column letter like C", col.Column)
+		}
+	}
+	return &tmpl, nil
+}
+
+// buildApplyTemplateRequests returns the requests that format the sheet with
+// sheetID like tmpl. Columns at or beyond columnCount are skipped and
+// counted. existingRules conditional format rules are deleted first unless
+// keepRules is set.
+func buildApplyTemplateRequests(tmpl *sheetTemplate, sheetID, columnCount int64, existingRules int, keepRules bool) ([]*sheets.Request, int) {
+	gridProps := &sheets.GridProperties{
+		FrozenRowCount:    tmpl.FrozenRows,
+		FrozenColumnCount: tmpl.FrozenCols,
+		ForceSendFields:   []string{"FrozenRowCount", "FrozenColumnCount"},
+	}
+	requests := []*sheets.Request{{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: sheetID, GridProperties: gridProps},
+			Fields:     "gridProperties.frozenRowCount,gridProperties.frozenColumnCount",
+		},
+	}}
+
+	skipped := 0
+	for _, col := range tmpl.Columns {
+		index := columnLetterToIndex(col.Column)
+		if index >= columnCount {
+			skipped++
+			continue
+		}
+		if col.Width > 0 {
+			requests = append(requests, &sheets.Request{
+				UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+					Range:      &sheets.DimensionRange{SheetId: sheetID, Dimension: "COLUMNS", StartIndex: index, EndIndex: index + 1},
+					Properties: &sheets.DimensionProperties{PixelSize: col.Width},
+					Fields:     "pixelSize",
+				},
+			})
+		}
+		if col.NumberFormat != nil {
+			requests = append(requests, &sheets.Request{
+				RepeatCell: &sheets.RepeatCellRequest{
+					Range: &sheets.GridRange{
+						SheetId:          sheetID,
+						StartRowIndex:    tmpl.HeaderRows,
+						StartColumnIndex: index,
+						EndColumnIndex:   index + 1,
+					},
+					Cell:   &sheets.CellData{UserEnteredFormat: &sheets.CellFormat{NumberFormat: col.NumberFormat}},
+					Fields: "userEnteredFormat.numberFormat",
+				},
+			})
+		}
+	}
+
+	for r, row := range tmpl.Header {
+		if row.Height > 0 {
+			requests = append(requests, &sheets.Request{
+				UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+					Range:      &sheets.DimensionRange{SheetId: sheetID, Dimension: "ROWS", StartIndex: int64(r), EndIndex: int64(r) + 1},
+					Properties: &sheets.DimensionProperties{PixelSize: row.Height},
+					Fields:     "pixelSize",
+				},
+			})
+		}
+		formats := row.Formats
+		if int64(len(formats)) > columnCount {
+			formats = formats[:columnCount]
+		}
+		if len(formats) == 0 {
+			continue
+		}
+		cells := make([]*sheets.CellData, len(formats))
+		for c, format := range formats {
+			cells[c] = &sheets.CellData{UserEnteredFormat: format}
+		}
+		requests = append(requests, &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Start:  &sheets.GridCoordinate{SheetId: sheetID, RowIndex: int64(r), ForceSendFields: []string{"SheetId"}},
+				Rows:   []*sheets.RowData{{Values: cells}},
+				Fields: "userEnteredFormat",
+			},
+		})
+	}
+
+	offset := int64(0)
+	if keepRules {
+		offset = int64(existingRules)
+	} else {
+		for i := 0; i < existingRules; i++ {
+			requests = append(requests, &sheets.Request{
+				DeleteConditionalFormatRule: &sheets.DeleteConditionalFormatRuleRequest{SheetId: sheetID, Index: 0, ForceSendFields: []string{"Index"}},
+			})
+		}
+	}
+	for i, rule := range tmpl.ConditionalFormats {
+		for _, gr := range rule.Ranges {
+			gr.SheetId = sheetID
+			gr.ForceSendFields = []string{"SheetId"}
+		}
+		requests = append(requests, &sheets.Request{
+			AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{Rule: rule, Index: offset + int64(i), ForceSendFields: []string{"Index"}},
+		})
+	}
+	return requests, skipped
+}
+
+func runSheetsApplyTemplate(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	file, _ := cmd.Flags().GetString("file")
+	keepRules, _ := cmd.Flags().GetBool("keep-rules")
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read template: %w", err))
+	}
+	tmpl, err := parseSheetTemplate(data)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsApplyTemplateWithService(svc, args[0], sheetName, tmpl, keepRules, p)
+}
+
+func runSheetsApplyTemplateWithService(svc *sheets.Service, spreadsheetID, sheetName string, tmpl *sheetTemplate, keepRules bool, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets(properties,conditionalFormats)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+	sheet, err := findSheet(spreadsheet, sheetName)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	var columnCount int64
+	if gp := sheet.Properties.GridProperties; gp != nil {
+		columnCount = gp.ColumnCount
+	}
+
+	requests, skipped := buildApplyTemplateRequests(tmpl, sheet.Properties.SheetId, columnCount, len(sheet.ConditionalFormats), keepRules)
+	_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to apply template: %w", err))
+	}
+
+	replaced := 0
+	if !keepRules {
+		replaced = len(sheet.ConditionalFormats)
+	}
+	return p.Print(map[string]interface{}{
+		"status":              "applied",
+		"spreadsheet":         spreadsheetID,
+		"sheet":               sheetName,
+		"source_sheet":        tmpl.Sheet,
+		"header_rows":         tmpl.HeaderRows,
+		"columns":             len(tmpl.Columns) - skipped,
+		"skipped_columns":     skipped,
+		"conditional_formats": len(tmpl.ConditionalFormats),
+		"replaced_rules":      replaced,
+		"requests":            len(requests),
+	})
+}
+
+// metadataLocationTypes maps --location values to DeveloperMetadataLocation
+// location types.
+var metadataLocationTypes = map[string]string{
+	"spreadsheet": "SPREADSHEET",
+	"sheet":       "SHEET",
+	"row":         "ROW",
+	"column":      "COLUMN",
+}
+
+type metadataOptions struct {
+	Key        string
+	Value      string
+	Location   string
+	Sheet      string
+	Row        int64
+	Col        string
+	Visibility string
+}
+
+// buildMetadataLocation places metadata on the spreadsheet, a sheet, or a
+// single row or column of sheetID.
+func buildMetadataLocation(opts metadataOptions, sheetID int64) *sheets.DeveloperMetadataLocation {
+	switch opts.Location {
+	case "sheet":
+		return &sheets.DeveloperMetadataLocation{SheetId: sheetID, ForceSendFields: []string{"SheetId"}}
+	case "row":
+		return &sheets.DeveloperMetadataLocation{DimensionRange: &sheets.DimensionRange{
+			SheetId:         sheetID,
+			Dimension:       "ROWS",
+			StartIndex:      opts.Row - 1,
+			EndIndex:        opts.Row,
+			ForceSendFields: []string{"SheetId", "StartIndex"},
+		}}
+	case "column":
+		col := columnLetterToIndex(opts.Col)
+		return &sheets.DeveloperMetadataLocation{DimensionRange: &sheets.DimensionRange{
+			SheetId:         sheetID,
+			Dimension:       "COLUMNS",
+			StartIndex:      col,
+			EndIndex:        col + 1,
+			ForceSendFields: []string{"SheetId", "StartIndex"},
+		}}
+	}
+	return &sheets.DeveloperMetadataLocation{Spreadsheet: true}
+}
+
+// describeMetadata flattens a DeveloperMetadata entry for output, turning
+// dimension ranges into 1-based rows or column letters. sheetTitles maps
+// sheet IDs to names; IDs missing from it are reported without a name.
+func describeMetadata(md *sheets.DeveloperMetadata, sheetTitles map[int64]string) map[string]interface{} {
+	entry := map[string]interface{}{
+		"metadata_id": md.MetadataId,
+		"key":         md.MetadataKey,
+		"value":       md.MetadataValue,
+		"visibility":  md.Visibility,
+	}
+	loc := md.Location
+	if loc == nil {
+		return entry
+	}
+
+	location := map[string]interface{}{"type": strings.ToLower(loc.LocationType)}
+	addSheet := func(sheetID int64) {
+		location["sheet_id"] = sheetID
+		if title, ok := sheetTitles[sheetID]; ok {
+			location["sheet"] = title
+		}
+	}
+	switch {
+	case loc.DimensionRange != nil:
+		dr := loc.DimensionRange
+		addSheet(dr.SheetId)
+		if dr.Dimension == "COLUMNS" {
+			location["column"] = columnIndexToLetter(dr.StartIndex)
+			location["end_column"] = columnIndexToLetter(dr.EndIndex - 1)
+		} else {
+			location["row"] = dr.StartIndex + 1
+			location["end_row"] = dr.EndIndex
+		}
+	case loc.LocationType == "SHEET":
+		addSheet(loc.SheetId)
+	}
+	entry["location"] = location
+	return entry
+}
+
+// sheetTitlesByID maps each sheet ID in the spreadsheet to its title.
+func sheetTitlesByID(svc *sheets.Service, spreadsheetID string) (map[int64]string, error) {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets(properties(sheetId,title))").Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spreadsheet: %w", err)
+	}
+	titles := make(map[int64]string, len(spreadsheet.Sheets))
+	for _, s := range spreadsheet.Sheets {
+		if s.Properties != nil {
+			titles[s.Properties.SheetId] = s.Properties.Title
+		}
+	}
+	return titles, nil
+}
+
+func runSheetsSetMetadata(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	var opts metadataOptions
+	opts.Key, _ = cmd.Flags().GetString("key")
+	opts.Value, _ = cmd.Flags().GetString("value")
+	opts.Location, _ = cmd.Flags().GetString("location")
+	opts.Sheet, _ = cmd.Flags().GetString("sheet")
+	opts.Row, _ = cmd.Flags().GetInt64("row")
+	opts.Col, _ = cmd.Flags().GetString("col")
+	opts.Visibility, _ = cmd.Flags().GetString("visibility")
+
+	opts.Location = strings.ToLower(strings.TrimSpace(opts.Location))
+	opts.Col = strings.ToUpper(strings.TrimSpace(opts.Col))
+	opts.Visibility = strings.ToUpper(strings.TrimSpace(opts.Visibility))
+	if strings.TrimSpace(opts.Key) == "" {
+		return usageErrorf("--key must not be empty")
+	}
+	if _, ok := metadataLocationTypes[opts.Location]; !ok {
+		return usageErrorf("invalid --location %q: must be spreadsheet, sheet, row, or column", opts.Location)
+	}
+	if opts.Visibility != "DOCUMENT" && opts.Visibility != "PROJECT" {
+		return usageErrorf("invalid --visibility %q: must be DOCUMENT or PROJECT", opts.Visibility)
+	}
+	if opts.Location != "spreadsheet" && opts.Sheet == "" {
+		return usageErrorf("--sheet is required for --location %s", opts.Location)
+	}
+	if opts.Location == "row" && opts.Row < 1 {
+		return usageErrorf("--row must be 1 or greater for --location row")
+	}
+	if opts.Location == "column" && (opts.Col == "" || strings.TrimLeft(opts.Col, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "") {
+		return usageErrorf("invalid --col %q: expected column letters like B or AA", opts.Col)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsSetMetadataWithService(svc, args[0], opts, p)
+}
+
+func runSheetsSetMetadataWithService(svc *sheets.Service, spreadsheetID string, opts metadataOptions, p printer.Printer) error {
+	var sheetID int64
+	titles := map[int64]string{}
+	if opts.Location != "spreadsheet" {
+		var err error
+		sheetID, err = getSheetID(svc, spreadsheetID, opts.Sheet)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		titles[sheetID] = opts.Sheet
+	}
+
+	resp, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+				DeveloperMetadata: &sheets.DeveloperMetadata{
+					MetadataKey:   opts.Key,
+					MetadataValue: opts.Value,
+					Location:      buildMetadataLocation(opts, sheetID),
+					Visibility:    opts.Visibility,
+				},
+			},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set metadata: %w", err))
+	}
+	if len(resp.Replies) == 0 || resp.Replies[0].CreateDeveloperMetadata == nil || resp.Replies[0].CreateDeveloperMetadata.DeveloperMetadata == nil {
+		return p.PrintError(fmt.Errorf("failed to set metadata: empty reply"))
+	}
+
+	result := describeMetadata(resp.Replies[0].CreateDeveloperMetadata.DeveloperMetadata, titles)
+	result["status"] = "created"
+	result["spreadsheet"] = spreadsheetID
+	return p.Print(result)
+}
+
+func runSheetsSearchMetadata(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	key, _ := cmd.Flags().GetString("key")
+	value, _ := cmd.Flags().GetString("value")
+	location, _ := cmd.Flags().GetString("location")
+
+	if key == "" && value == "" {
+		return usageErrorf("specify --key, --value, or both")
+	}
+	locationType := ""
+	if location != "" {
+		var ok bool
+		locationType, ok = metadataLocationTypes[strings.ToLower(strings.TrimSpace(location))]
+		if !ok {
+			return usageErrorf("invalid --location %q: must be spreadsheet, sheet, row, or column", location)
+		}
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsSearchMetadataWithService(svc, args[0], key, value, locationType, p)
+}
+
+func runSheetsSearchMetadataWithService(svc *sheets.Service, spreadsheetID, key, value, locationType string, p printer.Printer) error {
+	resp, err := svc.Spreadsheets.DeveloperMetadata.Search(spreadsheetID, &sheets.SearchDeveloperMetadataRequest{
+		DataFilters: []*sheets.DataFilter{{
+			DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{
+				MetadataKey:   key,
+				MetadataValue: value,
+				LocationType:  locationType,
+			},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to search metadata: %w", err))
+	}
+
+	// Only look up sheet names when a match is attached to a sheet.
+	titles := map[int64]string{}
+	for _, m := range resp.MatchedDeveloperMetadata {
+		if md := m.DeveloperMetadata; md != nil && md.Location != nil && md.Location.LocationType != "SPREADSHEET" {
+			titles, err = sheetTitlesByID(svc, spreadsheetID)
+			if err != nil {
+				return p.PrintError(err)
+			}
+			break
+		}
+	}
+
+	matches := make([]map[string]interface{}, 0, len(resp.MatchedDeveloperMetadata))
+	for _, m := range resp.MatchedDeveloperMetadata {
+		if m.DeveloperMetadata != nil {
+			matches = append(matches, describeMetadata(m.DeveloperMetadata, titles))
+		}
+	}
+
+	return p.Print(map[string]interface{}{
+		"spreadsheet": spreadsheetID,
+		"metadata":    matches,
+		"count":       len(matches),
+	})
+}
+
+// mapRecordsToHeader aligns object records to header, returning one row per
+// record that starts at the header's first named column and ends at its
+// last. Keys that match no header are returned, sorted, in ignored.
+func mapRecordsToHeader(records []map[string]interface{}, header []interface{}) (rows [][]interface{}, first int, ignored []string, err error) {
+	columns := make(map[string]int)
+	first, last := -1, -1
+	for i, h := range header {
+		name := strings.TrimSpace(fmt.Sprint(h))
+		if h == nil || name == "" {
+			continue
+		}
+		if _, dup := columns[name]; !dup {
+			columns[name] = i
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	if first < 0 {
+		return nil, 0, nil, fmt.Errorf("row 1 is empty; add header names before appending mapped records")
+	}
+
+	seen := make(map[string]bool)
+	rows = make([][]interface{}, 0, len(records))
+	for i, rec := range records {
+		row := make([]interface{}, last-first+1)
+		for j := range row {
+			row[j] = ""
+		}
+		matched := false
+		for key, v := range rec {
+			col, ok := columns[key]
+			if !ok {
+				if !seen[key] {
+					seen[key] = true
+					ignored = append(ignored, key)
+				}
+				continue
+			}
+			if v != nil {
+				row[col-first] = v
+			}
+			matched = true
+		}
+		if !matched {
+			return nil, 0, nil, fmt.Errorf("record %d has no keys matching the header row", i+1)
+		}
+		rows = append(rows, row)
+	}
+	sort.Strings(ignored)
+	return rows, first, ignored, nil
+}
+
+func runSheetsAppendMapped(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	sheetName, _ := cmd.Flags().GetString("sheet")
+	raw, _ := cmd.Flags().GetString("json")
+
+	var records []map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &records); err != nil {
+		return usageErrorf("invalid --json: expected an array of objects: %v", err)
+	}
+	if len(records) == 0 {
+		return usageErrorf("--json has no records")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsAppendMappedWithService(svc, args[0], sheetName, records, p)
+}
+
+// runSheetsAppendMappedWithService reads the sheet's first row as the header
+// and appends records aligned to it.
+func runSheetsAppendMappedWithService(svc *sheets.Service, spreadsheetID, sheetName string, records []map[string]interface{}, p printer.Printer) error {
+	sheetRef := quoteSheetName(sheetName)
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, sheetRef+"!1:1").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read header row: %w", err))
+	}
+	var header []interface{}
+	if len(resp.Values) > 0 {
+		header = resp.Values[0]
+	}
+
+	rows, first, ignored, err := mapRecordsToHeader(records, header)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("cannot map records to sheet %q: %w", sheetName, err))
+	}
+
+	// Anchor the append at the header's first named column so the API's
+	// table detection starts the rows in the same column as the header.
+	startCol := columnIndexToLetter(int64(first))
+	endCol := columnIndexToLetter(int64(first + len(rows[0]) - 1))
+	appendRange := fmt.Sprintf("%s!%s1:%s1", sheetRef, startCol, endCol)
+	appended, err := svc.Spreadsheets.Values.Append(spreadsheetID, appendRange, &sheets.ValueRange{Values: rows}).
+		ValueInputOption("USER_ENTERED").
+		InsertDataOption("INSERT_ROWS").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to append values: %w", err))
+	}
+	if appended.Updates == nil {
+		return p.PrintError(fmt.Errorf("unexpected empty response from API"))
+	}
+
+	result := map[string]interface{}{
+		"status":        "appended",
+		"spreadsheet":   spreadsheetID,
+		"sheet":         sheetName,
+		"range":         appended.Updates.UpdatedRange,
+		"rows_appended": appended.Updates.UpdatedRows,
+		"columns":       len(rows[0]),
+	}
+	if len(ignored) > 0 {
+		result["ignored_keys"] = ignored
+	}
+	return p.Print(result)
+}
+
+// describeMerges lists a sheet's merges in reading order, each with its A1
+// range and spans.
+func describeMerges(sheet *sheets.Sheet) []map[string]interface{} {
+	merges := append([]*sheets.GridRange(nil), sheet.Merges...)
+	sort.SliceStable(merges, func(i, j int) bool {
+		if merges[i].StartRowIndex != merges[j].StartRowIndex {
+			return merges[i].StartRowIndex < merges[j].StartRowIndex
+		}
+		return merges[i].StartColumnIndex < merges[j].StartColumnIndex
+	})
+
+	entries := make([]map[string]interface{}, 0, len(merges))
+	for _, gr := range merges {
+		entries = append(entries, map[string]interface{}{
+			"sheet":   sheet.Properties.Title,
+			"range":   formatA1Range(sheet.Properties.Title, gr, false),
+			"rows":    gr.EndRowIndex - gr.StartRowIndex,
+			"columns": gr.EndColumnIndex - gr.StartColumnIndex,
+		})
+	}
+	return entries
+}
+
+func runSheetsListMerges(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	sheetName, _ := cmd.Flags().GetString("sheet")
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Sheets()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSheetsListMergesWithService(svc, args[0], sheetName, p)
+}
+
+func runSheetsListMergesWithService(svc *sheets.Service, spreadsheetID, sheetName string, p printer.Printer) error {
+	spreadsheet, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets(properties(sheetId,title),merges)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get spreadsheet: %w", err))
+	}
+
+	selected := spreadsheet.Sheets
+	if sheetName != "" {
+		sheet, err := findSheet(spreadsheet, sheetName)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		selected = []*sheets.Sheet{sheet}
+	}
+
+	merges := make([]map[string]interface{}, 0)
+	for _, sheet := range selected {
+		if sheet.Properties != nil {
+			merges = append(merges, describeMerges(sheet)...)
+		}
+	}
+
+	result := map[string]interface{}{
+		"spreadsheet": spreadsheetID,
+		"merges":      merges,
+		"count":       len(merges),
+	}
+	if sheetName != "" {
+		result["sheet"] = sheetName
+	}
+	return p.Print(result)
+}
@@ -1,14 +1,26 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
 )
 
 // TestSheetsCommands_Flags tests that all sheets commands have expected flags
@@ -1994,7 +2006,7 @@ func TestSheetsAddChartCommand_Flags(t *testing.T) {
 		t.Fatal("add-chart command not found")
 	}
 
-	expectedFlags := []string{"type", "data", "title", "sheet"}
+	expectedFlags := []string{"type", "data", "title", "sheet", "x-axis-title", "y-axis-title", "series-colors", "legend-position"}
 	for _, flag := range expectedFlags {
 		if cmd.Flags().Lookup(flag) == nil {
 			t.Errorf("expected flag '--%s' not found", flag)
@@ -2002,6 +2014,100 @@ func TestSheetsAddChartCommand_Flags(t *testing.T) {
 	}
 }
 
+// TestSheetsUpdateChartCommand_Flags tests update-chart command flags
+func TestSheetsUpdateChartCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "update-chart")
+	if cmd == nil {
+		t.Fatal("update-chart command not found")
+	}
+
+	expectedFlags := []string{"chart-id", "title", "x-axis-title", "y-axis-title", "series-colors", "legend-position"}
+	for _, flag := range expectedFlags {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+}
+
+func TestParseSeriesColors(t *testing.T) {
+	colors, err := parseSeriesColors("#F00, #00ff00")
+	if err != nil {
+		t.Fatalf("parseSeriesColors: %v", err)
+	}
+	if len(colors) != 2 || sheetsColorToHex(colors[0]) != "#FF0000" || sheetsColorToHex(colors[1]) != "#00FF00" {
+		t.Errorf("unexpected colors: %v, %v", sheetsColorToHex(colors[0]), sheetsColorToHex(colors[1]))
+	}
+
+	if _, err := parseSeriesColors("#F00,red"); err == nil {
+		t.Error("expected error for non-hex color")
+	}
+}
+
+func TestNormalizeLegendPosition(t *testing.T) {
+	tests := map[string]string{
+		"bottom":        "BOTTOM_LEGEND",
+		"BOTTOM_LEGEND": "BOTTOM_LEGEND",
+		"none":          "NO_LEGEND",
+		"no_legend":     "NO_LEGEND",
+		"Labeled":       "LABELED_LEGEND",
+	}
+	for in, want := range tests {
+		got, err := normalizeLegendPosition(in)
+		if err != nil || got != want {
+			t.Errorf("normalizeLegendPosition(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := normalizeLegendPosition("middle"); err == nil {
+		t.Error("expected error for unknown legend position")
+	}
+}
+
+func TestApplyChartStyle(t *testing.T) {
+	x, y := "Month", "Revenue"
+	red, _ := parseSheetsHexColor("#FF0000")
+	spec := &sheets.ChartSpec{
+		BasicChart: &sheets.BasicChartSpec{
+			ChartType: "LINE",
+			Axis:      []*sheets.BasicChartAxis{{Position: "LEFT_AXIS", Title: "old"}},
+			Series:    []*sheets.BasicChartSeries{{}, {}},
+		},
+	}
+	err := applyChartStyle(spec, chartStyle{
+		xAxisTitle:     &x,
+		yAxisTitle:     &y,
+		seriesColors:   []*sheets.Color{red},
+		legendPosition: "BOTTOM_LEGEND",
+	})
+	if err != nil {
+		t.Fatalf("applyChartStyle: %v", err)
+	}
+	basic := spec.BasicChart
+	if len(basic.Axis) != 2 || basic.Axis[0].Title != "Revenue" || basic.Axis[1].Position != "BOTTOM_AXIS" || basic.Axis[1].Title != "Month" {
+		t.Errorf("unexpected axes: %+v, %+v", basic.Axis[0], basic.Axis[len(basic.Axis)-1])
+	}
+	if basic.Series[0].ColorStyle == nil || sheetsColorToHex(basic.Series[0].ColorStyle.RgbColor) != "#FF0000" {
+		t.Error("expected first series to be red")
+	}
+	if basic.Series[1].ColorStyle != nil {
+		t.Error("expected second series color to be left alone")
+	}
+	if basic.LegendPosition != "BOTTOM_LEGEND" {
+		t.Errorf("expected BOTTOM_LEGEND, got %s", basic.LegendPosition)
+	}
+
+	if err := applyChartStyle(spec, chartStyle{seriesColors: []*sheets.Color{red, red, red}}); err == nil {
+		t.Error("expected error when there are more colors than series")
+	}
+
+	pie := &sheets.ChartSpec{PieChart: &sheets.PieChartSpec{}}
+	if err := applyChartStyle(pie, chartStyle{legendPosition: "LABELED_LEGEND"}); err != nil || pie.PieChart.LegendPosition != "LABELED_LEGEND" {
+		t.Errorf("expected pie legend to be set, got %v", err)
+	}
+	if err := applyChartStyle(pie, chartStyle{xAxisTitle: &x}); err == nil {
+		t.Error("expected error for axis title on a pie chart")
+	}
+}
+
 // TestSheetsListChartsCommand tests list-charts command
 func TestSheetsListChartsCommand(t *testing.T) {
 	cmd := findSubcommand(sheetsCmd, "list-charts")
@@ -2463,3 +2569,3721 @@ func TestSheetsFreeze_MockServer(t *testing.T) {
 		t.Fatal("server not created")
 	}
 }
+
+func TestSheetsAddRangeDropdownCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "add-range-dropdown")
+	if cmd == nil {
+		t.Fatal("add-range-dropdown command not found")
+	}
+
+	expectedFlags := []string{"source", "relative", "allow-invalid"}
+	for _, flag := range expectedFlags {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+}
+
+func TestColumnIndexToLetter(t *testing.T) {
+	tests := []struct {
+		index int64
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := columnIndexToLetter(tt.index); got != tt.want {
+			t.Errorf("columnIndexToLetter(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+		if back := columnLetterToIndex(tt.want); back != tt.index {
+			t.Errorf("columnLetterToIndex(%q) = %d, want %d", tt.want, back, tt.index)
+		}
+	}
+}
+
+func TestFormatA1Range(t *testing.T) {
+	gr := &sheets.GridRange{StartColumnIndex: 0, StartRowIndex: 0, EndColumnIndex: 1, EndRowIndex: 20}
+
+	tests := []struct {
+		name     string
+		sheet    string
+		absolute bool
+		want     string
+	}{
+		{"absolute", "Lists", true, "Lists!$A$1:$A$20"},
+		{"relative", "Lists", false, "Lists!A1:A20"},
+		{"no sheet", "", true, "$A$1:$A$20"},
+		{"quoted sheet", "My Lists", true, "'My Lists'!$A$1:$A$20"},
+		{"apostrophe", "Bob's", false, "'Bob''s'!A1:A20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatA1Range(tt.sheet, gr, tt.absolute); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSheetsAddRangeDropdown_QuotedSourceSheet(t *testing.T) {
+	var captured sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Form"}},
+				{Properties: &sheets.SheetProperties{SheetId: 7, Title: "My Sheet"}},
+			}})
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&captured)
+		json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsAddRangeDropdownWithService(svc, "test-id", "Form!B2:B10", "'My Sheet'!A1:A5", false, false, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsAddRangeDropdownWithService: %v", err)
+	}
+	if len(captured.Requests) != 1 {
+		t.Fatalf("expected 1 request, got %+v", captured.Requests)
+	}
+	rule := captured.Requests[0].SetDataValidation.Rule
+	if got := rule.Condition.Values[0].UserEnteredValue; got != "='My Sheet'!$A$1:$A$5" {
+		t.Errorf("source formula = %q, want ='My Sheet'!$A$1:$A$5", got)
+	}
+}
+
+func TestSheetsAddRangeDropdown_MockServer(t *testing.T) {
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"spreadsheetId": "test-id",
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"sheetId": 0, "title": "Form"}},
+					{"properties": map[string]interface{}{"sheetId": 7, "title": "Lists"}},
+				},
+			})
+			return
+		}
+		if r.Method == "POST" && strings.Contains(r.URL.Path, ":batchUpdate") {
+			json.NewDecoder(r.Body).Decode(&captured)
+			json.NewEncoder(w).Encode(map[string]interface{}{"spreadsheetId": "test-id"})
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	_, target, err := parseRange(svc, "test-id", "Form!B2:B10")
+	if err != nil {
+		t.Fatalf("parseRange target: %v", err)
+	}
+	_, source, err := parseRange(svc, "test-id", "Lists!A1:A20")
+	if err != nil {
+		t.Fatalf("parseRange source: %v", err)
+	}
+	if source.SheetId != 7 {
+		t.Errorf("expected source sheet ID 7, got %d", source.SheetId)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate("test-id", &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			SetDataValidation: &sheets.SetDataValidationRequest{
+				Range: target,
+				Rule:  buildRangeDropdownRule(formatA1Range("Lists", source, true), false),
+			},
+		}},
+	}).Do()
+	if err != nil {
+		t.Fatalf("batch update failed: %v", err)
+	}
+
+	req := captured["requests"].([]interface{})[0].(map[string]interface{})
+	rule := req["setDataValidation"].(map[string]interface{})["rule"].(map[string]interface{})
+	cond := rule["condition"].(map[string]interface{})
+	if cond["type"] != "ONE_OF_RANGE" {
+		t.Errorf("expected ONE_OF_RANGE, got %v", cond["type"])
+	}
+	value := cond["values"].([]interface{})[0].(map[string]interface{})["userEnteredValue"]
+	if value != "=Lists!$A$1:$A$20" {
+		t.Errorf("unexpected source formula: %v", value)
+	}
+	if strict, ok := rule["strict"]; !ok || strict != false {
+		t.Errorf("expected strict=false to be sent, got %v", rule["strict"])
+	}
+}
+
+func TestBuildValidationRule(t *testing.T) {
+	num := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name    string
+		opts    validationOptions
+		want    []string
+		wantErr string
+	}{
+		{"list", validationOptions{Type: "ONE_OF_LIST", Values: []string{"Open", " Done ", ""}, ShowDropdown: true}, []string{"Open", "Done"}, ""},
+		{"number", validationOptions{Type: "NUMBER_BETWEEN", Min: num(0), Max: num(12.5)}, []string{"0", "12.5"}, ""},
+		{"text", validationOptions{Type: "TEXT_CONTAINS", Text: "@"}, []string{"@"}, ""},
+		{"formula gets =", validationOptions{Type: "CUSTOM_FORMULA", Formula: "B2>0"}, []string{"=B2>0"}, ""},
+		{"list without values", validationOptions{Type: "ONE_OF_LIST"}, nil, "requires --values"},
+		{"number missing max", validationOptions{Type: "NUMBER_BETWEEN", Min: num(1)}, nil, "requires --min and --max"},
+		{"min above max", validationOptions{Type: "NUMBER_BETWEEN", Min: num(5), Max: num(1)}, nil, "must not be greater"},
+		{"unused flag", validationOptions{Type: "TEXT_CONTAINS", Text: "x", Values: []string{"a"}}, nil, "--values only applies to --type ONE_OF_LIST"},
+		{"dropdown on number", validationOptions{Type: "NUMBER_BETWEEN", Min: num(0), Max: num(1), ShowDropdown: true}, nil, "--show-dropdown only applies"},
+		{"unknown type", validationOptions{Type: "DATE_AFTER"}, nil, "invalid --type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := buildValidationRule(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, v := range rule.Condition.Values {
+				got = append(got, v.UserEnteredValue)
+			}
+			if rule.Condition.Type != tt.opts.Type || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("condition = %s %v, want %s %v", rule.Condition.Type, got, tt.opts.Type, tt.want)
+			}
+			if rule.ShowCustomUi != tt.opts.ShowDropdown {
+				t.Errorf("ShowCustomUi = %v, want %v", rule.ShowCustomUi, tt.opts.ShowDropdown)
+			}
+		})
+	}
+}
+
+func TestSheetsAddAndClearValidation(t *testing.T) {
+	var captured []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"sheetId": 4, "title": "Form"}},
+				},
+			})
+			return
+		}
+		if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/v4/spreadsheets/test-id:batchUpdate") {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			captured = append(captured, body)
+			json.NewEncoder(w).Encode(map[string]interface{}{"spreadsheetId": "test-id"})
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	rule, err := buildValidationRule(validationOptions{Type: "ONE_OF_LIST", Values: []string{"Open", "Done"}, Strict: true, ShowDropdown: true})
+	if err != nil {
+		t.Fatalf("buildValidationRule: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := runSheetsAddValidationWithService(svc, "test-id", "Form!B2:B10", rule, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("add-validation failed: %v", err)
+	}
+	if err := runSheetsClearValidationWithService(svc, "test-id", "Form!B2:B10", printer.New(&bytes.Buffer{}, "json")); err != nil {
+		t.Fatalf("clear-validation failed: %v", err)
+	}
+	if len(captured) != 2 {
+		t.Fatalf("expected 2 batch updates, got %d", len(captured))
+	}
+
+	set := captured[0]["requests"].([]interface{})[0].(map[string]interface{})["setDataValidation"].(map[string]interface{})
+	gr := set["range"].(map[string]interface{})
+	if gr["sheetId"] != float64(4) || gr["startRowIndex"] != float64(1) || gr["endRowIndex"] != float64(10) || gr["startColumnIndex"] != float64(1) {
+		t.Errorf("unexpected grid range: %v", gr)
+	}
+	sent := set["rule"].(map[string]interface{})
+	if sent["strict"] != true || sent["showCustomUi"] != true {
+		t.Errorf("expected strict and dropdown, got %v", sent)
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["condition_type"] != "ONE_OF_LIST" || out["status"] != "added" {
+		t.Errorf("unexpected output: %v", out)
+	}
+
+	clear := captured[1]["requests"].([]interface{})[0].(map[string]interface{})["setDataValidation"].(map[string]interface{})
+	if _, hasRule := clear["rule"]; hasRule {
+		t.Errorf("expected clear-validation to send no rule, got %v", clear["rule"])
+	}
+	if clear["range"] == nil {
+		t.Error("expected clear-validation to send the range")
+	}
+}
+
+func TestSheetsLinkRangeCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "link-range")
+	if cmd == nil {
+		t.Fatal("link-range command not found")
+	}
+
+	expectedFlags := []string{"dst-cell", "src-id", "src-range", "query"}
+	for _, flag := range expectedFlags {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+}
+
+func TestBuildImportRangeFormula(t *testing.T) {
+	tests := []struct {
+		name     string
+		srcID    string
+		srcRange string
+		query    string
+		want     string
+	}{
+		{
+			name:     "plain import",
+			srcID:    "abc123",
+			srcRange: "Sheet1!A1:D",
+			want:     `=IMPORTRANGE("abc123","Sheet1!A1:D")`,
+		},
+		{
+			name:     "wrapped in query",
+			srcID:    "abc123",
+			srcRange: "Data!A:C",
+			query:    "select Col1, Col3",
+			want:     `=QUERY(IMPORTRANGE("abc123","Data!A:C"),"select Col1, Col3")`,
+		},
+		{
+			name:     "quotes are escaped",
+			srcID:    "abc123",
+			srcRange: "Data!A:C",
+			query:    `select Col1 where Col2 = "open"`,
+			want:     `=QUERY(IMPORTRANGE("abc123","Data!A:C"),"select Col1 where Col2 = ""open""")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildImportRangeFormula(tt.srcID, tt.srcRange, tt.query)
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSheetsLinkRange_InvalidDstCell(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "link-range")
+	cmd.Flags().Set("dst-cell", "Summary!A1:B2")
+	cmd.Flags().Set("src-id", "abc123")
+	cmd.Flags().Set("src-range", "Sheet1!A1:D")
+	defer cmd.Flags().Set("dst-cell", "")
+
+	err := cmd.RunE(cmd, []string{"dst-id"})
+	if err == nil || !strings.Contains(err.Error(), "single cell") {
+		t.Errorf("expected single-cell validation error, got %v", err)
+	}
+}
+
+func TestSplitA1Range(t *testing.T) {
+	tests := []struct {
+		in        string
+		sheet     string
+		start     a1Corner
+		end       a1Corner
+		expectErr bool
+	}{
+		{in: "Sheet1!A1:D10", sheet: "Sheet1", start: a1Corner{"A", 1}, end: a1Corner{"D", 10}},
+		{in: "Sheet1!A:D", sheet: "Sheet1", start: a1Corner{"A", 0}, end: a1Corner{"D", 0}},
+		{in: "'My Data'!B2:C", sheet: "My Data", start: a1Corner{"B", 2}, end: a1Corner{"C", 0}},
+		{in: "Sheet1", sheet: "Sheet1"},
+		{in: "A1:D10", start: a1Corner{"A", 1}, end: a1Corner{"D", 10}},
+		{in: "Sheet1!5:100", sheet: "Sheet1", start: a1Corner{"", 5}, end: a1Corner{"", 100}},
+		{in: "Sheet1!$A$1:$B$2", sheet: "Sheet1", start: a1Corner{"A", 1}, end: a1Corner{"B", 2}},
+		{in: "Sheet1!A1:B2:C3", expectErr: true},
+		{in: "Sheet1!A1x:B2", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			sheet, start, end, err := splitA1Range(tt.in)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sheet != tt.sheet || start != tt.start || end != tt.end {
+				t.Errorf("got (%q, %+v, %+v), want (%q, %+v, %+v)", sheet, start, end, tt.sheet, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestReadSheetPages_MockServer(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v4/spreadsheets/test-id" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"sheetId": 0, "title": "Data", "gridProperties": map[string]interface{}{"rowCount": 5}}},
+				},
+			})
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/v4/spreadsheets/test-id/values/") {
+			rng := strings.TrimPrefix(r.URL.Path, "/v4/spreadsheets/test-id/values/")
+			requested = append(requested, rng)
+			values := map[string][][]interface{}{
+				"Data!A1:B1": {{"name", "score"}},
+				"Data!A2:B3": {{"a", "1"}, {"b", "2"}},
+				"Data!A4:B5": {{"c", "3"}},
+			}[rng]
+			json.NewEncoder(w).Encode(map[string]interface{}{"range": rng, "values": values})
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var pages []sheetPage
+	headers, err := readSheetPages(svc, "test-id", "Data!A:B", pagedReadOptions{PageRows: 2, Headers: true}, func(page sheetPage) error {
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readSheetPages failed: %v", err)
+	}
+
+	if strings.Join(headers, ",") != "name,score" {
+		t.Errorf("expected headers [name score], got %v", headers)
+	}
+	wantRanges := []string{"Data!A1:B1", "Data!A2:B3", "Data!A4:B5"}
+	if strings.Join(requested, " ") != strings.Join(wantRanges, " ") {
+		t.Errorf("expected requests %v, got %v", wantRanges, requested)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	if pages[1].StartRow != 4 || pages[1].EndRow != 5 || len(pages[1].Values) != 1 {
+		t.Errorf("unexpected second page: %+v", pages[1])
+	}
+	if len(pages[0].Headers) != 2 {
+		t.Errorf("expected headers carried on each page, got %v", pages[0].Headers)
+	}
+
+	// Resuming with --start-row skips earlier windows.
+	requested = nil
+	pages = nil
+	_, err = readSheetPages(svc, "test-id", "Data!A:B", pagedReadOptions{PageRows: 2, StartRow: 4}, func(page sheetPage) error {
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readSheetPages with start row failed: %v", err)
+	}
+	if len(requested) != 1 || requested[0] != "Data!A4:B5" {
+		t.Errorf("expected only Data!A4:B5, got %v", requested)
+	}
+}
+
+func TestReadSheetPages_PadsTrailingBlankRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v4/spreadsheets/test-id" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"sheetId": 0, "title": "Data", "gridProperties": map[string]interface{}{"rowCount": 6}}},
+				},
+			})
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/v4/spreadsheets/test-id/values/") {
+			rng := strings.TrimPrefix(r.URL.Path, "/v4/spreadsheets/test-id/values/")
+			// Rows 2 and 3 are blank, so the first page comes back short.
+			values := map[string][][]interface{}{
+				"Data!A1:B3": {{"a", "1"}},
+				"Data!A4:B6": {{"d", "4"}},
+			}[rng]
+			json.NewEncoder(w).Encode(map[string]interface{}{"range": rng, "values": values})
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var rows [][]interface{}
+	_, err = readSheetPages(svc, "test-id", "Data!A:B", pagedReadOptions{PageRows: 3}, func(page sheetPage) error {
+		rows = append(rows, page.Values...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readSheetPages failed: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows (first page padded to 3), got %d: %v", len(rows), rows)
+	}
+	if len(rows[1]) != 0 || len(rows[2]) != 0 || rows[3][0] != "d" {
+		t.Errorf("expected row 4 to stay at index 3 after two blank rows, got %v", rows)
+	}
+}
+
+func TestSheetsRead_RenderOptions(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v4/spreadsheets/test-id/values/") {
+			queries = append(queries, r.URL.Query())
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"range": "Data!A1:B3",
+				"values": [][]interface{}{
+					{"=UPPER(\"item\")", 2024},
+					{"pens", "=B3*2"},
+					{"ink", 45292},
+				},
+			})
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	render := renderOptions{Value: "FORMULA", Date: "FORMATTED_STRING"}
+	var buf bytes.Buffer
+	if err := runSheetsReadWithService(svc, "test-id", "Data!A1:B3", "json", true, render, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(queries) != 1 || queries[0].Get("valueRenderOption") != "FORMULA" || queries[0].Get("dateTimeRenderOption") != "FORMATTED_STRING" {
+		t.Fatalf("expected render options on the request, got %v", queries)
+	}
+
+	var out struct {
+		Headers []string                 `json:"headers"`
+		Data    []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v\n%s", err, buf.String())
+	}
+	if strings.Join(out.Headers, ",") != `=UPPER("item"),2024` {
+		t.Errorf("expected the first returned row verbatim as headers, got %v", out.Headers)
+	}
+	if len(out.Data) != 2 || out.Data[0]["2024"] != "=B3*2" {
+		t.Errorf("expected formula cells in data, got %v", out.Data)
+	}
+
+	// The default leaves the date option to the API.
+	queries = nil
+	buf.Reset()
+	if err := runSheetsReadWithService(svc, "test-id", "Data!A1:B3", "json", false, renderOptions{Value: "FORMATTED_VALUE"}, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if queries[0].Has("dateTimeRenderOption") {
+		t.Errorf("expected no dateTimeRenderOption by default, got %v", queries[0])
+	}
+}
+
+func TestSheetsRead_RenderFlagValidation(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "read")
+	for _, tt := range []struct{ flag, value, want string }{
+		{"value-render", "RAW", "invalid --value-render"},
+		{"date-render", "ISO", "invalid --date-render"},
+	} {
+		def := cmd.Flags().Lookup(tt.flag).DefValue
+		cmd.Flags().Set(tt.flag, tt.value)
+		err := cmd.RunE(cmd, []string{"id", "Sheet1!A:B"})
+		cmd.Flags().Set(tt.flag, def)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("--%s %s: expected %q error, got %v", tt.flag, tt.value, tt.want, err)
+		}
+	}
+}
+
+func TestSheetsRead_PagingFlagValidation(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "read")
+	cmd.Flags().Set("stream", "true")
+	defer cmd.Flags().Set("stream", "false")
+
+	err := cmd.RunE(cmd, []string{"id", "Sheet1!A:B"})
+	if err == nil || !strings.Contains(err.Error(), "require --page-rows") {
+		t.Errorf("expected --page-rows requirement error, got %v", err)
+	}
+}
+
+func TestSheetsRead_StartRowBoundary(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "read")
+	flag := cmd.Flags().Lookup("start-row")
+	defer func() {
+		flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	}()
+
+	cmd.Flags().Set("start-row", "0")
+	err := cmd.RunE(cmd, []string{"id", "Sheet1!A:B"})
+	if err == nil || !strings.Contains(err.Error(), "--start-row must be 1 or greater") {
+		t.Errorf("--start-row 0: expected range error, got %v", err)
+	}
+
+	// Row 1 is valid, so the next check (paging required) is what fails.
+	cmd.Flags().Set("start-row", "1")
+	err = cmd.RunE(cmd, []string{"id", "Sheet1!A:B"})
+	if err == nil || !strings.Contains(err.Error(), "require --page-rows") {
+		t.Errorf("--start-row 1: expected --page-rows requirement error, got %v", err)
+	}
+}
+
+func TestSheetsFormatAsTableCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "format-as-table")
+	if cmd == nil {
+		t.Fatal("format-as-table command not found")
+	}
+
+	expectedFlags := []string{"header-bold", "header-bg", "header-color", "banded", "band-color", "no-freeze"}
+	for _, flag := range expectedFlags {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+}
+
+func TestBuildTableStyleRequests(t *testing.T) {
+	gr := &sheets.GridRange{SheetId: 7, StartRowIndex: 2, EndRowIndex: 20, StartColumnIndex: 0, EndColumnIndex: 5}
+	requests, err := buildTableStyleRequests(7, gr, tableStyleOptions{
+		HeaderBold:  true,
+		HeaderBg:    "#4285F4",
+		HeaderColor: "#FFFFFF",
+		Banded:      true,
+		BandColor:   "#F3F3F3",
+		Freeze:      true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests (format, banding, freeze), got %d", len(requests))
+	}
+
+	repeat := requests[0].RepeatCell
+	if repeat == nil {
+		t.Fatal("expected RepeatCell header request first")
+	}
+	if repeat.Range.StartRowIndex != 2 || repeat.Range.EndRowIndex != 3 {
+		t.Errorf("header range should cover only row index 2, got %d-%d", repeat.Range.StartRowIndex, repeat.Range.EndRowIndex)
+	}
+	if !repeat.Cell.UserEnteredFormat.TextFormat.Bold {
+		t.Error("expected bold header")
+	}
+	for _, f := range []string{"textFormat.bold", "textFormat.foregroundColorStyle", "backgroundColorStyle"} {
+		if !strings.Contains(repeat.Fields, f) {
+			t.Errorf("expected field mask to contain %s, got %s", f, repeat.Fields)
+		}
+	}
+
+	banding := requests[1].AddBanding
+	if banding == nil || banding.BandedRange.RowProperties.HeaderColorStyle == nil {
+		t.Fatalf("expected AddBanding with header color, got %+v", requests[1])
+	}
+
+	freeze := requests[2].UpdateSheetProperties
+	if freeze == nil || freeze.Properties.GridProperties.FrozenRowCount != 3 {
+		t.Errorf("expected 3 frozen rows (through the header), got %+v", requests[2])
+	}
+}
+
+func TestBuildTableStyleRequests_FreezeOnly(t *testing.T) {
+	gr := &sheets.GridRange{StartRowIndex: 0, EndRowIndex: 10, EndColumnIndex: 3}
+	requests, err := buildTableStyleRequests(0, gr, tableStyleOptions{Freeze: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 1 || requests[0].UpdateSheetProperties == nil {
+		t.Errorf("expected only a freeze request, got %d requests", len(requests))
+	}
+}
+
+func TestSheetsFormulasCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "formulas")
+	if cmd == nil {
+		t.Fatal("formulas command not found")
+	}
+	if cmd.Flags().Lookup("contains") == nil {
+		t.Error("expected flag '--contains' not found")
+	}
+}
+
+func TestExtractFormulas(t *testing.T) {
+	values := [][]interface{}{
+		{"Name", "Total", "=SUM(B3:B10)"},
+		{},
+		{"a", float64(5), "=VLOOKUP(A3, Data!A:B, 2, FALSE)"},
+	}
+
+	formulas, err := extractFormulas("'Q1 Report'!B2:D4", values, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(formulas) != 2 {
+		t.Fatalf("expected 2 formulas, got %d: %v", len(formulas), formulas)
+	}
+	if formulas[0]["cell"] != "D2" || formulas[0]["formula"] != "=SUM(B3:B10)" {
+		t.Errorf("unexpected first formula: %v", formulas[0])
+	}
+	if formulas[1]["cell"] != "D4" {
+		t.Errorf("expected second formula at D4, got %v", formulas[1]["cell"])
+	}
+
+	filtered, err := extractFormulas("Sheet1!A1:C3", values, "vlookup")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0]["cell"] != "C3" {
+		t.Errorf("expected only the VLOOKUP formula at C3, got %v", filtered)
+	}
+}
+
+func TestSheetsLockHeaderCommand_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "lock-header")
+	if cmd == nil {
+		t.Fatal("lock-header command not found")
+	}
+	for _, flag := range []string{"sheet", "rows", "description"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+	if def := cmd.Flags().Lookup("rows").DefValue; def != "1" {
+		t.Errorf("expected --rows default '1', got %q", def)
+	}
+}
+
+func TestBuildLockHeaderRequests(t *testing.T) {
+	requests := buildLockHeaderRequests(42, 2, "Header row")
+	if len(requests) != 2 {
+		t.Fatalf("expected freeze and protect requests, got %d", len(requests))
+	}
+
+	freeze := requests[0].UpdateSheetProperties
+	if freeze == nil || freeze.Properties.SheetId != 42 || freeze.Properties.GridProperties.FrozenRowCount != 2 {
+		t.Errorf("unexpected freeze request: %+v", requests[0])
+	}
+	if freeze.Fields != "gridProperties.frozenRowCount" {
+		t.Errorf("unexpected field mask %q", freeze.Fields)
+	}
+
+	protect := requests[1].AddProtectedRange
+	if protect == nil {
+		t.Fatal("expected AddProtectedRange request")
+	}
+	pr := protect.ProtectedRange
+	if !pr.WarningOnly {
+		t.Error("expected warning-only protection")
+	}
+	if pr.Range.SheetId != 42 || pr.Range.StartRowIndex != 0 || pr.Range.EndRowIndex != 2 {
+		t.Errorf("expected protection over rows 0-2 of sheet 42, got %+v", pr.Range)
+	}
+}
+
+func TestSheetsA1Command_Flags(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "a1")
+	if cmd == nil {
+		t.Fatal("a1 command not found")
+	}
+	for _, flag := range []string{"to-index", "to-a1"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' not found", flag)
+		}
+	}
+}
+
+func TestA1ToIndex(t *testing.T) {
+	tests := []struct {
+		ref     string
+		col     int64
+		row     int64 // -1 when the result has no row
+		wantErr bool
+	}{
+		{"A1", 0, 0, false},
+		{"b3", 1, 2, false},
+		{"$AA$10", 26, 9, false},
+		{"AA", 26, -1, false},
+		{"12", 0, 0, true},
+		{"A0", 0, 0, true},
+		{"A1B", 0, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := a1ToIndex(tt.ref)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("a1ToIndex(%q): expected error, got %v", tt.ref, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("a1ToIndex(%q): unexpected error: %v", tt.ref, err)
+			continue
+		}
+		if got["column_index"] != tt.col {
+			t.Errorf("a1ToIndex(%q): column_index = %v, want %d", tt.ref, got["column_index"], tt.col)
+		}
+		if tt.row == -1 {
+			if _, ok := got["row_index"]; ok {
+				t.Errorf("a1ToIndex(%q): expected no row_index, got %v", tt.ref, got["row_index"])
+			}
+		} else if got["row_index"] != tt.row {
+			t.Errorf("a1ToIndex(%q): row_index = %v, want %d", tt.ref, got["row_index"], tt.row)
+		}
+	}
+}
+
+func TestIndexToA1(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"2,3", "C4", false},
+		{"0, 0", "A1", false},
+		{"26,9", "AA10", false},
+		{"27", "AB", false},
+		{"-1,0", "", true},
+		{"a,1", "", true},
+		{"1,2,3", "", true},
+	}
+	for _, tt := range tests {
+		got, err := indexToA1(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("indexToA1(%q): expected error, got %v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("indexToA1(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		key := "ref"
+		if !strings.Contains(tt.in, ",") {
+			key = "column"
+		}
+		if got[key] != tt.want {
+			t.Errorf("indexToA1(%q): %s = %v, want %q", tt.in, key, got[key], tt.want)
+		}
+	}
+}
+
+func TestSheetsFreezeValuesCommand(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "freeze-values")
+	if cmd == nil {
+		t.Fatal("freeze-values command not found")
+	}
+	if err := cmd.Args(cmd, []string{"id"}); err == nil {
+		t.Error("expected error with only one argument")
+	}
+	if err := cmd.Args(cmd, []string{"id", "Sheet1!A1:B2"}); err != nil {
+		t.Errorf("unexpected error with two arguments: %v", err)
+	}
+}
+
+func TestBuildFreezeValuesRequest(t *testing.T) {
+	gr := &sheets.GridRange{SheetId: 7, StartRowIndex: 1, EndRowIndex: 10, StartColumnIndex: 0, EndColumnIndex: 4}
+	req := buildFreezeValuesRequest(gr)
+
+	cp := req.CopyPaste
+	if cp == nil {
+		t.Fatal("expected CopyPaste request")
+	}
+	if cp.Source != gr || cp.Destination != gr {
+		t.Error("expected source and destination to be the same range")
+	}
+	if cp.PasteType != "PASTE_VALUES" {
+		t.Errorf("expected PASTE_VALUES, got %q", cp.PasteType)
+	}
+}
+
+func TestSheetsColorToHex(t *testing.T) {
+	for _, hex := range []string{"#000000", "#FFFFFF", "#4285F4", "#F3F3F3"} {
+		c, err := parseSheetsHexColor(hex)
+		if err != nil {
+			t.Fatalf("parseSheetsHexColor(%s): %v", hex, err)
+		}
+		if got := sheetsColorToHex(c); got != hex {
+			t.Errorf("round trip %s -> %s", hex, got)
+		}
+	}
+	if got := sheetsColorToHex(nil); got != "" {
+		t.Errorf("expected empty string for nil color, got %q", got)
+	}
+}
+
+func TestCellStyleCSS(t *testing.T) {
+	f := &sheets.CellFormat{
+		TextFormat: &sheets.TextFormat{
+			Bold:                 true,
+			Underline:            true,
+			Strikethrough:        true,
+			ForegroundColorStyle: &sheets.ColorStyle{RgbColor: &sheets.Color{Red: 1}},
+		},
+		BackgroundColor:     &sheets.Color{Red: 1, Green: 1, Blue: 1},
+		HorizontalAlignment: "CENTER",
+	}
+	want := "font-weight:bold;text-decoration:underline line-through;color:#FF0000;text-align:center"
+	if got := cellStyleCSS(f); got != want {
+		t.Errorf("cellStyleCSS = %q, want %q", got, want)
+	}
+
+	plain := &sheets.CellFormat{
+		TextFormat:      &sheets.TextFormat{ForegroundColor: &sheets.Color{}},
+		BackgroundColor: &sheets.Color{Red: 1, Green: 1, Blue: 1},
+	}
+	if got := cellStyleCSS(plain); got != "" {
+		t.Errorf("expected defaults to produce no CSS, got %q", got)
+	}
+}
+
+func TestRenderHTMLTable(t *testing.T) {
+	rows := []*sheets.RowData{
+		{Values: []*sheets.CellData{
+			{FormattedValue: "Name", EffectiveFormat: &sheets.CellFormat{TextFormat: &sheets.TextFormat{Bold: true}}},
+			{FormattedValue: "Notes"},
+		}},
+		{Values: []*sheets.CellData{{FormattedValue: "<b>A&B</b>"}}},
+	}
+
+	out, columns := renderHTMLTable(rows, false, true)
+	if columns != 2 {
+		t.Errorf("expected 2 columns, got %d", columns)
+	}
+	want := "<table>\n  <tr><th>Name</th><th>Notes</th></tr>\n  <tr><td>&lt;b&gt;A&amp;B&lt;/b&gt;</td><td></td></tr>\n</table>\n"
+	if out != want {
+		t.Errorf("unexpected HTML:\n%s\nwant:\n%s", out, want)
+	}
+
+	styled, _ := renderHTMLTable(rows, true, false)
+	if !strings.Contains(styled, `<td style="font-weight:bold">Name</td>`) {
+		t.Errorf("expected inline bold style, got:\n%s", styled)
+	}
+}
+
+func TestInferNumberFormatType(t *testing.T) {
+	tests := map[string]string{
+		"yyyy-mm-dd":       "DATE",
+		"dd/mm/yyyy hh:mm": "DATE_TIME",
+		"hh:mm:ss":         "TIME",
+		"0.00%":            "PERCENT",
+		"$#,##0.00":        "CURRENCY",
+		"0.00E+00":         "SCIENTIFIC",
+		"#,##0":            "NUMBER",
+		`0 "days"`:         "NUMBER",
+		"@":                "TEXT",
+
+		"$#,##0.00;[Red]($#,##0.00)": "CURRENCY",
+		"0.00%;[Red]-0.00%":          "PERCENT",
+		"[$USD] #,##0.00":            "CURRENCY",
+		"[$-409]#,##0":               "NUMBER",
+		"[h]:mm:ss":                  "TIME",
+		"[Blue][>=1000]#,##0":        "NUMBER",
+	}
+	for pattern, want := range tests {
+		if got := inferNumberFormatType(pattern); got != want {
+			t.Errorf("inferNumberFormatType(%q) = %s, want %s", pattern, got, want)
+		}
+	}
+}
+
+func TestBuildDefaultFormatRequest(t *testing.T) {
+	req := buildDefaultFormatRequest(9, 1, 1, "DATE", "yyyy-mm-dd")
+	rc := req.RepeatCell
+	if rc == nil {
+		t.Fatal("expected RepeatCell request")
+	}
+	gr := rc.Range
+	if gr.SheetId != 9 || gr.StartColumnIndex != 1 || gr.EndColumnIndex != 2 || gr.StartRowIndex != 1 {
+		t.Errorf("unexpected range: %+v", gr)
+	}
+	if gr.EndRowIndex != 0 {
+		t.Errorf("expected an unbounded end row, got %d", gr.EndRowIndex)
+	}
+	nf := rc.Cell.UserEnteredFormat.NumberFormat
+	if nf.Type != "DATE" || nf.Pattern != "yyyy-mm-dd" || rc.Fields != "userEnteredFormat.numberFormat" {
+		t.Errorf("unexpected format %+v / fields %q", nf, rc.Fields)
+	}
+}
+
+func TestSheetsSetDefaultFormat_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"bad column", map[string]string{"col": "B2"}, "invalid --col"},
+		{"bad type", map[string]string{"type": "money"}, "invalid --type"},
+		{"negative skip", map[string]string{"skip-rows": "-1"}, "--skip-rows must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "set-default-format", RunE: runSheetsSetDefaultFormat}
+			cmd.Flags().String("sheet", "Sheet1", "")
+			cmd.Flags().String("col", "B", "")
+			cmd.Flags().String("number-format", "yyyy-mm-dd", "")
+			cmd.Flags().String("type", "", "")
+			cmd.Flags().Int64("skip-rows", 0, "")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, []string{"id"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSheetsNumberFormat_SendsRepeatCell(t *testing.T) {
+	var captured map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"sheetId": 7, "title": "Orders"}},
+				},
+			})
+			return
+		}
+		if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/v4/spreadsheets/test-id:batchUpdate") {
+			json.NewDecoder(r.Body).Decode(&captured)
+			json.NewEncoder(w).Encode(map[string]interface{}{"spreadsheetId": "test-id"})
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsNumberFormatWithService(svc, "test-id", "Orders!B2:C10", "CURRENCY", "$#,##0.00", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("number-format failed: %v", err)
+	}
+
+	rc := captured["requests"].([]interface{})[0].(map[string]interface{})["repeatCell"].(map[string]interface{})
+	if rc["fields"] != "userEnteredFormat.numberFormat" {
+		t.Errorf("unexpected fields %v", rc["fields"])
+	}
+	gr := rc["range"].(map[string]interface{})
+	if gr["sheetId"] != float64(7) || gr["startRowIndex"] != float64(1) || gr["endRowIndex"] != float64(10) ||
+		gr["startColumnIndex"] != float64(1) || gr["endColumnIndex"] != float64(3) {
+		t.Errorf("unexpected grid range: %v", gr)
+	}
+	nf := rc["cell"].(map[string]interface{})["userEnteredFormat"].(map[string]interface{})["numberFormat"].(map[string]interface{})
+	if nf["type"] != "CURRENCY" || nf["pattern"] != "$#,##0.00" {
+		t.Errorf("unexpected number format: %v", nf)
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["status"] != "formatted" || out["type"] != "CURRENCY" || out["pattern"] != "$#,##0.00" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsNumberFormat_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"no type or pattern", map[string]string{}, "specify --type, --pattern, or both"},
+		{"bad type", map[string]string{"type": "money"}, "invalid --type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "number-format", RunE: runSheetsNumberFormat}
+			cmd.Flags().String("type", "", "")
+			cmd.Flags().String("pattern", "", "")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, []string{"id", "A1:B2"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSheetsCommentsCommand_Subcommands(t *testing.T) {
+	if findSubcommand(sheetsCommentsCmd, "list") == nil || findSubcommand(sheetsCommentsCmd, "add") == nil {
+		t.Fatal("expected comments list and add subcommands")
+	}
+	for _, flag := range []string{"text", "anchor"} {
+		if sheetsCommentsAddCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' on comments add", flag)
+		}
+	}
+	if len(sheetsCommentsCmd.Aliases) != 1 || sheetsCommentsCmd.Aliases[0] != "comment" {
+		t.Error("expected 'comment' alias on comments")
+	}
+}
+
+func TestSheetsCommentsList_SkipsResolved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/sheet-1/comments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(&drive.CommentList{Comments: []*drive.Comment{
+			{
+				Id: "c1", Content: "please verify", Anchor: "Sheet1!B2",
+				Author:  &drive.User{DisplayName: "Dana", EmailAddress: "dana@example.com"},
+				Replies: []*drive.Reply{{Id: "r1", Content: "done", Action: "resolve"}},
+			},
+			{Id: "c2", Content: "old", Resolved: true},
+		}})
+	}))
+	defer server.Close()
+
+	svc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsCommentsListWithService(svc, "sheet-1", 100, false, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsCommentsListWithService: %v", err)
+	}
+
+	var out struct {
+		Count    int                      `json:"count"`
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Count != 1 || len(out.Comments) != 1 {
+		t.Fatalf("expected 1 unresolved comment, got %d", out.Count)
+	}
+	c := out.Comments[0]
+	if c["text"] != "please verify" || c["anchor"] != "Sheet1!B2" || c["resolved"] != false {
+		t.Errorf("unexpected comment: %v", c)
+	}
+	if author, _ := c["author"].(map[string]interface{}); author["email"] != "dana@example.com" {
+		t.Errorf("unexpected author: %v", c["author"])
+	}
+	if replies, _ := c["replies"].([]interface{}); len(replies) != 1 {
+		t.Errorf("expected 1 reply, got %v", c["replies"])
+	}
+}
+
+func TestSheetsCommentsAdd_SendsAnchor(t *testing.T) {
+	var sent drive.Comment
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/files/sheet-1/comments" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		sent.Id = "c9"
+		json.NewEncoder(w).Encode(&sent)
+	}))
+	defer server.Close()
+
+	svc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsCommentsAddWithService(svc, "sheet-1", "please verify", "Sheet1!B2", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsCommentsAddWithService: %v", err)
+	}
+	if sent.Content != "please verify" || sent.Anchor != "Sheet1!B2" {
+		t.Errorf("unexpected request body: %+v", sent)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["status"] != "created" || out["id"] != "c9" || out["anchor"] != "Sheet1!B2" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestParseLooseNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"42", 42, true},
+		{" 1,234.50 ", 1234.5, true},
+		{"$1,000", 1000, true},
+		{"-€12.5", -12.5, true},
+		{"(300)", -300, true},
+		{"12.5%", 0.125, true},
+		{"1e3", 1000, true},
+		{"n/a", 0, false},
+		{"NaN", 0, false},
+		{"$", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLooseNumber(tt.in)
+		if ok != tt.ok || (ok && math.Abs(got-tt.want) > 1e-9) {
+			t.Errorf("parseLooseNumber(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseLooseDate(t *testing.T) {
+	tests := []struct {
+		in       string
+		dayFirst bool
+		want     string
+		ok       bool
+	}{
+		{"2026-10-17", false, "2026-10-17", true},
+		{"2026-10-17 09:30", false, "2026-10-17 09:30:00", true},
+		{"Oct 17, 2026", false, "2026-10-17", true},
+		{"17-Oct-2026", false, "2026-10-17", true},
+		{"10/17/2026", false, "2026-10-17", true},
+		{"17/10/2026", true, "2026-10-17", true},
+		{"17/10/2026", false, "", false},
+		{"soon", false, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLooseDate(tt.in, tt.dayFirst)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseLooseDate(%q, %v) = %q, %v; want %q, %v", tt.in, tt.dayFirst, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetypeValues(t *testing.T) {
+	values := [][]interface{}{
+		{"1,200", "=SUM(A1:A2)", float64(7)},
+		{"", "abc", "$3"},
+	}
+	converted, skipped, unchanged := retypeValues(values, "number", false)
+	if len(converted) != 2 || converted[0] != (retypeCell{Row: 0, Col: 0, Value: float64(1200)}) || converted[1] != (retypeCell{Row: 1, Col: 2, Value: float64(3)}) {
+		t.Errorf("unexpected converted cells: %+v", converted)
+	}
+	if len(skipped) != 1 || skipped[0] != [2]int{1, 1} {
+		t.Errorf("unexpected skipped cells: %v", skipped)
+	}
+	if unchanged != 3 {
+		t.Errorf("expected 3 unchanged cells (formula, number, empty), got %d", unchanged)
+	}
+}
+
+func TestCleanValues(t *testing.T) {
+	values := [][]interface{}{
+		{"  Acme   Corp ", " $1,200 ", "=A1", float64(7)},
+		{"17/10/2026", "ok", "", "line one\n  line two"},
+	}
+	opts := cleanOptions{Trim: true, CollapseSpaces: true, ToNumber: true, ToDate: true, DayFirst: true}
+	text, converted, summary := cleanValues(values, opts)
+	wantText := []retypeCell{
+		{Row: 0, Col: 0, Value: "Acme Corp"},
+		{Row: 1, Col: 3, Value: "line one\n line two"},
+	}
+	wantConverted := []retypeCell{
+		{Row: 0, Col: 1, Value: float64(1200)},
+		{Row: 1, Col: 0, Value: "2026-10-17"},
+	}
+	if !reflect.DeepEqual(text, wantText) {
+		t.Errorf("text = %+v, want %+v", text, wantText)
+	}
+	if !reflect.DeepEqual(converted, wantConverted) {
+		t.Errorf("converted = %+v, want %+v", converted, wantConverted)
+	}
+	if summary.Numbers != 1 || summary.Dates != 1 || summary.Unchanged != 4 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	// Without --to-number, numeric text is only trimmed and stays text.
+	text, converted, _ = cleanValues([][]interface{}{{" 00042 "}}, cleanOptions{Trim: true})
+	if len(text) != 1 || text[0].Value != "00042" || len(converted) != 0 {
+		t.Errorf("expected trimmed text, got %+v / %+v", text, converted)
+	}
+}
+
+func TestSheetsClean_WritesOnlyChangedCells(t *testing.T) {
+	var batches []sheets.BatchUpdateValuesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v4/spreadsheets/sheet-1/values/Import!B2:C3":
+			if r.URL.Query().Get("valueRenderOption") != "FORMULA" {
+				t.Errorf("expected FORMULA rendering, got %q", r.URL.Query().Get("valueRenderOption"))
+			}
+			json.NewEncoder(w).Encode(&sheets.ValueRange{
+				Range:  "Import!B2:C3",
+				Values: [][]interface{}{{" 12 ", "done"}, {"=B2*2", " n/a"}},
+			})
+		case r.URL.Path == "/v4/spreadsheets/sheet-1/values:batchUpdate":
+			var sent sheets.BatchUpdateValuesRequest
+			json.NewDecoder(r.Body).Decode(&sent)
+			batches = append(batches, sent)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateValuesResponse{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := cleanOptions{Trim: true, ToNumber: true}
+	if err := runSheetsCleanWithService(svc, "sheet-1", "Import!B2:C3", opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsCleanWithService: %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected a RAW and a USER_ENTERED write, got %+v", batches)
+	}
+	text, numbers := batches[0], batches[1]
+	if text.ValueInputOption != "RAW" || len(text.Data) != 1 || text.Data[0].Range != "Import!C3" || text.Data[0].Values[0][0] != "n/a" {
+		t.Errorf("unexpected text write: %+v", text)
+	}
+	if numbers.ValueInputOption != "USER_ENTERED" || len(numbers.Data) != 1 || numbers.Data[0].Range != "Import!B2" || numbers.Data[0].Values[0][0] != float64(12) {
+		t.Errorf("unexpected number write: %+v", numbers)
+	}
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["changed"] != float64(2) || out["numbers"] != float64(1) || out["unchanged"] != float64(2) {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestParseStatusColorMap(t *testing.T) {
+	rules, err := parseStatusColorMap("Done=#00ff00, In Progress = #FFFF00,Blocked=#FF0000")
+	if err != nil {
+		t.Fatalf("parseStatusColorMap: %v", err)
+	}
+	if len(rules) != 3 || rules[1].Status != "In Progress" || rules[1].Hex != "#FFFF00" || rules[0].Hex != "#00FF00" {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+	if rules[0].Color.Green != 1 || rules[0].Color.Red != 0 {
+		t.Errorf("unexpected color for Done: %+v", rules[0].Color)
+	}
+
+	for _, spec := range []string{"", "Done", "=#00FF00", "Done=green", "Done=#00FF00,done=#FF0000"} {
+		if _, err := parseStatusColorMap(spec); err == nil {
+			t.Errorf("parseStatusColorMap(%q): expected error", spec)
+		}
+	}
+}
+
+func TestSheetsStatusColors_GroupsRunsByColor(t *testing.T) {
+	var sent sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Tasks"}},
+			}})
+		case "/v4/spreadsheets/sheet-1/values/Tasks!A2:C7":
+			json.NewEncoder(w).Encode(&sheets.ValueRange{
+				Range: "Tasks!A2:C7",
+				Values: [][]interface{}{
+					{"a", "Done"}, {"b", "done "}, {"c", "Blocked"}, {"d", "Done"}, {"e", "Later"},
+				},
+			})
+		case "/v4/spreadsheets/sheet-1:batchUpdate":
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	rules, _ := parseStatusColorMap("Done=#00FF00,Blocked=#FF0000")
+	var buf bytes.Buffer
+	if err := runSheetsStatusColorsWithService(svc, "sheet-1", "Tasks!A2:C7", "B", rules, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsStatusColorsWithService: %v", err)
+	}
+
+	type span struct{ start, end int64 }
+	var got []span
+	for _, req := range sent.Requests {
+		rc := req.RepeatCell
+		if rc.Range.SheetId != 7 || rc.Range.StartColumnIndex != 0 || rc.Range.EndColumnIndex != 3 {
+			t.Errorf("unexpected columns or sheet: %+v", rc.Range)
+		}
+		if rc.Fields != "userEnteredFormat.backgroundColor" {
+			t.Errorf("unexpected fields: %s", rc.Fields)
+		}
+		got = append(got, span{rc.Range.StartRowIndex, rc.Range.EndRowIndex})
+	}
+	want := []span{{1, 3}, {4, 5}, {3, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("row spans = %v, want %v (Done runs first, then Blocked)", got, want)
+	}
+	if sent.Requests[2].RepeatCell.Cell.UserEnteredFormat.BackgroundColor.Red != 1 {
+		t.Errorf("expected Blocked rows painted red")
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["colored"] != float64(4) || out["unmatched"] != float64(2) || out["requests"] != float64(3) {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsStatusColors_ColumnOutsideRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Tasks"}},
+		}})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+	rules, _ := parseStatusColorMap("Done=#00FF00")
+	err = runSheetsStatusColorsWithService(svc, "sheet-1", "Tasks!A2:C7", "E", rules, printer.New(&bytes.Buffer{}, "json"))
+	if err == nil || !strings.Contains(err.Error(), "outside the range") {
+		t.Errorf("expected out-of-range error, got %v", err)
+	}
+}
+
+func TestSheetsStatusColors_ReversedRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Tasks"}},
+		}})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+	rules, _ := parseStatusColorMap("Done=#00FF00")
+	err = runSheetsStatusColorsWithService(svc, "sheet-1", "Tasks!A200:H2", "E", rules, printer.New(&bytes.Buffer{}, "json"))
+	if err == nil || !strings.Contains(err.Error(), "top row first") {
+		t.Errorf("expected reversed-range usage error, got %v", err)
+	}
+}
+
+func TestCSVFileName(t *testing.T) {
+	used := make(map[string]bool)
+	names := []string{
+		csvFileName("Q3/Q4 Plan", used),
+		csvFileName("Summary", used),
+		csvFileName("summary", used),
+		csvFileName("  ", used),
+	}
+	want := []string{"Q3_Q4 Plan.csv", "Summary.csv", "summary-2.csv", "sheet.csv"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("csvFileName #%d = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
+
+func mockSheetsDumpServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"properties": map[string]interface{}{"title": "Budget"},
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"title": "Summary", "sheetType": "GRID", "gridProperties": map[string]interface{}{"rowCount": 10, "columnCount": 5}}},
+					{"properties": map[string]interface{}{"title": "Chart 1", "sheetType": "OBJECT"}},
+					{"properties": map[string]interface{}{"title": "Raw Data", "sheetType": "GRID", "gridProperties": map[string]interface{}{"rowCount": 100, "columnCount": 5}}},
+				},
+			})
+		case "/v4/spreadsheets/sheet-1/values:batchGet":
+			if got := r.URL.Query()["ranges"]; !reflect.DeepEqual(got, []string{"Summary", "'Raw Data'"}) {
+				t.Errorf("unexpected ranges: %v", got)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"valueRanges": []map[string]interface{}{
+					{"range": "Summary!A1:B2", "values": [][]interface{}{{"Region", "Total"}, {"EMEA", "120"}}},
+					{"range": "'Raw Data'!A1:A1", "values": [][]interface{}{{"x"}}},
+				},
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSheetsDump_PrintsAllSheets(t *testing.T) {
+	server := mockSheetsDumpServer(t)
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsDumpWithService(svc, "sheet-1", "", 1000, "FORMATTED_VALUE", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsDumpWithService: %v", err)
+	}
+
+	var out struct {
+		SheetNames []string                   `json:"sheet_names"`
+		Sheets     map[string][][]interface{} `json:"sheets"`
+		Cells      int                        `json:"cells"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if !reflect.DeepEqual(out.SheetNames, []string{"Summary", "Raw Data"}) || out.Cells != 5 {
+		t.Errorf("unexpected dump: %+v", out)
+	}
+	if out.Sheets["Summary"][1][0] != "EMEA" {
+		t.Errorf("unexpected Summary values: %v", out.Sheets["Summary"])
+	}
+}
+
+func TestSheetsDump_WritesCSVPerSheet(t *testing.T) {
+	server := mockSheetsDumpServer(t)
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := runSheetsDumpWithService(svc, "sheet-1", dir, 0, "FORMATTED_VALUE", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsDumpWithService: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "Summary.csv"))
+	if err != nil {
+		t.Fatalf("expected Summary.csv: %v", err)
+	}
+	if string(data) != "Region,Total\nEMEA,120\n" {
+		t.Errorf("unexpected CSV: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Raw Data.csv")); err != nil {
+		t.Errorf("expected Raw Data.csv: %v", err)
+	}
+}
+
+func TestSheetsDump_RefusesOverMaxCells(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "batchGet") {
+			t.Error("values should not be fetched over the cell limit")
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sheets": []map[string]interface{}{
+				{"properties": map[string]interface{}{"title": "Big", "gridProperties": map[string]interface{}{"rowCount": 1000, "columnCount": 26}}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = runSheetsDumpWithService(svc, "sheet-1", "", 10000, "FORMATTED_VALUE", printer.New(&buf, "json"))
+	if err == nil || !strings.Contains(err.Error(), "--max-cells") {
+		t.Errorf("expected max-cells error, got %v", err)
+	}
+}
+
+func TestParseReplacements(t *testing.T) {
+	got, err := parseReplacements([]string{"{{client}}=Acme Corp", "{{formula}}==SUM(A1:A3)"})
+	if err != nil {
+		t.Fatalf("parseReplacements: %v", err)
+	}
+	want := [][2]string{{"{{client}}", "Acme Corp"}, {"{{formula}}", "=SUM(A1:A3)"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseReplacements = %v, want %v", got, want)
+	}
+	for _, bad := range []string{"no-equals", "=value"} {
+		if _, err := parseReplacements([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestSheetsCopySpreadsheet_CopiesAndReplaces(t *testing.T) {
+	var copied drive.File
+	var batch sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/files/tmpl-1":
+			json.NewEncoder(w).Encode(&drive.File{Id: "tmpl-1", Name: "Invoice template", MimeType: "application/vnd.google-apps.spreadsheet"})
+		case r.Method == "POST" && r.URL.Path == "/files/tmpl-1/copy":
+			json.NewDecoder(r.Body).Decode(&copied)
+			json.NewEncoder(w).Encode(&drive.File{Id: "new-1", Name: copied.Name, WebViewLink: "https://docs.google.com/spreadsheets/d/new-1/edit"})
+		case r.Method == "POST" && r.URL.Path == "/v4/spreadsheets/new-1:batchUpdate":
+			json.NewDecoder(r.Body).Decode(&batch)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{Replies: []*sheets.Response{
+				{FindReplace: &sheets.FindReplaceResponse{OccurrencesChanged: 2}},
+				{FindReplace: &sheets.FindReplaceResponse{}},
+			}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+	sheetsSvc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := sheetsCopyOptions{
+		SourceID:     "tmpl-1",
+		Title:        "Acme invoice",
+		FolderID:     "folder-9",
+		Replacements: [][2]string{{"{{client}}", "Acme"}, {"{{date}}", "2026-10-17"}},
+	}
+	if err := runSheetsCopySpreadsheetWithService(driveSvc, sheetsSvc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsCopySpreadsheetWithService: %v", err)
+	}
+
+	if copied.Name != "Acme invoice" || !reflect.DeepEqual(copied.Parents, []string{"folder-9"}) {
+		t.Errorf("unexpected copy request: %+v", copied)
+	}
+	if len(batch.Requests) != 2 || !batch.Requests[0].FindReplace.AllSheets || !batch.Requests[0].FindReplace.MatchCase {
+		t.Fatalf("unexpected find/replace requests: %+v", batch.Requests)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["spreadsheet_id"] != "new-1" || out["url"] != "https://docs.google.com/spreadsheets/d/new-1/edit" {
+		t.Errorf("unexpected output: %v", out)
+	}
+	replacements, _ := out["replacements"].([]interface{})
+	if len(replacements) != 2 || replacements[0].(map[string]interface{})["occurrences"] != float64(2) {
+		t.Errorf("unexpected replacements: %v", out["replacements"])
+	}
+}
+
+func TestSheetsCopySpreadsheet_RejectsNonSpreadsheet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("nothing should be copied, got %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(&drive.File{Id: "doc-1", MimeType: "application/vnd.google-apps.document"})
+	}))
+	defer server.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = runSheetsCopySpreadsheetWithService(driveSvc, nil, sheetsCopyOptions{SourceID: "doc-1"}, printer.New(&buf, "json"))
+	if err == nil || !strings.Contains(err.Error(), "not a Google Sheets spreadsheet") {
+		t.Errorf("expected mime type error, got %v", err)
+	}
+}
+
+func TestSnapshotNameTemplate(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 5, 3, 0, time.UTC)
+	if got := snapshotName("{title} snapshot {date} {time}", "OKRs (Q4)", now); got != "OKRs (Q4) snapshot 2026-10-17 090503" {
+		t.Errorf("snapshotName = %q", got)
+	}
+
+	pattern := snapshotNamePattern("{title} snapshot {date} {time}", "OKRs (Q4)")
+	for name, want := range map[string]bool{
+		"OKRs (Q4) snapshot 2026-10-17 090503":         true,
+		"OKRs (Q4) snapshot 2025-01-02 235959":         true,
+		"OKRs (Q4)":                                    false,
+		"OKRs (Q4) snapshot 2026-10-17":                false,
+		"Copy of OKRs (Q4) snapshot 2026-10-17 090503": false,
+		"OKRs xQ4) snapshot 2026-10-17 090503":         false,
+	} {
+		if got := pattern.MatchString(name); got != want {
+			t.Errorf("pattern.MatchString(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if err := validateSnapshotTemplate("backup-{date}"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateSnapshotTemplate("backup-{datetime}"); err == nil || !strings.Contains(err.Error(), "{datetime}") {
+		t.Errorf("expected unknown placeholder error, got %v", err)
+	}
+	if err := validateSnapshotTemplate("  "); err == nil {
+		t.Error("expected an error for an empty template")
+	}
+}
+
+func TestSheetsSnapshot_CopiesAndPrunes(t *testing.T) {
+	var copied drive.File
+	var listQuery string
+	var trashedIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/files/okr-1":
+			json.NewEncoder(w).Encode(&drive.File{Id: "okr-1", Name: "OKRs", MimeType: "application/vnd.google-apps.spreadsheet"})
+		case r.Method == "POST" && r.URL.Path == "/files/okr-1/copy":
+			json.NewDecoder(r.Body).Decode(&copied)
+			json.NewEncoder(w).Encode(&drive.File{Id: "snap-new", Name: copied.Name, Parents: copied.Parents})
+		case r.Method == "GET" && r.URL.Path == "/files":
+			listQuery = r.URL.Query().Get("q")
+			json.NewEncoder(w).Encode(&drive.FileList{Files: []*drive.File{
+				{Id: "snap-new", Name: "backup-2026-10-17"},
+				{Id: "snap-3", Name: "backup-2026-10-16"},
+				{Id: "other", Name: "budget"},
+				{Id: "snap-2", Name: "backup-2026-10-15"},
+				{Id: "snap-1", Name: "backup-2026-10-14"},
+			}})
+		case r.Method == "PATCH":
+			var f drive.File
+			json.NewDecoder(r.Body).Decode(&f)
+			if !f.Trashed {
+				t.Errorf("expected a trash update, got %+v", f)
+			}
+			trashedIDs = append(trashedIDs, strings.TrimPrefix(r.URL.Path, "/files/"))
+			json.NewEncoder(w).Encode(&f)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := sheetsSnapshotOptions{
+		SourceID:     "okr-1",
+		FolderID:     "backups",
+		NameTemplate: "backup-{date}",
+		Keep:         2,
+		Now:          time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+	}
+	if err := runSheetsSnapshotWithService(driveSvc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsSnapshotWithService: %v", err)
+	}
+
+	if copied.Name != "backup-2026-10-17" || !reflect.DeepEqual(copied.Parents, []string{"backups"}) {
+		t.Errorf("unexpected copy request: %+v", copied)
+	}
+	if copied.AppProperties["gwsSnapshotOf"] != "okr-1" {
+		t.Errorf("expected the copy to be tagged with its source, got %v", copied.AppProperties)
+	}
+	if !strings.Contains(listQuery, "'backups' in parents") {
+		t.Errorf("expected snapshots to be listed from the folder, got query %q", listQuery)
+	}
+	if !strings.Contains(listQuery, "appProperties has { key='gwsSnapshotOf' and value='okr-1' }") {
+		t.Errorf("expected snapshots to be filtered by source tag, got query %q", listQuery)
+	}
+	if !reflect.DeepEqual(trashedIDs, []string{"snap-2", "snap-1"}) {
+		t.Errorf("trashed %v, want [snap-2 snap-1]", trashedIDs)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["spreadsheet_id"] != "snap-new" || out["folder"] != "backups" {
+		t.Errorf("unexpected output: %v", out)
+	}
+	if trashed, _ := out["trashed"].([]interface{}); len(trashed) != 2 {
+		t.Errorf("expected 2 trashed snapshots in output, got %v", out["trashed"])
+	}
+}
+
+func TestSplitRangeSpec(t *testing.T) {
+	const id = "1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms"
+	tests := []struct {
+		spec, defaultID string
+		wantID, wantRng string
+	}{
+		{"Sheet1!A1:D10", "abc", "abc", "Sheet1!A1:D10"},
+		{id + "!Sheet1!A1:D10", "abc", id, "Sheet1!A1:D10"},
+		{id + "!Sheet1!A1:D10", "", id, "Sheet1!A1:D10"},
+		{"'My Sheet'!A:C", "abc", "abc", "'My Sheet'!A:C"},
+	}
+	for _, tt := range tests {
+		gotID, gotRng, err := splitRangeSpec(tt.spec, tt.defaultID)
+		if err != nil {
+			t.Errorf("splitRangeSpec(%q): %v", tt.spec, err)
+			continue
+		}
+		if gotID != tt.wantID || gotRng != tt.wantRng {
+			t.Errorf("splitRangeSpec(%q) = %q, %q; want %q, %q", tt.spec, gotID, gotRng, tt.wantID, tt.wantRng)
+		}
+	}
+
+	if _, _, err := splitRangeSpec("Sheet1!A1:B2", ""); err == nil {
+		t.Error("expected error without a spreadsheet ID")
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	a := [][]interface{}{
+		{"Region", "Revenue"},
+		{"EMEA", "120"},
+		{"APAC", "80", "x"},
+		{"LATAM", "40"},
+	}
+	b := [][]interface{}{
+		{"Region", "Revenue"},
+		{"EMEA", "125"},
+		{"APAC", "80"},
+	}
+	cells, removed, added := diffValues(a, b)
+	want := []cellDiff{
+		{Row: 1, Col: 1, A: "120", B: "125"},
+		{Row: 2, Col: 2, A: "x", B: ""},
+	}
+	if !reflect.DeepEqual(cells, want) {
+		t.Errorf("cells = %+v, want %+v", cells, want)
+	}
+	if !reflect.DeepEqual(removed, []int{3}) || len(added) != 0 {
+		t.Errorf("removed = %v, added = %v", removed, added)
+	}
+
+	_, removed, added = diffValues(b, a)
+	if len(removed) != 0 || !reflect.DeepEqual(added, []int{3}) {
+		t.Errorf("reversed: removed = %v, added = %v", removed, added)
+	}
+
+	if cells, removed, added := diffValues(a, a); len(cells)+len(removed)+len(added) != 0 {
+		t.Errorf("expected no differences comparing a range to itself")
+	}
+}
+
+func TestRangeCellRef(t *testing.T) {
+	ref, err := rangeCellRef("'Q3 Data'!B5:D9")
+	if err != nil {
+		t.Fatalf("rangeCellRef: %v", err)
+	}
+	if got := ref(0, 0); got != "'Q3 Data'!B5" {
+		t.Errorf("ref(0,0) = %q", got)
+	}
+	if got := ref(2, 1); got != "'Q3 Data'!C7" {
+		t.Errorf("ref(2,1) = %q", got)
+	}
+}
+
+func TestUpsertRows(t *testing.T) {
+	header := []interface{}{"ID", "Name", "Region"}
+	records := []interface{}{
+		[]interface{}{"C-1", "Acme"},
+		map[string]interface{}{"Region": "APAC", "ID": "C-2"},
+	}
+	rows, err := upsertRows(records, header)
+	if err != nil {
+		t.Fatalf("upsertRows: %v", err)
+	}
+	want := [][]interface{}{{"C-1", "Acme"}, {"C-2", nil, "APAC"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+
+	if _, err := upsertRows([]interface{}{map[string]interface{}{"Owner": "x"}}, header); err == nil {
+		t.Error("expected error for unknown header")
+	}
+	if _, err := upsertRows([]interface{}{"C-3"}, header); err == nil {
+		t.Error("expected error for a scalar record")
+	}
+}
+
+func TestSheetsUpsert_UpdatesAndAppends(t *testing.T) {
+	var batch sheets.BatchUpdateValuesRequest
+	var appended sheets.ValueRange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			json.NewEncoder(w).Encode(&sheets.ValueRange{
+				Range: "Customers!A1:C4",
+				Values: [][]interface{}{
+					{"ID", "Name", "Region"},
+					{"C-1", "Acme", "EMEA"},
+					{"C-2", "Globex", "APAC"},
+					{"C-3", "Initech", "AMER"},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "values:batchUpdate"):
+			json.NewDecoder(r.Body).Decode(&batch)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateValuesResponse{})
+		case strings.HasSuffix(r.URL.Path, ":append"):
+			json.NewDecoder(r.Body).Decode(&appended)
+			json.NewEncoder(w).Encode(&sheets.AppendValuesResponse{
+				Updates: &sheets.UpdateValuesResponse{UpdatedRange: "Customers!A5:C6"},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	opts := sheetsUpsertOptions{
+		SpreadsheetID: "sheet-1",
+		Range:         "Customers!A:C",
+		KeyCol:        "A",
+		Records: []interface{}{
+			[]interface{}{"C-3", "Initech", "EMEA"},
+			map[string]interface{}{"ID": "C-4", "Name": "Umbrella"},
+			[]interface{}{"C-1", "Acme Corp", "EMEA"},
+			[]interface{}{"C-5", "Hooli", "AMER"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := runSheetsUpsertWithService(svc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsUpsertWithService: %v", err)
+	}
+
+	if len(batch.Data) != 2 || batch.Data[0].Range != "Customers!A2" || batch.Data[1].Range != "Customers!A4" {
+		t.Fatalf("unexpected updates: %+v", batch.Data)
+	}
+	if batch.Data[0].Values[0][1] != "Acme Corp" || batch.ValueInputOption != "USER_ENTERED" {
+		t.Errorf("unexpected update values: %+v", batch.Data[0].Values)
+	}
+	if len(appended.Values) != 2 || appended.Values[0][0] != "C-4" || appended.Values[1][0] != "C-5" {
+		t.Errorf("unexpected appended rows: %v", appended.Values)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["updated"] != float64(2) || out["inserted"] != float64(2) || out["inserted_range"] != "Customers!A5:C6" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsUpsert_MergesObjectsAndSkipsHeader(t *testing.T) {
+	var batch sheets.BatchUpdateValuesRequest
+	var appended sheets.ValueRange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			json.NewEncoder(w).Encode(&sheets.ValueRange{
+				Range: "Customers!A1:C3",
+				Values: [][]interface{}{
+					{"ID", "Name", "Region"},
+					{"C-1", "Acme", "EMEA"},
+					{"C-2", "Globex", "APAC"},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "values:batchUpdate"):
+			json.NewDecoder(r.Body).Decode(&batch)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateValuesResponse{})
+		case strings.HasSuffix(r.URL.Path, ":append"):
+			json.NewDecoder(r.Body).Decode(&appended)
+			json.NewEncoder(w).Encode(&sheets.AppendValuesResponse{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	opts := sheetsUpsertOptions{
+		SpreadsheetID: "sheet-1",
+		Range:         "Customers!A:C",
+		KeyCol:        "A",
+		Records: []interface{}{
+			map[string]interface{}{"ID": "C-2", "Region": "LATAM"},
+			[]interface{}{"ID", "not", "a header"},
+		},
+	}
+	if err := runSheetsUpsertWithService(svc, opts, printer.New(&bytes.Buffer{}, "json")); err != nil {
+		t.Fatalf("runSheetsUpsertWithService: %v", err)
+	}
+
+	if len(batch.Data) != 1 || batch.Data[0].Range != "Customers!A3" {
+		t.Fatalf("unexpected updates: %+v", batch.Data)
+	}
+	want := []interface{}{"C-2", "Globex", "LATAM"}
+	if !reflect.DeepEqual(batch.Data[0].Values[0], want) {
+		t.Errorf("updated row = %v, want %v (unnamed columns kept)", batch.Data[0].Values[0], want)
+	}
+	if len(appended.Values) != 1 || appended.Values[0][0] != "ID" {
+		t.Errorf("expected a record keyed like the header to be appended, got %v", appended.Values)
+	}
+}
+
+func TestEnvKey(t *testing.T) {
+	tests := []struct {
+		key, prefix string
+		upper       bool
+		want        string
+	}{
+		{"api_url", "", false, "api_url"},
+		{"api url", "APP_", true, "APP_API_URL"},
+		{"feature-flag.beta", "", false, "feature_flag_beta"},
+		{"2fa", "", false, "_2fa"},
+	}
+	for _, tt := range tests {
+		if got := envKey(tt.key, tt.prefix, tt.upper); got != tt.want {
+			t.Errorf("envKey(%q, %q, %v) = %q, want %q", tt.key, tt.prefix, tt.upper, got, tt.want)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":              "plain",
+		"https://x.io/a?b=1": "'https://x.io/a?b=1'",
+		"two words":          "'two words'",
+		"it's":               `'it'\''s'`,
+		"":                   "''",
+		"$HOME":              "'$HOME'",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEnvLines(t *testing.T) {
+	rows := [][]interface{}{
+		{"db host", "localhost"},
+		{"", "ignored"},
+		{},
+		{"port", float64(5432), "extra"},
+		{"empty"},
+	}
+	lines, keys := envLines(rows, "APP_", true, true)
+	want := []string{"export APP_DB_HOST=localhost", "export APP_PORT=5432", "export APP_EMPTY=''"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	if !reflect.DeepEqual(keys, []string{"APP_DB_HOST", "APP_PORT", "APP_EMPTY"}) {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
+
+func TestBuildWriteTypedRequest(t *testing.T) {
+	format := &sheets.NumberFormat{Type: "CURRENCY", Pattern: "$#,##0.00"}
+	req, err := buildWriteTypedRequest(7, 1, 2, [][]interface{}{{"Total", 1200.5}, {"=SUM(D2:D3)", nil, true}}, format)
+	if err != nil {
+		t.Fatalf("buildWriteTypedRequest: %v", err)
+	}
+	uc := req.UpdateCells
+	if uc.Start.SheetId != 7 || uc.Start.RowIndex != 1 || uc.Start.ColumnIndex != 2 {
+		t.Errorf("unexpected start: %+v", uc.Start)
+	}
+	if uc.Fields != "userEnteredValue,userEnteredFormat.numberFormat" {
+		t.Errorf("unexpected fields: %s", uc.Fields)
+	}
+	row0, row1 := uc.Rows[0].Values, uc.Rows[1].Values
+	if *row0[0].UserEnteredValue.StringValue != "Total" || *row0[1].UserEnteredValue.NumberValue != 1200.5 {
+		t.Errorf("unexpected first row: %+v", row0)
+	}
+	if *row1[0].UserEnteredValue.FormulaValue != "=SUM(D2:D3)" || row1[1].UserEnteredValue != nil || !*row1[2].UserEnteredValue.BoolValue {
+		t.Errorf("unexpected second row: %+v", row1)
+	}
+	if row1[1].UserEnteredFormat.NumberFormat != format {
+		t.Error("every written cell should carry the number format")
+	}
+
+	if _, err := buildWriteTypedRequest(0, 0, 0, [][]interface{}{{map[string]interface{}{"a": 1}}}, format); err == nil {
+		t.Error("expected error for an object value")
+	}
+}
+
+func TestSheetsWriteTyped_SingleBatchUpdate(t *testing.T) {
+	var batches int
+	var got sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Data"}},
+				{Properties: &sheets.SheetProperties{SheetId: 42, Title: "Q3 Summary"}},
+			}})
+		case strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			batches++
+			json.NewDecoder(r.Body).Decode(&got)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	opts := sheetsWriteTypedOptions{
+		SpreadsheetID: "sheet-1",
+		Range:         "'Q3 Summary'!B2",
+		Values:        [][]interface{}{{1200.5, 980.0}, {310.0}},
+		Pattern:       "$#,##0.00",
+		FormatType:    "CURRENCY",
+	}
+	var buf bytes.Buffer
+	if err := runSheetsWriteTypedWithService(svc, opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsWriteTypedWithService: %v", err)
+	}
+	if batches != 1 || len(got.Requests) != 1 || got.Requests[0].UpdateCells.Start.SheetId != 42 {
+		t.Fatalf("expected one UpdateCells on sheet 42, got %d batches: %+v", batches, got.Requests)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["range"] != "'Q3 Summary'!B2:C3" {
+		t.Errorf("unexpected range: %v", out["range"])
+	}
+
+	opts.Range = "'Q3 Summary'!B2:B3"
+	if err := runSheetsWriteTypedWithService(svc, opts, printer.New(&buf, "json")); err == nil {
+		t.Error("expected error when the data is wider than the range")
+	}
+}
+
+func TestBorderStyle(t *testing.T) {
+	tests := []struct {
+		style string
+		width int64
+		want  string
+	}{
+		{"solid", 1, "SOLID"},
+		{"SOLID", 2, "SOLID_MEDIUM"},
+		{"SOLID", 3, "SOLID_THICK"},
+		{"dashed", 3, "DASHED"},
+		{"NONE", 1, "NONE"},
+	}
+	for _, tt := range tests {
+		got, err := borderStyle(tt.style, tt.width)
+		if err != nil || got != tt.want {
+			t.Errorf("borderStyle(%q, %d) = %q, %v; want %q", tt.style, tt.width, got, err, tt.want)
+		}
+	}
+	if _, err := borderStyle("WAVY", 1); err == nil {
+		t.Error("expected error for unknown style")
+	}
+	if _, err := borderStyle("SOLID", 4); err == nil {
+		t.Error("expected error for width out of range")
+	}
+}
+
+func TestBuildBordersRequest(t *testing.T) {
+	gridRange := &sheets.GridRange{SheetId: 3, StartRowIndex: 0, EndRowIndex: 10, StartColumnIndex: 0, EndColumnIndex: 4}
+	border := &sheets.Border{Style: "SOLID"}
+	req := buildBordersRequest(gridRange, map[string]bool{"top": true, "inner-vertical": true}, border)
+
+	ub := req.UpdateBorders
+	if ub.Range != gridRange {
+		t.Error("request should target the parsed grid range")
+	}
+	if ub.Top != border || ub.InnerVertical != border {
+		t.Errorf("selected sides should carry the border: %+v", ub)
+	}
+	if ub.Bottom != nil || ub.Left != nil || ub.Right != nil || ub.InnerHorizontal != nil {
+		t.Errorf("unselected sides should stay nil: %+v", ub)
+	}
+}
+
+func TestParseWhere(t *testing.T) {
+	resolve := whereColumnResolver([]string{"Name", "Amount", "Status", "Unit Price"}, 0)
+	rows := [][]interface{}{
+		{"Acme", 150.0, "active", 9.5},
+		{"Globex", 80.0, "active", 12.0},
+		{"Initech", 300.0, "closed", "n/a"},
+		{"Hooli", "120", "Active"},
+	}
+	tests := []struct {
+		where string
+		want  []int
+	}{
+		{"B>100 AND C=active", []int{0}},
+		{"Amount > 100 and Status = active", []int{0}},
+		{"Status=closed OR Amount<100", []int{1, 2}},
+		{"(Status=active OR Status=closed) AND B>=150", []int{0, 2}},
+		{"Status~ACT", []int{0, 1, 3}},
+		{"Name != \"Acme\"", []int{1, 2, 3}},
+		{"[Unit Price] < 10", []int{0}},
+		{"D = \"\"", []int{3}},
+	}
+	for _, tt := range tests {
+		expr, err := parseWhere(tt.where, resolve)
+		if err != nil {
+			t.Errorf("parseWhere(%q): %v", tt.where, err)
+			continue
+		}
+		var got []int
+		for i, row := range rows {
+			if expr.match(row) {
+				got = append(got, i)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q matched rows %v, want %v", tt.where, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "B >", "B 100", "AND B>1", "(B>1", "B>1 C=2", "Missing=1", "B ! 1", "Name=\"open"} {
+		if _, err := parseWhere(bad, resolve); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestWhereColumnResolver_RangeOffset(t *testing.T) {
+	resolve := whereColumnResolver(nil, columnLetterToIndex("C"))
+	if idx, err := resolve("E"); err != nil || idx != 2 {
+		t.Errorf("resolve(E) = %d, %v; want 2", idx, err)
+	}
+	if _, err := resolve("A"); err == nil {
+		t.Error("expected error for a column left of the range")
+	}
+}
+
+func TestSheetsFilterRead_HeaderRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("valueRenderOption"); got != "UNFORMATTED_VALUE" {
+			t.Errorf("valueRenderOption = %q", got)
+		}
+		json.NewEncoder(w).Encode(&sheets.ValueRange{
+			Range: "Orders!B1:D4",
+			Values: [][]interface{}{
+				{"Customer", "Total", "State"},
+				{"Acme", 150, "open"},
+				{"Globex", 90, "open"},
+				{"Initech", 400, "closed"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsFilterReadWithService(svc, "sheet-1", "Orders!B1:D4", "Total>100", true, "UNFORMATTED_VALUE", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsFilterReadWithService: %v", err)
+	}
+	var out struct {
+		Rows       []map[string]interface{} `json:"rows"`
+		RowNumbers []int                    `json:"row_numbers"`
+		Count      int                      `json:"count"`
+		Scanned    int                      `json:"scanned"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Count != 2 || out.Scanned != 3 || out.Rows[1]["Customer"] != "Initech" {
+		t.Errorf("unexpected rows: %+v", out)
+	}
+	if !reflect.DeepEqual(out.RowNumbers, []int{2, 4}) {
+		t.Errorf("row_numbers = %v, want [2 4]", out.RowNumbers)
+	}
+
+	// Column letters are sheet columns, offset by the range start (B).
+	buf.Reset()
+	if err := runSheetsFilterReadWithService(svc, "sheet-1", "Orders!B1:D4", "D=closed", true, "UNFORMATTED_VALUE", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsFilterReadWithService: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"count": 1`) {
+		t.Errorf("expected one closed row, got %s", buf.String())
+	}
+}
+
+func TestExtractFormulaRefs(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    []string
+	}{
+		{"SUM(A1:A3)*B2", []string{"A1:A3", "B2"}},
+		{"$C$4+Rates!$B$2", []string{"C4", "Rates!B2"}},
+		{"VLOOKUP(A2,'Q1 Data'!A:C,3,FALSE)", []string{"A2", "'Q1 Data'!A:C"}},
+		{"'It''s'!D1", []string{"'It''s'!D1"}},
+		{"SUM(2:5)+SUM(A2:B)", []string{"2:5", "A2:B"}},
+		{"LOG10(A1)+ATAN2(B1,C1)", []string{"A1", "B1", "C1"}},
+		{`IF(A1="B2","C3",D4)`, []string{"A1", "D4"}},
+		{"TaxRate*Total2024+1.5", nil},
+		{"IMPORTRANGE(\"abc\",\"Sheet1!A1\")", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, ref := range extractFormulaRefs(tt.formula) {
+			got = append(got, ref.a1())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("extractFormulaRefs(%q) = %v, want %v", tt.formula, got, tt.want)
+		}
+	}
+}
+
+func TestSheetsTrace_BuildsTree(t *testing.T) {
+	formulas := map[string][][]interface{}{
+		"Sheet1!D5":    {{"=SUM(A1:A3)*Rates!B2"}},
+		"Sheet1!A1:A3": {{10}, {"=A1*2"}, {"=D5"}},
+		"Rates!B2":     {{"=Rates!B1/100"}},
+		"Rates!B1":     {{7}},
+	}
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{Title: "Sheet1"}},
+			}})
+		case "/v4/spreadsheets/sheet-1/values:batchGet":
+			if got := r.URL.Query().Get("valueRenderOption"); got != "FORMULA" {
+				t.Errorf("valueRenderOption = %q", got)
+			}
+			ranges := r.URL.Query()["ranges"]
+			batches = append(batches, ranges)
+			resp := &sheets.BatchGetValuesResponse{}
+			for _, rng := range ranges {
+				resp.ValueRanges = append(resp.ValueRanges, &sheets.ValueRange{Range: rng, Values: formulas[rng]})
+			}
+			json.NewEncoder(w).Encode(resp)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsTraceWithService(svc, "sheet-1", "D5", 10, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsTraceWithService: %v", err)
+	}
+	var out struct {
+		Cell       string                 `json:"cell"`
+		Precedents []string               `json:"precedents"`
+		Tree       map[string]interface{} `json:"tree"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Cell != "Sheet1!D5" {
+		t.Errorf("cell = %q", out.Cell)
+	}
+	wantPrecedents := []string{"Sheet1!A1:A3", "Rates!B2", "Sheet1!A1", "Sheet1!D5", "Rates!B1"}
+	if !reflect.DeepEqual(out.Precedents, wantPrecedents) {
+		t.Errorf("precedents = %v, want %v", out.Precedents, wantPrecedents)
+	}
+	wantBatches := [][]string{{"Sheet1!D5"}, {"Sheet1!A1:A3", "Rates!B2"}, {"Sheet1!A1", "Rates!B1"}}
+	if !reflect.DeepEqual(batches, wantBatches) {
+		t.Errorf("batches = %v, want %v", batches, wantBatches)
+	}
+
+	refs, _ := out.Tree["references"].([]interface{})
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 root references, got %v", out.Tree)
+	}
+	cells, _ := refs[0].(map[string]interface{})["formula_cells"].([]interface{})
+	if len(cells) != 2 {
+		t.Fatalf("expected 2 formula cells in A1:A3, got %v", refs[0])
+	}
+	// A3 points back at D5: listed, but not traced again.
+	back, _ := cells[1].(map[string]interface{})["references"].([]interface{})
+	if len(back) != 1 || back[0].(map[string]interface{})["seen"] != true {
+		t.Errorf("expected the circular reference to be marked seen, got %v", cells[1])
+	}
+}
+
+func TestSheetsRefresh_NothingToRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{Title: "Sheet1"}},
+		}})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsRefreshWithService(svc, "sheet-1", "", false, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsRefreshWithService: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"status": "nothing_to_refresh"`) {
+		t.Errorf("expected nothing_to_refresh, got %s", buf.String())
+	}
+}
+
+func TestSheetsRefresh_ReportsObjects(t *testing.T) {
+	var sent sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{
+				Sheets: []*sheets.Sheet{
+					{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Summary"}},
+				},
+				DataSources: []*sheets.DataSource{{
+					DataSourceId: "ds1",
+					Spec:         &sheets.DataSourceSpec{BigQuery: &sheets.BigQueryDataSourceSpec{ProjectId: "proj"}},
+				}},
+			})
+		case "/v4/spreadsheets/sheet-1:batchUpdate":
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{Replies: []*sheets.Response{{
+				RefreshDataSource: &sheets.RefreshDataSourceResponse{Statuses: []*sheets.RefreshDataSourceObjectExecutionStatus{
+					{
+						Reference:           &sheets.DataSourceObjectReference{SheetId: "ds-sheet"},
+						DataExecutionStatus: &sheets.DataExecutionStatus{State: "SUCCEEDED", LastRefreshTime: "2026-10-17T08:00:00Z"},
+					},
+					{
+						Reference:           &sheets.DataSourceObjectReference{DataSourceFormulaCell: &sheets.GridCoordinate{SheetId: 7, RowIndex: 1, ColumnIndex: 2}},
+						DataExecutionStatus: &sheets.DataExecutionStatus{State: "FAILED", ErrorCode: "QUERY_FAILED", ErrorMessage: "bad column"},
+					},
+				}},
+			}}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsRefreshWithService(svc, "sheet-1", "", true, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsRefreshWithService: %v", err)
+	}
+	if len(sent.Requests) != 1 || sent.Requests[0].RefreshDataSource == nil ||
+		!sent.Requests[0].RefreshDataSource.IsAll || !sent.Requests[0].RefreshDataSource.Force {
+		t.Fatalf("expected one forced refresh-all request, got %+v", sent.Requests)
+	}
+
+	var out struct {
+		Count   int                      `json:"count"`
+		Failed  int                      `json:"failed_count"`
+		Objects []map[string]interface{} `json:"objects"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Count != 2 || out.Failed != 1 {
+		t.Fatalf("expected 2 objects with 1 failure, got %s", buf.String())
+	}
+	if out.Objects[1]["type"] != "formula" || out.Objects[1]["cell"] != "Summary!C2" || out.Objects[1]["error_code"] != "QUERY_FAILED" {
+		t.Errorf("unexpected formula object: %v", out.Objects[1])
+	}
+}
+
+func TestParseEditorEmails(t *testing.T) {
+	got, err := parseEditorEmails(" a@x.com, ,b@x.com,A@x.com")
+	if err != nil {
+		t.Fatalf("parseEditorEmails: %v", err)
+	}
+	if strings.Join(got, ",") != "a@x.com,b@x.com" {
+		t.Errorf("expected deduped emails, got %v", got)
+	}
+	if _, err := parseEditorEmails("a@x.com,bob"); err == nil || !strings.Contains(err.Error(), `"bob"`) {
+		t.Errorf("expected invalid email error, got %v", err)
+	}
+}
+
+func TestFindNamedRange(t *testing.T) {
+	ranges := []*sheets.NamedRange{{NamedRangeId: "nr1", Name: "Inputs"}, {NamedRangeId: "nr2", Name: "Totals"}}
+	if nr, err := findNamedRange(ranges, "inputs"); err != nil || nr.NamedRangeId != "nr1" {
+		t.Errorf("expected case-insensitive name match, got %v, %v", nr, err)
+	}
+	if nr, err := findNamedRange(ranges, "nr2"); err != nil || nr.Name != "Totals" {
+		t.Errorf("expected ID match, got %v, %v", nr, err)
+	}
+	if _, err := findNamedRange(ranges, "Outputs"); err == nil || !strings.Contains(err.Error(), "Inputs, Totals") {
+		t.Errorf("expected not-found error listing names, got %v", err)
+	}
+}
+
+func TestSheetsProtectNamed_BacksProtectionWithNamedRange(t *testing.T) {
+	var sent sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{
+				Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{SheetId: 3, Title: "Model"}}},
+				NamedRanges: []*sheets.NamedRange{{
+					NamedRangeId: "nr-in",
+					Name:         "Inputs",
+					Range:        &sheets.GridRange{SheetId: 3, StartRowIndex: 1, EndRowIndex: 5, StartColumnIndex: 1, EndColumnIndex: 3},
+				}},
+			})
+		case "/v4/spreadsheets/sheet-1:batchUpdate":
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{Replies: []*sheets.Response{{
+				AddProtectedRange: &sheets.AddProtectedRangeResponse{ProtectedRange: &sheets.ProtectedRange{ProtectedRangeId: 99}},
+			}}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsProtectNamedWithService(svc, "sheet-1", "Inputs", []string{"a@x.com"}, false, "", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsProtectNamedWithService: %v", err)
+	}
+
+	if len(sent.Requests) != 1 || sent.Requests[0].AddProtectedRange == nil {
+		t.Fatalf("expected one AddProtectedRange request, got %+v", sent.Requests)
+	}
+	pr := sent.Requests[0].AddProtectedRange.ProtectedRange
+	if pr.NamedRangeId != "nr-in" || pr.Range != nil {
+		t.Errorf("expected protection backed by the named range only, got %+v", pr)
+	}
+	if pr.Editors == nil || strings.Join(pr.Editors.Users, ",") != "a@x.com" {
+		t.Errorf("expected editor a@x.com, got %+v", pr.Editors)
+	}
+	if pr.Description != "Inputs" {
+		t.Errorf("expected description to default to the range name, got %q", pr.Description)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v", err)
+	}
+	if out["status"] != "protected" || out["range"] != "Model!B2:C5" || out["protected_range_id"] != float64(99) {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsProtect_RangeAndWholeSheet(t *testing.T) {
+	var sent []sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Config"}},
+				{Properties: &sheets.SheetProperties{SheetId: 7, Title: "OKRs"}},
+			}})
+		case "/v4/spreadsheets/sheet-1:batchUpdate":
+			var req sheets.BatchUpdateSpreadsheetRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sent = append(sent, req)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{Replies: []*sheets.Response{{
+				AddProtectedRange: &sheets.AddProtectedRangeResponse{ProtectedRange: &sheets.ProtectedRange{ProtectedRangeId: 42}},
+			}}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsProtectWithService(svc, "sheet-1", "OKRs!E2:G50", "", []string{"a@x.com"}, false, "Score formulas", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("protect range: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v", err)
+	}
+	if out["status"] != "protected" || out["protected_range_id"] != float64(42) || out["range"] != "OKRs!E2:G50" {
+		t.Errorf("unexpected output: %v", out)
+	}
+
+	buf.Reset()
+	if err := runSheetsProtectWithService(svc, "sheet-1", "", "Config", nil, true, "", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("protect sheet: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 batch updates, got %d", len(sent))
+	}
+	pr := sent[0].Requests[0].AddProtectedRange.ProtectedRange
+	if r := pr.Range; r == nil || r.SheetId != 7 || r.StartRowIndex != 1 || r.EndRowIndex != 50 || r.StartColumnIndex != 4 || r.EndColumnIndex != 7 {
+		t.Errorf("expected OKRs!E2:G50 as a grid range, got %+v", r)
+	}
+	if pr.Editors == nil || strings.Join(pr.Editors.Users, ",") != "a@x.com" || pr.WarningOnly || pr.Description != "Score formulas" {
+		t.Errorf("unexpected protected range: %+v", pr)
+	}
+	pr = sent[1].Requests[0].AddProtectedRange.ProtectedRange
+	if pr.Range == nil || pr.Range.SheetId != 0 || pr.Range.EndRowIndex != 0 || pr.Range.EndColumnIndex != 0 {
+		t.Errorf("expected whole-sheet range on sheet 0, got %+v", pr.Range)
+	}
+	if !pr.WarningOnly || pr.Editors != nil {
+		t.Errorf("expected warning-only protection without editors, got %+v", pr)
+	}
+}
+
+func TestSheetsProtect_Validation(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "protect")
+	if cmd == nil {
+		t.Fatal("protect command not found")
+	}
+	defer func() {
+		cmd.Flags().Set("sheet", "")
+		cmd.Flags().Set("editors", "")
+		cmd.Flags().Set("warning-only", "false")
+	}()
+
+	tests := []struct {
+		name  string
+		args  []string
+		flags map[string]string
+		want  string
+	}{
+		{"neither range nor sheet", []string{"id"}, nil, "either a range or --sheet"},
+		{"both range and sheet", []string{"id", "A1:B2"}, map[string]string{"sheet": "Config"}, "either a range or --sheet"},
+		{"bad editor", []string{"id", "A1:B2"}, map[string]string{"editors": "nobody"}, "invalid editor email"},
+		{"editors with warning-only", []string{"id", "A1:B2"}, map[string]string{"editors": "a@x.com", "warning-only": "true"}, "cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd.Flags().Set("sheet", "")
+			cmd.Flags().Set("editors", "")
+			cmd.Flags().Set("warning-only", "false")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := runSheetsProtect(cmd, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSheetsListProtections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/spreadsheets/sheet-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+			{
+				Properties: &sheets.SheetProperties{SheetId: 0, Title: "Config"},
+				ProtectedRanges: []*sheets.ProtectedRange{{
+					ProtectedRangeId: 1,
+					Range:            &sheets.GridRange{},
+					WarningOnly:      true,
+				}},
+			},
+			{
+				Properties: &sheets.SheetProperties{SheetId: 7, Title: "OKRs"},
+				ProtectedRanges: []*sheets.ProtectedRange{{
+					ProtectedRangeId: 2,
+					Range:            &sheets.GridRange{SheetId: 7, StartRowIndex: 1, EndRowIndex: 50, StartColumnIndex: 4, EndColumnIndex: 7},
+					Description:      "Score formulas",
+					Editors:          &sheets.Editors{Users: []string{"a@x.com"}},
+				}},
+			},
+		}})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsListProtectionsWithService(svc, "sheet-1", "", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("list protections: %v", err)
+	}
+	var out struct {
+		Count       int                      `json:"count"`
+		Protections []map[string]interface{} `json:"protections"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v", err)
+	}
+	if out.Count != 2 || len(out.Protections) != 2 {
+		t.Fatalf("expected 2 protections, got %s", buf.String())
+	}
+	if whole := out.Protections[0]; whole["whole_sheet"] != true || whole["sheet"] != "Config" || whole["range"] != nil {
+		t.Errorf("unexpected whole-sheet protection: %v", whole)
+	}
+	if rng := out.Protections[1]; rng["range"] != "OKRs!E2:G50" || rng["whole_sheet"] != false || rng["protected_range_id"] != float64(2) {
+		t.Errorf("unexpected range protection: %v", rng)
+	}
+
+	buf.Reset()
+	if err := runSheetsListProtectionsWithService(svc, "sheet-1", "Missing", printer.New(&buf, "json")); err == nil {
+		t.Error("expected an error for an unknown sheet")
+	}
+}
+
+func TestSheetsSerialDate(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want float64
+	}{
+		{time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), 2},
+		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 45292.5},
+		// The wall clock counts, not the instant: 06:00 in UTC-5 is 0.25.
+		{time.Date(2024, 1, 1, 6, 0, 0, 0, time.FixedZone("EST", -5*3600)), 45292.25},
+	}
+	for _, tt := range tests {
+		if got := sheetsSerialDate(tt.t); got != tt.want {
+			t.Errorf("sheetsSerialDate(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestSheetsStamp_WritesTypedDateInSpreadsheetZone(t *testing.T) {
+	var sent sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{
+				Properties: &sheets.SpreadsheetProperties{TimeZone: "America/New_York"},
+				Sheets: []*sheets.Sheet{
+					{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Sheet1"}},
+					{Properties: &sheets.SheetProperties{SheetId: 5, Title: "Log"}},
+				},
+			})
+		case "/v4/spreadsheets/sheet-1:batchUpdate":
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 17, 0, 30, 500, time.UTC) // 12:00:30 in New York
+	var buf bytes.Buffer
+	if err := runSheetsStampWithService(svc, "sheet-1", "Log!B3", "yyyy-mm-dd hh:mm", false, now, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsStampWithService: %v", err)
+	}
+
+	if len(sent.Requests) != 1 || sent.Requests[0].UpdateCells == nil {
+		t.Fatalf("expected one UpdateCells request, got %+v", sent.Requests)
+	}
+	uc := sent.Requests[0].UpdateCells
+	if uc.Start.SheetId != 5 || uc.Start.RowIndex != 2 || uc.Start.ColumnIndex != 1 {
+		t.Errorf("expected Log!B3, got %+v", uc.Start)
+	}
+	cell := uc.Rows[0].Values[0]
+	if want := 45292.5 + 30.0/86400; cell.UserEnteredValue.NumberValue == nil || *cell.UserEnteredValue.NumberValue != want {
+		t.Errorf("expected serial %v, got %+v", want, cell.UserEnteredValue)
+	}
+	if nf := cell.UserEnteredFormat.NumberFormat; nf.Type != "DATE_TIME" || nf.Pattern != "yyyy-mm-dd hh:mm" {
+		t.Errorf("unexpected number format %+v", nf)
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["timestamp"] != "2024-01-01T12:00:30-05:00" || out["time_zone"] != "America/New_York" || out["cell"] != "Log!B3" {
+		t.Errorf("unexpected output: %v", out)
+	}
+
+	sent = sheets.BatchUpdateSpreadsheetRequest{}
+	if err := runSheetsStampWithService(svc, "sheet-1", "A1", "yyyy-mm-dd", true, now, printer.New(&bytes.Buffer{}, "json")); err != nil {
+		t.Fatalf("runSheetsStampWithService --utc: %v", err)
+	}
+	if got := *sent.Requests[0].UpdateCells.Rows[0].Values[0].UserEnteredValue.NumberValue; got != 45292+(17*3600+30)/86400.0 {
+		t.Errorf("expected the UTC wall clock with --utc, got %v", got)
+	}
+}
+
+func TestSheetsStamp_RejectsRanges(t *testing.T) {
+	for _, cell := range []string{"A1:B2", "Log!A", "", "Log!"} {
+		err := runSheetsStampWithService(nil, "sheet-1", cell, "yyyy-mm-dd", true, time.Now(), printer.New(&bytes.Buffer{}, "json"))
+		if err == nil || !strings.Contains(err.Error(), "single cell") {
+			t.Errorf("--cell %q: expected single-cell error, got %v", cell, err)
+		}
+	}
+}
+
+func TestParsePivotValues(t *testing.T) {
+	values, err := parsePivotValues("e:sum, F:average,G")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []pivotValue{{"E", "SUM"}, {"F", "AVERAGE"}, {"G", "SUM"}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("parsePivotValues = %+v, want %+v", values, want)
+	}
+
+	for _, bad := range []string{"E:TOTAL", "5:SUM", "E1:SUM"} {
+		if _, err := parsePivotValues(bad); err == nil {
+			t.Errorf("parsePivotValues(%q): expected an error", bad)
+		}
+	}
+	if _, err := parsePivotColumns("B,C1", "--rows"); err == nil || !strings.Contains(err.Error(), "--rows") {
+		t.Errorf("expected an invalid --rows column error, got %v", err)
+	}
+}
+
+func TestBuildPivotTable_OffsetsFromSourceStart(t *testing.T) {
+	source := &sheets.GridRange{SheetId: 4, StartRowIndex: 0, EndRowIndex: 500, StartColumnIndex: 1, EndColumnIndex: 6}
+	pt, err := buildPivotTable(source, []string{"B", "C"}, []string{"D"}, []pivotValue{{"F", "COUNT"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pt.Rows) != 2 || pt.Rows[0].SourceColumnOffset != 0 || pt.Rows[1].SourceColumnOffset != 1 {
+		t.Errorf("unexpected row groups: %+v", pt.Rows)
+	}
+	if len(pt.Columns) != 1 || pt.Columns[0].SourceColumnOffset != 2 || pt.Columns[0].SortOrder != "ASCENDING" {
+		t.Errorf("unexpected column groups: %+v", pt.Columns)
+	}
+	if len(pt.Values) != 1 || pt.Values[0].SourceColumnOffset != 4 || pt.Values[0].SummarizeFunction != "COUNT" {
+		t.Errorf("unexpected values: %+v", pt.Values)
+	}
+
+	if _, err := buildPivotTable(source, []string{"A"}, nil, nil); err == nil || !strings.Contains(err.Error(), "outside the source range (B:F)") {
+		t.Errorf("expected an out-of-range error, got %v", err)
+	}
+	if _, err := buildPivotTable(source, nil, nil, []pivotValue{{"G", "SUM"}}); err == nil {
+		t.Error("expected an error for a value column past the source")
+	}
+}
+
+func TestSheetsAddPivot_WritesPivotAtAnchor(t *testing.T) {
+	var sent sheets.BatchUpdateSpreadsheetRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/spreadsheets/sheet-1":
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Export"}},
+				{Properties: &sheets.SheetProperties{SheetId: 9, Title: "Pivot"}},
+			}})
+		case "/v4/spreadsheets/sheet-1:batchUpdate":
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = runSheetsAddPivotWithService(svc, "sheet-1", "Export!A1:F500", "Pivot!c3", []string{"B"}, nil, []pivotValue{{"E", "SUM"}}, printer.New(&buf, "json"))
+	if err != nil {
+		t.Fatalf("runSheetsAddPivotWithService: %v", err)
+	}
+
+	if len(sent.Requests) != 1 || sent.Requests[0].UpdateCells == nil {
+		t.Fatalf("expected one UpdateCells request, got %+v", sent.Requests)
+	}
+	uc := sent.Requests[0].UpdateCells
+	if uc.Fields != "pivotTable" || uc.Start.SheetId != 9 || uc.Start.RowIndex != 2 || uc.Start.ColumnIndex != 2 {
+		t.Errorf("unexpected update cells request: fields=%q start=%+v", uc.Fields, uc.Start)
+	}
+	pt := uc.Rows[0].Values[0].PivotTable
+	if pt == nil || pt.Source.EndRowIndex != 500 || pt.Source.EndColumnIndex != 6 {
+		t.Fatalf("unexpected pivot table: %+v", pt)
+	}
+	if len(pt.Rows) != 1 || pt.Rows[0].SourceColumnOffset != 1 || len(pt.Values) != 1 || pt.Values[0].SourceColumnOffset != 4 {
+		t.Errorf("unexpected pivot groups or values: rows=%+v values=%+v", pt.Rows, pt.Values)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v", err)
+	}
+	if out["status"] != "created" || out["sheet_id"] != float64(9) || out["anchor"] != "Pivot!C3" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsAddPivot_Validation(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "add-pivot")
+	if cmd == nil {
+		t.Fatal("add-pivot command not found")
+	}
+	reset := func() {
+		for _, name := range []string{"rows", "cols", "values", "anchor"} {
+			cmd.Flags().Set(name, "")
+		}
+		cmd.Flags().Set("source", "Export!A1:F10")
+	}
+	defer func() {
+		reset()
+		cmd.Flags().Set("source", "")
+	}()
+
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"nothing to pivot", map[string]string{"anchor": "A1"}, "at least one of --rows, --cols, or --values"},
+		{"range anchor", map[string]string{"rows": "B", "anchor": "Pivot!A1:B2"}, "--anchor must be a single cell"},
+		{"bad function", map[string]string{"values": "E:TOTAL", "anchor": "A1"}, "invalid --values function"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := runSheetsAddPivot(cmd, []string{"id"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestReadCSVValues(t *testing.T) {
+	input := "\ufeffname,amount\n\"Smith, J\",12\nsolo\n"
+	values, err := readCSVValues(strings.NewReader(input), ',', false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]interface{}{{"name", "amount"}, {"Smith, J", "12"}, {"solo"}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("readCSVValues = %v, want %v", values, want)
+	}
+
+	values, err = readCSVValues(strings.NewReader("a\tb\n1\t2\n"), '\t', true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(values, [][]interface{}{{"1", "2"}}) {
+		t.Errorf("expected the header skipped, got %v", values)
+	}
+
+	if _, err := readCSVValues(strings.NewReader("a,\"b\n"), ',', false); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func TestParseCSVDelimiter(t *testing.T) {
+	for value, want := range map[string]rune{",": ',', ";": ';', `\t`: '\t', "TAB": '\t', "|": '|'} {
+		if got, err := parseCSVDelimiter(value); err != nil || got != want {
+			t.Errorf("parseCSVDelimiter(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", ";;", `"`, "\n"} {
+		if _, err := parseCSVDelimiter(bad); err == nil {
+			t.Errorf("parseCSVDelimiter(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestSheetsImportCSV_ClearsThenWrites(t *testing.T) {
+	var calls []string
+	var written sheets.ValueRange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v4/spreadsheets/sheet-1/values/Data:clear":
+			calls = append(calls, "clear")
+			json.NewEncoder(w).Encode(&sheets.ClearValuesResponse{ClearedRange: "Data!A1:Z1000"})
+		case r.Method == "PUT" && r.URL.Path == "/v4/spreadsheets/sheet-1/values/Data":
+			calls = append(calls, "update")
+			if got := r.URL.Query().Get("valueInputOption"); got != "RAW" {
+				t.Errorf("expected valueInputOption RAW, got %q", got)
+			}
+			json.NewDecoder(r.Body).Decode(&written)
+			json.NewEncoder(w).Encode(&sheets.UpdateValuesResponse{SpreadsheetId: "sheet-1", UpdatedRange: "Data!A1:B2", UpdatedRows: 2, UpdatedCells: 4})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	values := [][]interface{}{{"a", "b"}, {"1", "2"}}
+	if err := runSheetsImportCSVWithService(svc, "sheet-1", "Data", values, "RAW", true, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSheetsImportCSVWithService: %v", err)
+	}
+
+	if strings.Join(calls, ",") != "clear,update" {
+		t.Errorf("expected clear then update, got %v", calls)
+	}
+	if len(written.Values) != 2 || written.Values[1][1] != "2" {
+		t.Errorf("unexpected values written: %v", written.Values)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v", err)
+	}
+	if out["status"] != "written" || out["rows_updated"] != float64(2) || out["cells_updated"] != float64(4) || out["cleared"] != true {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsImportCSV_Validation(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "import-csv")
+	if cmd == nil {
+		t.Fatal("import-csv command not found")
+	}
+	dir := t.TempDir()
+	headerOnly := filepath.Join(dir, "header.csv")
+	if err := os.WriteFile(headerOnly, []byte("name,amount\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd.Flags().Set("file", "")
+		cmd.Flags().Set("delimiter", ",")
+		cmd.Flags().Set("skip-header", "false")
+		cmd.Flags().Set("value-input", "USER_ENTERED")
+	}()
+
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"bad value input", map[string]string{"value-input": "PARSED"}, "invalid --value-input"},
+		{"bad delimiter", map[string]string{"delimiter": "ab"}, "invalid --delimiter"},
+		{"header only", map[string]string{"file": headerOnly, "skip-header": "true"}, "has no rows to import"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd.Flags().Set("file", "")
+			cmd.Flags().Set("delimiter", ",")
+			cmd.Flags().Set("skip-header", "false")
+			cmd.Flags().Set("value-input", "USER_ENTERED")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := runSheetsImportCSV(cmd, []string{"id", "Data!A1"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSheetsExportCSV_WritesFileAndStdout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/spreadsheets/sheet-1/values/Data!A1:C3" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"range":  "Data!A1:C3",
+			"values": [][]interface{}{{"name", "note"}, {"Ann", "says \"hi\", twice"}, {"Bob"}},
+		})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.csv")
+	var buf bytes.Buffer
+	if err := runSheetsExportCSVWithService(svc, "sheet-1", "Data!A1:C3", path, ',', io.Discard, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected CSV file: %v", err)
+	}
+	if want := "name,note\nAnn,\"says \"\"hi\"\", twice\"\nBob\n"; string(data) != want {
+		t.Errorf("unexpected CSV:\n%q\nwant\n%q", data, want)
+	}
+	if !strings.Contains(buf.String(), `"rows": 3`) || !strings.Contains(buf.String(), `"status": "exported"`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	var stdout, printed bytes.Buffer
+	if err := runSheetsExportCSVWithService(svc, "sheet-1", "Data!A1:C3", "-", '\t', &stdout, printer.New(&printed, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "name\tnote\n") {
+		t.Errorf("expected tab-separated CSV on stdout, got %q", stdout.String())
+	}
+	if printed.Len() != 0 {
+		t.Errorf("expected no JSON when writing to stdout, got %s", printed.String())
+	}
+}
+
+func TestSheetsExportCSV_AllSheets(t *testing.T) {
+	server := mockSheetsDumpServer(t)
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "export")
+	var buf bytes.Buffer
+	if err := runSheetsExportAllCSVWithService(svc, "sheet-1", dir, ';', printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "Summary.csv"))
+	if err != nil {
+		t.Fatalf("expected Summary.csv: %v", err)
+	}
+	if string(data) != "Region;Total\nEMEA;120\n" {
+		t.Errorf("unexpected CSV: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Raw Data.csv")); err != nil {
+		t.Errorf("expected Raw Data.csv: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"sheet_count": 2`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestSheetsExportCSV_Validation(t *testing.T) {
+	cmd := findSubcommand(sheetsCmd, "export-csv")
+	if cmd == nil {
+		t.Fatal("export-csv command not found")
+	}
+	defer func() {
+		cmd.Flags().Set("output", "")
+		cmd.Flags().Set("delimiter", ",")
+		cmd.Flags().Set("all-sheets", "false")
+	}()
+
+	tests := []struct {
+		name  string
+		args  []string
+		flags map[string]string
+		want  string
+	}{
+		{"no range", []string{"id"}, map[string]string{"output": "out.csv"}, "a range is required"},
+		{"range with all sheets", []string{"id", "Data"}, map[string]string{"all-sheets": "true"}, "do not pass a range"},
+		{"stdout with all sheets", []string{"id"}, map[string]string{"all-sheets": "true", "output": "-"}, "must be a directory"},
+		{"no output", []string{"id", "Data"}, map[string]string{}, "--output is required"},
+		{"bad delimiter", []string{"id", "Data"}, map[string]string{"output": "-", "delimiter": "\n"}, "invalid --delimiter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd.Flags().Set("output", "")
+			cmd.Flags().Set("delimiter", ",")
+			cmd.Flags().Set("all-sheets", "false")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := runSheetsExportCSV(cmd, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBuildSheetTemplate(t *testing.T) {
+	sheet := &sheets.Sheet{
+		Properties: &sheets.SheetProperties{
+			SheetId:        7,
+			Title:          "Report",
+			GridProperties: &sheets.GridProperties{FrozenRowCount: 1, FrozenColumnCount: 2},
+		},
+		ConditionalFormats: []*sheets.ConditionalFormatRule{{
+			Ranges: []*sheets.GridRange{{SheetId: 7, StartRowIndex: 1, StartColumnIndex: 2, EndColumnIndex: 3}},
+		}},
+		Data: []*sheets.GridData{{
+			ColumnMetadata: []*sheets.DimensionProperties{{PixelSize: 150}, {PixelSize: 90}},
+			RowMetadata:    []*sheets.DimensionProperties{{PixelSize: 32}, {PixelSize: 21}},
+			RowData: []*sheets.RowData{
+				{Values: []*sheets.CellData{
+					{UserEnteredFormat: &sheets.CellFormat{TextFormat: &sheets.TextFormat{Bold: true}}},
+					{},
+				}},
+				{Values: []*sheets.CellData{
+					{},
+					{UserEnteredFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "CURRENCY", Pattern: "$#,##0.00"}}},
+					{UserEnteredFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "PERCENT"}}},
+				}},
+			},
+		}},
+	}
+
+	tmpl := buildSheetTemplate(sheet, 1)
+	if tmpl.Version != sheetTemplateVersion || tmpl.Sheet != "Report" || tmpl.FrozenRows != 1 || tmpl.FrozenCols != 2 {
+		t.Errorf("unexpected template header: %+v", tmpl)
+	}
+	if len(tmpl.Columns) != 3 || tmpl.Columns[0].Column != "A" || tmpl.Columns[0].Width != 150 {
+		t.Fatalf("unexpected columns: %+v", tmpl.Columns)
+	}
+	if tmpl.Columns[0].NumberFormat != nil || tmpl.Columns[1].NumberFormat.Type != "CURRENCY" || tmpl.Columns[2].Column != "C" || tmpl.Columns[2].NumberFormat.Type != "PERCENT" {
+		t.Errorf("expected number formats from the first data row, got %+v", tmpl.Columns)
+	}
+	if len(tmpl.Header) != 1 || tmpl.Header[0].Height != 32 || !tmpl.Header[0].Formats[0].TextFormat.Bold || tmpl.Header[0].Formats[1] != nil {
+		t.Errorf("unexpected header: %+v", tmpl.Header)
+	}
+	if gr := tmpl.ConditionalFormats[0].Ranges[0]; gr.SheetId != 0 || gr.StartColumnIndex != 2 {
+		t.Errorf("expected rule range without a sheet ID, got %+v", gr)
+	}
+}
+
+func TestBuildApplyTemplateRequests(t *testing.T) {
+	tmpl, err := parseSheetTemplate([]byte(`{
+		"version": 1,
+		"frozen_rows": 1,
+		"header_rows": 1,
+		"columns": [
+			{"column": "A", "width": 150},
+			{"column": "B", "number_format": {"type": "CURRENCY"}},
+			{"column": "Z", "width": 80}
+		],
+		"header": [{"height": 32, "formats": [{"textFormat": {"bold": true}}, null]}],
+		"conditional_formats": [{"ranges": [{"startRowIndex": 1}], "booleanRule": {"condition": {"type": "NOT_BLANK"}}}]
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	reqs, skipped := buildApplyTemplateRequests(tmpl, 9, 5, 2, false)
+	if skipped != 1 {
+		t.Errorf("expected column Z skipped on a 5-column sheet, got %d", skipped)
+	}
+	var kinds []string
+	for _, r := range reqs {
+		switch {
+		case r.UpdateSheetProperties != nil:
+			kinds = append(kinds, "freeze")
+		case r.UpdateDimensionProperties != nil:
+			kinds = append(kinds, strings.ToLower(r.UpdateDimensionProperties.Range.Dimension))
+		case r.RepeatCell != nil:
+			kinds = append(kinds, "number")
+		case r.UpdateCells != nil:
+			kinds = append(kinds, "header")
+		case r.DeleteConditionalFormatRule != nil:
+			kinds = append(kinds, "delete-rule")
+		case r.AddConditionalFormatRule != nil:
+			kinds = append(kinds, "add-rule")
+		}
+	}
+	want := []string{"freeze", "columns", "number", "rows", "header", "delete-rule", "delete-rule", "add-rule"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("requests = %v, want %v", kinds, want)
+	}
+	if rc := reqs[2].RepeatCell; rc.Range.StartRowIndex != 1 || rc.Range.EndRowIndex != 0 || rc.Range.StartColumnIndex != 1 || rc.Fields != "userEnteredFormat.numberFormat" {
+		t.Errorf("expected number format from below the header to the end of column B, got %+v", rc.Range)
+	}
+	if uc := reqs[4].UpdateCells; len(uc.Rows[0].Values) != 2 || uc.Rows[0].Values[1].UserEnteredFormat != nil || uc.Fields != "userEnteredFormat" {
+		t.Errorf("unexpected header formats: %+v", uc)
+	}
+	if rule := reqs[7].AddConditionalFormatRule; rule.Index != 0 || rule.Rule.Ranges[0].SheetId != 9 {
+		t.Errorf("expected rule added on the target sheet, got %+v", rule)
+	}
+
+	reqs, _ = buildApplyTemplateRequests(tmpl, 9, 5, 2, true)
+	last := reqs[len(reqs)-1].AddConditionalFormatRule
+	if last == nil || last.Index != 2 {
+		t.Errorf("expected --keep-rules to add after the existing rules, got %+v", last)
+	}
+	for _, r := range reqs {
+		if r.DeleteConditionalFormatRule != nil {
+			t.Error("expected no rule deletions with --keep-rules")
+		}
+	}
+}
+
+func TestParseSheetTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not json", `{`, "invalid template JSON"},
+		{"old version", `{"version": 0}`, "unsupported template version"},
+		{"bad column", `{"version": 1, "columns": [{"column": "A1"}]}`, "invalid template column"},
+		{"negative rows", `{"version": 1, "header_rows": -1}`, "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSheetTemplate([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSheetsCaptureTemplate_WritesFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		props := map[string]interface{}{"sheetId": 3, "title": "Data", "gridProperties": map[string]interface{}{"frozenRowCount": 2, "columnCount": 4}}
+		if ranges := r.URL.Query()["ranges"]; len(ranges) > 0 {
+			if ranges[0] != "Data!1:3" {
+				t.Errorf("expected two header rows plus one data row, got %v", ranges)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"sheets": []map[string]interface{}{{
+				"properties": props,
+				"data": []map[string]interface{}{{
+					"columnMetadata": []map[string]interface{}{{"pixelSize": 120}},
+					"rowData": []map[string]interface{}{
+						{"values": []map[string]interface{}{{"userEnteredFormat": map[string]interface{}{"textFormat": map[string]interface{}{"bold": true}}}}},
+					},
+				}},
+			}}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"sheets": []map[string]interface{}{{"properties": props}}})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tmpl.json")
+	var buf bytes.Buffer
+	if err := runSheetsCaptureTemplateWithService(svc, "sheet-1", "Data", path, 0, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected template file: %v", err)
+	}
+	tmpl, err := parseSheetTemplate(data)
+	if err != nil {
+		t.Fatalf("captured template does not parse: %v", err)
+	}
+	if tmpl.HeaderRows != 2 || tmpl.FrozenRows != 2 || len(tmpl.Header) != 2 || !tmpl.Header[0].Formats[0].TextFormat.Bold {
+		t.Errorf("unexpected template: %s", data)
+	}
+	if !strings.Contains(buf.String(), `"status": "captured"`) || !strings.Contains(buf.String(), `"header_rows": 2`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestDescribeMetadata(t *testing.T) {
+	titles := map[int64]string{0: "Orders"}
+	row := describeMetadata(&sheets.DeveloperMetadata{
+		MetadataId: 9, MetadataKey: "record-id", MetadataValue: "4821", Visibility: "DOCUMENT",
+		Location: &sheets.DeveloperMetadataLocation{
+			LocationType:   "ROW",
+			DimensionRange: &sheets.DimensionRange{SheetId: 0, Dimension: "ROWS", StartIndex: 11, EndIndex: 12},
+		},
+	}, titles)
+	loc := row["location"].(map[string]interface{})
+	if loc["type"] != "row" || loc["sheet"] != "Orders" || loc["row"] != int64(12) || loc["end_row"] != int64(12) {
+		t.Errorf("unexpected row location: %v", loc)
+	}
+	if row["metadata_id"] != int64(9) || row["key"] != "record-id" || row["value"] != "4821" {
+		t.Errorf("unexpected entry: %v", row)
+	}
+
+	col := describeMetadata(&sheets.DeveloperMetadata{
+		Location: &sheets.DeveloperMetadataLocation{
+			LocationType:   "COLUMN",
+			DimensionRange: &sheets.DimensionRange{SheetId: 5, Dimension: "COLUMNS", StartIndex: 2, EndIndex: 4},
+		},
+	}, titles)
+	loc = col["location"].(map[string]interface{})
+	if loc["column"] != "C" || loc["end_column"] != "D" || loc["sheet_id"] != int64(5) {
+		t.Errorf("unexpected column location: %v", loc)
+	}
+	if _, ok := loc["sheet"]; ok {
+		t.Errorf("expected no sheet name for unknown sheet ID, got %v", loc["sheet"])
+	}
+
+	whole := describeMetadata(&sheets.DeveloperMetadata{
+		Location: &sheets.DeveloperMetadataLocation{LocationType: "SPREADSHEET", Spreadsheet: true},
+	}, titles)
+	loc = whole["location"].(map[string]interface{})
+	if loc["type"] != "spreadsheet" || len(loc) != 1 {
+		t.Errorf("unexpected spreadsheet location: %v", loc)
+	}
+}
+
+func newMetadataTestServer(t *testing.T, captured *map[string]interface{}) *sheets.Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sheets": []map[string]interface{}{
+					{"properties": map[string]interface{}{"sheetId": 0, "title": "Orders"}},
+					{"properties": map[string]interface{}{"sheetId": 3, "title": "Contacts"}},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			json.NewDecoder(r.Body).Decode(captured)
+			md := (*captured)["requests"].([]interface{})[0].(map[string]interface{})["createDeveloperMetadata"].(map[string]interface{})["developerMetadata"].(map[string]interface{})
+			md["metadataId"] = 77
+			md["location"].(map[string]interface{})["locationType"] = "ROW"
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"replies": []map[string]interface{}{{"createDeveloperMetadata": map[string]interface{}{"developerMetadata": md}}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/developerMetadata:search"):
+			json.NewDecoder(r.Body).Decode(captured)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"matchedDeveloperMetadata": []map[string]interface{}{{
+					"developerMetadata": map[string]interface{}{
+						"metadataId": 77, "metadataKey": "record-id", "metadataValue": "4821", "visibility": "DOCUMENT",
+						"location": map[string]interface{}{
+							"locationType":   "ROW",
+							"dimensionRange": map[string]interface{}{"sheetId": 3, "dimension": "ROWS", "startIndex": 11, "endIndex": 12},
+						},
+					},
+				}},
+			})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+	return svc
+}
+
+func TestSheetsSetMetadata_Row(t *testing.T) {
+	var captured map[string]interface{}
+	svc := newMetadataTestServer(t, &captured)
+
+	opts := metadataOptions{Key: "record-id", Value: "4821", Location: "row", Sheet: "Orders", Row: 12, Visibility: "DOCUMENT"}
+	var buf bytes.Buffer
+	if err := runSheetsSetMetadataWithService(svc, "test-id", opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("set-metadata failed: %v", err)
+	}
+
+	md := captured["requests"].([]interface{})[0].(map[string]interface{})["createDeveloperMetadata"].(map[string]interface{})["developerMetadata"].(map[string]interface{})
+	dr := md["location"].(map[string]interface{})["dimensionRange"].(map[string]interface{})
+	if dr["sheetId"] != float64(0) || dr["dimension"] != "ROWS" || dr["startIndex"] != float64(11) || dr["endIndex"] != float64(12) {
+		t.Errorf("unexpected dimension range: %v", dr)
+	}
+	if md["metadataKey"] != "record-id" || md["visibility"] != "DOCUMENT" {
+		t.Errorf("unexpected metadata: %v", md)
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	loc := out["location"].(map[string]interface{})
+	if out["status"] != "created" || out["metadata_id"] != float64(77) || loc["sheet"] != "Orders" || loc["row"] != float64(12) {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsSearchMetadata_ResolvesSheetNames(t *testing.T) {
+	var captured map[string]interface{}
+	svc := newMetadataTestServer(t, &captured)
+
+	var buf bytes.Buffer
+	if err := runSheetsSearchMetadataWithService(svc, "test-id", "record-id", "", "ROW", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("search-metadata failed: %v", err)
+	}
+
+	lookup := captured["dataFilters"].([]interface{})[0].(map[string]interface{})["developerMetadataLookup"].(map[string]interface{})
+	if lookup["metadataKey"] != "record-id" || lookup["locationType"] != "ROW" {
+		t.Errorf("unexpected lookup: %v", lookup)
+	}
+	if _, ok := lookup["metadataValue"]; ok {
+		t.Errorf("expected no value filter, got %v", lookup["metadataValue"])
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["count"] != float64(1) {
+		t.Fatalf("expected 1 match, got %v", out)
+	}
+	loc := out["metadata"].([]interface{})[0].(map[string]interface{})["location"].(map[string]interface{})
+	if loc["sheet"] != "Contacts" || loc["row"] != float64(12) {
+		t.Errorf("unexpected location: %v", loc)
+	}
+}
+
+func TestSheetsSetMetadata_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"bad location", map[string]string{"location": "cell"}, "invalid --location"},
+		{"bad visibility", map[string]string{"visibility": "PUBLIC"}, "invalid --visibility"},
+		{"sheet required", map[string]string{"location": "sheet"}, "--sheet is required"},
+		{"row required", map[string]string{"location": "row", "sheet": "Orders"}, "--row must be 1 or greater"},
+		{"bad column", map[string]string{"location": "column", "sheet": "Orders", "col": "C2"}, "invalid --col"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := findSubcommand(sheetsCmd, "set-metadata")
+			cmd.Flags().Set("key", "k")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			defer func() {
+				for _, name := range []string{"key", "value", "location", "sheet", "row", "col", "visibility"} {
+					f := cmd.Flags().Lookup(name)
+					f.Value.Set(f.DefValue)
+					f.Changed = false
+				}
+			}()
+			err := runSheetsSetMetadata(cmd, []string{"id"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestMapRecordsToHeader(t *testing.T) {
+	header := []interface{}{"", "Name", " City ", "", "Name", "Zip"}
+	records := []map[string]interface{}{
+		{"Name": "Ana", "City": "Lisbon", "Age": 31},
+		{"Zip": "1000", "Note": "x", "Age": 40},
+	}
+	rows, first, ignored, err := mapRecordsToHeader(records, header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != 1 {
+		t.Errorf("first = %d, want 1", first)
+	}
+	want := [][]interface{}{
+		{"Ana", "Lisbon", "", "", ""},
+		{"", "", "", "", "1000"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+	if !reflect.DeepEqual(ignored, []string{"Age", "Note"}) {
+		t.Errorf("ignored = %v", ignored)
+	}
+
+	if _, _, _, err := mapRecordsToHeader(records, []interface{}{"", " "}); err == nil || !strings.Contains(err.Error(), "row 1 is empty") {
+		t.Errorf("expected empty header error, got %v", err)
+	}
+	if _, _, _, err := mapRecordsToHeader([]map[string]interface{}{{"Other": 1}}, header); err == nil || !strings.Contains(err.Error(), "record 1 has no keys") {
+		t.Errorf("expected unmatched record error, got %v", err)
+	}
+}
+
+func TestSheetsAppendMapped_AppendsAlignedRows(t *testing.T) {
+	var appendPath string
+	var appended map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/values/"):
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"range":  "'My Contacts'!A1:Z1",
+				"values": [][]interface{}{{"City", "Name"}},
+			})
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, ":append"):
+			appendPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&appended)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"updates": map[string]interface{}{"updatedRange": "'My Contacts'!A5:B6", "updatedRows": 2},
+			})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	records := []map[string]interface{}{{"Name": "Ana", "City": "Lisbon"}, {"Name": "Bo", "Phone": "555"}}
+	var buf bytes.Buffer
+	if err := runSheetsAppendMappedWithService(svc, "test-id", "My Contacts", records, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("append-mapped failed: %v", err)
+	}
+
+	if !strings.HasSuffix(appendPath, "/values/'My Contacts'!A1:B1:append") {
+		t.Errorf("unexpected append path %q", appendPath)
+	}
+	values := appended["values"].([]interface{})
+	if !reflect.DeepEqual(values[0], []interface{}{"Lisbon", "Ana"}) || !reflect.DeepEqual(values[1], []interface{}{"", "Bo"}) {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["rows_appended"] != float64(2) || !reflect.DeepEqual(out["ignored_keys"], []interface{}{"Phone"}) {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSheetsListMerges(t *testing.T) {
+	var fields string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sheets": []map[string]interface{}{
+				{
+					"properties": map[string]interface{}{"sheetId": 0, "title": "Summary"},
+					"merges": []map[string]interface{}{
+						{"sheetId": 0, "startRowIndex": 4, "endRowIndex": 6, "startColumnIndex": 0, "endColumnIndex": 2},
+						{"sheetId": 0, "startRowIndex": 0, "endRowIndex": 1, "startColumnIndex": 1, "endColumnIndex": 4},
+					},
+				},
+				{
+					"properties": map[string]interface{}{"sheetId": 9, "title": "Q3 Data"},
+					"merges": []map[string]interface{}{
+						{"sheetId": 9, "startRowIndex": 0, "endRowIndex": 3, "startColumnIndex": 2, "endColumnIndex": 3},
+					},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	svc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create sheets service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSheetsListMergesWithService(svc, "test-id", "", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("list-merges failed: %v", err)
+	}
+	if !strings.Contains(fields, "merges") {
+		t.Errorf("expected merges in field mask, got %q", fields)
+	}
+
+	var out struct {
+		Merges []struct {
+			Sheet   string `json:"sheet"`
+			Range   string `json:"range"`
+			Rows    int64  `json:"rows"`
+			Columns int64  `json:"columns"`
+		} `json:"merges"`
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if out.Count != 3 {
+		t.Fatalf("expected 3 merges, got %d", out.Count)
+	}
+	want := []string{"Summary!B1:D1", "Summary!A5:B6", "'Q3 Data'!C1:C3"}
+	for i, m := range out.Merges {
+		if m.Range != want[i] {
+			t.Errorf("merge %d range = %q, want %q", i, m.Range, want[i])
+		}
+	}
+	if out.Merges[1].Rows != 2 || out.Merges[1].Columns != 2 || out.Merges[2].Sheet != "Q3 Data" {
+		t.Errorf("unexpected spans: %+v", out.Merges)
+	}
+
+	buf.Reset()
+	if err := runSheetsListMergesWithService(svc, "test-id", "Q3 Data", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("list-merges --sheet failed: %v", err)
+	}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out.Count != 1 || out.Merges[0].Range != "'Q3 Data'!C1:C3" {
+		t.Errorf("expected only Q3 Data merges, got %+v", out)
+	}
+
+	if err := runSheetsListMergesWithService(svc, "test-id", "Missing", printer.New(&bytes.Buffer{}, "json")); err == nil {
+		t.Error("expected an error for a missing sheet")
+	}
+}
@@ -1,23 +1,37 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	_ "image/gif"
 	_ "image/jpeg"
-	_ "image/png"
+	"image/png"
 	"io"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf16"
 
 	"github.com/omriariav/workspace-cli/internal/client"
+	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/slides/v1"
 )
 
@@ -38,17 +52,39 @@ var slidesInfoCmd = &cobra.Command{
 var slidesListCmd = &cobra.Command{
 	Use:   "list <presentation-id>",
 	Short: "List slides",
-	Long:  "Lists all slides in a presentation with their content.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSlidesList,
+	Long: `Lists all slides in a presentation with their content.
+
+--contains keeps only slides whose text includes the given substring
+(case-insensitive unless --match-case). --element-type keeps only slides
+with at least one element of that kind: shape, table, image, line, video,
+chart, or group. Filters are applied client-side; slide numbers stay those
+of the full deck and count is the number of slides returned.
+
+Examples:
+  gws slides list <presentation-id>
+  gws slides list <presentation-id> --contains "revenue"
+  gws slides list <presentation-id> --contains "Q3" --match-case
+  gws slides list <presentation-id> --element-type table`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesList,
 }
 
 var slidesReadCmd = &cobra.Command{
 	Use:   "read <presentation-id> [slide-number]",
 	Short: "Read slide content",
-	Long:  "Reads the text content of a specific slide (1-indexed) or all slides.",
-	Args:  cobra.RangeArgs(1, 2),
-	RunE:  runSlidesRead,
+	Long: `Reads the text content of a specific slide (1-indexed) or all slides.
+
+--output-format markdown prints Markdown instead of JSON, ready to paste
+into docs or prompts: a "## Slide N: <title>" heading per slide, the body
+text, other text boxes as a bulleted list, tables as Markdown tables, and
+speaker notes (with --notes) as a "> Notes:" blockquote.
+
+Examples:
+  gws slides read <id>
+  gws slides read <id> 3 --notes
+  gws slides read <id> --output-format markdown --notes`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSlidesRead,
 }
 
 var slidesCreateCmd = &cobra.Command{
@@ -77,9 +113,20 @@ var slidesDeleteSlideCmd = &cobra.Command{
 var slidesDuplicateSlideCmd = &cobra.Command{
 	Use:   "duplicate-slide <presentation-id>",
 	Short: "Duplicate a slide",
-	Long:  "Creates a copy of an existing slide in the presentation.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSlidesDuplicateSlide,
+	Long: `Creates a copy of an existing slide in the presentation.
+
+By default the API generates every new object ID. --id-map names them
+instead: each oldID=newID pair maps the source slide or one of its elements
+to the ID its copy gets, so follow-up commands can target the copy without
+re-reading the presentation. New IDs must be 5-50 characters of letters,
+digits, _, -, or :, starting with a letter, digit, or _. With --id-map the
+output lists every source-to-copy ID, including generated ones.
+
+Examples:
+  gws slides duplicate-slide <id> --slide-number 2
+  gws slides duplicate-slide <id> --slide-id p2 --id-map p2=p2_copy --id-map body_2=body_2_copy`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesDuplicateSlide,
 }
 
 var slidesAddShapeCmd = &cobra.Command{
@@ -153,6 +200,22 @@ Color should be specified as hex "#RRGGBB".`,
 	RunE: runSlidesUpdateTextStyle,
 }
 
+var slidesAddHyperlinkCmd = &cobra.Command{
+	Use:   "add-hyperlink <presentation-id>",
+	Short: "Link a text range",
+	Long: `Applies a hyperlink to text within a shape.
+
+Use --url for an external link or --slide-number to jump to another slide
+in the same presentation. --from/--to select the text range by index, as
+in update-text-style; without --to the whole text is linked.
+
+Examples:
+  gws slides add-hyperlink <id> --object-id box1 --from 0 --to 9 --url https://example.com
+  gws slides add-hyperlink <id> --object-id box1 --slide-number 5`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesAddHyperlink,
+}
+
 var slidesUpdateTransformCmd = &cobra.Command{
 	Use:   "update-transform <presentation-id>",
 	Short: "Move or resize elements",
@@ -173,6 +236,108 @@ Position and size are in points (PT).`,
 	RunE: runSlidesCreateTable,
 }
 
+var slidesAddDataTableCmd = &cobra.Command{
+	Use:   "add-data-table <presentation-id>",
+	Short: "Add a table filled with data",
+	Long: `Creates a table sized to the data and fills every cell in a single batch
+update. --json is a 2D array of rows; numbers and booleans are written as
+text, null cells are left empty, and short rows are padded with empty cells.
+
+Position and size are in points (PT).
+
+Examples:
+  gws slides add-data-table <id> --slide-number 2 --json '[["Region","Revenue"],["EMEA","120"],["APAC","95"]]'
+  gws slides add-data-table <id> --slide-id p3 --json '[["Q","Total"],["Q1",10]]' --bold-header --y 150`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesAddDataTable,
+}
+
+var slidesSetBodyCmd = &cobra.Command{
+	Use:   "set-body <presentation-id>",
+	Short: "Replace a slide's body with a nested bulleted list",
+	Long: `Replaces the text of a slide's body placeholder with a bulleted list written
+as a markdown list. Each line is one item; indentation sets the nesting level
+(an item indented deeper than the one before it is nested under it) and the
+leading "-", "*", "+", or "1." marker is dropped. A literal \n in --markdown
+is read as a line break, so the list can be given on one line.
+
+The bullet style follows the first item: ordered markers ("1.") give a
+numbered list, anything else a disc/circle/square list. --preset overrides it
+with any Slides bullet preset.
+
+Use --object-id to target a shape other than the slide's first BODY
+placeholder.
+
+Examples:
+  gws slides set-body <id> --slide-number 2 --markdown "- Goals\n  - Ship v2\n  - Cut costs\n- Risks"
+  gws slides set-body <id> --slide-id p3 --markdown "$(cat agenda.md)"
+  gws slides set-body <id> --slide-number 4 --markdown "1. Plan\n2. Build" --preset NUMBERED_UPPERALPHA_ALPHA_ROMAN`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSetBody,
+}
+
+var slidesSetSlideCmd = &cobra.Command{
+	Use:   "set-slide <presentation-id>",
+	Short: "Replace a slide's content from a JSON spec",
+	Long: `Rebuilds a slide from a JSON spec in a single batch update: every element
+that is not a placeholder is deleted, the title and body placeholders are
+filled, and the spec's elements are created. Running it again with the same
+spec gives the same slide, so it suits generating decks from templates.
+
+The spec is a JSON object:
+  {
+    "title": "Q3 Results",
+    "body": "- Revenue up 12%\n- Churn down",
+    "elements": [
+      {"type": "text", "text": "Draft", "x": 560, "y": 20, "width": 120, "height": 30,
+       "style": {"font_size": 14, "bold": true, "color": "#CC0000", "alignment": "END"}},
+      {"type": "shape", "shape_type": "ROUNDED_RECTANGLE", "text": "Next steps",
+       "x": 40, "y": 300, "width": 200, "height": 60, "style": {"fill": "#E8F0FE"}},
+      {"type": "image", "url": "https://example.com/chart.png", "x": 380, "y": 120, "width": 300, "height": 200},
+      {"type": "table", "rows": [["Region", "Revenue"], ["EMEA", 120]], "bold_header": true,
+       "x": 40, "y": 120, "width": 300, "height": 100}
+    ]
+  }
+
+"title" and "body" go into the slide's TITLE (or CENTERED_TITLE) and BODY
+(or SUBTITLE) placeholders; an empty string clears a placeholder and an
+omitted key leaves it as it is. A body written as a markdown list is
+bulleted as in set-body. Positions and sizes are in points. Element types are
+text (a text box), shape (any add-shape type), image (a public http(s) URL),
+and table; "style" takes font_family, font_size, bold, italic, color, fill,
+and alignment (START, CENTER, END, JUSTIFIED). An optional "id" sets the
+element's object ID, so reruns recreate the element under the same ID.
+
+Examples:
+  gws slides set-slide <id> --slide-number 3 --spec slide.json
+  gws slides set-slide <id> --slide-id p5 --spec templates/summary.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSetSlide,
+}
+
+var slidesAddBulletsCmd = &cobra.Command{
+	Use:   "add-bullets <presentation-id>",
+	Short: "Insert a bulleted or numbered list into a shape",
+	Long: `Inserts list items into a shape or text box as new paragraphs and bullets
+them. Items come from repeated --item flags and/or --items, one item per line
+(a literal \n in --items is read as a line break).
+
+--at is the text index to insert at (0 = beginning). Only the inserted
+paragraphs are bulleted: when --at falls inside an existing paragraph, the
+items are split off into paragraphs of their own, so existing text keeps its
+formatting.
+
+--type takes a Slides bullet preset, or "bullet" / "numbered" for
+BULLET_DISC_CIRCLE_SQUARE / NUMBERED_DIGIT_ALPHA_ROMAN.
+
+Examples:
+  gws slides add-bullets <id> --object-id box1 --item "Ship v2" --item "Cut costs"
+  gws slides add-bullets <id> --object-id box1 --items "Plan\nBuild\nLaunch" --type numbered
+  gws slides add-bullets <id> --object-id box1 --items "$(cat steps.txt)" --at 42 --type BULLET_CHECKBOX`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesAddBullets,
+}
+
 var slidesInsertTableRowsCmd = &cobra.Command{
 	Use:   "insert-table-rows <presentation-id>",
 	Short: "Add rows to a table",
@@ -257,9 +422,37 @@ var slidesListLayoutsCmd = &cobra.Command{
 var slidesAddLineCmd = &cobra.Command{
 	Use:   "add-line <presentation-id>",
 	Short: "Add a line to a slide",
-	Long:  "Creates a line or connector on a slide.\n\nLine categories: STRAIGHT, BENT, CURVED.\nYou can also use connector names like STRAIGHT_CONNECTOR_1 (the category is extracted from the prefix).",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSlidesAddLine,
+	Long: `Creates a line or connector on a slide.
+
+Line categories: STRAIGHT, BENT, CURVED.
+You can also use connector names like STRAIGHT_CONNECTOR_1 (the category is extracted from the prefix).
+
+Dash styles: SOLID, DOT, DASH, DASH_DOT, LONG_DASH, LONG_DASH_DOT.
+Arrow styles: NONE, FILL_ARROW, STEALTH_ARROW, OPEN_ARROW, FILL_CIRCLE,
+OPEN_CIRCLE, FILL_SQUARE, OPEN_SQUARE, FILL_DIAMOND, OPEN_DIAMOND.
+
+Examples:
+  gws slides add-line <id> --slide-number 1 --start-x 50 --start-y 100 --end-x 300 --end-y 100 --end-arrow FILL_ARROW
+  gws slides add-line <id> --slide-number 2 --type BENT --dash DASH --color "#888888"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesAddLine,
+}
+
+var slidesUpdateLineCmd = &cobra.Command{
+	Use:   "update-line <presentation-id>",
+	Short: "Change a line's color, weight, dash style, or arrowheads",
+	Long: `Updates the style of an existing line or connector. Only the flags you pass
+are changed.
+
+Dash styles: SOLID, DOT, DASH, DASH_DOT, LONG_DASH, LONG_DASH_DOT.
+Arrow styles: NONE, FILL_ARROW, STEALTH_ARROW, OPEN_ARROW, FILL_CIRCLE,
+OPEN_CIRCLE, FILL_SQUARE, OPEN_SQUARE, FILL_DIAMOND, OPEN_DIAMOND.
+
+Examples:
+  gws slides update-line <id> --object-id <line-id> --end-arrow FILL_ARROW
+  gws slides update-line <id> --object-id <line-id> --dash DOT --weight 2 --start-arrow OPEN_CIRCLE`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesUpdateLine,
 }
 
 var slidesGroupCmd = &cobra.Command{
@@ -280,10 +473,385 @@ var slidesUngroupCmd = &cobra.Command{
 
 var slidesThumbnailCmd = &cobra.Command{
 	Use:   "thumbnail <presentation-id>",
-	Short: "Get slide page thumbnail",
-	Long:  "Gets the thumbnail image URL for a specific slide page. Optionally downloads the image to a file.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSlidesThumbnail,
+	Short: "Get slide page thumbnails",
+	Long: `Gets the thumbnail image URL for a slide page, or for every slide when no
+slide is given. Optionally downloads the images.
+
+With a slide, --output is the file to save the image to. Without one, --output
+is a directory and each slide is saved as slide-NN.png.
+
+Examples:
+  gws slides thumbnail 1abc --slide-number 2
+  gws slides thumbnail 1abc --slide-id g1a2b3 --size LARGE --output cover.png
+  gws slides thumbnail 1abc --size SMALL --output previews/`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesThumbnail,
+}
+
+var slidesContactSheetCmd = &cobra.Command{
+	Use:   "contact-sheet <presentation-id>",
+	Short: "Render all slides into one grid image",
+	Long: `Renders a thumbnail of every slide and arranges them into a single PNG
+grid, each cell labeled with its slide number, for a printable overview of
+the whole deck.
+
+Thumbnails keep the deck's aspect ratio and are centered in equal cells
+sized to the largest one, with --gap pixels between cells and around the
+edge. --size picks the thumbnail resolution (SMALL is 200px wide, MEDIUM
+800px, LARGE 1600px).
+
+Examples:
+  gws slides contact-sheet 1abc --output sheet.png
+  gws slides contact-sheet 1abc --cols 4 --size SMALL --output review/deck.png`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesContactSheet,
+}
+
+var slidesAddFooterCmd = &cobra.Command{
+	Use:   "add-footer <presentation-id>",
+	Short: "Add a footer text and/or logo to every slide",
+	Long: `Adds a small text box and/or logo image at the same position on every slide
+in a single batch update.
+
+At least one of --text or --logo-url is required. The logo URL must be publicly
+accessible. Use --skip-first to leave the title slide untouched.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesAddFooter,
+}
+
+var slidesSetAltTextCmd = &cobra.Command{
+	Use:   "set-alt-text <presentation-id>",
+	Short: "Set alt text on an image or shape",
+	Long: `Sets the alt text title and/or description of a page element (image,
+shape, table, etc.) for screen readers and other accessibility tools.
+
+Only the flags you pass are updated; pass an empty value (--title "") to clear it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSetAltText,
+}
+
+var slidesReplaceShapesWithImageCmd = &cobra.Command{
+	Use:   "replace-shapes-with-image <presentation-id>",
+	Short: "Replace placeholder shapes with an image",
+	Long: `Replaces every shape whose text contains --contains (or --find) with an
+image, sized to fit the shape's bounds. Intended for template decks where placeholder boxes
+such as "{{logo}}" are swapped for real images.
+
+The image URL must be publicly accessible.
+
+Examples:
+  gws slides replace-shapes-with-image <id> --contains "{{logo}}" --url https://example.com/logo.png
+  gws slides replace-shapes-with-image <id> --contains "{{hero}}" --url https://... --method center-crop --slide-number 2
+  gws slides replace-shapes-with-image <id> --find "{{logo}}" --url https://... --method CENTER_INSIDE`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesReplaceShapesWithImage,
+}
+
+var slidesReplaceImageCmd = &cobra.Command{
+	Use:   "replace-image <presentation-id>",
+	Short: "Swap an existing image's source",
+	Long: `Replaces the image --object-id with the image at --url, keeping its position
+and size. --method sets how the new image fits the old bounds.
+
+With --replace-all, every image whose alt text title or description equals
+--match (case-insensitive) is replaced in one batch update instead. Template
+decks can tag placeholder images this way (see 'gws slides set-alt-text').
+
+The image URL must be publicly accessible over http(s).
+
+Examples:
+  gws slides replace-image <id> --object-id img_1 --url https://example.com/q3.png
+  gws slides replace-image <id> --object-id img_1 --url https://... --method center-crop
+  gws slides replace-image <id> --replace-all --match "hero" --url https://example.com/hero.png`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesReplaceImage,
+}
+
+var slidesBatchCmd = &cobra.Command{
+	Use:   "batch <presentation-id>",
+	Short: "Apply raw batchUpdate requests",
+	Long: `Sends Slides API requests from a JSON file or inline JSON as one
+batchUpdate, so they are applied atomically: if any request fails, none are.
+
+The JSON is either an array of requests or a batchUpdate body with a
+"requests" array, using the API's request shapes, e.g.
+  [{"createParagraphBullets": {"objectId": "box1", "textRange": {"type": "ALL"}}}]
+It is validated before anything is sent; unknown request or field names are
+rejected. The API's replies are printed in request order.
+
+See https://developers.google.com/slides/api/reference/rest/v1/presentations/request
+
+Examples:
+  gws slides batch <id> --requests-file reqs.json
+  gws slides batch <id> --requests-json '[{"deleteObject": {"objectId": "box1"}}]'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesBatch,
+}
+
+var slidesReplaceShapesWithChartCmd = &cobra.Command{
+	Use:   "replace-shapes-with-chart <presentation-id>",
+	Short: "Replace placeholder shapes with a Sheets chart",
+	Long: `Replaces every shape whose text contains --contains with a chart from a
+Google Sheets spreadsheet. The chart is placed within the shape's bounds.
+
+By default the chart is embedded as a static image; pass --linked to keep it
+linked to the spreadsheet so it can be refreshed later.
+
+Examples:
+  gws slides replace-shapes-with-chart <id> --contains "{{revenue}}" --spreadsheet-id <sheet-id> --chart-id 123456
+  gws slides replace-shapes-with-chart <id> --contains "{{chart}}" --spreadsheet-id <sheet-id> --chart-id 123456 --linked`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesReplaceShapesWithChart,
+}
+
+var slidesCloneAsCmd = &cobra.Command{
+	Use:   "clone-as",
+	Short: "Copy a presentation into a new deck with a different page size",
+	Long: `Creates a new presentation with the page size given by --aspect and
+recreates every slide of --from in it, the same way merge does. The Slides
+API cannot resize an existing presentation, so this is how a 4:3 deck
+becomes 16:9 (or back).
+
+--scale controls how elements are mapped onto the new page:
+  fit      scale uniformly to fit and center (default)
+  stretch  scale width and height independently to fill the page
+  none     keep the original positions and sizes
+
+Fidelity caveats: everything merge cannot copy is also lost here (theme,
+layouts, paragraph styles, outlines, speaker notes, animations). Scaling
+changes element boxes only; font sizes stay the same, so text may wrap
+differently.
+
+Examples:
+  gws slides clone-as --from <src-id> --title "16:9 copy" --aspect WIDESCREEN_16_9
+  gws slides clone-as --from <src-id> --title "Print" --aspect STANDARD_4_3 --scale stretch`,
+	Args: cobra.NoArgs,
+	RunE: runSlidesCloneAs,
+}
+
+var slidesExportPDFCmd = &cobra.Command{
+	Use:   "export-pdf <presentation-id>",
+	Short: "Download a presentation as PDF",
+	Long: `Exports a presentation to PDF through the Drive export endpoint and
+writes it to --output (default: "<title>.pdf" in the current directory).
+
+--pages limits the export to some slides, e.g. "3", "2-5", or "1,4-6"
+(1-indexed). Drive always exports whole files, so this makes a temporary
+copy of the deck, deletes the other slides from the copy, exports it, and
+then deletes the copy.
+
+Exporting needs view access, and fails when the owner has disabled
+download, print, and copy for viewers.
+
+Examples:
+  gws slides export-pdf <presentation-id>
+  gws slides export-pdf <presentation-id> --output review.pdf
+  gws slides export-pdf <presentation-id> --pages 2-5 --output section.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesExportPDF,
+}
+
+var slidesCommentsCmd = &cobra.Command{
+	Use:   "comments <presentation-id>",
+	Short: "List comments on a presentation",
+	Long: `Lists the comments on a presentation through the Drive Comments API,
+with author, anchor, quoted text, resolved state, and replies.
+
+Resolved comments are skipped unless --include-resolved is set. Anchors on
+slides are opaque Drive anchor strings; they are reported as-is.
+
+Examples:
+  gws slides comments <presentation-id>
+  gws slides comments <presentation-id> --include-resolved --max 500`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesComments,
+}
+
+var slidesResolveCommentCmd = &cobra.Command{
+	Use:   "resolve-comment <presentation-id>",
+	Short: "Resolve a comment on a presentation",
+	Long: `Marks a comment as resolved by posting a reply with action=resolve,
+the same way drive resolve-comment does. Optional --content attaches a
+closing note.
+
+Examples:
+  gws slides resolve-comment <presentation-id> --id AAAAxyz
+  gws slides resolve-comment <presentation-id> --id AAAAxyz --content "Fixed on slide 4"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesResolveComment,
+}
+
+var slidesDeleteCommentCmd = &cobra.Command{
+	Use:   "delete-comment <presentation-id>",
+	Short: "Delete one or all comments on a presentation",
+	Long: `Deletes the comment given by --id, or every comment with --all.
+With --all, --resolved limits the cleanup to resolved comments, which is
+the usual step before sharing a reviewed deck.
+
+Deleting stops at the first failure; the comments already deleted are
+listed in the error output.
+
+Examples:
+  gws slides delete-comment <presentation-id> --id AAAAxyz
+  gws slides delete-comment <presentation-id> --all --resolved
+  gws slides delete-comment <presentation-id> --all`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesDeleteComment,
+}
+
+var slidesMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Append the slides of one presentation to another",
+	Long: `Copies every slide of --from into --into, starting at position --at
+(1-indexed; default appends to the end). The Slides API cannot move slides
+between presentations, so each slide is recreated on a blank layout in one
+batch update.
+
+What is copied: shapes and text boxes (text, per-run text style, solid
+fill), images, tables (cell text), lines, linked Sheets charts, videos,
+grouped elements (ungrouped on copy), and solid slide backgrounds.
+
+What is not copied: layouts/masters and theme, paragraph styles, borders
+and outlines, table cell styling, image cropping and adjustments, speaker
+notes, animations, and word art. Placeholders (titles, bodies) are
+recreated as plain text boxes, so any styling they inherited from the
+source layout is lost. Images are fetched from short-lived content URLs,
+so run the merge soon after reading.
+
+When the page sizes differ, elements are mapped onto the destination page
+with --scale: fit (default) scales uniformly and centers, stretch fills the
+page, none keeps the source coordinates.
+
+Examples:
+  gws slides merge --into <dest-id> --from <src-id>
+  gws slides merge --into <dest-id> --from <src-id> --at 3
+  gws slides merge --into <dest-id> --from <src-id> --scale stretch`,
+	Args: cobra.NoArgs,
+	RunE: runSlidesMerge,
+}
+
+var slidesSetFontCmd = &cobra.Command{
+	Use:   "set-font <presentation-id>",
+	Short: "Apply one font across the whole deck",
+	Long: `Sets the font family (and optionally size) of all text in every shape and
+table cell on every slide, including elements inside groups, in one batch
+update. Useful for normalizing a deck assembled from mixed sources.
+
+Speaker notes, layouts, and masters are not changed.
+
+Examples:
+  gws slides set-font <id> --family "Roboto"
+  gws slides set-font <id> --family "Arial" --size 18`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSetFont,
+}
+
+var slidesSetDefaultsCmd = &cobra.Command{
+	Use:   "set-defaults <presentation-id>",
+	Short: "Set the default title and body text style of a deck",
+	Long: `Sets the font and size of the title and body placeholders on every master
+and layout, in one batch update, so slides created afterwards inherit them.
+Title placeholders are TITLE and CENTERED_TITLE; body placeholders are BODY
+and SUBTITLE.
+
+Existing slides pick up the change wherever their text still inherits from
+the layout; text styled directly on a slide keeps its own font. Use
+set-font to restyle existing slides.
+
+Examples:
+  gws slides set-defaults <id> --title-font "Arial" --title-size 30 --body-font "Arial" --body-size 14
+  gws slides set-defaults <id> --body-font "Roboto"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSetDefaults,
+}
+
+var slidesFontsCmd = &cobra.Command{
+	Use:   "fonts <presentation-id>",
+	Short: "List the fonts used in a presentation",
+	Long: `Lists every font family set on text runs in shapes and table cells on every
+slide, including elements inside groups, with the number of runs and the
+slides that use it. Runs without an explicit font inherit it from the
+placeholder or theme and are counted separately. Read-only.
+
+Run this before set-font to spot inconsistent or unexpected fonts.
+
+Examples:
+  gws slides fonts <id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesFonts,
+}
+
+var slidesToggleSlideNumbersCmd = &cobra.Command{
+	Use:   "toggle-slide-numbers <presentation-id>",
+	Short: "Turn slide numbers on or off for the whole deck",
+	Long: `Shows or hides slide numbers on every slide in a single batch update.
+
+--off deletes the slide-number placeholders on each slide, plus any numbers
+added earlier by --on.
+
+--on numbers every slide that has no slide-number placeholder. The Slides API
+cannot insert auto-updating slide numbers, so --on adds a text box with the
+slide's position, placed where the slide's layout (or master) puts its
+slide-number placeholder, or in the bottom-right corner if it has none.
+These numbers are static: run --on again after adding or reordering slides
+to renumber them. Slides that already show a placeholder number are left
+alone.
+
+Examples:
+  gws slides toggle-slide-numbers <id> --on
+  gws slides toggle-slide-numbers <id> --on --skip-first
+  gws slides toggle-slide-numbers <id> --off`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesToggleSlideNumbers,
+}
+
+var slidesGridLayoutCmd = &cobra.Command{
+	Use:   "grid-layout <presentation-id>",
+	Short: "Arrange elements in a grid on a slide",
+	Long: `Moves and resizes the given elements into a grid that fills the slide, in
+the order listed (left to right, then top to bottom). The grid spans the
+page size minus --margin on every side, with --gap points between cells.
+
+Each element is scaled to fit its cell with its aspect ratio kept and is
+centered in the cell; --stretch fills the cell exactly instead. Rotation is
+reset. --cols defaults to the smallest square grid that holds every element.
+
+Examples:
+  gws slides grid-layout <id> --slide-number 3 --object-ids img1,img2,img3,img4 --cols 2
+  gws slides grid-layout <id> --slide-id p5 --object-ids a,b,c,d,e,f --cols 3 --gap 10 --margin 20`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesGridLayout,
+}
+
+var slidesSetAllBackgroundsCmd = &cobra.Command{
+	Use:   "set-all-backgrounds <presentation-id>",
+	Short: "Set the background of every slide",
+	Long: `Sets the background of every slide to a solid color or an image URL in a
+single batch update. Use --skip-first to leave the title slide as it is.
+
+Examples:
+  gws slides set-all-backgrounds <id> --color "#FFFFFF"
+  gws slides set-all-backgrounds <id> --image-url https://example.com/brand-bg.png --skip-first`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSetAllBackgrounds,
+}
+
+var slidesSkipCmd = &cobra.Command{
+	Use:   "skip <presentation-id>",
+	Short: "Skip or unskip slides in present mode",
+	Long: `Marks slides as skipped so they stay in the deck but are not shown when
+presenting, e.g. for backup or appendix slides. --unskip shows them again.
+
+Select one slide with --slide-number or several with --slides, e.g. "3",
+"2-5", or "1,4-6" (1-indexed). Slides already in the requested state are
+left alone and reported with changed: false.
+
+Examples:
+  gws slides skip <id> --slide-number 7
+  gws slides skip <id> --slides 12-15
+  gws slides skip <id> --slides 12-15 --unskip`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSlidesSkip,
 }
 
 func init() {
@@ -302,8 +870,13 @@ func init() {
 	slidesCmd.AddCommand(slidesDeleteObjectCmd)
 	slidesCmd.AddCommand(slidesDeleteTextCmd)
 	slidesCmd.AddCommand(slidesUpdateTextStyleCmd)
+	slidesCmd.AddCommand(slidesAddHyperlinkCmd)
 	slidesCmd.AddCommand(slidesUpdateTransformCmd)
 	slidesCmd.AddCommand(slidesCreateTableCmd)
+	slidesCmd.AddCommand(slidesAddDataTableCmd)
+	slidesCmd.AddCommand(slidesSetBodyCmd)
+	slidesCmd.AddCommand(slidesSetSlideCmd)
+	slidesCmd.AddCommand(slidesAddBulletsCmd)
 	slidesCmd.AddCommand(slidesInsertTableRowsCmd)
 	slidesCmd.AddCommand(slidesDeleteTableRowCmd)
 	slidesCmd.AddCommand(slidesUpdateTableCellCmd)
@@ -317,12 +890,37 @@ func init() {
 	slidesCmd.AddCommand(slidesGroupCmd)
 	slidesCmd.AddCommand(slidesUngroupCmd)
 	slidesCmd.AddCommand(slidesThumbnailCmd)
+	slidesCmd.AddCommand(slidesContactSheetCmd)
+	slidesCmd.AddCommand(slidesAddFooterCmd)
+	slidesCmd.AddCommand(slidesSetAltTextCmd)
+	slidesCmd.AddCommand(slidesReplaceShapesWithImageCmd)
+	slidesCmd.AddCommand(slidesReplaceImageCmd)
+	slidesCmd.AddCommand(slidesBatchCmd)
+	slidesCmd.AddCommand(slidesReplaceShapesWithChartCmd)
+	slidesCmd.AddCommand(slidesMergeCmd)
+	slidesCmd.AddCommand(slidesCloneAsCmd)
+	slidesCmd.AddCommand(slidesSetFontCmd)
+	slidesCmd.AddCommand(slidesFontsCmd)
+	slidesCmd.AddCommand(slidesUpdateLineCmd)
+	slidesCmd.AddCommand(slidesToggleSlideNumbersCmd)
+	slidesCmd.AddCommand(slidesSetAllBackgroundsCmd)
+	slidesCmd.AddCommand(slidesGridLayoutCmd)
+	slidesCmd.AddCommand(slidesCommentsCmd)
+	slidesCmd.AddCommand(slidesResolveCommentCmd)
+	slidesCmd.AddCommand(slidesDeleteCommentCmd)
+	slidesCmd.AddCommand(slidesExportPDFCmd)
+	slidesCmd.AddCommand(slidesSetDefaultsCmd)
+	slidesCmd.AddCommand(slidesSkipCmd)
 
 	// Notes flags for read commands
 	slidesInfoCmd.Flags().Bool("notes", false, "Include speaker notes in output")
 	slidesListCmd.Flags().Bool("notes", false, "Include speaker notes in output")
+	slidesListCmd.Flags().String("contains", "", "Only list slides whose text contains this substring")
+	slidesListCmd.Flags().Bool("match-case", false, "Case-sensitive --contains matching")
+	slidesListCmd.Flags().String("element-type", "", "Only list slides containing this element kind (shape, table, image, line, video, chart, group)")
 	slidesReadCmd.Flags().Bool("notes", false, "Include speaker notes in output")
 	slidesReadCmd.Flags().Bool("elements", false, "Include element IDs and types for each slide")
+	slidesReadCmd.Flags().String("output-format", "json", "Output format: json or markdown")
 
 	// Create flags
 	slidesCreateCmd.Flags().String("title", "", "Presentation title (required)")
@@ -341,6 +939,7 @@ func init() {
 	// Duplicate-slide flags
 	slidesDuplicateSlideCmd.Flags().String("slide-id", "", "Slide object ID to duplicate")
 	slidesDuplicateSlideCmd.Flags().Int("slide-number", 0, "Slide number to duplicate (1-indexed)")
+	slidesDuplicateSlideCmd.Flags().StringArray("id-map", nil, "Object ID for a copy as oldID=newID (repeatable, or comma-separated)")
 
 	// Add-shape flags
 	slidesAddShapeCmd.Flags().String("slide-id", "", "Slide object ID")
@@ -398,7 +997,7 @@ func init() {
 	// Update-text-style flags
 	slidesUpdateTextStyleCmd.Flags().String("object-id", "", "Shape containing text (required)")
 	slidesUpdateTextStyleCmd.Flags().Int("from", 0, "Start index")
-	slidesUpdateTextStyleCmd.Flags().Int("to", -1, "End index (if omitted, applies to all text)")
+	slidesUpdateTextStyleCmd.Flags().Int("to", -1, "End index (if omitted, applies from --from to the end)")
 	slidesUpdateTextStyleCmd.Flags().Bool("bold", false, "Make text bold")
 	slidesUpdateTextStyleCmd.Flags().Bool("italic", false, "Make text italic")
 	slidesUpdateTextStyleCmd.Flags().Bool("underline", false, "Underline text")
@@ -407,6 +1006,14 @@ func init() {
 	slidesUpdateTextStyleCmd.Flags().String("color", "", "Text color as hex #RRGGBB")
 	slidesUpdateTextStyleCmd.MarkFlagRequired("object-id")
 
+	// Add-hyperlink flags
+	slidesAddHyperlinkCmd.Flags().String("object-id", "", "Shape containing text (required)")
+	slidesAddHyperlinkCmd.Flags().Int("from", 0, "Start index")
+	slidesAddHyperlinkCmd.Flags().Int("to", -1, "End index (if omitted, links from --from to the end)")
+	slidesAddHyperlinkCmd.Flags().String("url", "", "External link URL")
+	slidesAddHyperlinkCmd.Flags().Int("slide-number", 0, "Slide to link to, 1-indexed")
+	slidesAddHyperlinkCmd.MarkFlagRequired("object-id")
+
 	// Update-transform flags
 	slidesUpdateTransformCmd.Flags().String("object-id", "", "Element to transform (required)")
 	slidesUpdateTransformCmd.Flags().Float64("x", 0, "X position in points")
@@ -428,6 +1035,39 @@ func init() {
 	slidesCreateTableCmd.MarkFlagRequired("rows")
 	slidesCreateTableCmd.MarkFlagRequired("cols")
 
+	// Add-data-table flags
+	slidesAddDataTableCmd.Flags().String("slide-id", "", "Slide object ID")
+	slidesAddDataTableCmd.Flags().Int("slide-number", 0, "Slide number (1-indexed)")
+	slidesAddDataTableCmd.Flags().String("json", "", "Table data as a JSON 2D array of rows (required)")
+	slidesAddDataTableCmd.Flags().Bool("bold-header", false, "Bold the text of the first row")
+	slidesAddDataTableCmd.Flags().Float64("x", 100, "X position in points")
+	slidesAddDataTableCmd.Flags().Float64("y", 100, "Y position in points")
+	slidesAddDataTableCmd.Flags().Float64("width", 400, "Width in points")
+	slidesAddDataTableCmd.Flags().Float64("height", 200, "Height in points")
+	slidesAddDataTableCmd.MarkFlagRequired("json")
+
+	// Set-body flags
+	slidesSetBodyCmd.Flags().String("slide-id", "", "Slide object ID")
+	slidesSetBodyCmd.Flags().Int("slide-number", 0, "Slide number (1-indexed)")
+	slidesSetBodyCmd.Flags().String("markdown", "", "Markdown list; indentation sets nesting (required)")
+	slidesSetBodyCmd.Flags().String("object-id", "", "Shape to fill instead of the slide's BODY placeholder")
+	slidesSetBodyCmd.Flags().String("preset", "", "Bullet preset, e.g. BULLET_DISC_CIRCLE_SQUARE or NUMBERED_DIGIT_ALPHA_ROMAN (default from the first marker)")
+	slidesSetBodyCmd.MarkFlagRequired("markdown")
+
+	// Set-slide flags
+	slidesSetSlideCmd.Flags().String("slide-id", "", "Slide object ID")
+	slidesSetSlideCmd.Flags().Int("slide-number", 0, "Slide number (1-indexed)")
+	slidesSetSlideCmd.Flags().String("spec", "", "Path to the JSON slide spec (required)")
+	slidesSetSlideCmd.MarkFlagRequired("spec")
+
+	// Add-bullets flags
+	slidesAddBulletsCmd.Flags().String("object-id", "", "Shape or text box to insert the list into (required)")
+	slidesAddBulletsCmd.Flags().StringArray("item", nil, "List item (repeatable)")
+	slidesAddBulletsCmd.Flags().String("items", "", "List items, one per line")
+	slidesAddBulletsCmd.Flags().String("type", "bullet", "Bullet preset, e.g. BULLET_DISC_CIRCLE_SQUARE or NUMBERED_DIGIT_ALPHA_ROMAN (bullet, numbered)")
+	slidesAddBulletsCmd.Flags().Int64("at", 0, "Text index to insert at (0 = beginning)")
+	slidesAddBulletsCmd.MarkFlagRequired("object-id")
+
 	// Insert-table-rows flags
 	slidesInsertTableRowsCmd.Flags().String("table-id", "", "Table object ID (required)")
 	slidesInsertTableRowsCmd.Flags().Int("at", 0, "Row index to insert at (required)")
@@ -502,6 +1142,14 @@ func init() {
 	slidesAddLineCmd.Flags().Float64("end-y", 200, "End Y position in points")
 	slidesAddLineCmd.Flags().String("color", "", "Line color as hex #RRGGBB")
 	slidesAddLineCmd.Flags().Float64("weight", 1, "Line thickness in points")
+	addLineStyleFlags(slidesAddLineCmd)
+
+	// Update-line flags
+	slidesUpdateLineCmd.Flags().String("object-id", "", "Line to update (required)")
+	slidesUpdateLineCmd.Flags().String("color", "", "Line color as hex #RRGGBB")
+	slidesUpdateLineCmd.Flags().Float64("weight", 0, "Line thickness in points")
+	addLineStyleFlags(slidesUpdateLineCmd)
+	slidesUpdateLineCmd.MarkFlagRequired("object-id")
 
 	// Group flags
 	slidesGroupCmd.Flags().String("object-ids", "", "Comma-separated element IDs to group (required)")
@@ -512,10 +1160,136 @@ func init() {
 	slidesUngroupCmd.MarkFlagRequired("group-id")
 
 	// Thumbnail flags
-	slidesThumbnailCmd.Flags().String("slide", "", "Slide object ID or 1-based slide number (required)")
+	slidesThumbnailCmd.Flags().String("slide", "", "Slide object ID or 1-based slide number (default: all slides)")
+	slidesThumbnailCmd.Flags().String("slide-id", "", "Slide object ID")
+	slidesThumbnailCmd.Flags().Int("slide-number", 0, "Slide number (1-indexed)")
 	slidesThumbnailCmd.Flags().String("size", "MEDIUM", "Thumbnail size: SMALL, MEDIUM, LARGE")
-	slidesThumbnailCmd.Flags().String("download", "", "Download thumbnail image to this file path")
-	slidesThumbnailCmd.MarkFlagRequired("slide")
+	slidesThumbnailCmd.Flags().String("mime-type", "PNG", "Thumbnail image format (the API supports PNG)")
+	slidesThumbnailCmd.Flags().String("output", "", "Save the image to this file (a directory when rendering all slides)")
+	slidesThumbnailCmd.Flags().String("download", "", "Alias for --output")
+
+	// Contact sheet flags
+	slidesContactSheetCmd.Flags().Int("cols", 3, "Number of columns in the grid")
+	slidesContactSheetCmd.Flags().String("output", "", "PNG file to write the contact sheet to (required)")
+	slidesContactSheetCmd.Flags().String("size", "MEDIUM", "Thumbnail size: SMALL, MEDIUM, LARGE")
+	slidesContactSheetCmd.Flags().Int("gap", 16, "Spacing between cells and around the edge, in pixels")
+	slidesContactSheetCmd.Flags().Int("concurrency", 4, "Thumbnails to fetch in parallel")
+	slidesContactSheetCmd.MarkFlagRequired("output")
+
+	// Add-footer flags
+	slidesAddFooterCmd.Flags().String("text", "", "Footer text")
+	slidesAddFooterCmd.Flags().String("logo-url", "", "Publicly accessible logo image URL")
+	slidesAddFooterCmd.Flags().String("position", "bottom-left", "Footer position: bottom-left, bottom-center, bottom-right")
+	slidesAddFooterCmd.Flags().Bool("skip-first", false, "Skip the first (title) slide")
+	slidesAddFooterCmd.Flags().Float64("font-size", 10, "Footer text font size in points")
+	slidesAddFooterCmd.Flags().Float64("logo-size", 32, "Logo width and height in points")
+
+	// Set-alt-text flags
+	slidesSetAltTextCmd.Flags().String("object-id", "", "Page element to update (required)")
+	slidesSetAltTextCmd.Flags().String("title", "", "Alt text title")
+	slidesSetAltTextCmd.Flags().String("description", "", "Alt text description")
+	slidesSetAltTextCmd.MarkFlagRequired("object-id")
+
+	// Replace-shapes-with-image flags
+	slidesReplaceShapesWithImageCmd.Flags().String("contains", "", "Replace shapes whose text contains this string (required unless --find)")
+	slidesReplaceShapesWithImageCmd.Flags().String("find", "", "Same as --contains, as in replace-text")
+	slidesReplaceShapesWithImageCmd.Flags().String("url", "", "Publicly accessible image URL (required)")
+	slidesReplaceShapesWithImageCmd.Flags().String("method", "center-inside", "How the image fits the shape: center-inside (CENTER_INSIDE), center-crop (CENTER_CROP)")
+	slidesReplaceShapesWithImageCmd.Flags().Bool("match-case", true, "Case-sensitive matching")
+	slidesReplaceShapesWithImageCmd.Flags().String("slide-id", "", "Scope replacement to a specific slide by object ID")
+	slidesReplaceShapesWithImageCmd.Flags().Int("slide-number", 0, "Scope replacement to a specific slide by number (1-indexed)")
+	slidesReplaceShapesWithImageCmd.MarkFlagRequired("url")
+
+	// Replace-image flags
+	slidesReplaceImageCmd.Flags().String("object-id", "", "Image to replace")
+	slidesReplaceImageCmd.Flags().String("url", "", "Publicly accessible image URL (required)")
+	slidesReplaceImageCmd.Flags().String("method", "center-inside", "How the image fits the old bounds: center-inside, center-crop")
+	slidesReplaceImageCmd.Flags().Bool("replace-all", false, "Replace every image whose alt text equals --match")
+	slidesReplaceImageCmd.Flags().String("match", "", "Alt text title or description to match (with --replace-all)")
+	slidesReplaceImageCmd.MarkFlagRequired("url")
+
+	// Batch flags
+	slidesBatchCmd.Flags().String("requests-file", "", "Path to a JSON file of batchUpdate requests")
+	slidesBatchCmd.Flags().String("requests-json", "", "Inline JSON of batchUpdate requests")
+
+	// Replace-shapes-with-chart flags
+	slidesReplaceShapesWithChartCmd.Flags().String("contains", "", "Replace shapes whose text contains this string (required)")
+	slidesReplaceShapesWithChartCmd.Flags().String("spreadsheet-id", "", "Spreadsheet containing the chart (required)")
+	slidesReplaceShapesWithChartCmd.Flags().Int64("chart-id", 0, "Chart ID within the spreadsheet (required)")
+	slidesReplaceShapesWithChartCmd.Flags().Bool("linked", false, "Keep the chart linked to the spreadsheet")
+	slidesReplaceShapesWithChartCmd.Flags().Bool("match-case", true, "Case-sensitive matching")
+	slidesReplaceShapesWithChartCmd.Flags().String("slide-id", "", "Scope replacement to a specific slide by object ID")
+	slidesReplaceShapesWithChartCmd.Flags().Int("slide-number", 0, "Scope replacement to a specific slide by number (1-indexed)")
+	slidesReplaceShapesWithChartCmd.MarkFlagRequired("contains")
+	slidesReplaceShapesWithChartCmd.MarkFlagRequired("spreadsheet-id")
+	slidesReplaceShapesWithChartCmd.MarkFlagRequired("chart-id")
+
+	// Merge flags
+	slidesMergeCmd.Flags().String("into", "", "Destination presentation ID (required)")
+	slidesMergeCmd.Flags().String("from", "", "Source presentation ID (required)")
+	slidesMergeCmd.Flags().Int("at", 0, "Position (1-indexed) for the first copied slide (default: append)")
+	slidesMergeCmd.Flags().String("scale", "fit", "Element mapping when page sizes differ: fit, stretch, none")
+	slidesMergeCmd.MarkFlagRequired("into")
+	slidesMergeCmd.MarkFlagRequired("from")
+
+	// Clone-as flags
+	slidesCloneAsCmd.Flags().String("from", "", "Source presentation ID (required)")
+	slidesCloneAsCmd.Flags().String("title", "", "Title of the new presentation (required)")
+	slidesCloneAsCmd.Flags().String("aspect", "WIDESCREEN_16_9", "Page size: WIDESCREEN_16_9, WIDESCREEN_16_10, STANDARD_4_3")
+	slidesCloneAsCmd.Flags().String("scale", "fit", "Element mapping: fit, stretch, none")
+	slidesCloneAsCmd.MarkFlagRequired("from")
+	slidesCloneAsCmd.MarkFlagRequired("title")
+
+	// Set-font flags
+	slidesSetFontCmd.Flags().String("family", "", "Font family, e.g. Roboto (required)")
+	slidesSetFontCmd.Flags().Float64("size", 0, "Font size in points (default: keep existing sizes)")
+	slidesSetFontCmd.MarkFlagRequired("family")
+
+	// Toggle-slide-numbers flags
+	slidesToggleSlideNumbersCmd.Flags().Bool("on", false, "Show slide numbers")
+	slidesToggleSlideNumbersCmd.Flags().Bool("off", false, "Hide slide numbers")
+	slidesToggleSlideNumbersCmd.Flags().Bool("skip-first", false, "Leave the first (title) slide untouched")
+	slidesToggleSlideNumbersCmd.Flags().Float64("font-size", 10, "Font size in points for added numbers")
+
+	// Set-all-backgrounds flags
+	slidesSetAllBackgroundsCmd.Flags().String("color", "", "Background color as hex #RRGGBB")
+	slidesSetAllBackgroundsCmd.Flags().String("image-url", "", "Background image URL")
+	slidesSetAllBackgroundsCmd.Flags().Bool("skip-first", false, "Leave the first (title) slide untouched")
+
+	// Grid-layout flags
+	slidesGridLayoutCmd.Flags().String("slide-id", "", "Slide object ID")
+	slidesGridLayoutCmd.Flags().Int("slide-number", 0, "Slide number (1-indexed)")
+	slidesGridLayoutCmd.Flags().String("object-ids", "", "Comma-separated element IDs, in grid order (required)")
+	slidesGridLayoutCmd.Flags().Int("cols", 0, "Number of columns (default: smallest square grid)")
+	slidesGridLayoutCmd.Flags().Float64("gap", 10, "Space between cells in points")
+	slidesGridLayoutCmd.Flags().Float64("margin", 20, "Space between the grid and the slide edges in points")
+	slidesGridLayoutCmd.Flags().Bool("stretch", false, "Fill each cell exactly instead of keeping the aspect ratio")
+	slidesGridLayoutCmd.MarkFlagRequired("object-ids")
+
+	// Set-defaults flags
+	slidesSetDefaultsCmd.Flags().String("title-font", "", "Font family for title placeholders")
+	slidesSetDefaultsCmd.Flags().Float64("title-size", 0, "Font size in points for title placeholders")
+	slidesSetDefaultsCmd.Flags().String("body-font", "", "Font family for body and subtitle placeholders")
+	slidesSetDefaultsCmd.Flags().Float64("body-size", 0, "Font size in points for body and subtitle placeholders")
+
+	// Export-pdf flags
+	slidesExportPDFCmd.Flags().String("output", "", "Output file path (default: <title>.pdf)")
+	slidesExportPDFCmd.Flags().String("pages", "", "Slides to include, e.g. 3, 2-5, or 1,4-6 (default: all)")
+
+	// Comment flags
+	slidesCommentsCmd.Flags().Int64("max", 100, "Maximum number of comments to fetch")
+	slidesCommentsCmd.Flags().Bool("include-resolved", false, "Include resolved comments")
+	slidesResolveCommentCmd.Flags().String("id", "", "Comment ID (required)")
+	slidesResolveCommentCmd.Flags().String("content", "", "Optional closing note attached to the resolve reply")
+	slidesResolveCommentCmd.MarkFlagRequired("id")
+	slidesDeleteCommentCmd.Flags().String("id", "", "Comment ID to delete")
+	slidesDeleteCommentCmd.Flags().Bool("all", false, "Delete every comment on the presentation")
+	slidesDeleteCommentCmd.Flags().Bool("resolved", false, "With --all, delete only resolved comments")
+
+	// Skip flags
+	slidesSkipCmd.Flags().Int("slide-number", 0, "Slide number (1-indexed)")
+	slidesSkipCmd.Flags().String("slides", "", "Slides to change, e.g. 3, 2-5, or 1,4-6")
+	slidesSkipCmd.Flags().Bool("unskip", false, "Show the slides in present mode again")
 }
 
 func runSlidesInfo(cmd *cobra.Command, args []string) error {
@@ -583,10 +1357,91 @@ func runSlidesInfo(cmd *cobra.Command, args []string) error {
 	return p.Print(result)
 }
 
+// slideElementTypes are the element kinds accepted by slides list
+// --element-type.
+var slideElementTypes = []string{"shape", "table", "image", "line", "video", "chart", "group"}
+
+// slidesListFilter selects which slides slides list returns. The zero
+// value matches every slide.
+type slidesListFilter struct {
+	contains    string
+	matchCase   bool
+	elementType string
+}
+
+// active reports whether any filter is set.
+func (f slidesListFilter) active() bool {
+	return f.contains != "" || f.elementType != ""
+}
+
+// matches reports whether slide passes the filter.
+func (f slidesListFilter) matches(slide *slides.Page) bool {
+	if f.contains != "" {
+		text, term := extractSlideText(slide), f.contains
+		if !f.matchCase {
+			text, term = strings.ToLower(text), strings.ToLower(term)
+		}
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	if f.elementType != "" && !hasElementType(slide.PageElements, f.elementType) {
+		return false
+	}
+	return true
+}
+
+// hasElementType reports whether any element, including those nested in
+// groups, is of the given kind.
+func hasElementType(elements []*slides.PageElement, kind string) bool {
+	for _, el := range elements {
+		var ok bool
+		switch kind {
+		case "shape":
+			ok = el.Shape != nil
+		case "table":
+			ok = el.Table != nil
+		case "image":
+			ok = el.Image != nil
+		case "line":
+			ok = el.Line != nil
+		case "video":
+			ok = el.Video != nil
+		case "chart":
+			ok = el.SheetsChart != nil
+		case "group":
+			ok = el.ElementGroup != nil
+		}
+		if ok {
+			return true
+		}
+		if el.ElementGroup != nil && hasElementType(el.ElementGroup.Children, kind) {
+			return true
+		}
+	}
+	return false
+}
+
 func runSlidesList(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
+	includeNotes, _ := cmd.Flags().GetBool("notes")
+	contains, _ := cmd.Flags().GetString("contains")
+	matchCase, _ := cmd.Flags().GetBool("match-case")
+	elementType, _ := cmd.Flags().GetString("element-type")
+
+	elementType = strings.ToLower(strings.TrimSpace(elementType))
+	if elementType != "" {
+		valid := false
+		for _, kind := range slideElementTypes {
+			valid = valid || kind == elementType
+		}
+		if !valid {
+			return usageErrorf("invalid --element-type %q: must be one of %s", elementType, strings.Join(slideElementTypes, ", "))
+		}
+	}
+
 	factory, err := client.NewFactory(ctx)
 	if err != nil {
 		return p.PrintError(err)
@@ -597,17 +1452,21 @@ func runSlidesList(cmd *cobra.Command, args []string) error {
 		return p.PrintError(err)
 	}
 
-	presentationID := args[0]
+	filter := slidesListFilter{contains: contains, matchCase: matchCase, elementType: elementType}
+	return runSlidesListWithService(svc, args[0], includeNotes, filter, p)
+}
 
+func runSlidesListWithService(svc *slides.Service, presentationID string, includeNotes bool, filter slidesListFilter, p printer.Printer) error {
 	presentation, err := svc.Presentations.Get(presentationID).Do()
 	if err != nil {
 		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
 	}
 
-	includeNotes, _ := cmd.Flags().GetBool("notes")
-
 	slidesList := make([]map[string]interface{}, 0, len(presentation.Slides))
 	for i, slide := range presentation.Slides {
+		if !filter.matches(slide) {
+			continue
+		}
 		slideData := map[string]interface{}{
 			"number": i + 1,
 			"id":     slide.ObjectId,
@@ -632,17 +1491,27 @@ func runSlidesList(cmd *cobra.Command, args []string) error {
 		slidesList = append(slidesList, slideData)
 	}
 
-	return p.Print(map[string]interface{}{
+	result := map[string]interface{}{
 		"presentation": presentation.Title,
 		"slides":       slidesList,
 		"count":        len(slidesList),
-	})
+	}
+	if filter.active() {
+		result["total_slides"] = len(presentation.Slides)
+	}
+	return p.Print(result)
 }
 
 func runSlidesRead(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
+	outputFormat, _ := cmd.Flags().GetString("output-format")
+	outputFormat = strings.ToLower(strings.TrimSpace(outputFormat))
+	if outputFormat != "json" && outputFormat != "markdown" {
+		return usageErrorf("invalid --output-format %q: must be json or markdown", outputFormat)
+	}
+
 	factory, err := client.NewFactory(ctx)
 	if err != nil {
 		return p.PrintError(err)
@@ -673,6 +1542,9 @@ func runSlidesRead(cmd *cobra.Command, args []string) error {
 		}
 
 		slide := presentation.Slides[slideNum-1]
+		if outputFormat == "markdown" {
+			return printSlidesMarkdown(slideMarkdown(slideNum, slide, includeNotes))
+		}
 		text := extractSlideText(slide)
 
 		result := map[string]interface{}{
@@ -697,6 +1569,10 @@ func runSlidesRead(cmd *cobra.Command, args []string) error {
 		return p.Print(result)
 	}
 
+	if outputFormat == "markdown" {
+		return printSlidesMarkdown(presentationMarkdown(presentation, includeNotes))
+	}
+
 	// Read all slides
 	slidesContent := make([]map[string]interface{}, 0, len(presentation.Slides))
 	for i, slide := range presentation.Slides {
@@ -790,13 +1666,7 @@ func extractTableText(table *slides.Table) string {
 		var cells []string
 		for _, cell := range row.TableCells {
 			if cell.Text != nil {
-				var cellText strings.Builder
-				for _, elem := range cell.Text.TextElements {
-					if elem.TextRun != nil {
-						cellText.WriteString(elem.TextRun.Content)
-					}
-				}
-				cells = append(cells, strings.TrimSpace(cellText.String()))
+				cells = append(cells, tableCellText(cell))
 			}
 		}
 		rows = append(rows, strings.Join(cells, "\t"))
@@ -805,6 +1675,194 @@ func extractTableText(table *slides.Table) string {
 	return strings.Join(rows, "\n")
 }
 
+// tableCellText returns a table cell's trimmed text.
+func tableCellText(cell *slides.TableCell) string {
+	if cell.Text == nil {
+		return ""
+	}
+	var cellText strings.Builder
+	for _, elem := range cell.Text.TextElements {
+		if elem.TextRun != nil {
+			cellText.WriteString(elem.TextRun.Content)
+		}
+	}
+	return strings.TrimSpace(cellText.String())
+}
+
+// printSlidesMarkdown writes slides read's Markdown output as-is, honoring
+// --quiet and --output-file like other printed results.
+func printSlidesMarkdown(markdown string) error {
+	if quiet && outputFile == "" {
+		return nil
+	}
+	_, err := fmt.Fprint(resultWriter(), markdown)
+	return err
+}
+
+// presentationMarkdown renders every slide of a presentation as Markdown
+// under a title heading.
+func presentationMarkdown(presentation *slides.Presentation, includeNotes bool) string {
+	var b strings.Builder
+	if presentation.Title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", presentation.Title)
+	}
+	for i, slide := range presentation.Slides {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(slideMarkdown(i+1, slide, includeNotes))
+	}
+	return b.String()
+}
+
+// slideMarkdown renders one slide as Markdown: a "## Slide N: <title>"
+// heading, body placeholder text (bulleted paragraphs stay bulleted), the
+// text of other shapes as a bulleted list, tables as Markdown tables, and
+// speaker notes as a "> Notes:" blockquote when includeNotes is set.
+func slideMarkdown(number int, slide *slides.Page, includeNotes bool) string {
+	var blocks []string
+	heading := fmt.Sprintf("## Slide %d", number)
+	if title := extractSlideTitle(slide); title != "" {
+		heading += ": " + strings.Join(strings.Fields(title), " ")
+	}
+	blocks = append(blocks, heading)
+
+	var body, others, tables []string
+	for _, element := range slide.PageElements {
+		switch {
+		case element.Shape != nil:
+			placeholder := ""
+			if element.Shape.Placeholder != nil {
+				placeholder = element.Shape.Placeholder.Type
+			}
+			switch placeholder {
+			case "TITLE", "CENTERED_TITLE":
+			case "BODY", "SUBTITLE":
+				if text := paragraphsMarkdown(element.Shape); text != "" {
+					body = append(body, text)
+				}
+			default:
+				for _, line := range strings.Split(extractShapeText(element.Shape), "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						others = append(others, "- "+line)
+					}
+				}
+			}
+		case element.Table != nil:
+			if table := tableMarkdown(element.Table); table != "" {
+				tables = append(tables, table)
+			}
+		}
+	}
+	blocks = append(blocks, body...)
+	if len(others) > 0 {
+		blocks = append(blocks, strings.Join(others, "\n"))
+	}
+	blocks = append(blocks, tables...)
+
+	if includeNotes {
+		if notes := extractSpeakerNotes(slide); notes != "" {
+			lines := strings.Split(notes, "\n")
+			quoted := []string{"> Notes: " + lines[0]}
+			for _, line := range lines[1:] {
+				quoted = append(quoted, strings.TrimRight("> "+line, " "))
+			}
+			blocks = append(blocks, strings.Join(quoted, "\n"))
+		}
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// paragraphsMarkdown renders a shape's paragraphs one per line, as "- "
+// list items (indented by nesting level) when the paragraph is bulleted.
+// Consecutive plain paragraphs are separated by blank lines.
+func paragraphsMarkdown(shape *slides.Shape) string {
+	if shape.Text == nil {
+		return ""
+	}
+	type paragraph struct {
+		text   strings.Builder
+		bullet bool
+		level  int64
+	}
+	current := &paragraph{}
+	paragraphs := []*paragraph{current}
+	for _, elem := range shape.Text.TextElements {
+		switch {
+		case elem.ParagraphMarker != nil:
+			current = &paragraph{}
+			if bullet := elem.ParagraphMarker.Bullet; bullet != nil {
+				current.bullet = true
+				current.level = bullet.NestingLevel
+			}
+			paragraphs = append(paragraphs, current)
+		case elem.TextRun != nil:
+			current.text.WriteString(elem.TextRun.Content)
+		case elem.AutoText != nil:
+			current.text.WriteString(elem.AutoText.Content)
+		}
+	}
+
+	var b strings.Builder
+	prevBullet := false
+	for _, para := range paragraphs {
+		text := strings.TrimSpace(strings.ReplaceAll(para.text.String(), "\v", " "))
+		if text == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+			if !para.bullet || !prevBullet {
+				b.WriteString("\n")
+			}
+		}
+		if para.bullet {
+			b.WriteString(strings.Repeat("  ", int(para.level)) + "- ")
+		}
+		b.WriteString(text)
+		prevBullet = para.bullet
+	}
+	return b.String()
+}
+
+// tableMarkdown renders a table as a Markdown table with its first row as
+// the header. Empty cells keep their column; line breaks become
+// <br> and pipes are escaped so each row stays on one line.
+func tableMarkdown(table *slides.Table) string {
+	var rows [][]string
+	columns := 0
+	for _, row := range table.TableRows {
+		var cells []string
+		for _, cell := range row.TableCells {
+			text := strings.ReplaceAll(tableCellText(cell), "|", "\\|")
+			text = strings.Join(strings.Fields(strings.ReplaceAll(text, "\n", " <br> ")), " ")
+			cells = append(cells, text)
+		}
+		if len(cells) > columns {
+			columns = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |")
+	}
+	writeRow(rows[0])
+	b.WriteString("\n|" + strings.Repeat(" --- |", columns))
+	for _, row := range rows[1:] {
+		b.WriteString("\n")
+		writeRow(row)
+	}
+	return b.String()
+}
+
 // extractSpeakerNotes extracts speaker notes text from a slide's notes page.
 func extractSpeakerNotes(slide *slides.Page) string {
 	if slide.SlideProperties == nil {
@@ -1145,6 +2203,18 @@ func runSlidesDuplicateSlide(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
 
+	slideID, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	idMapFlags, _ := cmd.Flags().GetStringArray("id-map")
+
+	if slideNumber <= 0 && slideID == "" {
+		return usageErrorf("must specify --slide-id or --slide-number")
+	}
+	idMap, err := parseIDMap(idMapFlags)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
 	factory, err := client.NewFactory(ctx)
 	if err != nil {
 		return p.PrintError(err)
@@ -1155,30 +2225,59 @@ func runSlidesDuplicateSlide(cmd *cobra.Command, args []string) error {
 		return p.PrintError(err)
 	}
 
-	presentationID := args[0]
-	slideID, _ := cmd.Flags().GetString("slide-id")
-	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	return runSlidesDuplicateSlideWithService(svc, args[0], slideID, slideNumber, idMap, p)
+}
 
-	// If slide number provided, look up slide ID
-	if slideNumber > 0 {
+func runSlidesDuplicateSlideWithService(svc *slides.Service, presentationID, slideID string, slideNumber int, idMap map[string]string, p printer.Printer) error {
+	// The source slide is needed to resolve --slide-number and to check
+	// --id-map against the objects that are actually being copied.
+	var source *slides.Page
+	if slideNumber > 0 || len(idMap) > 0 {
 		presentation, err := svc.Presentations.Get(presentationID).Do()
 		if err != nil {
 			return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
 		}
 
-		if slideNumber > len(presentation.Slides) {
-			return p.PrintError(fmt.Errorf("slide number %d out of range (1-%d)", slideNumber, len(presentation.Slides)))
+		if slideNumber > 0 {
+			if slideNumber > len(presentation.Slides) {
+				return p.PrintError(fmt.Errorf("slide number %d out of range (1-%d)", slideNumber, len(presentation.Slides)))
+			}
+			source = presentation.Slides[slideNumber-1]
+			slideID = source.ObjectId
+		} else {
+			for _, slide := range presentation.Slides {
+				if slide.ObjectId == slideID {
+					source = slide
+				}
+			}
+			if source == nil {
+				return p.PrintError(fmt.Errorf("slide %s not found", slideID))
+			}
 		}
+	}
 
-		slideID = presentation.Slides[slideNumber-1].ObjectId
-	} else if slideID == "" {
-		return usageErrorf("must specify --slide-id or --slide-number")
+	if len(idMap) > 0 {
+		onSlide := map[string]bool{}
+		for _, id := range slideObjectIDs(source) {
+			onSlide[id] = true
+		}
+		var unknown []string
+		for oldID := range idMap {
+			if !onSlide[oldID] {
+				unknown = append(unknown, oldID)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return p.PrintError(fmt.Errorf("--id-map: no object %s on slide %s", strings.Join(unknown, ", "), slideID))
+		}
 	}
 
 	requests := []*slides.Request{
 		{
 			DuplicateObject: &slides.DuplicateObjectRequest{
-				ObjectId: slideID,
+				ObjectId:  slideID,
+				ObjectIds: idMap,
 			},
 		},
 	}
@@ -1206,9 +2305,103 @@ func runSlidesDuplicateSlide(cmd *cobra.Command, args []string) error {
 		result["source_slide_number"] = slideNumber
 	}
 
+	if len(idMap) > 0 {
+		// Read the copy back so IDs the API generated for unmapped elements
+		// are reported too.
+		presentation, err := svc.Presentations.Get(presentationID).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("slide duplicated as %s, but failed to read back its object IDs: %w", newSlideID, err))
+		}
+		var copied *slides.Page
+		for _, slide := range presentation.Slides {
+			if slide.ObjectId == newSlideID {
+				copied = slide
+			}
+		}
+		result["object_ids"] = duplicatedObjectIDs(source, copied, idMap)
+	}
+
 	return p.Print(result)
 }
 
+// objectIDPattern is the Slides API's rule for caller-chosen object IDs.
+var objectIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_\-:]{4,49}$`)
+
+// parseIDMap parses --id-map values, each one or more comma-separated
+// oldID=newID pairs, into a DuplicateObjectRequest ObjectIds map.
+func parseIDMap(values []string) (map[string]string, error) {
+	idMap := map[string]string{}
+	used := map[string]string{}
+	for _, value := range values {
+		for _, pair := range strings.Split(value, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			oldID, newID, ok := strings.Cut(pair, "=")
+			oldID, newID = strings.TrimSpace(oldID), strings.TrimSpace(newID)
+			if !ok || oldID == "" || newID == "" {
+				return nil, fmt.Errorf("invalid --id-map %q: use oldID=newID", pair)
+			}
+			if !objectIDPattern.MatchString(newID) {
+				return nil, fmt.Errorf("invalid --id-map ID %q: must be 5-50 letters, digits, _, -, or :, not starting with - or :", newID)
+			}
+			if _, dup := idMap[oldID]; dup {
+				return nil, fmt.Errorf("--id-map maps %s more than once", oldID)
+			}
+			if other, dup := used[newID]; dup {
+				return nil, fmt.Errorf("--id-map gives %s and %s the same new ID %s", other, oldID, newID)
+			}
+			idMap[oldID] = newID
+			used[newID] = oldID
+		}
+	}
+	if len(idMap) == 0 {
+		return nil, nil
+	}
+	return idMap, nil
+}
+
+// slideObjectIDs lists a slide's ID followed by its elements' IDs, depth
+// first through groups.
+func slideObjectIDs(slide *slides.Page) []string {
+	if slide == nil {
+		return nil
+	}
+	ids := []string{slide.ObjectId}
+	var visit func(elements []*slides.PageElement)
+	visit = func(elements []*slides.PageElement) {
+		for _, el := range elements {
+			ids = append(ids, el.ObjectId)
+			if el.ElementGroup != nil {
+				visit(el.ElementGroup.Children)
+			}
+		}
+	}
+	visit(slide.PageElements)
+	return ids
+}
+
+// duplicatedObjectIDs maps each object of a duplicated slide to its copy.
+// A copy keeps its source's element order, so IDs are paired by position;
+// if the copy can't be read or doesn't line up, only the requested
+// mappings are reported.
+func duplicatedObjectIDs(source, copied *slides.Page, idMap map[string]string) map[string]string {
+	mapping := make(map[string]string, len(idMap))
+	for oldID, newID := range idMap {
+		mapping[oldID] = newID
+	}
+	sourceIDs, copiedIDs := slideObjectIDs(source), slideObjectIDs(copied)
+	if len(sourceIDs) != len(copiedIDs) {
+		return mapping
+	}
+	for i, oldID := range sourceIDs {
+		if _, ok := mapping[oldID]; !ok {
+			mapping[oldID] = copiedIDs[i]
+		}
+	}
+	return mapping
+}
+
 // getSlideID resolves a slide ID from either --slide-id or --slide-number flags.
 func getSlideID(svc *slides.Service, presentationID string, slideIDFlag string, slideNumber int) (string, error) {
 	if slideIDFlag != "" {
@@ -2087,26 +3280,11 @@ func runSlidesUpdateTextStyle(cmd *cobra.Command, args []string) error {
 		return usageErrorf("no style changes specified")
 	}
 
-	var textRange *slides.Range
-	if toIndex < 0 {
-		textRange = &slides.Range{
-			Type: "ALL",
-		}
-	} else {
-		startIdx := int64(fromIndex)
-		endIdx := int64(toIndex)
-		textRange = &slides.Range{
-			StartIndex: &startIdx,
-			EndIndex:   &endIdx,
-			Type:       "FIXED_RANGE",
-		}
-	}
-
 	requests := []*slides.Request{
 		{
 			UpdateTextStyle: &slides.UpdateTextStyleRequest{
 				ObjectId:  objectID,
-				TextRange: textRange,
+				TextRange: textStyleRange(fromIndex, toIndex),
 				Style:     style,
 				Fields:    strings.Join(fields, ","),
 			},
@@ -2128,6 +3306,149 @@ func runSlidesUpdateTextStyle(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// textStyleRange converts --from/--to into a text range: all text when
+// neither is set, from --from to the end when only --from is set,
+// otherwise the fixed range [from, to).
+func textStyleRange(from, to int) *slides.Range {
+	if to < 0 {
+		if from > 0 {
+			startIdx := int64(from)
+			return &slides.Range{
+				StartIndex: &startIdx,
+				Type:       "FROM_START_INDEX",
+			}
+		}
+		return &slides.Range{
+			Type: "ALL",
+		}
+	}
+	startIdx := int64(from)
+	endIdx := int64(to)
+	return &slides.Range{
+		StartIndex: &startIdx,
+		EndIndex:   &endIdx,
+		Type:       "FIXED_RANGE",
+	}
+}
+
+func runSlidesAddHyperlink(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	objectID, _ := cmd.Flags().GetString("object-id")
+	fromIndex, _ := cmd.Flags().GetInt("from")
+	toIndex, _ := cmd.Flags().GetInt("to")
+	linkURL, _ := cmd.Flags().GetString("url")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+
+	if (linkURL == "") == (slideNumber == 0) {
+		return usageErrorf("must specify exactly one of --url or --slide-number")
+	}
+	if linkURL != "" {
+		u, err := url.Parse(linkURL)
+		if err != nil || u.Scheme == "" {
+			return usageErrorf("--url must be an absolute URL such as https://example.com, got %q", linkURL)
+		}
+	}
+	if slideNumber < 0 {
+		return usageErrorf("--slide-number must be 1 or greater")
+	}
+	if fromIndex < 0 {
+		return usageErrorf("--from must be 0 or greater")
+	}
+	if toIndex >= 0 && toIndex <= fromIndex {
+		return usageErrorf("--to must be greater than --from")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesAddHyperlinkWithService(svc, args[0], objectID, fromIndex, toIndex, linkURL, slideNumber, p)
+}
+
+func runSlidesAddHyperlinkWithService(svc *slides.Service, presentationID, objectID string, fromIndex, toIndex int, linkURL string, slideNumber int, p printer.Printer) error {
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	var shape *slides.Shape
+	for _, slide := range presentation.Slides {
+		for _, el := range slide.PageElements {
+			if el.ObjectId == objectID {
+				if el.Shape == nil {
+					return p.PrintError(fmt.Errorf("object %s is not a shape or text box", objectID))
+				}
+				shape = el.Shape
+			}
+		}
+	}
+	if shape == nil {
+		return p.PrintError(fmt.Errorf("object %s not found", objectID))
+	}
+
+	// The linked substring, without the newline that ends every shape's text.
+	text := shapeTextUTF16(shape)
+	end := len(text)
+	if end > 0 && text[end-1] == '\n' {
+		end--
+	}
+	if toIndex >= 0 {
+		if toIndex > end {
+			return p.PrintError(fmt.Errorf("--to %d is past the end of the text in %s (max %d)", toIndex, objectID, end))
+		}
+		end = toIndex
+	}
+	if fromIndex >= end {
+		return p.PrintError(fmt.Errorf("--from %d is past the end of the text in %s (length %d)", fromIndex, objectID, end))
+	}
+
+	link := &slides.Link{Url: linkURL}
+	if slideNumber > 0 {
+		if slideNumber > len(presentation.Slides) {
+			return p.PrintError(fmt.Errorf("slide number %d out of range (presentation has %d slides)", slideNumber, len(presentation.Slides)))
+		}
+		// SlideIndex is zero-based; force it so slide 1 isn't dropped as empty.
+		link = &slides.Link{SlideIndex: int64(slideNumber - 1), ForceSendFields: []string{"SlideIndex"}}
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{
+			{
+				UpdateTextStyle: &slides.UpdateTextStyleRequest{
+					ObjectId:  objectID,
+					TextRange: textStyleRange(fromIndex, toIndex),
+					Style:     &slides.TextStyle{Link: link},
+					Fields:    "link",
+				},
+			},
+		},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to add hyperlink: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":          "linked",
+		"presentation_id": presentationID,
+		"object_id":       objectID,
+		"text":            string(utf16.Decode(text[fromIndex:end])),
+	}
+	if linkURL != "" {
+		result["url"] = linkURL
+	} else {
+		result["slide_number"] = slideNumber
+	}
+	return p.Print(result)
+}
+
 func runSlidesUpdateTransform(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
@@ -2268,6 +3589,157 @@ func runSlidesCreateTable(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// parseTableData parses a JSON 2D array into rows of cell text, padding
+// short rows so every row has the same number of columns.
+func parseTableData(raw string) ([][]string, error) {
+	var values [][]interface{}
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil, fmt.Errorf("invalid --json: %w", err)
+	}
+	data := tableCells(values)
+	if data == nil {
+		return nil, fmt.Errorf("--json must contain at least one row and one column")
+	}
+	return data, nil
+}
+
+// tableCells converts decoded JSON rows into cell text, padding short rows
+// to the widest one. It returns nil when there are no cells.
+func tableCells(values [][]interface{}) [][]string {
+	cols := 0
+	for _, row := range values {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if len(values) == 0 || cols == 0 {
+		return nil
+	}
+
+	data := make([][]string, len(values))
+	for i, row := range values {
+		data[i] = make([]string, cols)
+		for j, cell := range row {
+			if cell != nil {
+				data[i][j] = fmt.Sprint(cell)
+			}
+		}
+	}
+	return data
+}
+
+// buildDataTableRequests creates a table with the given object ID and fills
+// its non-empty cells, optionally bolding the first row.
+func buildDataTableRequests(slideID, tableID string, data [][]string, props *slides.PageElementProperties, boldHeader bool) []*slides.Request {
+	props.PageObjectId = slideID
+	requests := []*slides.Request{
+		{
+			CreateTable: &slides.CreateTableRequest{
+				ObjectId:          tableID,
+				Rows:              int64(len(data)),
+				Columns:           int64(len(data[0])),
+				ElementProperties: props,
+			},
+		},
+	}
+	for r, row := range data {
+		for c, text := range row {
+			if text == "" {
+				continue
+			}
+			cell := &slides.TableCellLocation{RowIndex: int64(r), ColumnIndex: int64(c)}
+			requests = append(requests, &slides.Request{
+				InsertText: &slides.InsertTextRequest{
+					ObjectId:     tableID,
+					CellLocation: cell,
+					Text:         text,
+				},
+			})
+			if boldHeader && r == 0 {
+				requests = append(requests, &slides.Request{
+					UpdateTextStyle: &slides.UpdateTextStyleRequest{
+						ObjectId:     tableID,
+						CellLocation: cell,
+						TextRange:    &slides.Range{Type: "ALL"},
+						Style:        &slides.TextStyle{Bold: true},
+						Fields:       "bold",
+					},
+				})
+			}
+		}
+	}
+	return requests
+}
+
+func runSlidesAddDataTable(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	presentationID := args[0]
+	slideIDFlag, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	raw, _ := cmd.Flags().GetString("json")
+	boldHeader, _ := cmd.Flags().GetBool("bold-header")
+	x, _ := cmd.Flags().GetFloat64("x")
+	y, _ := cmd.Flags().GetFloat64("y")
+	width, _ := cmd.Flags().GetFloat64("width")
+	height, _ := cmd.Flags().GetFloat64("height")
+
+	data, err := parseTableData(raw)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	slideID, err := getSlideID(svc, presentationID, slideIDFlag, slideNumber)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	tableID := fmt.Sprintf("gws_table_%d", time.Now().UnixNano())
+	props := &slides.PageElementProperties{
+		Size: &slides.Size{
+			Width:  &slides.Dimension{Magnitude: width, Unit: "PT"},
+			Height: &slides.Dimension{Magnitude: height, Unit: "PT"},
+		},
+		Transform: &slides.AffineTransform{
+			ScaleX:     1,
+			ScaleY:     1,
+			TranslateX: x,
+			TranslateY: y,
+			Unit:       "PT",
+		},
+	}
+	requests := buildDataTableRequests(slideID, tableID, data, props, boldHeader)
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to create table: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":          "created",
+		"presentation_id": presentationID,
+		"slide_id":        slideID,
+		"table_id":        tableID,
+		"rows":            len(data),
+		"cols":            len(data[0]),
+		"position":        map[string]float64{"x": x, "y": y},
+		"size":            map[string]float64{"width": width, "height": height},
+	})
+}
+
 func runSlidesInsertTableRows(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
@@ -2826,36 +4298,12 @@ func runSlidesUpdateSlideBackground(cmd *cobra.Command, args []string) error {
 		return p.PrintError(err)
 	}
 
-	pageProps := &slides.PageProperties{
-		PageBackgroundFill: &slides.PageBackgroundFill{},
-	}
-	fields := "pageBackgroundFill"
-
-	if colorHex != "" {
-		color, err := parseHexColor(colorHex)
-		if err != nil {
-			return p.PrintError(err)
-		}
-		pageProps.PageBackgroundFill.SolidFill = &slides.SolidFill{
-			Color: &slides.OpaqueColor{
-				RgbColor: color,
-			},
-		}
-	} else {
-		pageProps.PageBackgroundFill.StretchedPictureFill = &slides.StretchedPictureFill{
-			ContentUrl: imageURL,
-		}
+	fill, err := pageBackgroundFill(colorHex, imageURL)
+	if err != nil {
+		return p.PrintError(err)
 	}
 
-	requests := []*slides.Request{
-		{
-			UpdatePageProperties: &slides.UpdatePagePropertiesRequest{
-				ObjectId:       slideID,
-				PageProperties: pageProps,
-				Fields:         fields,
-			},
-		},
-	}
+	requests := []*slides.Request{backgroundRequest(slideID, fill)}
 
 	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
 		Requests: requests,
@@ -2878,6 +4326,36 @@ func runSlidesUpdateSlideBackground(cmd *cobra.Command, args []string) error {
 	return p.Print(result)
 }
 
+// pageBackgroundFill builds a solid color fill from colorHex, or a stretched
+// picture fill from imageURL when colorHex is empty.
+func pageBackgroundFill(colorHex, imageURL string) (*slides.PageBackgroundFill, error) {
+	if colorHex == "" {
+		return &slides.PageBackgroundFill{
+			StretchedPictureFill: &slides.StretchedPictureFill{ContentUrl: imageURL},
+		}, nil
+	}
+	color, err := parseHexColor(colorHex)
+	if err != nil {
+		return nil, err
+	}
+	return &slides.PageBackgroundFill{
+		SolidFill: &slides.SolidFill{
+			Color: &slides.OpaqueColor{RgbColor: color},
+		},
+	}, nil
+}
+
+// backgroundRequest returns the request that sets a page's background fill.
+func backgroundRequest(pageID string, fill *slides.PageBackgroundFill) *slides.Request {
+	return &slides.Request{
+		UpdatePageProperties: &slides.UpdatePagePropertiesRequest{
+			ObjectId:       pageID,
+			PageProperties: &slides.PageProperties{PageBackgroundFill: fill},
+			Fields:         "pageBackgroundFill",
+		},
+	}
+}
+
 func runSlidesListLayouts(cmd *cobra.Command, args []string) error {
 	p := GetPrinter()
 	ctx := context.Background()
@@ -2947,8 +4425,12 @@ func runSlidesAddLine(cmd *cobra.Command, args []string) error {
 	startY, _ := cmd.Flags().GetFloat64("start-y")
 	endX, _ := cmd.Flags().GetFloat64("end-x")
 	endY, _ := cmd.Flags().GetFloat64("end-y")
-	colorHex, _ := cmd.Flags().GetString("color")
-	weight, _ := cmd.Flags().GetFloat64("weight")
+	style := lineStyleFromFlags(cmd)
+
+	lineProps, lineFields, err := buildLineProperties(style)
+	if err != nil {
+		return usageErrorf("%s", err)
+	}
 
 	slideID, err := getSlideID(svc, presentationID, slideIDFlag, slideNumber)
 	if err != nil {
@@ -3017,51 +4499,23 @@ func runSlidesAddLine(cmd *cobra.Command, args []string) error {
 		lineObjectID = resp.Replies[0].CreateLine.ObjectId
 	}
 
-	// Apply line styling if color or weight specified
-	if lineObjectID != "" && (colorHex != "" || cmd.Flags().Changed("weight")) {
-		lineProps := &slides.LineProperties{}
-		var fields []string
-
-		if colorHex != "" {
-			color, err := parseHexColor(colorHex)
-			if err != nil {
-				return p.PrintError(err)
-			}
-			lineProps.LineFill = &slides.LineFill{
-				SolidFill: &slides.SolidFill{
-					Color: &slides.OpaqueColor{
-						RgbColor: color,
-					},
+	// Apply line styling if any style flag was set
+	if lineObjectID != "" && lineFields != "" {
+		styleRequests := []*slides.Request{
+			{
+				UpdateLineProperties: &slides.UpdateLinePropertiesRequest{
+					ObjectId:       lineObjectID,
+					LineProperties: lineProps,
+					Fields:         lineFields,
 				},
-			}
-			fields = append(fields, "lineFill")
+			},
 		}
 
-		if cmd.Flags().Changed("weight") {
-			lineProps.Weight = &slides.Dimension{
-				Magnitude: weight,
-				Unit:      "PT",
-			}
-			fields = append(fields, "weight")
-		}
-
-		if len(fields) > 0 {
-			styleRequests := []*slides.Request{
-				{
-					UpdateLineProperties: &slides.UpdateLinePropertiesRequest{
-						ObjectId:       lineObjectID,
-						LineProperties: lineProps,
-						Fields:         strings.Join(fields, ","),
-					},
-				},
-			}
-
-			_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
-				Requests: styleRequests,
-			}).Do()
-			if err != nil {
-				return p.PrintError(fmt.Errorf("line created but failed to apply styling: %w", err))
-			}
+		_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+			Requests: styleRequests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("line created but failed to apply styling: %w", err))
 		}
 	}
 
@@ -3186,6 +4640,38 @@ func runSlidesThumbnail(cmd *cobra.Command, args []string) error {
 	if !validSizes[sizeUpper] {
 		return usageErrorf("invalid size '%s': must be SMALL, MEDIUM, or LARGE", size)
 	}
+	mimeType, _ := cmd.Flags().GetString("mime-type")
+	mimeUpper := strings.ToUpper(mimeType)
+	if mimeUpper != "PNG" {
+		return usageErrorf("invalid mime type '%s': the Slides API only renders PNG thumbnails", mimeType)
+	}
+
+	slideFlag, _ := cmd.Flags().GetString("slide")
+	slideID, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	if slideFlag != "" {
+		if slideID != "" || slideNumber != 0 {
+			return usageErrorf("--slide cannot be combined with --slide-id or --slide-number")
+		}
+		// --slide takes either a slide object ID or a 1-based number
+		if num, err := strconv.Atoi(slideFlag); err == nil && num > 0 {
+			slideNumber = num
+		} else {
+			slideID = slideFlag
+		}
+	}
+	if slideNumber < 0 {
+		return usageErrorf("--slide-number must be 1 or greater")
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	download, _ := cmd.Flags().GetString("download")
+	if output != "" && download != "" {
+		return usageErrorf("--output and --download are the same flag; use only --output")
+	}
+	if output == "" {
+		output = download
+	}
 
 	ctx := context.Background()
 
@@ -3199,68 +4685,4087 @@ func runSlidesThumbnail(cmd *cobra.Command, args []string) error {
 		return p.PrintError(err)
 	}
 
-	presentationID := args[0]
-	slideFlag, _ := cmd.Flags().GetString("slide")
-	downloadPath, _ := cmd.Flags().GetString("download")
+	return runSlidesThumbnailWithService(svc, args[0], slideID, slideNumber, sizeUpper, mimeUpper, output, p)
+}
 
-	// Resolve slide flag: could be a slide object ID or a 1-based number
-	pageObjectID := slideFlag
-	if num, err := strconv.Atoi(slideFlag); err == nil && num > 0 {
-		// It's a number — fetch presentation to resolve to object ID
-		presentation, err := svc.Presentations.Get(presentationID).Do()
+// runSlidesThumbnailWithService renders one slide's thumbnail, or every
+// slide's when neither slideID nor slideNumber is set. With output, a single
+// thumbnail is saved to that file; all-slides thumbnails are saved into that
+// directory as slide-NN.png.
+func runSlidesThumbnailWithService(svc *slides.Service, presentationID, slideID string, slideNumber int, size, mimeType, output string, p printer.Printer) error {
+	getThumbnail := func(pageObjectID string) (*slides.Thumbnail, error) {
+		thumbnail, err := svc.Presentations.Pages.GetThumbnail(presentationID, pageObjectID).
+			ThumbnailPropertiesThumbnailSize(size).
+			ThumbnailPropertiesMimeType(mimeType).
+			Do()
 		if err != nil {
-			return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+			return nil, fmt.Errorf("failed to get thumbnail for slide %s: %w", pageObjectID, err)
+		}
+		return thumbnail, nil
+	}
+
+	if slideID != "" || slideNumber > 0 {
+		pageObjectID, err := getSlideID(svc, presentationID, slideID, slideNumber)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		thumbnail, err := getThumbnail(pageObjectID)
+		if err != nil {
+			return p.PrintError(err)
+		}
+
+		result := map[string]interface{}{
+			"slide_id":    pageObjectID,
+			"content_url": thumbnail.ContentUrl,
+			"width":       thumbnail.Width,
+			"height":      thumbnail.Height,
 		}
-		if num > len(presentation.Slides) {
-			return p.PrintError(fmt.Errorf("slide number %d out of range (1-%d)", num, len(presentation.Slides)))
+		if output != "" {
+			if err := downloadThumbnail(thumbnail.ContentUrl, output); err != nil {
+				return p.PrintError(err)
+			}
+			result["saved_to"] = output
 		}
-		pageObjectID = presentation.Slides[num-1].ObjectId
+		return p.Print(result)
 	}
 
-	thumbnail, err := svc.Presentations.Pages.GetThumbnail(presentationID, pageObjectID).
-		ThumbnailPropertiesThumbnailSize(sizeUpper).
-		Do()
+	presentation, err := svc.Presentations.Get(presentationID).Fields("slides(objectId)").Do()
 	if err != nil {
-		return p.PrintError(fmt.Errorf("failed to get thumbnail: %w", err))
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+	if output != "" {
+		if err := os.MkdirAll(output, 0755); err != nil {
+			return p.PrintError(fmt.Errorf("failed to create output directory: %w", err))
+		}
 	}
 
-	result := map[string]interface{}{
-		"content_url": thumbnail.ContentUrl,
-		"width":       thumbnail.Width,
-		"height":      thumbnail.Height,
+	thumbnails := make([]map[string]interface{}, 0, len(presentation.Slides))
+	for i, slide := range presentation.Slides {
+		thumbnail, err := getThumbnail(slide.ObjectId)
+		if err != nil {
+			return p.PrintError(err)
+		}
+		entry := map[string]interface{}{
+			"slide_number": i + 1,
+			"id":           slide.ObjectId,
+			"url":          thumbnail.ContentUrl,
+			"width":        thumbnail.Width,
+			"height":       thumbnail.Height,
+		}
+		if output != "" {
+			path := filepath.Join(output, fmt.Sprintf("slide-%02d.png", i+1))
+			if err := downloadThumbnail(thumbnail.ContentUrl, path); err != nil {
+				return p.PrintError(err)
+			}
+			entry["saved_to"] = path
+		}
+		thumbnails = append(thumbnails, entry)
+	}
+
+	return p.Print(map[string]interface{}{
+		"presentation_id": presentationID,
+		"thumbnails":      thumbnails,
+		"count":           len(thumbnails),
+	})
+}
+
+// openThumbnail starts downloading the image at a thumbnail content URL.
+// The caller closes the returned body.
+func openThumbnail(contentURL string) (io.ReadCloser, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Get(contentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download thumbnail: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download thumbnail: HTTP %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// downloadThumbnail saves the image at a thumbnail content URL to path.
+func downloadThumbnail(contentURL, path string) error {
+	body, err := openThumbnail(contentURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write thumbnail file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to finalize thumbnail file: %w", err)
+	}
+	return nil
+}
+
+// contactSheetOptions configures slides contact-sheet.
+type contactSheetOptions struct {
+	Cols        int
+	Size        string
+	Gap         int
+	Concurrency int
+	Output      string
+}
+
+func runSlidesContactSheet(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	var opts contactSheetOptions
+	opts.Cols, _ = cmd.Flags().GetInt("cols")
+	opts.Gap, _ = cmd.Flags().GetInt("gap")
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	opts.Output, _ = cmd.Flags().GetString("output")
+	size, _ := cmd.Flags().GetString("size")
+	opts.Size = strings.ToUpper(size)
+
+	if opts.Size != "SMALL" && opts.Size != "MEDIUM" && opts.Size != "LARGE" {
+		return usageErrorf("invalid size '%s': must be SMALL, MEDIUM, or LARGE", size)
+	}
+	if opts.Cols < 1 {
+		return usageErrorf("--cols must be 1 or greater")
+	}
+	if opts.Gap < 0 {
+		return usageErrorf("--gap must not be negative")
+	}
+	if opts.Concurrency < 1 {
+		return usageErrorf("--concurrency must be 1 or greater")
+	}
+	if opts.Output == "" {
+		return usageErrorf("--output is required")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesContactSheetWithService(ctx, svc, args[0], opts, p)
+}
+
+// runSlidesContactSheetWithService fetches every slide's thumbnail, lays
+// them out with contactSheet, and writes the grid to opts.Output as PNG.
+func runSlidesContactSheetWithService(ctx context.Context, svc *slides.Service, presentationID string, opts contactSheetOptions, p printer.Printer) error {
+	presentation, err := svc.Presentations.Get(presentationID).Fields("slides(objectId)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+	if len(presentation.Slides) == 0 {
+		return p.PrintError(fmt.Errorf("presentation has no slides"))
 	}
 
-	if downloadPath != "" {
-		httpClient := &http.Client{Timeout: 30 * time.Second}
-		resp, err := httpClient.Get(thumbnail.ContentUrl)
+	thumbs := make([]image.Image, len(presentation.Slides))
+	errs := make([]error, len(presentation.Slides))
+	forEachRateLimited(ctx, len(presentation.Slides), opts.Concurrency, 0, func(ctx context.Context, i int) {
+		slideID := presentation.Slides[i].ObjectId
+		thumbnail, err := svc.Presentations.Pages.GetThumbnail(presentationID, slideID).
+			ThumbnailPropertiesThumbnailSize(opts.Size).
+			ThumbnailPropertiesMimeType("PNG").
+			Context(ctx).
+			Do()
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to get thumbnail for slide %d: %w", i+1, err)
+			return
+		}
+		body, err := openThumbnail(thumbnail.ContentUrl)
+		if err != nil {
+			errs[i] = fmt.Errorf("slide %d: %w", i+1, err)
+			return
+		}
+		defer body.Close()
+		if thumbs[i], _, err = image.Decode(body); err != nil {
+			errs[i] = fmt.Errorf("failed to decode thumbnail for slide %d: %w", i+1, err)
+		}
+	})
+	for _, err := range errs {
 		if err != nil {
-			return p.PrintError(fmt.Errorf("failed to download thumbnail: %w", err))
+			return p.PrintError(err)
 		}
+	}
+
+	sheet, cols, rows := contactSheet(thumbs, opts.Cols, opts.Gap)
 
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			return p.PrintError(fmt.Errorf("failed to download thumbnail: HTTP %d", resp.StatusCode))
+	if dir := filepath.Dir(opts.Output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return p.PrintError(fmt.Errorf("failed to create output directory: %w", err))
 		}
+	}
+	f, err := os.Create(opts.Output)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to create file: %w", err))
+	}
+	if err := png.Encode(f, sheet); err != nil {
+		f.Close()
+		return p.PrintError(fmt.Errorf("failed to write contact sheet: %w", err))
+	}
+	if err := f.Close(); err != nil {
+		return p.PrintError(fmt.Errorf("failed to finalize contact sheet: %w", err))
+	}
 
-		f, err := os.Create(downloadPath)
-		if err != nil {
-			resp.Body.Close()
-			return p.PrintError(fmt.Errorf("failed to create file: %w", err))
+	return p.Print(map[string]interface{}{
+		"status":          "created",
+		"presentation_id": presentationID,
+		"saved_to":        opts.Output,
+		"slides":          len(thumbs),
+		"cols":            cols,
+		"rows":            rows,
+		"width":           sheet.Bounds().Dx(),
+		"height":          sheet.Bounds().Dy(),
+	})
+}
+
+// digitGlyphs are 3x5 bitmaps for 0-9, used to label contact sheet cells
+// without a font dependency.
+var digitGlyphs = [10][5]string{
+	{"###", "#.#", "#.#", "#.#", "###"},
+	{".#.", "##.", ".#.", ".#.", "###"},
+	{"###", "..#", "###", "#..", "###"},
+	{"###", "..#", "###", "..#", "###"},
+	{"#.#", "#.#", "###", "..#", "..#"},
+	{"###", "#..", "###", "..#", "###"},
+	{"###", "#..", "###", "#.#", "###"},
+	{"###", "..#", "..#", "..#", "..#"},
+	{"###", "#.#", "###", "#.#", "###"},
+	{"###", "#.#", "###", "..#", "###"},
+}
+
+// Contact sheet colors.
+var (
+	contactSheetBackground = color.RGBA{255, 255, 255, 255}
+	contactSheetBorder     = color.RGBA{200, 200, 200, 255}
+	contactSheetLabel      = color.RGBA{60, 60, 60, 255}
+)
+
+// contactSheet arranges images row by row into a grid of cols columns
+// (fewer when there are fewer images). Each cell is sized to the largest
+// image, which is centered in it with a thin border, and labeled below with
+// its 1-based position. It returns the sheet and the grid dimensions.
+func contactSheet(images []image.Image, cols, gap int) (*image.RGBA, int, int) {
+	if cols > len(images) {
+		cols = len(images)
+	}
+	rows := (len(images) + cols - 1) / cols
+
+	cellW, cellH := 0, 0
+	for _, img := range images {
+		cellW = max(cellW, img.Bounds().Dx())
+		cellH = max(cellH, img.Bounds().Dy())
+	}
+	// Glyph pixels scale with the cell so labels stay legible at any size.
+	scale := max(2, cellW/160)
+	labelH := 7 * scale
+	pitchX, pitchY := cellW+gap, cellH+labelH+gap
+
+	sheet := image.NewRGBA(image.Rect(0, 0, gap+cols*pitchX, gap+rows*pitchY))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(contactSheetBackground), image.Point{}, draw.Src)
+
+	for i, img := range images {
+		x0, y0 := gap+(i%cols)*pitchX, gap+(i/cols)*pitchY
+		b := img.Bounds()
+		at := image.Pt(x0+(cellW-b.Dx())/2, y0+(cellH-b.Dy())/2)
+		frame := image.Rectangle{Min: at, Max: at.Add(b.Size())}.Inset(-1)
+		draw.Draw(sheet, frame, image.NewUniform(contactSheetBorder), image.Point{}, draw.Src)
+		draw.Draw(sheet, image.Rectangle{Min: at, Max: at.Add(b.Size())}, img, b.Min, draw.Src)
+
+		label := strconv.Itoa(i + 1)
+		labelW := len(label)*4*scale - scale
+		drawDigits(sheet, label, x0+(cellW-labelW)/2, y0+cellH+scale, scale, contactSheetLabel)
+	}
+	return sheet, cols, rows
+}
+
+// drawDigits draws a string of decimal digits with digitGlyphs at (x, y),
+// each glyph pixel scaled to a scale x scale square.
+func drawDigits(dst draw.Image, digits string, x, y, scale int, c color.Color) {
+	src := image.NewUniform(c)
+	for _, d := range digits {
+		glyph := digitGlyphs[d-'0']
+		for row, line := range glyph {
+			for col, px := range line {
+				if px != '#' {
+					continue
+				}
+				r := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(dst, r, src, image.Point{}, draw.Src)
+			}
 		}
+		x += 4 * scale
+	}
+}
+
+// Footer layout constants, in points.
+const (
+	footerMargin     = 12.0
+	footerGap        = 8.0
+	footerTextWidth  = 240.0
+	footerTextHeight = 24.0
+)
 
-		if _, err := io.Copy(f, resp.Body); err != nil {
-			f.Close()
-			resp.Body.Close()
-			return p.PrintError(fmt.Errorf("failed to write thumbnail file: %w", err))
+// footerOptions describes the footer stamped onto each slide by add-footer.
+type footerOptions struct {
+	Text      string
+	LogoURL   string
+	Position  string
+	SkipFirst bool
+	FontSize  float64
+	LogoSize  float64
+	// IDPrefix seeds the generated object IDs so the text box can be
+	// targeted by InsertText within the same batch.
+	IDPrefix string
+}
+
+// pageSizeInPoints returns the presentation page size in points, defaulting
+// to the standard 16:9 size (720x405) when the API omits it.
+func pageSizeInPoints(presentation *slides.Presentation) (float64, float64) {
+	width, height := 720.0, 405.0
+	if presentation.PageSize != nil {
+		if w := dimensionToPoints(presentation.PageSize.Width); w > 0 {
+			width = w
 		}
-		resp.Body.Close()
+		if h := dimensionToPoints(presentation.PageSize.Height); h > 0 {
+			height = h
+		}
+	}
+	return width, height
+}
+
+// dimensionToPoints converts a Slides dimension (EMU or PT) to points.
+func dimensionToPoints(d *slides.Dimension) float64 {
+	if d == nil {
+		return 0
+	}
+	if d.Unit == "EMU" {
+		return d.Magnitude / 12700
+	}
+	return d.Magnitude
+}
+
+// footerLayout computes the top-left corners of the footer text box and logo
+// for a page of the given size. The logo sits on the outer edge for
+// bottom-left and bottom-right, and before the text for bottom-center.
+func footerLayout(pageWidth, pageHeight float64, opts footerOptions) (textX, textY, logoX, logoY float64) {
+	hasText := opts.Text != ""
+	hasLogo := opts.LogoURL != ""
+
+	rowHeight := 0.0
+	totalWidth := 0.0
+	if hasText {
+		rowHeight = footerTextHeight
+		totalWidth += footerTextWidth
+	}
+	if hasLogo {
+		rowHeight = math.Max(rowHeight, opts.LogoSize)
+		totalWidth += opts.LogoSize
+	}
+	if hasText && hasLogo {
+		totalWidth += footerGap
+	}
+
+	var startX float64
+	switch opts.Position {
+	case "bottom-center":
+		startX = (pageWidth - totalWidth) / 2
+	case "bottom-right":
+		startX = pageWidth - footerMargin - totalWidth
+	default:
+		startX = footerMargin
+	}
+	baseY := pageHeight - footerMargin - rowHeight
+
+	textY = baseY + (rowHeight-footerTextHeight)/2
+	logoY = baseY + (rowHeight-opts.LogoSize)/2
 
-		if err := f.Close(); err != nil {
-			return p.PrintError(fmt.Errorf("failed to finalize thumbnail file: %w", err))
+	if opts.Position == "bottom-right" {
+		textX = startX
+		logoX = startX
+		if hasText {
+			logoX += footerTextWidth + footerGap
 		}
+	} else {
+		logoX = startX
+		textX = startX
+		if hasLogo {
+			textX += opts.LogoSize + footerGap
+		}
+	}
+	return textX, textY, logoX, logoY
+}
+
+// buildFooterRequests returns the batch requests that add the footer to every
+// targeted slide, along with the IDs of the slides it touches.
+func buildFooterRequests(presentation *slides.Presentation, opts footerOptions) ([]*slides.Request, []string) {
+	pageWidth, pageHeight := pageSizeInPoints(presentation)
+	textX, textY, logoX, logoY := footerLayout(pageWidth, pageHeight, opts)
+
+	var requests []*slides.Request
+	var slideIDs []string
+	for i, slide := range presentation.Slides {
+		if opts.SkipFirst && i == 0 {
+			continue
+		}
+		slideIDs = append(slideIDs, slide.ObjectId)
+
+		if opts.LogoURL != "" {
+			requests = append(requests, &slides.Request{
+				CreateImage: &slides.CreateImageRequest{
+					ObjectId: fmt.Sprintf("%s_%d_logo", opts.IDPrefix, i),
+					Url:      opts.LogoURL,
+					ElementProperties: &slides.PageElementProperties{
+						PageObjectId: slide.ObjectId,
+						Size: &slides.Size{
+							Width:  &slides.Dimension{Magnitude: opts.LogoSize, Unit: "PT"},
+							Height: &slides.Dimension{Magnitude: opts.LogoSize, Unit: "PT"},
+						},
+						Transform: &slides.AffineTransform{
+							ScaleX:     1,
+							ScaleY:     1,
+							TranslateX: logoX,
+							TranslateY: logoY,
+							Unit:       "PT",
+						},
+					},
+				},
+			})
+		}
+
+		if opts.Text != "" {
+			textID := fmt.Sprintf("%s_%d_text", opts.IDPrefix, i)
+			alignment := "START"
+			switch opts.Position {
+			case "bottom-center":
+				alignment = "CENTER"
+			case "bottom-right":
+				alignment = "END"
+			}
+			requests = append(requests,
+				&slides.Request{
+					CreateShape: &slides.CreateShapeRequest{
+						ObjectId:  textID,
+						ShapeType: "TEXT_BOX",
+						ElementProperties: &slides.PageElementProperties{
+							PageObjectId: slide.ObjectId,
+							Size: &slides.Size{
+								Width:  &slides.Dimension{Magnitude: footerTextWidth, Unit: "PT"},
+								Height: &slides.Dimension{Magnitude: footerTextHeight, Unit: "PT"},
+							},
+							Transform: &slides.AffineTransform{
+								ScaleX:     1,
+								ScaleY:     1,
+								TranslateX: textX,
+								TranslateY: textY,
+								Unit:       "PT",
+							},
+						},
+					},
+				},
+				&slides.Request{
+					InsertText: &slides.InsertTextRequest{
+						ObjectId: textID,
+						Text:     opts.Text,
+					},
+				},
+				&slides.Request{
+					UpdateTextStyle: &slides.UpdateTextStyleRequest{
+						ObjectId: textID,
+						Style: &slides.TextStyle{
+							FontSize: &slides.Dimension{Magnitude: opts.FontSize, Unit: "PT"},
+						},
+						TextRange: &slides.Range{Type: "ALL"},
+						Fields:    "fontSize",
+					},
+				},
+				&slides.Request{
+					UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
+						ObjectId:  textID,
+						Style:     &slides.ParagraphStyle{Alignment: alignment},
+						TextRange: &slides.Range{Type: "ALL"},
+						Fields:    "alignment",
+					},
+				},
+			)
+		}
+	}
+	return requests, slideIDs
+}
+
+func runSlidesAddFooter(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	text, _ := cmd.Flags().GetString("text")
+	logoURL, _ := cmd.Flags().GetString("logo-url")
+	position, _ := cmd.Flags().GetString("position")
+	skipFirst, _ := cmd.Flags().GetBool("skip-first")
+	fontSize, _ := cmd.Flags().GetFloat64("font-size")
+	logoSize, _ := cmd.Flags().GetFloat64("logo-size")
+
+	if text == "" && logoURL == "" {
+		return usageErrorf("at least one of --text or --logo-url is required")
+	}
+	position = strings.ToLower(position)
+	switch position {
+	case "bottom-left", "bottom-center", "bottom-right":
+	default:
+		return usageErrorf("invalid --position '%s': must be bottom-left, bottom-center, or bottom-right", position)
+	}
+	if fontSize <= 0 {
+		return usageErrorf("--font-size must be greater than 0")
+	}
+	if logoSize <= 0 {
+		return usageErrorf("--logo-size must be greater than 0")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	opts := footerOptions{
+		Text:      text,
+		LogoURL:   logoURL,
+		Position:  position,
+		SkipFirst: skipFirst,
+		FontSize:  fontSize,
+		LogoSize:  logoSize,
+		IDPrefix:  fmt.Sprintf("gws_footer_%d", time.Now().UnixNano()),
+	}
+	requests, slideIDs := buildFooterRequests(presentation, opts)
+	if len(requests) == 0 {
+		return p.PrintError(fmt.Errorf("no slides to update"))
+	}
 
-		result["saved_to"] = downloadPath
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to add footer: %w", err))
 	}
 
+	result := map[string]interface{}{
+		"status":          "added",
+		"presentation_id": presentationID,
+		"position":        position,
+		"slides_updated":  len(slideIDs),
+		"slide_ids":       slideIDs,
+	}
+	if text != "" {
+		result["text"] = text
+	}
+	if logoURL != "" {
+		result["logo_url"] = logoURL
+	}
 	return p.Print(result)
 }
+
+func runSlidesSetAltText(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	objectID, _ := cmd.Flags().GetString("object-id")
+	title, _ := cmd.Flags().GetString("title")
+	description, _ := cmd.Flags().GetString("description")
+	titleSet := cmd.Flags().Changed("title")
+	descriptionSet := cmd.Flags().Changed("description")
+
+	if !titleSet && !descriptionSet {
+		return usageErrorf("at least one of --title or --description is required")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	altText := &slides.UpdatePageElementAltTextRequest{ObjectId: objectID}
+	result := map[string]interface{}{
+		"status":          "updated",
+		"presentation_id": presentationID,
+		"object_id":       objectID,
+	}
+	// Explicitly send empty values so --title "" clears the existing text.
+	if titleSet {
+		altText.Title = title
+		altText.ForceSendFields = append(altText.ForceSendFields, "Title")
+		result["title"] = title
+	}
+	if descriptionSet {
+		altText.Description = description
+		altText.ForceSendFields = append(altText.ForceSendFields, "Description")
+		result["description"] = description
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{UpdatePageElementAltText: altText}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set alt text: %w", err))
+	}
+
+	return p.Print(result)
+}
+
+// imageReplaceMethods maps --method values to ImageReplaceMethod enums.
+var imageReplaceMethods = map[string]string{
+	"center-inside": "CENTER_INSIDE",
+	"center-crop":   "CENTER_CROP",
+}
+
+// parseImageReplaceMethod accepts a --method value as center-inside or as
+// the API's CENTER_INSIDE spelling.
+func parseImageReplaceMethod(method string) (string, error) {
+	replaceMethod, ok := imageReplaceMethods[strings.ReplaceAll(strings.ToLower(method), "_", "-")]
+	if !ok {
+		return "", fmt.Errorf("invalid --method '%s'. Valid methods: center-inside, center-crop", method)
+	}
+	return replaceMethod, nil
+}
+
+// replaceShapesScope resolves the optional --slide-id/--slide-number scope
+// into PageObjectIds. Returns nil when the replacement is presentation-wide.
+func replaceShapesScope(cmd *cobra.Command, svc *slides.Service, presentationID string) ([]string, error) {
+	slideIDFlag, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	if slideIDFlag == "" && slideNumber <= 0 {
+		return nil, nil
+	}
+	pageID, err := getSlideID(svc, presentationID, slideIDFlag, slideNumber)
+	if err != nil {
+		return nil, err
+	}
+	return []string{pageID}, nil
+}
+
+func runSlidesReplaceShapesWithImage(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	contains, _ := cmd.Flags().GetString("contains")
+	find, _ := cmd.Flags().GetString("find")
+	imageURL, _ := cmd.Flags().GetString("url")
+	method, _ := cmd.Flags().GetString("method")
+	matchCase, _ := cmd.Flags().GetBool("match-case")
+
+	replaceMethod, err := parseImageReplaceMethod(method)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	// --find is the replace-text spelling of --contains
+	switch {
+	case contains != "" && find != "" && contains != find:
+		return usageErrorf("--find and --contains are the same flag; use only one")
+	case contains == "":
+		contains = find
+	}
+	if contains == "" {
+		return usageErrorf("must specify --contains (or --find)")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	pageIDs, err := replaceShapesScope(cmd, svc, presentationID)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	resp, err := svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{
+			ReplaceAllShapesWithImage: &slides.ReplaceAllShapesWithImageRequest{
+				ContainsText: &slides.SubstringMatchCriteria{
+					Text:      contains,
+					MatchCase: matchCase,
+				},
+				ImageUrl:           imageURL,
+				ImageReplaceMethod: replaceMethod,
+				PageObjectIds:      pageIDs,
+			},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to replace shapes with image: %w", err))
+	}
+
+	var occurrences int64
+	if len(resp.Replies) > 0 && resp.Replies[0].ReplaceAllShapesWithImage != nil {
+		occurrences = resp.Replies[0].ReplaceAllShapesWithImage.OccurrencesChanged
+	}
+
+	result := map[string]interface{}{
+		"status":              "replaced",
+		"presentation_id":     presentationID,
+		"contains":            contains,
+		"image_url":           imageURL,
+		"occurrences_changed": occurrences,
+	}
+	if len(pageIDs) > 0 {
+		result["slide_id"] = pageIDs[0]
+	}
+	return p.Print(result)
+}
+
+// validatePublicImageURL checks that imageURL looks fetchable by Google's
+// servers: an absolute http(s) URL whose host is not local or private.
+func validatePublicImageURL(imageURL string) error {
+	if strings.TrimSpace(imageURL) == "" {
+		return fmt.Errorf("--url must not be empty")
+	}
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return fmt.Errorf("invalid --url: %v", err)
+	}
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("invalid --url %q: must be an http or https URL", imageURL)
+	}
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("invalid --url %q: missing host", imageURL)
+	}
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".local") {
+		return fmt.Errorf("invalid --url %q: the image must be publicly accessible, not on a local host", imageURL)
+	}
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()) {
+		return fmt.Errorf("invalid --url %q: the image must be publicly accessible, not on a private address", imageURL)
+	}
+	return nil
+}
+
+// findImagesByAltText returns the object IDs of images, including those in
+// groups, whose alt text title or description equals match
+// (case-insensitive), in slide order.
+func findImagesByAltText(presentation *slides.Presentation, match string) []string {
+	var ids []string
+	var visit func(elem *slides.PageElement)
+	visit = func(elem *slides.PageElement) {
+		switch {
+		case elem == nil:
+		case elem.ElementGroup != nil:
+			for _, child := range elem.ElementGroup.Children {
+				visit(child)
+			}
+		case elem.Image != nil:
+			if strings.EqualFold(strings.TrimSpace(elem.Title), match) || strings.EqualFold(strings.TrimSpace(elem.Description), match) {
+				ids = append(ids, elem.ObjectId)
+			}
+		}
+	}
+	for _, slide := range presentation.Slides {
+		for _, elem := range slide.PageElements {
+			visit(elem)
+		}
+	}
+	return ids
+}
+
+func runSlidesReplaceImage(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	objectID, _ := cmd.Flags().GetString("object-id")
+	imageURL, _ := cmd.Flags().GetString("url")
+	method, _ := cmd.Flags().GetString("method")
+	replaceAll, _ := cmd.Flags().GetBool("replace-all")
+	match, _ := cmd.Flags().GetString("match")
+
+	replaceMethod, err := parseImageReplaceMethod(method)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	if err := validatePublicImageURL(imageURL); err != nil {
+		return usageErrorf("%v", err)
+	}
+	match = strings.TrimSpace(match)
+	switch {
+	case replaceAll && objectID != "":
+		return usageErrorf("--object-id and --replace-all are mutually exclusive")
+	case replaceAll && match == "":
+		return usageErrorf("--replace-all requires --match")
+	case !replaceAll && match != "":
+		return usageErrorf("--match requires --replace-all")
+	case !replaceAll && objectID == "":
+		return usageErrorf("must specify --object-id or --replace-all")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesReplaceImageWithService(svc, args[0], objectID, match, imageURL, replaceMethod, p)
+}
+
+// runSlidesReplaceImageWithService replaces objectID, or every image whose
+// alt text equals match when match is set.
+func runSlidesReplaceImageWithService(svc *slides.Service, presentationID, objectID, match, imageURL, replaceMethod string, p printer.Printer) error {
+	objectIDs := []string{objectID}
+	if match != "" {
+		presentation, err := svc.Presentations.Get(presentationID).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+		}
+		objectIDs = findImagesByAltText(presentation, match)
+		if len(objectIDs) == 0 {
+			return p.Print(map[string]interface{}{
+				"status":          "unchanged",
+				"presentation_id": presentationID,
+				"match":           match,
+				"object_ids":      []string{},
+				"count":           0,
+			})
+		}
+	}
+
+	requests := make([]*slides.Request, 0, len(objectIDs))
+	for _, id := range objectIDs {
+		requests = append(requests, &slides.Request{
+			ReplaceImage: &slides.ReplaceImageRequest{
+				ImageObjectId:      id,
+				Url:                imageURL,
+				ImageReplaceMethod: replaceMethod,
+			},
+		})
+	}
+	_, err := svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to replace image: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":          "replaced",
+		"presentation_id": presentationID,
+		"image_url":       imageURL,
+		"method":          replaceMethod,
+	}
+	if match != "" {
+		result["match"] = match
+		result["object_ids"] = objectIDs
+		result["count"] = len(objectIDs)
+	} else {
+		result["object_id"] = objectID
+	}
+	return p.Print(result)
+}
+
+// forceSendPresentFields adds every field that raw sets on v, a Slides API
+// struct or slice of them, to its ForceSendFields, recursing into nested
+// values. Zero values the user wrote, like an insertionIndex of 0 or a
+// false bold, are then sent instead of being dropped on re-encoding.
+func forceSendPresentFields(v reflect.Value, raw json.RawMessage) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(raw, &fields) != nil {
+			return
+		}
+		force := v.FieldByName("ForceSendFields")
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			value, ok := fields[name]
+			if !ok || name == "" || name == "-" {
+				continue
+			}
+			if v.Field(i).IsZero() && force.IsValid() {
+				force.Set(reflect.Append(force, reflect.ValueOf(t.Field(i).Name)))
+			}
+			forceSendPresentFields(v.Field(i), value)
+		}
+	case reflect.Slice:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return
+		}
+		for i := 0; i < v.Len() && i < len(items); i++ {
+			forceSendPresentFields(v.Index(i), items[i])
+		}
+	}
+}
+
+// parseSlidesRequests decodes a JSON array of Slides API requests, or a
+// batchUpdate body with a "requests" array. Unknown fields are rejected so a
+// misspelled request kind fails here rather than as an empty request, and
+// every field given is sent even when it holds a zero value.
+func parseSlidesRequests(data []byte) ([]*slides.Request, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("no requests given")
+	}
+
+	var requests []*slides.Request
+	raw := json.RawMessage(trimmed)
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.DisallowUnknownFields()
+	if trimmed[0] == '[' {
+		if err := dec.Decode(&requests); err != nil {
+			return nil, fmt.Errorf("invalid requests JSON: %w", err)
+		}
+	} else {
+		var body slides.BatchUpdatePresentationRequest
+		if err := dec.Decode(&body); err != nil {
+			return nil, fmt.Errorf("invalid requests JSON: %w", err)
+		}
+		requests = body.Requests
+		var rawBody struct {
+			Requests json.RawMessage `json:"requests"`
+		}
+		json.Unmarshal(trimmed, &rawBody)
+		raw = rawBody.Requests
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid requests JSON: unexpected data after the requests")
+	}
+
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no requests given")
+	}
+	for i, req := range requests {
+		if req == nil {
+			return nil, fmt.Errorf("request %d is null", i+1)
+		}
+		kinds, err := req.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("request %d: %w", i+1, err)
+		}
+		if string(kinds) == "{}" {
+			return nil, fmt.Errorf("request %d is empty: it must set one request kind, e.g. createShape", i+1)
+		}
+	}
+	forceSendPresentFields(reflect.ValueOf(requests), raw)
+	return requests, nil
+}
+
+func runSlidesBatch(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	requestsFile, _ := cmd.Flags().GetString("requests-file")
+	requestsJSON, _ := cmd.Flags().GetString("requests-json")
+
+	if (requestsFile == "") == (requestsJSON == "") {
+		return usageErrorf("must specify exactly one of --requests-file or --requests-json")
+	}
+	data := []byte(requestsJSON)
+	if requestsFile != "" {
+		var err error
+		data, err = os.ReadFile(requestsFile)
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to read requests file: %w", err))
+		}
+	}
+	requests, err := parseSlidesRequests(data)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesBatchWithService(svc, args[0], requests, p)
+}
+
+func runSlidesBatchWithService(svc *slides.Service, presentationID string, requests []*slides.Request, p printer.Printer) error {
+	resp, err := svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to apply batch update: %w", err))
+	}
+
+	replies := resp.Replies
+	if replies == nil {
+		replies = []*slides.Response{}
+	}
+	return p.Print(map[string]interface{}{
+		"status":          "applied",
+		"presentation_id": presentationID,
+		"requests":        len(requests),
+		"replies":         replies,
+	})
+}
+
+func runSlidesReplaceShapesWithChart(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	contains, _ := cmd.Flags().GetString("contains")
+	spreadsheetID, _ := cmd.Flags().GetString("spreadsheet-id")
+	chartID, _ := cmd.Flags().GetInt64("chart-id")
+	linked, _ := cmd.Flags().GetBool("linked")
+	matchCase, _ := cmd.Flags().GetBool("match-case")
+
+	if chartID <= 0 {
+		return usageErrorf("--chart-id must be a positive chart ID (see 'gws sheets list-charts')")
+	}
+
+	linkingMode := "NOT_LINKED_IMAGE"
+	if linked {
+		linkingMode = "LINKED"
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	pageIDs, err := replaceShapesScope(cmd, svc, presentationID)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	resp, err := svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{
+			ReplaceAllShapesWithSheetsChart: &slides.ReplaceAllShapesWithSheetsChartRequest{
+				ContainsText: &slides.SubstringMatchCriteria{
+					Text:      contains,
+					MatchCase: matchCase,
+				},
+				SpreadsheetId: spreadsheetID,
+				ChartId:       chartID,
+				LinkingMode:   linkingMode,
+				PageObjectIds: pageIDs,
+			},
+		}},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to replace shapes with chart: %w", err))
+	}
+
+	var occurrences int64
+	if len(resp.Replies) > 0 && resp.Replies[0].ReplaceAllShapesWithSheetsChart != nil {
+		occurrences = resp.Replies[0].ReplaceAllShapesWithSheetsChart.OccurrencesChanged
+	}
+
+	result := map[string]interface{}{
+		"status":              "replaced",
+		"presentation_id":     presentationID,
+		"contains":            contains,
+		"spreadsheet_id":      spreadsheetID,
+		"chart_id":            chartID,
+		"linked":              linked,
+		"occurrences_changed": occurrences,
+	}
+	if len(pageIDs) > 0 {
+		result["slide_id"] = pageIDs[0]
+	}
+	return p.Print(result)
+}
+
+// slideMerger recreates source slides in another presentation. It collects
+// the batch requests, the IDs of the new slides, and the elements it could
+// not copy.
+type slideMerger struct {
+	idPrefix string
+	nextID   int
+	requests []*slides.Request
+	slideIDs []string
+	skipped  []map[string]interface{}
+	// scale, when set, maps top-level elements onto a page of another size.
+	scale *pageScale
+}
+
+// pageScale scales page coordinates by ScaleX/ScaleY and then offsets them
+// by OffsetX/OffsetY points.
+type pageScale struct {
+	ScaleX, ScaleY   float64
+	OffsetX, OffsetY float64
+}
+
+// transform returns the scale as an affine transform in unit, the unit of
+// the element it will be composed with. Element transforms read from the
+// API are in EMU.
+func (s *pageScale) transform(unit string) *slides.AffineTransform {
+	if s == nil {
+		return nil
+	}
+	if unit == "" {
+		unit = "EMU"
+	}
+	perPoint := 12700.0
+	if unit == "PT" {
+		perPoint = 1
+	}
+	return &slides.AffineTransform{
+		ScaleX:     s.ScaleX,
+		ScaleY:     s.ScaleY,
+		TranslateX: s.OffsetX * perPoint,
+		TranslateY: s.OffsetY * perPoint,
+		Unit:       unit,
+	}
+}
+
+func (m *slideMerger) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s_%d", m.idPrefix, m.nextID)
+}
+
+// buildMergeRequests returns the requests that recreate every slide of src,
+// inserting the first one at insertionIndex (0-based).
+func buildMergeRequests(src *slides.Presentation, insertionIndex int64, idPrefix string, scale *pageScale) *slideMerger {
+	m := &slideMerger{idPrefix: idPrefix, scale: scale}
+	m.addSlides(src, insertionIndex)
+	return m
+}
+
+// addSlides appends the requests that recreate every slide of src.
+func (m *slideMerger) addSlides(src *slides.Presentation, insertionIndex int64) {
+	for i, slide := range src.Slides {
+		slideID := m.newID()
+		m.slideIDs = append(m.slideIDs, slideID)
+		m.requests = append(m.requests, &slides.Request{
+			CreateSlide: &slides.CreateSlideRequest{
+				ObjectId:       slideID,
+				InsertionIndex: insertionIndex + int64(i),
+				SlideLayoutReference: &slides.LayoutReference{
+					PredefinedLayout: "BLANK",
+				},
+				// Index 0 is omitted without this and the slide lands at the end.
+				ForceSendFields: []string{"InsertionIndex"},
+			},
+		})
+
+		if slide.PageProperties != nil && slide.PageProperties.PageBackgroundFill != nil &&
+			slide.PageProperties.PageBackgroundFill.SolidFill != nil {
+			m.requests = append(m.requests, &slides.Request{
+				UpdatePageProperties: &slides.UpdatePagePropertiesRequest{
+					ObjectId: slideID,
+					PageProperties: &slides.PageProperties{
+						PageBackgroundFill: &slides.PageBackgroundFill{
+							SolidFill: slide.PageProperties.PageBackgroundFill.SolidFill,
+						},
+					},
+					Fields: "pageBackgroundFill.solidFill",
+				},
+			})
+		}
+
+		for _, elem := range slide.PageElements {
+			var base *slides.AffineTransform
+			if m.scale != nil && elem != nil {
+				unit := ""
+				if elem.Transform != nil {
+					unit = elem.Transform.Unit
+				}
+				base = m.scale.transform(unit)
+			}
+			m.addElement(slide.ObjectId, slideID, elem, base)
+		}
+	}
+}
+
+// addElement recreates one page element on pageID. parent is the absolute
+// transform of the enclosing group, or nil at the top level.
+func (m *slideMerger) addElement(srcSlideID, pageID string, elem *slides.PageElement, parent *slides.AffineTransform) {
+	if elem == nil {
+		return
+	}
+	transform := composeTransforms(parent, elem.Transform)
+
+	if elem.ElementGroup != nil {
+		for _, child := range elem.ElementGroup.Children {
+			m.addElement(srcSlideID, pageID, child, transform)
+		}
+		return
+	}
+
+	skip := func(kind, reason string) {
+		m.skipped = append(m.skipped, map[string]interface{}{
+			"slide":     srcSlideID,
+			"object_id": elem.ObjectId,
+			"type":      kind,
+			"reason":    reason,
+		})
+	}
+
+	if elem.Size == nil && elem.Table == nil {
+		skip(pageElementKind(elem), "element has no size")
+		return
+	}
+	props := &slides.PageElementProperties{
+		PageObjectId: pageID,
+		Size:         elem.Size,
+		Transform:    transform,
+	}
+	id := m.newID()
+
+	switch {
+	case elem.Shape != nil:
+		shapeType := elem.Shape.ShapeType
+		if shapeType == "" {
+			shapeType = "TEXT_BOX"
+		}
+		m.requests = append(m.requests, &slides.Request{
+			CreateShape: &slides.CreateShapeRequest{
+				ObjectId:          id,
+				ShapeType:         shapeType,
+				ElementProperties: props,
+			},
+		})
+		if sp := elem.Shape.ShapeProperties; sp != nil && sp.ShapeBackgroundFill != nil && sp.ShapeBackgroundFill.SolidFill != nil {
+			m.requests = append(m.requests, &slides.Request{
+				UpdateShapeProperties: &slides.UpdateShapePropertiesRequest{
+					ObjectId: id,
+					ShapeProperties: &slides.ShapeProperties{
+						ShapeBackgroundFill: &slides.ShapeBackgroundFill{
+							SolidFill: sp.ShapeBackgroundFill.SolidFill,
+						},
+					},
+					Fields: "shapeBackgroundFill.solidFill",
+				},
+			})
+		}
+		m.addText(id, nil, elem.Shape.Text)
+
+	case elem.Image != nil:
+		if elem.Image.ContentUrl == "" {
+			skip("image", "image has no content URL")
+			return
+		}
+		m.requests = append(m.requests, &slides.Request{
+			CreateImage: &slides.CreateImageRequest{
+				ObjectId:          id,
+				Url:               elem.Image.ContentUrl,
+				ElementProperties: props,
+			},
+		})
+
+	case elem.Table != nil:
+		m.requests = append(m.requests, &slides.Request{
+			CreateTable: &slides.CreateTableRequest{
+				ObjectId:          id,
+				Rows:              elem.Table.Rows,
+				Columns:           elem.Table.Columns,
+				ElementProperties: props,
+			},
+		})
+		for r, row := range elem.Table.TableRows {
+			for c, cell := range row.TableCells {
+				if cell == nil || cell.Text == nil {
+					continue
+				}
+				m.addText(id, &slides.TableCellLocation{RowIndex: int64(r), ColumnIndex: int64(c)}, cell.Text)
+			}
+		}
+
+	case elem.Line != nil:
+		category := elem.Line.LineCategory
+		if category == "" {
+			category = "STRAIGHT"
+			if strings.HasPrefix(elem.Line.LineType, "BENT") {
+				category = "BENT"
+			} else if strings.HasPrefix(elem.Line.LineType, "CURVED") {
+				category = "CURVED"
+			}
+		}
+		m.requests = append(m.requests, &slides.Request{
+			CreateLine: &slides.CreateLineRequest{
+				ObjectId:          id,
+				Category:          category,
+				ElementProperties: props,
+			},
+		})
+
+	case elem.SheetsChart != nil:
+		m.requests = append(m.requests, &slides.Request{
+			CreateSheetsChart: &slides.CreateSheetsChartRequest{
+				ObjectId:          id,
+				SpreadsheetId:     elem.SheetsChart.SpreadsheetId,
+				ChartId:           elem.SheetsChart.ChartId,
+				LinkingMode:       "LINKED",
+				ElementProperties: props,
+			},
+		})
+
+	case elem.Video != nil:
+		m.requests = append(m.requests, &slides.Request{
+			CreateVideo: &slides.CreateVideoRequest{
+				ObjectId:          id,
+				Source:            elem.Video.Source,
+				Id:                elem.Video.Id,
+				ElementProperties: props,
+			},
+		})
+
+	default:
+		skip(pageElementKind(elem), "element type cannot be recreated")
+	}
+}
+
+// addText inserts the text of a shape or table cell and re-applies the text
+// style of each run. Indices from the source stay valid because the same
+// text is inserted; AutoText (e.g. slide numbers) is inserted as plain text.
+func (m *slideMerger) addText(objectID string, cell *slides.TableCellLocation, text *slides.TextContent) {
+	if text == nil {
+		return
+	}
+	var builder strings.Builder
+	for _, te := range text.TextElements {
+		switch {
+		case te.TextRun != nil:
+			builder.WriteString(te.TextRun.Content)
+		case te.AutoText != nil:
+			builder.WriteString(te.AutoText.Content)
+		}
+	}
+	// Every shape already ends with a newline; inserting the source's final
+	// newline would add an empty paragraph.
+	content := strings.TrimSuffix(builder.String(), "\n")
+	if content == "" {
+		return
+	}
+	m.requests = append(m.requests, &slides.Request{
+		InsertText: &slides.InsertTextRequest{
+			ObjectId:     objectID,
+			CellLocation: cell,
+			Text:         content,
+		},
+	})
+
+	length := int64(len(utf16.Encode([]rune(content))))
+	for _, te := range text.TextElements {
+		if te.TextRun == nil || te.TextRun.Style == nil {
+			continue
+		}
+		start, end := te.StartIndex, te.EndIndex
+		if end > length {
+			end = length
+		}
+		if start >= end {
+			continue
+		}
+		style, fields := mergeTextStyle(te.TextRun.Style)
+		if fields == "" {
+			continue
+		}
+		m.requests = append(m.requests, &slides.Request{
+			UpdateTextStyle: &slides.UpdateTextStyleRequest{
+				ObjectId:     objectID,
+				CellLocation: cell,
+				Style:        style,
+				Fields:       fields,
+				TextRange: &slides.Range{
+					Type:       "FIXED_RANGE",
+					StartIndex: &start,
+					EndIndex:   &end,
+				},
+			},
+		})
+	}
+}
+
+// mergeTextStyle copies the explicitly set properties of a source text style
+// and returns them with the matching field mask. Links to slides are dropped
+// because the slide IDs do not exist in the destination.
+func mergeTextStyle(src *slides.TextStyle) (*slides.TextStyle, string) {
+	style := &slides.TextStyle{}
+	var fields []string
+	if src.Bold {
+		style.Bold = true
+		fields = append(fields, "bold")
+	}
+	if src.Italic {
+		style.Italic = true
+		fields = append(fields, "italic")
+	}
+	if src.Underline {
+		style.Underline = true
+		fields = append(fields, "underline")
+	}
+	if src.Strikethrough {
+		style.Strikethrough = true
+		fields = append(fields, "strikethrough")
+	}
+	if src.FontFamily != "" {
+		style.FontFamily = src.FontFamily
+		fields = append(fields, "fontFamily")
+	}
+	if src.FontSize != nil {
+		style.FontSize = src.FontSize
+		fields = append(fields, "fontSize")
+	}
+	if src.ForegroundColor != nil {
+		style.ForegroundColor = src.ForegroundColor
+		fields = append(fields, "foregroundColor")
+	}
+	if src.BackgroundColor != nil {
+		style.BackgroundColor = src.BackgroundColor
+		fields = append(fields, "backgroundColor")
+	}
+	if src.Link != nil && src.Link.Url != "" {
+		style.Link = &slides.Link{Url: src.Link.Url}
+		fields = append(fields, "link")
+	}
+	return style, strings.Join(fields, ",")
+}
+
+// composeTransforms returns parent × child, the absolute transform of an
+// element inside a group. A nil parent returns child unchanged.
+func composeTransforms(parent, child *slides.AffineTransform) *slides.AffineTransform {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	return &slides.AffineTransform{
+		ScaleX:     parent.ScaleX*child.ScaleX + parent.ShearX*child.ShearY,
+		ShearX:     parent.ScaleX*child.ShearX + parent.ShearX*child.ScaleY,
+		TranslateX: parent.ScaleX*child.TranslateX + parent.ShearX*child.TranslateY + parent.TranslateX,
+		ShearY:     parent.ShearY*child.ScaleX + parent.ScaleY*child.ShearY,
+		ScaleY:     parent.ShearY*child.ShearX + parent.ScaleY*child.ScaleY,
+		TranslateY: parent.ShearY*child.TranslateX + parent.ScaleY*child.TranslateY + parent.TranslateY,
+		Unit:       child.Unit,
+	}
+}
+
+// pageElementKind names the type of a page element for reporting.
+func pageElementKind(elem *slides.PageElement) string {
+	switch {
+	case elem.Shape != nil:
+		return "shape"
+	case elem.Image != nil:
+		return "image"
+	case elem.Table != nil:
+		return "table"
+	case elem.Line != nil:
+		return "line"
+	case elem.SheetsChart != nil:
+		return "sheets_chart"
+	case elem.Video != nil:
+		return "video"
+	case elem.WordArt != nil:
+		return "word_art"
+	case elem.SpeakerSpotlight != nil:
+		return "speaker_spotlight"
+	case elem.ElementGroup != nil:
+		return "group"
+	}
+	return "unknown"
+}
+
+func runSlidesMerge(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	destID, _ := cmd.Flags().GetString("into")
+	srcID, _ := cmd.Flags().GetString("from")
+	at, _ := cmd.Flags().GetInt("at")
+	scaleMode, _ := cmd.Flags().GetString("scale")
+
+	scaleMode = strings.ToLower(scaleMode)
+	if scaleMode != "fit" && scaleMode != "stretch" && scaleMode != "none" {
+		return usageErrorf("invalid --scale %q: must be fit, stretch, or none", scaleMode)
+	}
+	if destID == srcID {
+		return usageErrorf("--into and --from must be different presentations (use duplicate-slide within one deck)")
+	}
+	if at < 0 {
+		return usageErrorf("--at must be 1 or greater")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	src, err := svc.Presentations.Get(srcID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get source presentation: %w", err))
+	}
+	if len(src.Slides) == 0 {
+		return p.PrintError(fmt.Errorf("source presentation %s has no slides", srcID))
+	}
+
+	dest, err := svc.Presentations.Get(destID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get destination presentation: %w", err))
+	}
+
+	insertionIndex := int64(len(dest.Slides))
+	if at > 0 {
+		if at > len(dest.Slides)+1 {
+			return usageErrorf("--at %d is out of range (destination has %d slides)", at, len(dest.Slides))
+		}
+		insertionIndex = int64(at - 1)
+	}
+
+	// Only rescale when the pages differ, so same-size merges keep the
+	// exact source transforms.
+	srcW, srcH := pageSizeInPoints(src)
+	destW, destH := pageSizeInPoints(dest)
+	sizesDiffer := srcW != destW || srcH != destH
+	var scale *pageScale
+	if sizesDiffer {
+		scale = pageScaleFor(srcW, srcH, destW, destH, scaleMode)
+	}
+	merger := buildMergeRequests(src, insertionIndex, fmt.Sprintf("gws_merge_%d", time.Now().UnixNano()), scale)
+
+	_, err = svc.Presentations.BatchUpdate(destID, &slides.BatchUpdatePresentationRequest{
+		Requests: merger.requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to merge slides: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":          "merged",
+		"presentation_id": destID,
+		"source_id":       srcID,
+		"slides_added":    len(merger.slideIDs),
+		"slide_ids":       merger.slideIDs,
+		"position":        insertionIndex + 1,
+	}
+	if len(merger.skipped) > 0 {
+		result["skipped"] = merger.skipped
+		result["skipped_count"] = len(merger.skipped)
+	}
+	if sizesDiffer {
+		result["scale"] = scaleMode
+		if scale == nil {
+			result["warning"] = fmt.Sprintf("page sizes differ (source %.0fx%.0fpt, destination %.0fx%.0fpt); elements keep their source positions", srcW, srcH, destW, destH)
+		}
+	}
+	return p.Print(result)
+}
+
+// hasTextContent reports whether a text body holds any non-empty run.
+// UpdateTextStyle fails on elements with no text.
+func hasTextContent(text *slides.TextContent) bool {
+	if text == nil {
+		return false
+	}
+	for _, te := range text.TextElements {
+		if te.TextRun != nil && strings.TrimSpace(te.TextRun.Content) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSetFontRequests returns one UpdateTextStyle per shape or table cell
+// that holds text, across every slide. It also returns the number of page
+// elements touched.
+func buildSetFontRequests(presentation *slides.Presentation, style *slides.TextStyle, fields string) ([]*slides.Request, int) {
+	var requests []*slides.Request
+	elements := 0
+
+	update := func(objectID string, cell *slides.TableCellLocation) {
+		requests = append(requests, &slides.Request{
+			UpdateTextStyle: &slides.UpdateTextStyleRequest{
+				ObjectId:     objectID,
+				CellLocation: cell,
+				TextRange:    &slides.Range{Type: "ALL"},
+				Style:        style,
+				Fields:       fields,
+			},
+		})
+	}
+
+	var visit func(elem *slides.PageElement)
+	visit = func(elem *slides.PageElement) {
+		switch {
+		case elem == nil:
+		case elem.ElementGroup != nil:
+			for _, child := range elem.ElementGroup.Children {
+				visit(child)
+			}
+		case elem.Shape != nil:
+			if hasTextContent(elem.Shape.Text) {
+				update(elem.ObjectId, nil)
+				elements++
+			}
+		case elem.Table != nil:
+			touched := false
+			for r, row := range elem.Table.TableRows {
+				for c, cell := range row.TableCells {
+					if cell != nil && hasTextContent(cell.Text) {
+						update(elem.ObjectId, &slides.TableCellLocation{RowIndex: int64(r), ColumnIndex: int64(c)})
+						touched = true
+					}
+				}
+			}
+			if touched {
+				elements++
+			}
+		}
+	}
+
+	for _, slide := range presentation.Slides {
+		for _, elem := range slide.PageElements {
+			visit(elem)
+		}
+	}
+	return requests, elements
+}
+
+func runSlidesSetFont(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	family, _ := cmd.Flags().GetString("family")
+	size, _ := cmd.Flags().GetFloat64("size")
+
+	family = strings.TrimSpace(family)
+	if family == "" {
+		return usageErrorf("--family must not be empty")
+	}
+	if size < 0 {
+		return usageErrorf("--size must be greater than 0")
+	}
+
+	style := &slides.TextStyle{FontFamily: family}
+	fields := []string{"fontFamily"}
+	if size > 0 {
+		style.FontSize = &slides.Dimension{Magnitude: size, Unit: "PT"}
+		fields = append(fields, "fontSize")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	requests, elements := buildSetFontRequests(presentation, style, strings.Join(fields, ","))
+	result := map[string]interface{}{
+		"status":           "updated",
+		"presentation_id":  presentationID,
+		"family":           family,
+		"slides":           len(presentation.Slides),
+		"elements_updated": elements,
+	}
+	if size > 0 {
+		result["size"] = size
+	}
+	if len(requests) == 0 {
+		return p.Print(result)
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set font: %w", err))
+	}
+
+	return p.Print(result)
+}
+
+// placeholderTextStyle is the style set-defaults applies to one group of
+// placeholders. A nil style leaves the group unchanged.
+type placeholderTextStyle struct {
+	style  *slides.TextStyle
+	fields string
+}
+
+// newPlaceholderTextStyle builds the style for a font family and size,
+// either of which may be unset.
+func newPlaceholderTextStyle(family string, size float64) placeholderTextStyle {
+	var fields []string
+	style := &slides.TextStyle{}
+	if family != "" {
+		style.FontFamily = family
+		fields = append(fields, "fontFamily")
+	}
+	if size > 0 {
+		style.FontSize = &slides.Dimension{Magnitude: size, Unit: "PT"}
+		fields = append(fields, "fontSize")
+	}
+	if len(fields) == 0 {
+		return placeholderTextStyle{}
+	}
+	return placeholderTextStyle{style: style, fields: strings.Join(fields, ",")}
+}
+
+// buildSetDefaultsRequests returns one UpdateTextStyle per title or body
+// placeholder with text on every master and layout, and the number of
+// placeholders updated in each group.
+func buildSetDefaultsRequests(presentation *slides.Presentation, title, body placeholderTextStyle) ([]*slides.Request, int, int) {
+	var requests []*slides.Request
+	titles, bodies := 0, 0
+
+	pages := append(append([]*slides.Page{}, presentation.Masters...), presentation.Layouts...)
+	for _, page := range pages {
+		for _, elem := range page.PageElements {
+			if elem == nil || elem.Shape == nil || elem.Shape.Placeholder == nil || !hasTextContent(elem.Shape.Text) {
+				continue
+			}
+			var target placeholderTextStyle
+			switch elem.Shape.Placeholder.Type {
+			case "TITLE", "CENTERED_TITLE":
+				target = title
+				if target.style != nil {
+					titles++
+				}
+			case "BODY", "SUBTITLE":
+				target = body
+				if target.style != nil {
+					bodies++
+				}
+			}
+			if target.style == nil {
+				continue
+			}
+			requests = append(requests, &slides.Request{
+				UpdateTextStyle: &slides.UpdateTextStyleRequest{
+					ObjectId:  elem.ObjectId,
+					TextRange: &slides.Range{Type: "ALL"},
+					Style:     target.style,
+					Fields:    target.fields,
+				},
+			})
+		}
+	}
+	return requests, titles, bodies
+}
+
+func runSlidesSetDefaults(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	titleFont, _ := cmd.Flags().GetString("title-font")
+	titleSize, _ := cmd.Flags().GetFloat64("title-size")
+	bodyFont, _ := cmd.Flags().GetString("body-font")
+	bodySize, _ := cmd.Flags().GetFloat64("body-size")
+
+	titleFont = strings.TrimSpace(titleFont)
+	bodyFont = strings.TrimSpace(bodyFont)
+	if titleSize < 0 || bodySize < 0 {
+		return usageErrorf("--title-size and --body-size must be greater than 0")
+	}
+	title := newPlaceholderTextStyle(titleFont, titleSize)
+	body := newPlaceholderTextStyle(bodyFont, bodySize)
+	if title.style == nil && body.style == nil {
+		return usageErrorf("at least one of --title-font, --title-size, --body-font, or --body-size is required")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).
+		Fields("masters(pageElements(objectId,shape(placeholder,text))),layouts(pageElements(objectId,shape(placeholder,text)))").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	requests, titles, bodies := buildSetDefaultsRequests(presentation, title, body)
+	result := map[string]interface{}{
+		"status":             "updated",
+		"presentation_id":    presentationID,
+		"masters":            len(presentation.Masters),
+		"layouts":            len(presentation.Layouts),
+		"title_placeholders": titles,
+		"body_placeholders":  bodies,
+	}
+	if title.style != nil {
+		result["title"] = describeTextDefaults(titleFont, titleSize)
+	}
+	if body.style != nil {
+		result["body"] = describeTextDefaults(bodyFont, bodySize)
+	}
+	if len(requests) == 0 {
+		result["status"] = "unchanged"
+		return p.Print(result)
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set default text styles: %w", err))
+	}
+
+	return p.Print(result)
+}
+
+// describeTextDefaults reports the font and size set-defaults applied,
+// leaving out the unset one.
+func describeTextDefaults(family string, size float64) map[string]interface{} {
+	out := map[string]interface{}{}
+	if family != "" {
+		out["font"] = family
+	}
+	if size > 0 {
+		out["size"] = size
+	}
+	return out
+}
+
+// fontUsage tallies the text runs using one font family.
+type fontUsage struct {
+	Family string
+	Runs   int
+	Slides []int // 1-indexed, ascending
+}
+
+// collectFonts counts non-blank text runs per font family across every
+// slide's shapes and table cells, recursing into groups. Runs with no
+// explicit family are returned as the inherited count.
+func collectFonts(presentation *slides.Presentation) ([]fontUsage, int) {
+	byFamily := map[string]*fontUsage{}
+	inherited := 0
+
+	countText := func(slideNum int, text *slides.TextContent) {
+		if text == nil {
+			return
+		}
+		for _, te := range text.TextElements {
+			if te.TextRun == nil || strings.TrimSpace(te.TextRun.Content) == "" {
+				continue
+			}
+			family := ""
+			if te.TextRun.Style != nil {
+				family = te.TextRun.Style.FontFamily
+			}
+			if family == "" {
+				inherited++
+				continue
+			}
+			u, ok := byFamily[family]
+			if !ok {
+				u = &fontUsage{Family: family}
+				byFamily[family] = u
+			}
+			u.Runs++
+			if n := len(u.Slides); n == 0 || u.Slides[n-1] != slideNum {
+				u.Slides = append(u.Slides, slideNum)
+			}
+		}
+	}
+
+	var visit func(slideNum int, elem *slides.PageElement)
+	visit = func(slideNum int, elem *slides.PageElement) {
+		switch {
+		case elem == nil:
+		case elem.ElementGroup != nil:
+			for _, child := range elem.ElementGroup.Children {
+				visit(slideNum, child)
+			}
+		case elem.Shape != nil:
+			countText(slideNum, elem.Shape.Text)
+		case elem.Table != nil:
+			for _, row := range elem.Table.TableRows {
+				for _, cell := range row.TableCells {
+					if cell != nil {
+						countText(slideNum, cell.Text)
+					}
+				}
+			}
+		}
+	}
+
+	for i, slide := range presentation.Slides {
+		for _, elem := range slide.PageElements {
+			visit(i+1, elem)
+		}
+	}
+
+	fonts := make([]fontUsage, 0, len(byFamily))
+	for _, u := range byFamily {
+		fonts = append(fonts, *u)
+	}
+	sort.Slice(fonts, func(i, j int) bool {
+		if fonts[i].Runs != fonts[j].Runs {
+			return fonts[i].Runs > fonts[j].Runs
+		}
+		return fonts[i].Family < fonts[j].Family
+	})
+	return fonts, inherited
+}
+
+func runSlidesFonts(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentationID := args[0]
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	fonts, inherited := collectFonts(presentation)
+	fontList := make([]map[string]interface{}, 0, len(fonts))
+	for _, f := range fonts {
+		fontList = append(fontList, map[string]interface{}{
+			"family": f.Family,
+			"runs":   f.Runs,
+			"slides": f.Slides,
+		})
+	}
+
+	return p.Print(map[string]interface{}{
+		"presentation_id": presentationID,
+		"fonts":           fontList,
+		"count":           len(fontList),
+		"inherited_runs":  inherited,
+	})
+}
+
+// lineDashStyles and lineArrowStyles list the values accepted by --dash
+// and --start-arrow/--end-arrow.
+var (
+	lineDashStyles = map[string]bool{
+		"SOLID": true, "DOT": true, "DASH": true, "DASH_DOT": true, "LONG_DASH": true, "LONG_DASH_DOT": true,
+	}
+	lineArrowStyles = map[string]bool{
+		"NONE": true, "FILL_ARROW": true, "STEALTH_ARROW": true, "OPEN_ARROW": true,
+		"FILL_CIRCLE": true, "OPEN_CIRCLE": true, "FILL_SQUARE": true, "OPEN_SQUARE": true,
+		"FILL_DIAMOND": true, "OPEN_DIAMOND": true,
+	}
+)
+
+// lineStyle holds the line styling flags shared by add-line and update-line.
+// Empty strings and an unset weight leave the property unchanged.
+type lineStyle struct {
+	Color      string
+	Weight     float64
+	WeightSet  bool
+	Dash       string
+	StartArrow string
+	EndArrow   string
+}
+
+// addLineStyleFlags registers the dash and arrowhead flags.
+func addLineStyleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("dash", "", "Dash style: SOLID, DOT, DASH, DASH_DOT, LONG_DASH, LONG_DASH_DOT")
+	cmd.Flags().String("start-arrow", "", "Arrowhead at the start, e.g. FILL_ARROW, OPEN_CIRCLE, NONE")
+	cmd.Flags().String("end-arrow", "", "Arrowhead at the end, e.g. FILL_ARROW, OPEN_CIRCLE, NONE")
+}
+
+func lineStyleFromFlags(cmd *cobra.Command) lineStyle {
+	var s lineStyle
+	s.Color, _ = cmd.Flags().GetString("color")
+	s.Weight, _ = cmd.Flags().GetFloat64("weight")
+	s.WeightSet = cmd.Flags().Changed("weight")
+	s.Dash, _ = cmd.Flags().GetString("dash")
+	s.StartArrow, _ = cmd.Flags().GetString("start-arrow")
+	s.EndArrow, _ = cmd.Flags().GetString("end-arrow")
+	return s
+}
+
+// buildLineProperties validates a lineStyle and returns the LineProperties
+// and field mask for UpdateLineProperties. The mask is empty when nothing
+// was set.
+func buildLineProperties(s lineStyle) (*slides.LineProperties, string, error) {
+	props := &slides.LineProperties{}
+	var fields []string
+
+	if s.Color != "" {
+		color, err := parseHexColor(s.Color)
+		if err != nil {
+			return nil, "", err
+		}
+		props.LineFill = &slides.LineFill{
+			SolidFill: &slides.SolidFill{
+				Color: &slides.OpaqueColor{RgbColor: color},
+			},
+		}
+		fields = append(fields, "lineFill")
+	}
+	if s.WeightSet {
+		if s.Weight <= 0 {
+			return nil, "", fmt.Errorf("--weight must be greater than 0")
+		}
+		props.Weight = &slides.Dimension{Magnitude: s.Weight, Unit: "PT"}
+		fields = append(fields, "weight")
+	}
+	if s.Dash != "" {
+		dash := strings.ToUpper(s.Dash)
+		if !lineDashStyles[dash] {
+			return nil, "", fmt.Errorf("invalid --dash %q: must be SOLID, DOT, DASH, DASH_DOT, LONG_DASH, or LONG_DASH_DOT", s.Dash)
+		}
+		props.DashStyle = dash
+		fields = append(fields, "dashStyle")
+	}
+	if s.StartArrow != "" {
+		arrow, err := parseArrowStyle("--start-arrow", s.StartArrow)
+		if err != nil {
+			return nil, "", err
+		}
+		props.StartArrow = arrow
+		fields = append(fields, "startArrow")
+	}
+	if s.EndArrow != "" {
+		arrow, err := parseArrowStyle("--end-arrow", s.EndArrow)
+		if err != nil {
+			return nil, "", err
+		}
+		props.EndArrow = arrow
+		fields = append(fields, "endArrow")
+	}
+
+	return props, strings.Join(fields, ","), nil
+}
+
+func parseArrowStyle(flag, value string) (string, error) {
+	arrow := strings.ToUpper(value)
+	if !lineArrowStyles[arrow] {
+		return "", fmt.Errorf("invalid %s %q: see --help for arrow styles", flag, value)
+	}
+	return arrow, nil
+}
+
+func runSlidesUpdateLine(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	presentationID := args[0]
+	objectID, _ := cmd.Flags().GetString("object-id")
+
+	props, fields, err := buildLineProperties(lineStyleFromFlags(cmd))
+	if err != nil {
+		return usageErrorf("%s", err)
+	}
+	if fields == "" {
+		return usageErrorf("at least one of --color, --weight, --dash, --start-arrow, or --end-arrow is required")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{
+			{
+				UpdateLineProperties: &slides.UpdateLinePropertiesRequest{
+					ObjectId:       objectID,
+					LineProperties: props,
+					Fields:         fields,
+				},
+			},
+		},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to update line: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":          "updated",
+		"presentation_id": presentationID,
+		"object_id":       objectID,
+		"fields":          strings.Split(fields, ","),
+	})
+}
+
+// slideNumberIDPrefix marks the text boxes added by toggle-slide-numbers
+// --on, so later runs can renumber or remove them.
+const slideNumberIDPrefix = "gws_slidenum_"
+
+// Size of an added slide number when no placeholder gives one, in points.
+const (
+	slideNumberWidth  = 48.0
+	slideNumberHeight = 24.0
+)
+
+// slideNumberSummary counts what toggle-slide-numbers did across the deck.
+type slideNumberSummary struct {
+	Added      int
+	Renumbered int
+	Removed    int
+	Native     int
+}
+
+// isSlideNumberPlaceholder reports whether el is a SLIDE_NUMBER placeholder.
+func isSlideNumberPlaceholder(el *slides.PageElement) bool {
+	return el != nil && el.Shape != nil && el.Shape.Placeholder != nil && el.Shape.Placeholder.Type == "SLIDE_NUMBER"
+}
+
+// slideNumberPlacement returns the size and transform of the SLIDE_NUMBER
+// placeholder on the slide's layout, falling back to its master and then to
+// the bottom-right corner of the page.
+func slideNumberPlacement(presentation *slides.Presentation, slide *slides.Page) (*slides.Size, *slides.AffineTransform) {
+	pages := map[string]*slides.Page{}
+	for _, l := range presentation.Layouts {
+		pages[l.ObjectId] = l
+	}
+	for _, m := range presentation.Masters {
+		pages[m.ObjectId] = m
+	}
+	if props := slide.SlideProperties; props != nil {
+		for _, id := range []string{props.LayoutObjectId, props.MasterObjectId} {
+			page := pages[id]
+			if page == nil {
+				continue
+			}
+			for _, el := range page.PageElements {
+				if isSlideNumberPlaceholder(el) && el.Size != nil && el.Transform != nil {
+					return el.Size, el.Transform
+				}
+			}
+		}
+	}
+
+	pageWidth, pageHeight := pageSizeInPoints(presentation)
+	size := &slides.Size{
+		Width:  &slides.Dimension{Magnitude: slideNumberWidth, Unit: "PT"},
+		Height: &slides.Dimension{Magnitude: slideNumberHeight, Unit: "PT"},
+	}
+	transform := &slides.AffineTransform{
+		ScaleX:     1,
+		ScaleY:     1,
+		TranslateX: pageWidth - footerMargin - slideNumberWidth,
+		TranslateY: pageHeight - footerMargin - slideNumberHeight,
+		Unit:       "PT",
+	}
+	return size, transform
+}
+
+// buildSlideNumberRequests returns the batch requests that turn slide
+// numbers on or off across the deck. idPrefix seeds the IDs of added text
+// boxes and must start with slideNumberIDPrefix.
+func buildSlideNumberRequests(presentation *slides.Presentation, on, skipFirst bool, fontSize float64, idPrefix string) ([]*slides.Request, slideNumberSummary) {
+	var requests []*slides.Request
+	var summary slideNumberSummary
+	for i, slide := range presentation.Slides {
+		if skipFirst && i == 0 {
+			continue
+		}
+		number := strconv.Itoa(i + 1)
+
+		var native bool
+		var added []*slides.PageElement
+		for _, el := range slide.PageElements {
+			switch {
+			case isSlideNumberPlaceholder(el):
+				if on {
+					native = true
+				} else {
+					requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+					summary.Removed++
+				}
+			case strings.HasPrefix(el.ObjectId, slideNumberIDPrefix):
+				added = append(added, el)
+			}
+		}
+
+		if !on || native {
+			// A native placeholder makes earlier static numbers redundant.
+			for _, el := range added {
+				requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+				summary.Removed++
+			}
+			if native {
+				summary.Native++
+			}
+			continue
+		}
+
+		if len(added) > 0 {
+			for _, el := range added {
+				if el.Shape == nil || strings.TrimSpace(extractShapeText(el.Shape)) == number {
+					continue
+				}
+				requests = append(requests,
+					&slides.Request{DeleteText: &slides.DeleteTextRequest{ObjectId: el.ObjectId, TextRange: &slides.Range{Type: "ALL"}}},
+					&slides.Request{InsertText: &slides.InsertTextRequest{ObjectId: el.ObjectId, Text: number}},
+				)
+				summary.Renumbered++
+			}
+			continue
+		}
+
+		size, transform := slideNumberPlacement(presentation, slide)
+		textID := fmt.Sprintf("%s_%d", idPrefix, i)
+		requests = append(requests,
+			&slides.Request{
+				CreateShape: &slides.CreateShapeRequest{
+					ObjectId:  textID,
+					ShapeType: "TEXT_BOX",
+					ElementProperties: &slides.PageElementProperties{
+						PageObjectId: slide.ObjectId,
+						Size:         size,
+						Transform:    transform,
+					},
+				},
+			},
+			&slides.Request{InsertText: &slides.InsertTextRequest{ObjectId: textID, Text: number}},
+			&slides.Request{
+				UpdateTextStyle: &slides.UpdateTextStyleRequest{
+					ObjectId:  textID,
+					Style:     &slides.TextStyle{FontSize: &slides.Dimension{Magnitude: fontSize, Unit: "PT"}},
+					TextRange: &slides.Range{Type: "ALL"},
+					Fields:    "fontSize",
+				},
+			},
+			&slides.Request{
+				UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
+					ObjectId:  textID,
+					Style:     &slides.ParagraphStyle{Alignment: "END"},
+					TextRange: &slides.Range{Type: "ALL"},
+					Fields:    "alignment",
+				},
+			},
+		)
+		summary.Added++
+	}
+	return requests, summary
+}
+
+func runSlidesToggleSlideNumbers(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	on, _ := cmd.Flags().GetBool("on")
+	off, _ := cmd.Flags().GetBool("off")
+	skipFirst, _ := cmd.Flags().GetBool("skip-first")
+	fontSize, _ := cmd.Flags().GetFloat64("font-size")
+
+	if on == off {
+		return usageErrorf("specify exactly one of --on or --off")
+	}
+	if fontSize <= 0 {
+		return usageErrorf("--font-size must be greater than 0")
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	idPrefix := fmt.Sprintf("%s%d", slideNumberIDPrefix, time.Now().UnixNano())
+	requests, summary := buildSlideNumberRequests(presentation, on, skipFirst, fontSize, idPrefix)
+	if len(requests) > 0 {
+		_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+			Requests: requests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to update slide numbers: %w", err))
+		}
+	}
+
+	status := "on"
+	if off {
+		status = "off"
+	}
+	return p.Print(map[string]interface{}{
+		"status":          status,
+		"presentation_id": presentationID,
+		"slides":          len(presentation.Slides),
+		"added":           summary.Added,
+		"renumbered":      summary.Renumbered,
+		"removed":         summary.Removed,
+		"native":          summary.Native,
+	})
+}
+
+func runSlidesSetAllBackgrounds(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	presentationID := args[0]
+	colorHex, _ := cmd.Flags().GetString("color")
+	imageURL, _ := cmd.Flags().GetString("image-url")
+	skipFirst, _ := cmd.Flags().GetBool("skip-first")
+
+	if colorHex == "" && imageURL == "" {
+		return usageErrorf("must specify --color or --image-url")
+	}
+	if colorHex != "" && imageURL != "" {
+		return usageErrorf("--color and --image-url are mutually exclusive")
+	}
+	fill, err := pageBackgroundFill(colorHex, imageURL)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).Fields("slides.objectId").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	var requests []*slides.Request
+	for i, slide := range presentation.Slides {
+		if skipFirst && i == 0 {
+			continue
+		}
+		requests = append(requests, backgroundRequest(slide.ObjectId, fill))
+	}
+
+	if len(requests) > 0 {
+		_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+			Requests: requests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to update slide backgrounds: %w", err))
+		}
+	}
+
+	result := map[string]interface{}{
+		"status":          "updated",
+		"presentation_id": presentationID,
+		"slides":          len(presentation.Slides),
+		"updated":         len(requests),
+	}
+	if colorHex != "" {
+		result["background_color"] = colorHex
+	} else {
+		result["background_image"] = imageURL
+	}
+	return p.Print(result)
+}
+
+// bulletItem is one line of a markdown list with its nesting level.
+type bulletItem struct {
+	Level int
+	Text  string
+}
+
+// bulletMarker matches a markdown list marker and the space after it.
+var bulletMarker = regexp.MustCompile(`^([-*+]|\d+[.)])\s+`)
+
+// parseMarkdownBullets turns a markdown list into items. Levels come from a
+// stack of indentation widths, so any consistent indent (2 spaces, 4
+// spaces, tabs) works and over-indented items nest only one level deeper.
+// It also reports whether the first item used an ordered marker.
+func parseMarkdownBullets(md string) ([]bulletItem, bool, error) {
+	var items []bulletItem
+	var indents []int
+	ordered := false
+	for _, line := range strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := 0
+		for _, r := range line {
+			if r == ' ' {
+				indent++
+			} else if r == '\t' {
+				indent += 4
+			} else {
+				break
+			}
+		}
+		text := strings.TrimSpace(line)
+		if m := bulletMarker.FindString(text); m != "" {
+			if len(items) == 0 {
+				ordered = m[0] >= '0' && m[0] <= '9'
+			}
+			text = strings.TrimSpace(text[len(m):])
+		}
+		for len(indents) > 0 && indents[len(indents)-1] > indent {
+			indents = indents[:len(indents)-1]
+		}
+		if len(indents) == 0 || indents[len(indents)-1] < indent {
+			indents = append(indents, indent)
+		}
+		items = append(items, bulletItem{Level: len(indents) - 1, Text: text})
+	}
+	if len(items) == 0 {
+		return nil, false, fmt.Errorf("--markdown has no list items")
+	}
+	return items, ordered, nil
+}
+
+// buildSetBodyRequests clears the shape (when it has text), inserts the items
+// with one leading tab per nesting level, and bullets them. The Slides API
+// reads the tabs as nesting levels and removes them.
+func buildSetBodyRequests(objectID string, hasText bool, items []bulletItem, preset string) []*slides.Request {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = strings.Repeat("\t", item.Level) + item.Text
+	}
+	var requests []*slides.Request
+	if hasText {
+		requests = append(requests, &slides.Request{
+			DeleteText: &slides.DeleteTextRequest{
+				ObjectId:  objectID,
+				TextRange: &slides.Range{Type: "ALL"},
+			},
+		})
+	}
+	return append(requests,
+		&slides.Request{
+			InsertText: &slides.InsertTextRequest{
+				ObjectId:       objectID,
+				InsertionIndex: 0,
+				Text:           strings.Join(lines, "\n"),
+			},
+		},
+		&slides.Request{
+			CreateParagraphBullets: &slides.CreateParagraphBulletsRequest{
+				ObjectId:     objectID,
+				TextRange:    &slides.Range{Type: "ALL"},
+				BulletPreset: preset,
+			},
+		},
+	)
+}
+
+// findBodyShape returns the shape to fill on slide: the element with
+// objectID when given, otherwise the first BODY placeholder.
+func findBodyShape(slide *slides.Page, objectID string) (*slides.PageElement, error) {
+	for _, el := range slide.PageElements {
+		if el.Shape == nil {
+			continue
+		}
+		if objectID != "" {
+			if el.ObjectId == objectID {
+				return el, nil
+			}
+			continue
+		}
+		if el.Shape.Placeholder != nil && el.Shape.Placeholder.Type == "BODY" {
+			return el, nil
+		}
+	}
+	if objectID != "" {
+		return nil, fmt.Errorf("shape %s not found on slide %s", objectID, slide.ObjectId)
+	}
+	return nil, fmt.Errorf("slide %s has no BODY placeholder; use --object-id to pick a shape", slide.ObjectId)
+}
+
+func runSlidesSetBody(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	presentationID := args[0]
+	slideIDFlag, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	markdown, _ := cmd.Flags().GetString("markdown")
+	objectID, _ := cmd.Flags().GetString("object-id")
+	preset, _ := cmd.Flags().GetString("preset")
+
+	if slideIDFlag == "" && slideNumber <= 0 {
+		return usageErrorf("must specify --slide-id or --slide-number")
+	}
+	items, ordered, err := parseMarkdownBullets(strings.ReplaceAll(markdown, `\n`, "\n"))
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	if preset == "" {
+		preset = "BULLET_DISC_CIRCLE_SQUARE"
+		if ordered {
+			preset = "NUMBERED_DIGIT_ALPHA_ROMAN"
+		}
+	}
+	preset = strings.ToUpper(preset)
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	slide, err := findSlide(presentation, slideIDFlag, slideNumber)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	shape, err := findBodyShape(slide, objectID)
+	if err != nil {
+		return p.PrintError(err)
+	}
+	requests := buildSetBodyRequests(shape.ObjectId, shapeHasText(shape), items, preset)
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to set body: %w", err))
+	}
+
+	maxLevel := 0
+	for _, item := range items {
+		if item.Level > maxLevel {
+			maxLevel = item.Level
+		}
+	}
+	return p.Print(map[string]interface{}{
+		"status":          "updated",
+		"presentation_id": presentationID,
+		"slide_id":        slide.ObjectId,
+		"object_id":       shape.ObjectId,
+		"items":           len(items),
+		"max_level":       maxLevel,
+		"preset":          preset,
+	})
+}
+
+// slideSpec is the JSON document read by slides set-slide. A nil Title or
+// Body leaves that placeholder untouched.
+type slideSpec struct {
+	Title    *string            `json:"title"`
+	Body     *string            `json:"body"`
+	Elements []slideSpecElement `json:"elements"`
+}
+
+// slideSpecElement is one element to create on the slide. Position and size
+// are in points.
+type slideSpecElement struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	X          float64         `json:"x"`
+	Y          float64         `json:"y"`
+	Width      float64         `json:"width"`
+	Height     float64         `json:"height"`
+	Text       string          `json:"text"`
+	ShapeType  string          `json:"shape_type"`
+	URL        string          `json:"url"`
+	Rows       [][]interface{} `json:"rows"`
+	BoldHeader bool            `json:"bold_header"`
+	Style      *slideSpecStyle `json:"style"`
+}
+
+// slideSpecStyle styles an element's text and, for text boxes and shapes,
+// its fill.
+type slideSpecStyle struct {
+	FontFamily string  `json:"font_family"`
+	FontSize   float64 `json:"font_size"`
+	Bold       bool    `json:"bold"`
+	Italic     bool    `json:"italic"`
+	Color      string  `json:"color"`
+	Fill       string  `json:"fill"`
+	Alignment  string  `json:"alignment"`
+}
+
+// paragraphAlignments are the alignments accepted in a slide spec style.
+var paragraphAlignments = map[string]bool{"START": true, "CENTER": true, "END": true, "JUSTIFIED": true}
+
+// textStyle returns the style's text properties and their field mask, which
+// is empty when the style sets none.
+func (s *slideSpecStyle) textStyle() (*slides.TextStyle, string, error) {
+	style := &slides.TextStyle{}
+	if s == nil {
+		return style, "", nil
+	}
+	var fields []string
+	if s.FontFamily != "" {
+		style.FontFamily = s.FontFamily
+		fields = append(fields, "fontFamily")
+	}
+	if s.FontSize > 0 {
+		style.FontSize = &slides.Dimension{Magnitude: s.FontSize, Unit: "PT"}
+		fields = append(fields, "fontSize")
+	}
+	if s.Bold {
+		style.Bold = true
+		fields = append(fields, "bold")
+	}
+	if s.Italic {
+		style.Italic = true
+		fields = append(fields, "italic")
+	}
+	if s.Color != "" {
+		color, err := parseHexColor(s.Color)
+		if err != nil {
+			return nil, "", err
+		}
+		style.ForegroundColor = &slides.OptionalColor{OpaqueColor: &slides.OpaqueColor{RgbColor: color}}
+		fields = append(fields, "foregroundColor")
+	}
+	return style, strings.Join(fields, ","), nil
+}
+
+// parseSlideSpec decodes and validates a set-slide spec. Unknown fields are
+// rejected so a misspelled key fails here rather than being ignored.
+func parseSlideSpec(data []byte) (*slideSpec, error) {
+	var spec slideSpec
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("invalid spec JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid spec JSON: unexpected data after the spec")
+	}
+
+	ids := make(map[string]bool)
+	for i := range spec.Elements {
+		el := &spec.Elements[i]
+		el.Type = strings.ToLower(el.Type)
+		if err := validateSlideSpecElement(el); err != nil {
+			return nil, fmt.Errorf("element %d: %v", i+1, err)
+		}
+		if el.ID != "" {
+			if ids[el.ID] {
+				return nil, fmt.Errorf("element %d: duplicate id %q", i+1, el.ID)
+			}
+			ids[el.ID] = true
+		}
+	}
+	return &spec, nil
+}
+
+func validateSlideSpecElement(el *slideSpecElement) error {
+	if el.ID != "" && !objectIDPattern.MatchString(el.ID) {
+		return fmt.Errorf("invalid id %q: must be 5-50 letters, digits, or _-: and not start with - or :", el.ID)
+	}
+	if el.Width <= 0 || el.Height <= 0 {
+		return fmt.Errorf("width and height must be positive")
+	}
+	if _, _, err := el.Style.textStyle(); err != nil {
+		return err
+	}
+	if el.Style != nil {
+		if el.Style.Fill != "" {
+			if _, err := parseHexColor(el.Style.Fill); err != nil {
+				return err
+			}
+		}
+		if el.Style.Alignment != "" {
+			el.Style.Alignment = strings.ToUpper(el.Style.Alignment)
+			if !paragraphAlignments[el.Style.Alignment] {
+				return fmt.Errorf("invalid alignment %q: use START, CENTER, END, or JUSTIFIED", el.Style.Alignment)
+			}
+		}
+	}
+
+	switch el.Type {
+	case "text":
+		if el.ShapeType != "" {
+			return fmt.Errorf("shape_type is only valid for shape elements")
+		}
+	case "shape":
+		el.ShapeType = strings.ToUpper(el.ShapeType)
+		if !validShapeTypes[el.ShapeType] {
+			return fmt.Errorf("invalid shape_type %q. Common types: RECTANGLE, ELLIPSE, TEXT_BOX, TRIANGLE, ARROW, STAR_5", el.ShapeType)
+		}
+	case "image":
+		parsed, err := url.Parse(el.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("image url must be a public http or https URL")
+		}
+		if el.Text != "" || el.Style != nil {
+			return fmt.Errorf("images take no text or style")
+		}
+	case "table":
+		if tableCells(el.Rows) == nil {
+			return fmt.Errorf("table rows must contain at least one row and one column")
+		}
+		if el.Text != "" || (el.Style != nil && el.Style.Fill != "") {
+			return fmt.Errorf("tables take no text or fill; put cell text in rows")
+		}
+	case "":
+		return fmt.Errorf("type is required (text, shape, image, or table)")
+	default:
+		return fmt.Errorf("unknown type %q: use text, shape, image, or table", el.Type)
+	}
+	if el.Type != "table" && (len(el.Rows) > 0 || el.BoldHeader) {
+		return fmt.Errorf("rows and bold_header are only valid for table elements")
+	}
+	if el.Type != "image" && el.URL != "" {
+		return fmt.Errorf("url is only valid for image elements")
+	}
+	return nil
+}
+
+// isMarkdownList reports whether every non-blank line of text starts with
+// a markdown list marker.
+func isMarkdownList(text string) bool {
+	found := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !bulletMarker.MatchString(line) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// findPlaceholder returns the first shape on slide whose placeholder type is
+// one of types, or nil.
+func findPlaceholder(slide *slides.Page, types ...string) *slides.PageElement {
+	for _, want := range types {
+		for _, el := range slide.PageElements {
+			if el.Shape != nil && el.Shape.Placeholder != nil && el.Shape.Placeholder.Type == want {
+				return el
+			}
+		}
+	}
+	return nil
+}
+
+// isPlaceholderElement reports whether el is a shape or image placeholder
+// inherited from the slide's layout.
+func isPlaceholderElement(el *slides.PageElement) bool {
+	return (el.Shape != nil && el.Shape.Placeholder != nil) || (el.Image != nil && el.Image.Placeholder != nil)
+}
+
+// setSlideSummary describes what buildSetSlideRequests changes.
+type setSlideSummary struct {
+	Deleted []string
+	Created []map[string]interface{}
+	TitleID string
+	BodyID  string
+}
+
+// buildSetSlideRequests returns the requests that rebuild slide from spec:
+// delete its non-placeholder elements, fill the title and body
+// placeholders, then create the spec's elements. Elements without an id get
+// idPrefix_N.
+func buildSetSlideRequests(slide *slides.Page, spec *slideSpec, idPrefix string) ([]*slides.Request, setSlideSummary, error) {
+	var requests []*slides.Request
+	summary := setSlideSummary{Deleted: []string{}, Created: []map[string]interface{}{}}
+
+	kept := make(map[string]bool)
+	for _, el := range slide.PageElements {
+		if isPlaceholderElement(el) {
+			kept[el.ObjectId] = true
+			continue
+		}
+		requests = append(requests, &slides.Request{
+			DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId},
+		})
+		summary.Deleted = append(summary.Deleted, el.ObjectId)
+	}
+
+	if spec.Title != nil {
+		title := findPlaceholder(slide, "TITLE", "CENTERED_TITLE")
+		if title == nil {
+			return nil, summary, fmt.Errorf("slide %s has no title placeholder", slide.ObjectId)
+		}
+		requests = append(requests, replaceShapeTextRequests(title, *spec.Title)...)
+		summary.TitleID = title.ObjectId
+	}
+	if spec.Body != nil {
+		body := findPlaceholder(slide, "BODY", "SUBTITLE")
+		if body == nil {
+			return nil, summary, fmt.Errorf("slide %s has no body placeholder", slide.ObjectId)
+		}
+		text := strings.ReplaceAll(*spec.Body, "\r\n", "\n")
+		if isMarkdownList(text) {
+			items, ordered, err := parseMarkdownBullets(text)
+			if err != nil {
+				return nil, summary, err
+			}
+			preset := "BULLET_DISC_CIRCLE_SQUARE"
+			if ordered {
+				preset = "NUMBERED_DIGIT_ALPHA_ROMAN"
+			}
+			requests = append(requests, buildSetBodyRequests(body.ObjectId, shapeHasText(body), items, preset)...)
+		} else {
+			requests = append(requests, replaceShapeTextRequests(body, text)...)
+		}
+		summary.BodyID = body.ObjectId
+	}
+
+	for i, el := range spec.Elements {
+		id := el.ID
+		if id == "" {
+			id = fmt.Sprintf("%s_%d", idPrefix, i+1)
+		}
+		if kept[id] {
+			return nil, summary, fmt.Errorf("element %d: id %q is a placeholder on slide %s", i+1, id, slide.ObjectId)
+		}
+		props := &slides.PageElementProperties{
+			PageObjectId: slide.ObjectId,
+			Size: &slides.Size{
+				Width:  &slides.Dimension{Magnitude: el.Width, Unit: "PT"},
+				Height: &slides.Dimension{Magnitude: el.Height, Unit: "PT"},
+			},
+			Transform: &slides.AffineTransform{
+				ScaleX:     1,
+				ScaleY:     1,
+				TranslateX: el.X,
+				TranslateY: el.Y,
+				Unit:       "PT",
+			},
+		}
+		style, fields, err := el.Style.textStyle()
+		if err != nil {
+			return nil, summary, err
+		}
+
+		switch el.Type {
+		case "text", "shape":
+			shapeType := el.ShapeType
+			if el.Type == "text" {
+				shapeType = "TEXT_BOX"
+			}
+			requests = append(requests, &slides.Request{
+				CreateShape: &slides.CreateShapeRequest{ObjectId: id, ShapeType: shapeType, ElementProperties: props},
+			})
+			if el.Style != nil && el.Style.Fill != "" {
+				color, _ := parseHexColor(el.Style.Fill)
+				requests = append(requests, &slides.Request{
+					UpdateShapeProperties: &slides.UpdateShapePropertiesRequest{
+						ObjectId: id,
+						ShapeProperties: &slides.ShapeProperties{
+							ShapeBackgroundFill: &slides.ShapeBackgroundFill{
+								SolidFill: &slides.SolidFill{Color: &slides.OpaqueColor{RgbColor: color}},
+							},
+						},
+						Fields: "shapeBackgroundFill",
+					},
+				})
+			}
+			if el.Text == "" {
+				break
+			}
+			requests = append(requests, &slides.Request{
+				InsertText: &slides.InsertTextRequest{ObjectId: id, Text: el.Text},
+			})
+			if fields != "" {
+				requests = append(requests, &slides.Request{
+					UpdateTextStyle: &slides.UpdateTextStyleRequest{
+						ObjectId:  id,
+						TextRange: &slides.Range{Type: "ALL"},
+						Style:     style,
+						Fields:    fields,
+					},
+				})
+			}
+			if el.Style != nil && el.Style.Alignment != "" {
+				requests = append(requests, &slides.Request{
+					UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
+						ObjectId:  id,
+						TextRange: &slides.Range{Type: "ALL"},
+						Style:     &slides.ParagraphStyle{Alignment: el.Style.Alignment},
+						Fields:    "alignment",
+					},
+				})
+			}
+		case "image":
+			requests = append(requests, &slides.Request{
+				CreateImage: &slides.CreateImageRequest{ObjectId: id, Url: el.URL, ElementProperties: props},
+			})
+		case "table":
+			data := tableCells(el.Rows)
+			requests = append(requests, buildDataTableRequests(slide.ObjectId, id, data, props, el.BoldHeader)...)
+			for r, row := range data {
+				for c, text := range row {
+					if text == "" {
+						continue
+					}
+					cell := &slides.TableCellLocation{RowIndex: int64(r), ColumnIndex: int64(c)}
+					if fields != "" {
+						requests = append(requests, &slides.Request{
+							UpdateTextStyle: &slides.UpdateTextStyleRequest{
+								ObjectId:     id,
+								CellLocation: cell,
+								TextRange:    &slides.Range{Type: "ALL"},
+								Style:        style,
+								Fields:       fields,
+							},
+						})
+					}
+					if el.Style != nil && el.Style.Alignment != "" {
+						requests = append(requests, &slides.Request{
+							UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
+								ObjectId:     id,
+								CellLocation: cell,
+								TextRange:    &slides.Range{Type: "ALL"},
+								Style:        &slides.ParagraphStyle{Alignment: el.Style.Alignment},
+								Fields:       "alignment",
+							},
+						})
+					}
+				}
+			}
+		}
+		summary.Created = append(summary.Created, map[string]interface{}{
+			"object_id": id,
+			"type":      el.Type,
+		})
+	}
+	return requests, summary, nil
+}
+
+// shapeHasText reports whether a shape element holds any text.
+func shapeHasText(el *slides.PageElement) bool {
+	return el.Shape != nil && el.Shape.Text != nil && len(el.Shape.Text.TextElements) > 0
+}
+
+// replaceShapeTextRequests clears a shape's text, when it has any, and
+// inserts text in its place.
+func replaceShapeTextRequests(el *slides.PageElement, text string) []*slides.Request {
+	var requests []*slides.Request
+	if shapeHasText(el) {
+		requests = append(requests, &slides.Request{
+			DeleteText: &slides.DeleteTextRequest{ObjectId: el.ObjectId, TextRange: &slides.Range{Type: "ALL"}},
+		})
+	}
+	if text != "" {
+		requests = append(requests, &slides.Request{
+			InsertText: &slides.InsertTextRequest{ObjectId: el.ObjectId, Text: text},
+		})
+	}
+	return requests
+}
+
+func runSlidesSetSlide(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	slideIDFlag, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	specPath, _ := cmd.Flags().GetString("spec")
+
+	if slideIDFlag == "" && slideNumber <= 0 {
+		return usageErrorf("must specify --slide-id or --slide-number")
+	}
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read spec file: %w", err))
+	}
+	spec, err := parseSlideSpec(data)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	idPrefix := fmt.Sprintf("gws_slide_%d", time.Now().UnixNano())
+	return runSlidesSetSlideWithService(svc, args[0], slideIDFlag, slideNumber, spec, idPrefix, p)
+}
+
+func runSlidesSetSlideWithService(svc *slides.Service, presentationID, slideIDFlag string, slideNumber int, spec *slideSpec, idPrefix string, p printer.Printer) error {
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	slide, err := findSlide(presentation, slideIDFlag, slideNumber)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	requests, summary, err := buildSetSlideRequests(slide, spec, idPrefix)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	if len(requests) > 0 {
+		_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+			Requests: requests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to set slide: %w", err))
+		}
+	}
+
+	result := map[string]interface{}{
+		"status":          "updated",
+		"presentation_id": presentationID,
+		"slide_id":        slide.ObjectId,
+		"deleted":         summary.Deleted,
+		"created":         summary.Created,
+		"requests":        len(requests),
+	}
+	if summary.TitleID != "" {
+		result["title_id"] = summary.TitleID
+	}
+	if summary.BodyID != "" {
+		result["body_id"] = summary.BodyID
+	}
+	return p.Print(result)
+}
+
+// bulletPresets are the Slides API's CreateParagraphBulletsRequest presets.
+var bulletPresets = map[string]bool{
+	"BULLET_DISC_CIRCLE_SQUARE":            true,
+	"BULLET_DIAMONDX_ARROW3D_SQUARE":       true,
+	"BULLET_CHECKBOX":                      true,
+	"BULLET_ARROW_DIAMOND_DISC":            true,
+	"BULLET_STAR_CIRCLE_SQUARE":            true,
+	"BULLET_ARROW3D_CIRCLE_SQUARE":         true,
+	"BULLET_LEFTTRIANGLE_DIAMOND_DISC":     true,
+	"BULLET_DIAMONDX_HOLLOWDIAMOND_SQUARE": true,
+	"BULLET_DIAMOND_CIRCLE_SQUARE":         true,
+	"NUMBERED_DIGIT_ALPHA_ROMAN":           true,
+	"NUMBERED_DIGIT_ALPHA_ROMAN_PARENS":    true,
+	"NUMBERED_DIGIT_NESTED":                true,
+	"NUMBERED_UPPERALPHA_ALPHA_ROMAN":      true,
+	"NUMBERED_UPPERROMAN_UPPERALPHA_DIGIT": true,
+	"NUMBERED_ZERODIGIT_ALPHA_ROMAN":       true,
+}
+
+// normalizeBulletPreset maps --type to a bullet preset, accepting the
+// "bullet" and "numbered" shorthands.
+func normalizeBulletPreset(t string) (string, error) {
+	switch preset := strings.ToUpper(strings.TrimSpace(t)); preset {
+	case "BULLET":
+		return "BULLET_DISC_CIRCLE_SQUARE", nil
+	case "NUMBERED":
+		return "NUMBERED_DIGIT_ALPHA_ROMAN", nil
+	default:
+		if !bulletPresets[preset] {
+			return "", fmt.Errorf("invalid --type %q: use bullet, numbered, or a Slides bullet preset such as BULLET_CHECKBOX", t)
+		}
+		return preset, nil
+	}
+}
+
+// collectBulletItems merges --item values and --items lines into list
+// items, one per non-blank line.
+func collectBulletItems(itemFlags []string, itemsFlag string) []string {
+	blocks := append([]string{}, itemFlags...)
+	blocks = append(blocks, strings.ReplaceAll(itemsFlag, `\n`, "\n"))
+	var items []string
+	for _, block := range blocks {
+		for _, line := range strings.Split(strings.ReplaceAll(block, "\r\n", "\n"), "\n") {
+			if strings.TrimSpace(line) != "" {
+				items = append(items, line)
+			}
+		}
+	}
+	return items
+}
+
+// shapeTextUTF16 returns a shape's full text, trailing newline included, in
+// the UTF-16 code units the Slides API indexes text by.
+func shapeTextUTF16(shape *slides.Shape) []uint16 {
+	if shape.Text == nil {
+		return nil
+	}
+	var builder strings.Builder
+	for _, te := range shape.Text.TextElements {
+		switch {
+		case te.TextRun != nil:
+			builder.WriteString(te.TextRun.Content)
+		case te.AutoText != nil:
+			builder.WriteString(te.AutoText.Content)
+		}
+	}
+	return utf16.Encode([]rune(builder.String()))
+}
+
+// buildAddBulletsRequests inserts items as new paragraphs at index at of a
+// shape whose current text is existing, then bullets only those paragraphs.
+// A newline goes before the items when at is inside a paragraph and after
+// them when text follows in that paragraph, so no existing text shares a
+// paragraph with an item.
+func buildAddBulletsRequests(objectID string, existing []uint16, at int64, items []string, preset string) []*slides.Request {
+	text := strings.Join(items, "\n")
+	start := at
+	insert := text
+	if at > 0 && existing[at-1] != '\n' {
+		insert = "\n" + insert
+		start++
+	}
+	if at < int64(len(existing)) && existing[at] != '\n' {
+		insert += "\n"
+	}
+	end := start + int64(len(utf16.Encode([]rune(text))))
+
+	return []*slides.Request{
+		{
+			InsertText: &slides.InsertTextRequest{
+				ObjectId:       objectID,
+				InsertionIndex: at,
+				Text:           insert,
+			},
+		},
+		{
+			CreateParagraphBullets: &slides.CreateParagraphBulletsRequest{
+				ObjectId: objectID,
+				TextRange: &slides.Range{
+					Type:       "FIXED_RANGE",
+					StartIndex: &start,
+					EndIndex:   &end,
+				},
+				BulletPreset: preset,
+			},
+		},
+	}
+}
+
+func runSlidesAddBullets(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	objectID, _ := cmd.Flags().GetString("object-id")
+	itemFlags, _ := cmd.Flags().GetStringArray("item")
+	itemsFlag, _ := cmd.Flags().GetString("items")
+	bulletType, _ := cmd.Flags().GetString("type")
+	at, _ := cmd.Flags().GetInt64("at")
+
+	items := collectBulletItems(itemFlags, itemsFlag)
+	if len(items) == 0 {
+		return usageErrorf("must specify at least one list item with --item or --items")
+	}
+	preset, err := normalizeBulletPreset(bulletType)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	if at < 0 {
+		return usageErrorf("--at must be 0 or greater")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesAddBulletsWithService(svc, args[0], objectID, items, preset, at, p)
+}
+
+func runSlidesAddBulletsWithService(svc *slides.Service, presentationID, objectID string, items []string, preset string, at int64, p printer.Printer) error {
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	var shape *slides.Shape
+	for _, slide := range presentation.Slides {
+		for _, el := range slide.PageElements {
+			if el.ObjectId == objectID {
+				if el.Shape == nil {
+					return p.PrintError(fmt.Errorf("object %s is not a shape or text box", objectID))
+				}
+				shape = el.Shape
+			}
+		}
+	}
+	if shape == nil {
+		return p.PrintError(fmt.Errorf("object %s not found", objectID))
+	}
+
+	existing := shapeTextUTF16(shape)
+	// Text always ends with a newline; nothing can be inserted after it.
+	maxAt := int64(len(existing)) - 1
+	if maxAt < 0 {
+		maxAt = 0
+	}
+	if at > maxAt {
+		return p.PrintError(fmt.Errorf("--at %d is past the end of the text in %s (max %d)", at, objectID, maxAt))
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: buildAddBulletsRequests(objectID, existing, at, items, preset),
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to add bullets: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":          "added",
+		"presentation_id": presentationID,
+		"object_id":       objectID,
+		"items":           len(items),
+		"preset":          preset,
+		"at":              at,
+	})
+}
+
+// gridCell is one cell of a grid layout, in points.
+type gridCell struct {
+	X, Y, Width, Height float64
+}
+
+// gridLayout splits the page (less margin) into cells for n elements in
+// cols columns, filled row by row.
+func gridLayout(n, cols int, pageWidth, pageHeight, gap, margin float64) ([]gridCell, error) {
+	rows := (n + cols - 1) / cols
+	cellWidth := (pageWidth - 2*margin - float64(cols-1)*gap) / float64(cols)
+	cellHeight := (pageHeight - 2*margin - float64(rows-1)*gap) / float64(rows)
+	if cellWidth <= 0 || cellHeight <= 0 {
+		return nil, fmt.Errorf("a %dx%d grid does not fit on a %.0fx%.0f pt slide with --margin %.0f and --gap %.0f", cols, rows, pageWidth, pageHeight, margin, gap)
+	}
+	cells := make([]gridCell, n)
+	for i := range cells {
+		cells[i] = gridCell{
+			X:      margin + float64(i%cols)*(cellWidth+gap),
+			Y:      margin + float64(i/cols)*(cellHeight+gap),
+			Width:  cellWidth,
+			Height: cellHeight,
+		}
+	}
+	return cells, nil
+}
+
+// fitTransform returns the absolute transform that places an element of
+// the given intrinsic size (points) in cell: stretched to fill it, or
+// scaled to fit with its aspect ratio kept and centered.
+func fitTransform(width, height float64, cell gridCell, stretch bool) *slides.AffineTransform {
+	scaleX, scaleY := cell.Width/width, cell.Height/height
+	x, y := cell.X, cell.Y
+	if !stretch {
+		scale := math.Min(scaleX, scaleY)
+		scaleX, scaleY = scale, scale
+		x += (cell.Width - width*scale) / 2
+		y += (cell.Height - height*scale) / 2
+	}
+	return &slides.AffineTransform{
+		ScaleX:     scaleX,
+		ScaleY:     scaleY,
+		TranslateX: x,
+		TranslateY: y,
+		Unit:       "PT",
+	}
+}
+
+func runSlidesGridLayout(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	presentationID := args[0]
+	slideIDFlag, _ := cmd.Flags().GetString("slide-id")
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	objectIDsStr, _ := cmd.Flags().GetString("object-ids")
+	cols, _ := cmd.Flags().GetInt("cols")
+	gap, _ := cmd.Flags().GetFloat64("gap")
+	margin, _ := cmd.Flags().GetFloat64("margin")
+	stretch, _ := cmd.Flags().GetBool("stretch")
+
+	if slideIDFlag == "" && slideNumber <= 0 {
+		return usageErrorf("must specify --slide-id or --slide-number")
+	}
+	var objectIDs []string
+	for _, id := range strings.Split(objectIDsStr, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			objectIDs = append(objectIDs, id)
+		}
+	}
+	if len(objectIDs) == 0 {
+		return usageErrorf("--object-ids must list at least one element")
+	}
+	if cols < 0 {
+		return usageErrorf("--cols must not be negative")
+	}
+	if cols == 0 {
+		cols = int(math.Ceil(math.Sqrt(float64(len(objectIDs)))))
+	}
+	if cols > len(objectIDs) {
+		cols = len(objectIDs)
+	}
+	if gap < 0 || margin < 0 {
+		return usageErrorf("--gap and --margin must not be negative")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	presentation, err := svc.Presentations.Get(presentationID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+
+	slide, err := findSlide(presentation, slideIDFlag, slideNumber)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	pageWidth, pageHeight := pageSizeInPoints(presentation)
+	cells, err := gridLayout(len(objectIDs), cols, pageWidth, pageHeight, gap, margin)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	elements := make(map[string]*slides.PageElement)
+	for _, el := range slide.PageElements {
+		elements[el.ObjectId] = el
+	}
+
+	var requests []*slides.Request
+	placed := make([]map[string]interface{}, 0, len(objectIDs))
+	for i, id := range objectIDs {
+		el, ok := elements[id]
+		if !ok {
+			return p.PrintError(fmt.Errorf("element %s not found on slide %s", id, slide.ObjectId))
+		}
+		var width, height float64
+		if el.Size != nil {
+			width, height = dimensionToPoints(el.Size.Width), dimensionToPoints(el.Size.Height)
+		}
+		if width <= 0 || height <= 0 {
+			return p.PrintError(fmt.Errorf("element %s has no size and cannot be placed in a grid", id))
+		}
+		transform := fitTransform(width, height, cells[i], stretch)
+		requests = append(requests, &slides.Request{
+			UpdatePageElementTransform: &slides.UpdatePageElementTransformRequest{
+				ObjectId:  id,
+				Transform: transform,
+				ApplyMode: "ABSOLUTE",
+			},
+		})
+		placed = append(placed, map[string]interface{}{
+			"object_id": id,
+			"x":         transform.TranslateX,
+			"y":         transform.TranslateY,
+			"width":     width * transform.ScaleX,
+			"height":    height * transform.ScaleY,
+		})
+	}
+
+	_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to arrange elements: %w", err))
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":          "arranged",
+		"presentation_id": presentationID,
+		"slide_id":        slide.ObjectId,
+		"cols":            cols,
+		"rows":            (len(objectIDs) + cols - 1) / cols,
+		"cell":            map[string]float64{"width": cells[0].Width, "height": cells[0].Height},
+		"elements":        placed,
+	})
+}
+
+// slideAspects maps --aspect values to page sizes in points, matching the
+// sizes Slides offers in File > Page setup.
+var slideAspects = map[string][2]float64{
+	"WIDESCREEN_16_9":  {720, 405},
+	"WIDESCREEN_16_10": {720, 450},
+	"STANDARD_4_3":     {720, 540},
+}
+
+// pageScaleFor returns how a page of srcW x srcH points maps onto one of
+// dstW x dstH points. mode is fit, stretch, or none; none returns nil.
+func pageScaleFor(srcW, srcH, dstW, dstH float64, mode string) *pageScale {
+	switch mode {
+	case "stretch":
+		return &pageScale{ScaleX: dstW / srcW, ScaleY: dstH / srcH}
+	case "fit":
+		k := math.Min(dstW/srcW, dstH/srcH)
+		return &pageScale{
+			ScaleX:  k,
+			ScaleY:  k,
+			OffsetX: (dstW - srcW*k) / 2,
+			OffsetY: (dstH - srcH*k) / 2,
+		}
+	}
+	return nil
+}
+
+// buildCloneRequests recreates every slide of src at the front of the new
+// presentation created, in source order, then deletes the default slides
+// the new presentation started with.
+func buildCloneRequests(src, created *slides.Presentation, idPrefix string, scale *pageScale) *slideMerger {
+	merger := &slideMerger{idPrefix: idPrefix, scale: scale}
+	merger.addSlides(src, 0)
+	for _, slide := range created.Slides {
+		merger.requests = append(merger.requests, &slides.Request{
+			DeleteObject: &slides.DeleteObjectRequest{ObjectId: slide.ObjectId},
+		})
+	}
+	return merger
+}
+
+func runSlidesCloneAs(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+
+	srcID, _ := cmd.Flags().GetString("from")
+	title, _ := cmd.Flags().GetString("title")
+	aspect, _ := cmd.Flags().GetString("aspect")
+	scaleMode, _ := cmd.Flags().GetString("scale")
+
+	aspect = strings.ToUpper(aspect)
+	size, ok := slideAspects[aspect]
+	if !ok {
+		return usageErrorf("invalid --aspect %q: must be WIDESCREEN_16_9, WIDESCREEN_16_10, or STANDARD_4_3", aspect)
+	}
+	scaleMode = strings.ToLower(scaleMode)
+	if scaleMode != "fit" && scaleMode != "stretch" && scaleMode != "none" {
+		return usageErrorf("invalid --scale %q: must be fit, stretch, or none", scaleMode)
+	}
+
+	ctx := context.Background()
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	src, err := svc.Presentations.Get(srcID).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get source presentation: %w", err))
+	}
+	if len(src.Slides) == 0 {
+		return p.PrintError(fmt.Errorf("source presentation %s has no slides", srcID))
+	}
+
+	dstW, dstH := size[0], size[1]
+	created, err := svc.Presentations.Create(&slides.Presentation{
+		Title: title,
+		PageSize: &slides.Size{
+			Width:  &slides.Dimension{Magnitude: dstW * 12700, Unit: "EMU"},
+			Height: &slides.Dimension{Magnitude: dstH * 12700, Unit: "EMU"},
+		},
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to create presentation: %w", err))
+	}
+	newID := created.PresentationId
+
+	// Scale against the page size the new deck actually got.
+	srcW, srcH := pageSizeInPoints(src)
+	gotW, gotH := pageSizeInPoints(created)
+	merger := buildCloneRequests(src, created, fmt.Sprintf("gws_clone_%d", time.Now().UnixNano()),
+		pageScaleFor(srcW, srcH, gotW, gotH, scaleMode))
+
+	_, err = svc.Presentations.BatchUpdate(newID, &slides.BatchUpdatePresentationRequest{
+		Requests: merger.requests,
+	}).Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("created presentation %s but failed to copy slides: %w", newID, err))
+	}
+
+	result := map[string]interface{}{
+		"status":       "cloned",
+		"id":           newID,
+		"title":        created.Title,
+		"source_id":    srcID,
+		"aspect":       aspect,
+		"page_size":    map[string]interface{}{"width_pt": gotW, "height_pt": gotH},
+		"scale":        scaleMode,
+		"slides_added": len(merger.slideIDs),
+		"url":          fmt.Sprintf("https://docs.google.com/presentation/d/%s/edit", newID),
+	}
+	if len(merger.skipped) > 0 {
+		result["skipped"] = merger.skipped
+		result["skipped_count"] = len(merger.skipped)
+	}
+	if gotW != dstW || gotH != dstH {
+		result["warning"] = fmt.Sprintf("the new presentation is %.0fx%.0fpt, not the requested %.0fx%.0fpt; elements were scaled to the actual size", gotW, gotH, dstW, dstH)
+	}
+	return p.Print(result)
+}
+
+// slidesCommentFields is the Drive field mask for a presentation comment.
+// Slides comments usually carry the text they were made on, so the quoted
+// content is requested on top of the sheets fields.
+const slidesCommentFields = sheetsCommentFields + ",quotedFileContent(value)"
+
+// mapSlidesComment converts a Drive comment into the comments output shape,
+// which is the sheets shape plus quoted_text.
+func mapSlidesComment(c *drive.Comment) map[string]interface{} {
+	out := mapSheetsComment(c)
+	if c.QuotedFileContent != nil && c.QuotedFileContent.Value != "" {
+		out["quoted_text"] = c.QuotedFileContent.Value
+	}
+	return out
+}
+
+// listSlidesComments pages through the comments on a presentation, up to
+// maxResults (0 means no limit).
+func listSlidesComments(svc *drive.Service, presentationID string, maxResults int64) ([]*drive.Comment, error) {
+	var all []*drive.Comment
+	pageToken := ""
+	for {
+		call := svc.Comments.List(presentationID).
+			PageSize(100).
+			Fields(googleapi.Field("nextPageToken,comments(" + slidesCommentFields + ")"))
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Comments...)
+		if resp.NextPageToken == "" || (maxResults > 0 && int64(len(all)) >= maxResults) {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	if maxResults > 0 && int64(len(all)) > maxResults {
+		all = all[:maxResults]
+	}
+	return all, nil
+}
+
+func runSlidesComments(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	maxResults, _ := cmd.Flags().GetInt64("max")
+	includeResolved, _ := cmd.Flags().GetBool("include-resolved")
+	if maxResults <= 0 {
+		return usageErrorf("--max must be positive")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesCommentsWithService(svc, args[0], maxResults, includeResolved, p)
+}
+
+func runSlidesCommentsWithService(svc *drive.Service, presentationID string, maxResults int64, includeResolved bool, p printer.Printer) error {
+	all, err := listSlidesComments(svc, presentationID, maxResults)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to list comments: %w", err))
+	}
+
+	comments := make([]map[string]interface{}, 0, len(all))
+	resolved := 0
+	for _, c := range all {
+		if c.Resolved {
+			resolved++
+			if !includeResolved {
+				continue
+			}
+		}
+		comments = append(comments, mapSlidesComment(c))
+	}
+
+	return p.Print(map[string]interface{}{
+		"presentation_id": presentationID,
+		"comments":        comments,
+		"count":           len(comments),
+		"resolved":        resolved,
+	})
+}
+
+func runSlidesResolveComment(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	commentID, _ := cmd.Flags().GetString("id")
+	content, _ := cmd.Flags().GetString("content")
+	if strings.TrimSpace(commentID) == "" {
+		return usageErrorf("--id must not be empty")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesResolveCommentWithService(svc, args[0], commentID, content, p)
+}
+
+func runSlidesResolveCommentWithService(svc *drive.Service, presentationID, commentID, content string, p printer.Printer) error {
+	created, err := svc.Replies.Create(presentationID, commentID, &drive.Reply{Action: "resolve", Content: content}).
+		Fields("id,action,content,createdTime").
+		Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to resolve comment: %w", err))
+	}
+
+	result := map[string]interface{}{
+		"status":          "resolved",
+		"presentation_id": presentationID,
+		"comment_id":      commentID,
+		"reply_id":        created.Id,
+	}
+	if created.Content != "" {
+		result["content"] = created.Content
+	}
+	return p.Print(result)
+}
+
+func runSlidesDeleteComment(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	commentID, _ := cmd.Flags().GetString("id")
+	all, _ := cmd.Flags().GetBool("all")
+	resolvedOnly, _ := cmd.Flags().GetBool("resolved")
+	if (commentID == "") == !all {
+		return usageErrorf("exactly one of --id or --all is required")
+	}
+	if resolvedOnly && !all {
+		return usageErrorf("--resolved can only be used with --all")
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	if !all {
+		if err := svc.Comments.Delete(args[0], commentID).Do(); err != nil {
+			return p.PrintError(fmt.Errorf("failed to delete comment: %w", err))
+		}
+		return p.Print(map[string]interface{}{
+			"status":          "deleted",
+			"presentation_id": args[0],
+			"comment_id":      commentID,
+		})
+	}
+	return runSlidesDeleteAllCommentsWithService(svc, args[0], resolvedOnly, p)
+}
+
+func runSlidesDeleteAllCommentsWithService(svc *drive.Service, presentationID string, resolvedOnly bool, p printer.Printer) error {
+	comments, err := listSlidesComments(svc, presentationID, 0)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to list comments: %w", err))
+	}
+
+	deleted := []string{}
+	skipped := 0
+	for _, c := range comments {
+		if resolvedOnly && !c.Resolved {
+			skipped++
+			continue
+		}
+		if err := svc.Comments.Delete(presentationID, c.Id).Do(); err != nil {
+			if printErr := p.Print(map[string]interface{}{
+				"status":          "partial",
+				"presentation_id": presentationID,
+				"deleted":         deleted,
+				"count":           len(deleted),
+				"failed_id":       c.Id,
+				"error":           err.Error(),
+			}); printErr != nil {
+				return printErr
+			}
+			return &printer.AlreadyPrintedError{Err: fmt.Errorf("failed to delete comment %s: %w", c.Id, err)}
+		}
+		deleted = append(deleted, c.Id)
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":          "deleted",
+		"presentation_id": presentationID,
+		"deleted":         deleted,
+		"count":           len(deleted),
+		"skipped":         skipped,
+	})
+}
+
+// parseSlidePages parses a 1-indexed slide selection such as "3", "2-5", or
+// "1,4-6" into sorted, distinct 0-based indexes below count.
+func parseSlidePages(spec string, count int) ([]int, error) {
+	selected := map[int]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi := part, part
+		if i := strings.Index(part, "-"); i >= 0 {
+			lo, hi = strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+		}
+		from, err1 := strconv.Atoi(lo)
+		to, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil || from < 1 || to < from {
+			return nil, fmt.Errorf("invalid slide range %q", part)
+		}
+		if to > count {
+			return nil, fmt.Errorf("slide range %q is beyond the last slide (%d)", part, count)
+		}
+		for n := from; n <= to; n++ {
+			selected[n-1] = true
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no slides selected")
+	}
+	indexes := make([]int, 0, len(selected))
+	for i := range selected {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+// pdfFileName turns a presentation title into a file name in the current
+// directory, replacing characters that are not allowed in file names.
+func pdfFileName(title, fallback string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < 0x20 {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" || name == "." || name == ".." {
+		name = fallback
+	}
+	return name + ".pdf"
+}
+
+// exportAccessError rewords the permission failures of an export so the
+// caller can tell "no access" from "download disabled".
+func exportAccessError(presentationID string, err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 403:
+			return fmt.Errorf("cannot export presentation %s: access denied or download disabled by the owner: %w", presentationID, err)
+		case 404:
+			return fmt.Errorf("presentation %s not found or not shared with you: %w", presentationID, err)
+		}
+	}
+	return fmt.Errorf("failed to export presentation: %w", err)
+}
+
+func runSlidesExportPDF(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	output, _ := cmd.Flags().GetString("output")
+	pages, _ := cmd.Flags().GetString("pages")
+	if pages != "" {
+		// Syntax only; the slide count is checked once the deck is read.
+		if _, err := parseSlidePages(pages, math.MaxInt32); err != nil {
+			return usageErrorf("invalid --pages: %v", err)
+		}
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	driveSvc, err := factory.Drive()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	var slidesSvc *slides.Service
+	if pages != "" {
+		if slidesSvc, err = factory.Slides(); err != nil {
+			return p.PrintError(err)
+		}
+	}
+
+	return runSlidesExportPDFWithServices(driveSvc, slidesSvc, args[0], output, pages, p)
+}
+
+// runSlidesExportPDFWithServices exports presentationID to output. The
+// slides service is only used when pages is set.
+func runSlidesExportPDFWithServices(driveSvc *drive.Service, slidesSvc *slides.Service, presentationID, output, pages string, p printer.Printer) error {
+	file, err := driveSvc.Files.Get(presentationID).SupportsAllDrives(true).Fields("name,mimeType").Do()
+	if err != nil {
+		return p.PrintError(exportAccessError(presentationID, err))
+	}
+	if file.MimeType != "application/vnd.google-apps.presentation" {
+		return p.PrintError(fmt.Errorf("%s is not a Google Slides presentation (mime type %s)", presentationID, file.MimeType))
+	}
+	if output == "" {
+		output = pdfFileName(file.Name, presentationID)
+	}
+
+	exportID := presentationID
+	slideCount := 0
+	if pages != "" {
+		pres, err := slidesSvc.Presentations.Get(presentationID).Fields("slides.objectId").Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+		}
+		keep, err := parseSlidePages(pages, len(pres.Slides))
+		if err != nil {
+			return p.PrintError(err)
+		}
+		slideCount = len(keep)
+
+		copied, err := driveSvc.Files.Copy(presentationID, &drive.File{Name: file.Name + " (PDF export)"}).
+			SupportsAllDrives(true).Fields("id").Do()
+		if err != nil {
+			return p.PrintError(exportAccessError(presentationID, err))
+		}
+		exportID = copied.Id
+		defer driveSvc.Files.Delete(copied.Id).SupportsAllDrives(true).Do()
+
+		copyPres, err := slidesSvc.Presentations.Get(copied.Id).Fields("slides.objectId").Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to read the temporary copy: %w", err))
+		}
+		kept := map[int]bool{}
+		for _, i := range keep {
+			kept[i] = true
+		}
+		var requests []*slides.Request
+		for i, slide := range copyPres.Slides {
+			if !kept[i] {
+				requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: slide.ObjectId}})
+			}
+		}
+		if len(requests) > 0 {
+			if _, err := slidesSvc.Presentations.BatchUpdate(copied.Id, &slides.BatchUpdatePresentationRequest{Requests: requests}).Do(); err != nil {
+				return p.PrintError(fmt.Errorf("failed to trim the temporary copy: %w", err))
+			}
+		}
+	}
+
+	resp, err := driveSvc.Files.Export(exportID, "application/pdf").Download()
+	if err != nil {
+		return p.PrintError(exportAccessError(presentationID, err))
+	}
+	defer resp.Body.Close()
+
+	outFile, err := os.Create(output)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to create output file: %w", err))
+	}
+	written, err := io.Copy(outFile, resp.Body)
+	if closeErr := outFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+		return p.PrintError(fmt.Errorf("failed to write file: %w", err))
+	}
+
+	if abs, err := filepath.Abs(output); err == nil {
+		output = abs
+	}
+	result := map[string]interface{}{
+		"status":          "exported",
+		"presentation_id": presentationID,
+		"title":           file.Name,
+		"output":          output,
+		"size":            written,
+	}
+	if pages != "" {
+		result["pages"] = pages
+		result["slide_count"] = slideCount
+	}
+	return p.Print(result)
+}
+
+func runSlidesSkip(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	slideNumber, _ := cmd.Flags().GetInt("slide-number")
+	pages, _ := cmd.Flags().GetString("slides")
+	unskip, _ := cmd.Flags().GetBool("unskip")
+
+	switch {
+	case slideNumber != 0 && pages != "":
+		return usageErrorf("--slide-number and --slides are mutually exclusive")
+	case slideNumber < 0:
+		return usageErrorf("--slide-number must be 1 or greater")
+	case slideNumber > 0:
+		pages = strconv.Itoa(slideNumber)
+	case pages == "":
+		return usageErrorf("must specify --slide-number or --slides")
+	}
+	// Syntax only; the slide count is checked once the deck is read.
+	if _, err := parseSlidePages(pages, math.MaxInt32); err != nil {
+		return usageErrorf("invalid --slides: %v", err)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Slides()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runSlidesSkipWithService(svc, args[0], pages, !unskip, p)
+}
+
+// runSlidesSkipWithService sets IsSkipped on the selected slides, sending
+// requests only for slides whose state changes.
+func runSlidesSkipWithService(svc *slides.Service, presentationID, pages string, skip bool, p printer.Printer) error {
+	presentation, err := svc.Presentations.Get(presentationID).Fields("slides(objectId,slideProperties/isSkipped)").Do()
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to get presentation: %w", err))
+	}
+	indexes, err := parseSlidePages(pages, len(presentation.Slides))
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	var requests []*slides.Request
+	results := make([]map[string]interface{}, 0, len(indexes))
+	for _, i := range indexes {
+		slide := presentation.Slides[i]
+		was := slide.SlideProperties != nil && slide.SlideProperties.IsSkipped
+		if was != skip {
+			requests = append(requests, &slides.Request{
+				UpdateSlideProperties: &slides.UpdateSlidePropertiesRequest{
+					ObjectId: slide.ObjectId,
+					SlideProperties: &slides.SlideProperties{
+						IsSkipped:       skip,
+						ForceSendFields: []string{"IsSkipped"},
+					},
+					Fields: "isSkipped",
+				},
+			})
+		}
+		results = append(results, map[string]interface{}{
+			"slide_number": i + 1,
+			"slide_id":     slide.ObjectId,
+			"skipped":      skip,
+			"changed":      was != skip,
+		})
+	}
+
+	if len(requests) > 0 {
+		_, err = svc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{
+			Requests: requests,
+		}).Do()
+		if err != nil {
+			return p.PrintError(fmt.Errorf("failed to update slides: %w", err))
+		}
+	}
+
+	status := "skipped"
+	if !skip {
+		status = "unskipped"
+	}
+	return p.Print(map[string]interface{}{
+		"status":          status,
+		"presentation_id": presentationID,
+		"slides":          results,
+		"changed":         len(requests),
+	})
+}
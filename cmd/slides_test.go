@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"image"
@@ -10,9 +11,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"unicode/utf16"
 
+	"github.com/omriariav/workspace-cli/internal/printer"
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
 	"google.golang.org/api/slides/v1"
 )
@@ -431,6 +437,96 @@ func TestSlidesRead_ExtractTableText(t *testing.T) {
 	}
 }
 
+func textContent(parts ...*slides.TextElement) *slides.TextContent {
+	return &slides.TextContent{TextElements: parts}
+}
+
+func TestSlideMarkdown(t *testing.T) {
+	run := func(s string) *slides.TextElement { return &slides.TextElement{TextRun: &slides.TextRun{Content: s}} }
+	para := func(bullet *slides.Bullet) *slides.TextElement {
+		return &slides.TextElement{ParagraphMarker: &slides.ParagraphMarker{Bullet: bullet}}
+	}
+	cell := func(s string) *slides.TableCell { return &slides.TableCell{Text: textContent(run(s))} }
+
+	slide := &slides.Page{
+		PageElements: []*slides.PageElement{
+			{Shape: &slides.Shape{
+				Placeholder: &slides.Placeholder{Type: "TITLE"},
+				Text:        textContent(para(nil), run("Quarterly\vReview\n")),
+			}},
+			{Shape: &slides.Shape{
+				Placeholder: &slides.Placeholder{Type: "BODY"},
+				Text: textContent(
+					para(nil), run("Intro line\n"),
+					para(&slides.Bullet{}), run("Revenue up\n"),
+					para(&slides.Bullet{NestingLevel: 1}), run("EMEA led\n"),
+				),
+			}},
+			{Shape: &slides.Shape{Text: textContent(run("Callout one\nCallout two\n"))}},
+			{Table: &slides.Table{TableRows: []*slides.TableRow{
+				{TableCells: []*slides.TableCell{cell("Region"), cell("Growth")}},
+				{TableCells: []*slides.TableCell{cell("A|B"), cell("up\n12%")}},
+				{TableCells: []*slides.TableCell{{}}},
+			}}},
+		},
+		SlideProperties: &slides.SlideProperties{NotesPage: &slides.Page{
+			NotesProperties: &slides.NotesProperties{SpeakerNotesObjectId: "notes"},
+			PageElements: []*slides.PageElement{
+				{ObjectId: "notes", Shape: &slides.Shape{Text: textContent(run("Mention EMEA\n\nThen Q&A\n"))}},
+			},
+		}},
+	}
+
+	want := `## Slide 2: Quarterly Review
+
+Intro line
+
+- Revenue up
+  - EMEA led
+
+- Callout one
+- Callout two
+
+| Region | Growth |
+| --- | --- |
+| A\|B | up <br> 12% |
+|  |  |
+
+> Notes: Mention EMEA
+>
+> Then Q&A
+`
+	if got := slideMarkdown(2, slide, true); got != want {
+		t.Errorf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := slideMarkdown(2, slide, false); strings.Contains(got, "Notes:") {
+		t.Errorf("expected no notes without includeNotes, got:\n%s", got)
+	}
+
+	pres := &slides.Presentation{
+		Title:  "Deck",
+		Slides: []*slides.Page{{}, {}},
+	}
+	if got, want := presentationMarkdown(pres, false), "# Deck\n\n## Slide 1\n\n## Slide 2\n"; got != want {
+		t.Errorf("presentationMarkdown = %q, want %q", got, want)
+	}
+}
+
+func TestSlidesRead_OutputFormatValidation(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "read")
+	if cmd == nil {
+		t.Fatal("read command not found")
+	}
+	cmd.Flags().Set("output-format", "xml")
+	defer cmd.Flags().Set("output-format", "json")
+
+	err := runSlidesRead(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --output-format") {
+		t.Fatalf("expected --output-format error, got %v", err)
+	}
+}
+
 func TestSlidesLayouts(t *testing.T) {
 	// Test that various layout types work
 	layouts := []string{
@@ -635,6 +731,106 @@ func TestSlidesDuplicateSlide_Success(t *testing.T) {
 	}
 }
 
+func TestSlidesDuplicateSlide_IDMap(t *testing.T) {
+	source := &slides.Page{
+		ObjectId: "p2",
+		PageElements: []*slides.PageElement{
+			{ObjectId: "title_2"},
+			{ObjectId: "grp", ElementGroup: &slides.Group{Children: []*slides.PageElement{{ObjectId: "a"}, {ObjectId: "b"}}}},
+		},
+	}
+	duplicated := false
+	var sent slides.BatchUpdatePresentationRequest
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-dup": func(w http.ResponseWriter, r *http.Request) {
+			pages := []*slides.Page{{ObjectId: "p1"}, source}
+			if duplicated {
+				pages = append(pages, &slides.Page{
+					ObjectId: "p2_copy",
+					PageElements: []*slides.PageElement{
+						{ObjectId: "title_copy"},
+						{ObjectId: "g_auto", ElementGroup: &slides.Group{Children: []*slides.PageElement{{ObjectId: "a_auto"}, {ObjectId: "b_auto"}}}},
+					},
+				})
+			}
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: pages})
+		},
+		"/v1/presentations/pres-dup:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			duplicated = true
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{Replies: []*slides.Response{
+				{DuplicateObject: &slides.DuplicateObjectResponse{ObjectId: "p2_copy"}},
+			}})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	idMap := map[string]string{"p2": "p2_copy", "title_2": "title_copy"}
+	var buf bytes.Buffer
+	if err := runSlidesDuplicateSlideWithService(svc, "pres-dup", "", 2, idMap, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	dup := sent.Requests[0].DuplicateObject
+	if dup.ObjectId != "p2" || dup.ObjectIds["title_2"] != "title_copy" || len(dup.ObjectIds) != 2 {
+		t.Errorf("unexpected request: %+v", dup)
+	}
+	var out struct {
+		NewSlideID string            `json:"new_slide_id"`
+		ObjectIDs  map[string]string `json:"object_ids"`
+	}
+	json.Unmarshal(buf.Bytes(), &out)
+	want := map[string]string{"p2": "p2_copy", "title_2": "title_copy", "grp": "g_auto", "a": "a_auto", "b": "b_auto"}
+	if out.NewSlideID != "p2_copy" || len(out.ObjectIDs) != len(want) {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	for k, v := range want {
+		if out.ObjectIDs[k] != v {
+			t.Errorf("object_ids[%s] = %q, want %q", k, out.ObjectIDs[k], v)
+		}
+	}
+
+	duplicated = false
+	err = runSlidesDuplicateSlideWithService(svc, "pres-dup", "p2", 0, map[string]string{"nope1": "nope_copy"}, printer.New(&bytes.Buffer{}, "json"))
+	if err == nil || !strings.Contains(err.Error(), "no object nope1 on slide p2") {
+		t.Errorf("expected unknown-ID error, got %v", err)
+	}
+	if duplicated {
+		t.Error("expected no duplicate request when --id-map names an unknown object")
+	}
+}
+
+func TestParseIDMap(t *testing.T) {
+	got, err := parseIDMap([]string{"p2=p2_copy, body=body_copy", "title=title_copy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 || got["body"] != "body_copy" || got["title"] != "title_copy" {
+		t.Errorf("unexpected map: %v", got)
+	}
+	if got, err := parseIDMap(nil); err != nil || got != nil {
+		t.Errorf("expected nil map for no flags, got %v, %v", got, err)
+	}
+	for _, bad := range [][]string{
+		{"p2"},
+		{"=p2_copy"},
+		{"p2=abc"},
+		{"p2=-leading"},
+		{"p2=has space"},
+		{"p2=p2_copy", "p2=p2_other"},
+		{"a=same_id", "b=same_id"},
+	} {
+		if _, err := parseIDMap(bad); err == nil {
+			t.Errorf("parseIDMap(%q): expected error", bad)
+		}
+	}
+}
+
 // TestSlidesAddShapeCommand_Flags tests add-shape command flags
 func TestSlidesAddShapeCommand_Flags(t *testing.T) {
 	cmd := findSubcommand(slidesCmd, "add-shape")
@@ -991,6 +1187,94 @@ func TestSlidesListCommand_NotesFlag(t *testing.T) {
 	}
 }
 
+func TestSlidesList_Filters(t *testing.T) {
+	text := func(s string) *slides.PageElement {
+		return &slides.PageElement{Shape: &slides.Shape{Text: textContent(&slides.TextElement{TextRun: &slides.TextRun{Content: s}})}}
+	}
+	pres := &slides.Presentation{
+		Title: "Deck",
+		Slides: []*slides.Page{
+			{ObjectId: "s1", PageElements: []*slides.PageElement{text("Revenue overview")}},
+			{ObjectId: "s2", PageElements: []*slides.PageElement{text("Costs"), {Table: &slides.Table{}}}},
+			{ObjectId: "s3", PageElements: []*slides.PageElement{
+				text("revenue by region"),
+				{ElementGroup: &slides.Group{Children: []*slides.PageElement{{Image: &slides.Image{}}}}},
+			}},
+		},
+	}
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-list": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(pres)
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter slidesListFilter
+		want   []string
+	}{
+		{"no filter", slidesListFilter{}, []string{"s1", "s2", "s3"}},
+		{"contains ignores case", slidesListFilter{contains: "REVENUE"}, []string{"s1", "s3"}},
+		{"match case", slidesListFilter{contains: "Revenue", matchCase: true}, []string{"s1"}},
+		{"element type", slidesListFilter{elementType: "table"}, []string{"s2"}},
+		{"element in group", slidesListFilter{elementType: "image"}, []string{"s3"}},
+		{"both", slidesListFilter{contains: "revenue", elementType: "table"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runSlidesListWithService(svc, "pres-list", false, tt.filter, printer.New(&buf, "json")); err != nil {
+				t.Fatalf("runner failed: %v", err)
+			}
+			var result struct {
+				Slides []struct {
+					ID     string `json:"id"`
+					Number int    `json:"number"`
+				} `json:"slides"`
+				Count       int  `json:"count"`
+				TotalSlides *int `json:"total_slides"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			got := []string{}
+			for _, s := range result.Slides {
+				got = append(got, s.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("slides = %v, want %v", got, tt.want)
+			}
+			if result.Count != len(tt.want) {
+				t.Errorf("count = %d, want %d", result.Count, len(tt.want))
+			}
+			if tt.filter.active() && (result.TotalSlides == nil || *result.TotalSlides != 3) {
+				t.Errorf("expected total_slides 3 when filtering, got %v", result.TotalSlides)
+			}
+			if len(result.Slides) > 0 && result.Slides[len(result.Slides)-1].ID == "s3" && result.Slides[len(result.Slides)-1].Number != 3 {
+				t.Errorf("expected slide numbers from the full deck, got %d", result.Slides[len(result.Slides)-1].Number)
+			}
+		})
+	}
+}
+
+func TestSlidesList_InvalidElementType(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "list")
+	cmd.Flags().Set("element-type", "diagram")
+	defer cmd.Flags().Set("element-type", "")
+
+	err := runSlidesList(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --element-type") {
+		t.Fatalf("expected --element-type error, got %v", err)
+	}
+}
+
 // TestSlidesInfoCommand_NotesFlag tests that --notes flag exists on info
 func TestSlidesInfoCommand_NotesFlag(t *testing.T) {
 	cmd := findSubcommand(slidesCmd, "info")
@@ -2663,3 +2947,2209 @@ func TestSlidesThumbnail_Download(t *testing.T) {
 		t.Errorf("expected 'fake-png-data', got '%s'", string(data))
 	}
 }
+
+func TestSlidesThumbnail_AllSlides(t *testing.T) {
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("png:" + r.URL.Path))
+	}))
+	defer imageServer.Close()
+
+	thumb := func(id string) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("thumbnailProperties.thumbnailSize"); got != "SMALL" {
+				t.Errorf("expected size SMALL, got %q", got)
+			}
+			if got := r.URL.Query().Get("thumbnailProperties.mimeType"); got != "PNG" {
+				t.Errorf("expected mime type PNG, got %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"contentUrl": imageServer.URL + "/" + id,
+				"width":      200,
+				"height":     112,
+			})
+		}
+	}
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-all": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{
+				PresentationId: "pres-all",
+				Slides:         []*slides.Page{{ObjectId: "s1"}, {ObjectId: "s2"}},
+			})
+		},
+		"/v1/presentations/pres-all/pages/s1/thumbnail": thumb("s1"),
+		"/v1/presentations/pres-all/pages/s2/thumbnail": thumb("s2"),
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "previews")
+	var buf bytes.Buffer
+	if err := runSlidesThumbnailWithService(svc, "pres-all", "", 0, "SMALL", "PNG", dir, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+
+	var out struct {
+		Count      int `json:"count"`
+		Thumbnails []struct {
+			SlideNumber int    `json:"slide_number"`
+			ID          string `json:"id"`
+			URL         string `json:"url"`
+			SavedTo     string `json:"saved_to"`
+		} `json:"thumbnails"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v\n%s", err, buf.String())
+	}
+	if out.Count != 2 || len(out.Thumbnails) != 2 {
+		t.Fatalf("expected 2 thumbnails, got %s", buf.String())
+	}
+	second := out.Thumbnails[1]
+	if second.SlideNumber != 2 || second.ID != "s2" || second.URL != imageServer.URL+"/s2" {
+		t.Errorf("unexpected second thumbnail: %+v", second)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "slide-02.png"))
+	if err != nil || string(data) != "png:/s2" {
+		t.Errorf("expected slide-02.png with slide 2's image, got %q (err=%v)", data, err)
+	}
+}
+
+func TestSlidesContactSheet(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 40; x++ {
+				img.Set(x, y, red)
+			}
+		}
+		png.Encode(w, img)
+	}))
+	defer imageServer.Close()
+
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-sheet": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{
+				Slides: []*slides.Page{{ObjectId: "s1"}, {ObjectId: "s2"}, {ObjectId: "s3"}, {ObjectId: "s4"}},
+			})
+		},
+	}
+	for _, id := range []string{"s1", "s2", "s3", "s4"} {
+		handlers["/v1/presentations/pres-sheet/pages/"+id+"/thumbnail"] = func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("thumbnailProperties.thumbnailSize"); got != "SMALL" {
+				t.Errorf("expected size SMALL, got %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"contentUrl": imageServer.URL + "/" + id})
+		}
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	output := filepath.Join(t.TempDir(), "review", "sheet.png")
+	opts := contactSheetOptions{Cols: 3, Size: "SMALL", Gap: 4, Concurrency: 2, Output: output}
+	var buf bytes.Buffer
+	if err := runSlidesContactSheetWithService(context.Background(), svc, "pres-sheet", opts, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("bad output: %v\n%s", err, buf.String())
+	}
+	// 3 cols of 40px cells plus gaps; 2 rows of 20px cells plus 14px labels.
+	if out["cols"] != float64(3) || out["rows"] != float64(2) || out["width"] != float64(136) || out["height"] != float64(80) {
+		t.Errorf("unexpected layout: %v", out)
+	}
+
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatalf("expected contact sheet file: %v", err)
+	}
+	defer f.Close()
+	sheet, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("contact sheet is not a PNG: %v", err)
+	}
+	if got := color.RGBAModel.Convert(sheet.At(4+20, 4+10)); got != red {
+		t.Errorf("expected slide 1 at the first cell, got %v", got)
+	}
+	if got := color.RGBAModel.Convert(sheet.At(4+2*44+20, 42+10)); got != contactSheetBackground {
+		t.Errorf("expected the unused last cell to be blank, got %v", got)
+	}
+	labelInked := false
+	for y := 4 + 20; y < 4+34; y++ {
+		for x := 4; x < 44; x++ {
+			labelInked = labelInked || color.RGBAModel.Convert(sheet.At(x, y)) == contactSheetLabel
+		}
+	}
+	if !labelInked {
+		t.Error("expected a slide number label under the first cell")
+	}
+}
+
+func TestSlidesContactSheet_Validation(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "contact-sheet")
+	if cmd == nil {
+		t.Fatal("slides contact-sheet command not found")
+	}
+	cmd.Flags().Set("output", "sheet.png")
+	cmd.Flags().Set("cols", "0")
+	defer func() {
+		cmd.Flags().Set("output", "")
+		cmd.Flags().Set("cols", "3")
+	}()
+
+	err := runSlidesContactSheet(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "--cols") {
+		t.Fatalf("expected --cols error, got %v", err)
+	}
+}
+
+func TestSlidesThumbnail_SingleSlideBySlideNumber(t *testing.T) {
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-one": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{
+				Slides: []*slides.Page{{ObjectId: "a"}, {ObjectId: "b"}},
+			})
+		},
+		"/v1/presentations/pres-one/pages/b/thumbnail": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"contentUrl": "https://example.com/b.png", "width": 800, "height": 450})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesThumbnailWithService(svc, "pres-one", "", 2, "MEDIUM", "PNG", "", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["slide_id"] != "b" || out["content_url"] != "https://example.com/b.png" {
+		t.Errorf("unexpected output: %v", out)
+	}
+	if _, ok := out["saved_to"]; ok {
+		t.Error("expected no saved_to without --output")
+	}
+}
+
+func TestSlidesAddFooter_Flags(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "add-footer")
+	if cmd == nil {
+		t.Fatal("slides add-footer command not found")
+	}
+
+	for _, name := range []string{"text", "logo-url", "position", "skip-first", "font-size", "logo-size"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+	if def := cmd.Flags().Lookup("position").DefValue; def != "bottom-left" {
+		t.Errorf("expected --position default 'bottom-left', got '%s'", def)
+	}
+}
+
+func TestSlidesAddFooter_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"no text or logo", map[string]string{}, "at least one of --text or --logo-url"},
+		{"bad position", map[string]string{"text": "x", "position": "top-left"}, "invalid --position"},
+		{"zero font size", map[string]string{"text": "x", "font-size": "0"}, "--font-size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := findSubcommand(slidesCmd, "add-footer")
+			cmd.Flags().Set("text", "")
+			cmd.Flags().Set("position", "bottom-left")
+			cmd.Flags().Set("font-size", "10")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, []string{"pres-1"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFooterLayout(t *testing.T) {
+	opts := footerOptions{Text: "Confidential", LogoURL: "https://example.com/logo.png", LogoSize: 32}
+
+	opts.Position = "bottom-left"
+	textX, textY, logoX, logoY := footerLayout(720, 405, opts)
+	if logoX != footerMargin || textX != footerMargin+32+footerGap {
+		t.Errorf("bottom-left: got logoX=%v textX=%v", logoX, textX)
+	}
+	if logoY != 405-footerMargin-32 || textY != logoY+(32-footerTextHeight)/2 {
+		t.Errorf("bottom-left: got logoY=%v textY=%v", logoY, textY)
+	}
+
+	opts.Position = "bottom-right"
+	textX, _, logoX, _ = footerLayout(720, 405, opts)
+	if logoX != 720-footerMargin-32 || textX != logoX-footerGap-footerTextWidth {
+		t.Errorf("bottom-right: got logoX=%v textX=%v", logoX, textX)
+	}
+
+	opts.Position = "bottom-center"
+	opts.LogoURL = ""
+	textX, textY, _, _ = footerLayout(720, 405, opts)
+	if textX != (720-footerTextWidth)/2 || textY != 405-footerMargin-footerTextHeight {
+		t.Errorf("bottom-center text only: got textX=%v textY=%v", textX, textY)
+	}
+}
+
+func TestBuildFooterRequests(t *testing.T) {
+	presentation := &slides.Presentation{
+		PageSize: &slides.Size{
+			Width:  &slides.Dimension{Magnitude: 9144000, Unit: "EMU"},
+			Height: &slides.Dimension{Magnitude: 5143500, Unit: "EMU"},
+		},
+		Slides: []*slides.Page{
+			{ObjectId: "title"},
+			{ObjectId: "s2"},
+			{ObjectId: "s3"},
+		},
+	}
+	opts := footerOptions{
+		Text:      "Confidential",
+		LogoURL:   "https://example.com/logo.png",
+		Position:  "bottom-right",
+		SkipFirst: true,
+		FontSize:  9,
+		LogoSize:  32,
+		IDPrefix:  "footer",
+	}
+
+	requests, slideIDs := buildFooterRequests(presentation, opts)
+	if len(slideIDs) != 2 || slideIDs[0] != "s2" || slideIDs[1] != "s3" {
+		t.Fatalf("expected slides [s2 s3], got %v", slideIDs)
+	}
+	// Per slide: CreateImage, CreateShape, InsertText, UpdateTextStyle, UpdateParagraphStyle
+	if len(requests) != 10 {
+		t.Fatalf("expected 10 requests, got %d", len(requests))
+	}
+
+	img := requests[0].CreateImage
+	if img == nil || img.ElementProperties.PageObjectId != "s2" || img.Url != opts.LogoURL {
+		t.Fatalf("expected CreateImage on s2, got %+v", requests[0])
+	}
+	if img.ElementProperties.Transform.TranslateX != 720-footerMargin-32 {
+		t.Errorf("expected logo at right edge of 720pt page, got x=%v", img.ElementProperties.Transform.TranslateX)
+	}
+
+	shape := requests[1].CreateShape
+	if shape == nil || shape.ShapeType != "TEXT_BOX" || shape.ObjectId != "footer_1_text" {
+		t.Fatalf("expected TEXT_BOX footer_1_text, got %+v", requests[1])
+	}
+	if insert := requests[2].InsertText; insert == nil || insert.ObjectId != shape.ObjectId || insert.Text != "Confidential" {
+		t.Errorf("expected InsertText into %s, got %+v", shape.ObjectId, requests[2])
+	}
+	if style := requests[3].UpdateTextStyle; style == nil || style.Style.FontSize.Magnitude != 9 {
+		t.Errorf("expected font size 9, got %+v", requests[3])
+	}
+	if para := requests[4].UpdateParagraphStyle; para == nil || para.Style.Alignment != "END" {
+		t.Errorf("expected END alignment for bottom-right, got %+v", requests[4])
+	}
+}
+
+func TestSlidesSetAltText_Flags(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "set-alt-text")
+	if cmd == nil {
+		t.Fatal("slides set-alt-text command not found")
+	}
+
+	for _, name := range []string{"object-id", "title", "description"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestSlidesSetAltText_RequiresTitleOrDescription(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "set-alt-text")
+	cmd.Flags().Set("object-id", "img-1")
+	defer cmd.Flags().Set("object-id", "")
+
+	err := cmd.RunE(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "at least one of --title or --description") {
+		t.Errorf("expected title/description validation error, got %v", err)
+	}
+}
+
+func TestSlidesSetAltText_Success(t *testing.T) {
+	var captured map[string]interface{}
+
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-alt:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&captured)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{PresentationId: "pres-alt"})
+		},
+	}
+
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	_, err = svc.Presentations.BatchUpdate("pres-alt", &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{
+			UpdatePageElementAltText: &slides.UpdatePageElementAltTextRequest{
+				ObjectId:        "chart-1",
+				Title:           "Chart",
+				ForceSendFields: []string{"Title", "Description"},
+			},
+		}},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to set alt text: %v", err)
+	}
+
+	requests, _ := captured["requests"].([]interface{})
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %v", captured)
+	}
+	alt, _ := requests[0].(map[string]interface{})["updatePageElementAltText"].(map[string]interface{})
+	if alt["objectId"] != "chart-1" || alt["title"] != "Chart" {
+		t.Errorf("unexpected alt text request: %v", alt)
+	}
+	if desc, ok := alt["description"]; !ok || desc != "" {
+		t.Errorf("expected empty description to be sent explicitly, got %v (present=%v)", desc, ok)
+	}
+}
+
+func TestSlidesReplaceShapes_Flags(t *testing.T) {
+	tests := map[string][]string{
+		"replace-shapes-with-image": {"contains", "url", "method", "match-case", "slide-id", "slide-number"},
+		"replace-shapes-with-chart": {"contains", "spreadsheet-id", "chart-id", "linked", "match-case", "slide-id", "slide-number"},
+	}
+	for name, flags := range tests {
+		cmd := findSubcommand(slidesCmd, name)
+		if cmd == nil {
+			t.Fatalf("slides %s command not found", name)
+		}
+		for _, flag := range flags {
+			if cmd.Flags().Lookup(flag) == nil {
+				t.Errorf("%s: expected --%s flag", name, flag)
+			}
+		}
+	}
+}
+
+func TestSlidesReplaceShapesWithImage_InvalidMethod(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "replace-shapes-with-image")
+	cmd.Flags().Set("method", "stretch")
+	defer cmd.Flags().Set("method", "center-inside")
+
+	err := cmd.RunE(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --method") {
+		t.Errorf("expected invalid method error, got %v", err)
+	}
+}
+
+func TestParseImageReplaceMethod(t *testing.T) {
+	for in, want := range map[string]string{"center-inside": "CENTER_INSIDE", "CENTER_CROP": "CENTER_CROP", "Center_Inside": "CENTER_INSIDE"} {
+		if got, err := parseImageReplaceMethod(in); err != nil || got != want {
+			t.Errorf("parseImageReplaceMethod(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseImageReplaceMethod("stretch"); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
+
+func TestSlidesReplaceShapesWithImage_FindFlag(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "replace-shapes-with-image")
+	if cmd.Flags().Lookup("find") == nil {
+		t.Fatal("expected --find flag")
+	}
+	defer func() {
+		cmd.Flags().Set("contains", "")
+		cmd.Flags().Set("find", "")
+	}()
+
+	err := cmd.RunE(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "--contains (or --find)") {
+		t.Errorf("expected missing --contains/--find error, got %v", err)
+	}
+
+	cmd.Flags().Set("contains", "{{logo}}")
+	cmd.Flags().Set("find", "{{hero}}")
+	err = cmd.RunE(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "use only one") {
+		t.Errorf("expected conflicting --find/--contains error, got %v", err)
+	}
+}
+
+func TestSlidesReplaceShapesWithChart_RequiresChartID(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "replace-shapes-with-chart")
+	err := cmd.RunE(cmd, []string{"pres-1"})
+	if err == nil || !strings.Contains(err.Error(), "--chart-id") {
+		t.Errorf("expected chart-id validation error, got %v", err)
+	}
+}
+
+func TestSlidesReplaceShapesWithImage_Success(t *testing.T) {
+	var captured slides.BatchUpdatePresentationRequest
+
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-tpl:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&captured)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{
+				PresentationId: "pres-tpl",
+				Replies: []*slides.Response{{
+					ReplaceAllShapesWithImage: &slides.ReplaceAllShapesWithImageResponse{OccurrencesChanged: 3},
+				}},
+			})
+		},
+	}
+
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	resp, err := svc.Presentations.BatchUpdate("pres-tpl", &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{
+			ReplaceAllShapesWithImage: &slides.ReplaceAllShapesWithImageRequest{
+				ContainsText:       &slides.SubstringMatchCriteria{Text: "{{logo}}", MatchCase: true},
+				ImageUrl:           "https://example.com/logo.png",
+				ImageReplaceMethod: imageReplaceMethods["center-crop"],
+			},
+		}},
+	}).Do()
+	if err != nil {
+		t.Fatalf("failed to replace shapes: %v", err)
+	}
+
+	req := captured.Requests[0].ReplaceAllShapesWithImage
+	if req == nil {
+		t.Fatal("expected ReplaceAllShapesWithImage request")
+	}
+	if req.ContainsText.Text != "{{logo}}" || req.ImageReplaceMethod != "CENTER_CROP" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if got := resp.Replies[0].ReplaceAllShapesWithImage.OccurrencesChanged; got != 3 {
+		t.Errorf("expected 3 occurrences changed, got %d", got)
+	}
+}
+
+func TestValidatePublicImageURL(t *testing.T) {
+	for _, ok := range []string{"https://example.com/a.png", "http://cdn.example.org:8080/img?id=1"} {
+		if err := validatePublicImageURL(ok); err != nil {
+			t.Errorf("expected %q to be accepted, got %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "  ", "example.com/a.png", "ftp://example.com/a.png", "file:///tmp/a.png", "http://localhost/a.png", "http://127.0.0.1/a.png", "http://10.0.0.5/a.png", "http://printer.local/a.png"} {
+		if err := validatePublicImageURL(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestSlidesReplaceImage_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"no target", map[string]string{"url": "https://example.com/a.png"}, "--object-id or --replace-all"},
+		{"both targets", map[string]string{"url": "https://example.com/a.png", "object-id": "img", "replace-all": "true", "match": "hero"}, "mutually exclusive"},
+		{"replace-all without match", map[string]string{"url": "https://example.com/a.png", "replace-all": "true"}, "requires --match"},
+		{"private url", map[string]string{"url": "http://192.168.1.2/a.png", "object-id": "img"}, "publicly accessible"},
+		{"bad method", map[string]string{"url": "https://example.com/a.png", "object-id": "img", "method": "stretch"}, "invalid --method"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := findSubcommand(slidesCmd, "replace-image")
+			for k, v := range map[string]string{"object-id": "", "url": "", "method": "center-inside", "replace-all": "false", "match": ""} {
+				cmd.Flags().Set(k, v)
+			}
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, []string{"pres-1"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSlidesReplaceImage_ReplaceAllByAltText(t *testing.T) {
+	var sent slides.BatchUpdatePresentationRequest
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-img": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: []*slides.Page{
+				{PageElements: []*slides.PageElement{
+					{ObjectId: "img1", Title: "Hero", Image: &slides.Image{}},
+					{ObjectId: "img2", Description: "logo", Image: &slides.Image{}},
+					{ObjectId: "box", Title: "hero", Shape: &slides.Shape{}},
+				}},
+				{PageElements: []*slides.PageElement{
+					{ObjectId: "grp", ElementGroup: &slides.Group{Children: []*slides.PageElement{
+						{ObjectId: "img3", Description: " hero ", Image: &slides.Image{}},
+					}}},
+				}},
+			}})
+		},
+		"/v1/presentations/pres-img:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesReplaceImageWithService(svc, "pres-img", "", "hero", "https://example.com/new.png", "CENTER_CROP", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(sent.Requests) != 2 {
+		t.Fatalf("expected 2 replace requests, got %d", len(sent.Requests))
+	}
+	for i, want := range []string{"img1", "img3"} {
+		r := sent.Requests[i].ReplaceImage
+		if r == nil || r.ImageObjectId != want || r.Url != "https://example.com/new.png" || r.ImageReplaceMethod != "CENTER_CROP" {
+			t.Errorf("request %d: unexpected %+v", i, sent.Requests[i])
+		}
+	}
+	if !strings.Contains(buf.String(), `"count": 2`) {
+		t.Errorf("expected count 2, got %s", buf.String())
+	}
+
+	buf.Reset()
+	sent = slides.BatchUpdatePresentationRequest{}
+	if err := runSlidesReplaceImageWithService(svc, "pres-img", "", "missing", "https://example.com/new.png", "CENTER_INSIDE", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(sent.Requests) != 0 || !strings.Contains(buf.String(), `"status": "unchanged"`) {
+		t.Errorf("expected no batch update when nothing matches, got %d requests and %s", len(sent.Requests), buf.String())
+	}
+}
+
+func TestSlidesReplaceImage_SingleObject(t *testing.T) {
+	var sent slides.BatchUpdatePresentationRequest
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-one:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesReplaceImageWithService(svc, "pres-one", "img_1", "", "https://example.com/q3.png", "CENTER_INSIDE", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(sent.Requests) != 1 || sent.Requests[0].ReplaceImage.ImageObjectId != "img_1" {
+		t.Fatalf("expected one replace request for img_1, got %+v", sent.Requests)
+	}
+	var out map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out["object_id"] != "img_1" || out["image_url"] != "https://example.com/q3.png" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
+
+func TestSlidesMerge_Flags(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "merge")
+	if cmd == nil {
+		t.Fatal("slides merge command not found")
+	}
+	for _, name := range []string{"into", "from", "at"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestBuildMergeRequests(t *testing.T) {
+	size := &slides.Size{
+		Width:  &slides.Dimension{Magnitude: 100, Unit: "PT"},
+		Height: &slides.Dimension{Magnitude: 50, Unit: "PT"},
+	}
+	src := &slides.Presentation{
+		Slides: []*slides.Page{
+			{
+				ObjectId: "src-1",
+				PageElements: []*slides.PageElement{
+					{
+						ObjectId:  "title",
+						Size:      size,
+						Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: 10, TranslateY: 20, Unit: "PT"},
+						Shape: &slides.Shape{
+							ShapeType: "TEXT_BOX",
+							Text: &slides.TextContent{TextElements: []*slides.TextElement{
+								{EndIndex: 6, ParagraphMarker: &slides.ParagraphMarker{}},
+								{EndIndex: 6, TextRun: &slides.TextRun{Content: "Hello ", Style: &slides.TextStyle{Bold: true}}},
+								{StartIndex: 6, EndIndex: 12, TextRun: &slides.TextRun{Content: "world\n", Style: &slides.TextStyle{}}},
+							}},
+						},
+					},
+					{
+						ObjectId: "word-art",
+						Size:     size,
+						WordArt:  &slides.WordArt{RenderedText: "Wow"},
+					},
+				},
+			},
+			{
+				ObjectId: "src-2",
+				PageElements: []*slides.PageElement{
+					{
+						ObjectId:  "group",
+						Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: 100, TranslateY: 100, Unit: "PT"},
+						ElementGroup: &slides.Group{Children: []*slides.PageElement{{
+							ObjectId:  "pic",
+							Size:      size,
+							Transform: &slides.AffineTransform{ScaleX: 2, ScaleY: 2, TranslateX: 5, TranslateY: 5, Unit: "PT"},
+							Image:     &slides.Image{ContentUrl: "https://example.com/pic.png"},
+						}}},
+					},
+				},
+			},
+		},
+	}
+
+	m := buildMergeRequests(src, 3, "gws_merge_test", nil)
+
+	if len(m.slideIDs) != 2 {
+		t.Fatalf("expected 2 new slides, got %v", m.slideIDs)
+	}
+	if first := m.requests[0].CreateSlide; first == nil || first.InsertionIndex != 3 || first.ObjectId != m.slideIDs[0] {
+		t.Errorf("unexpected first request: %+v", m.requests[0])
+	}
+
+	var insert *slides.InsertTextRequest
+	var styles []*slides.UpdateTextStyleRequest
+	var image *slides.CreateImageRequest
+	var secondSlide *slides.CreateSlideRequest
+	for _, r := range m.requests {
+		switch {
+		case r.InsertText != nil:
+			insert = r.InsertText
+		case r.UpdateTextStyle != nil:
+			styles = append(styles, r.UpdateTextStyle)
+		case r.CreateImage != nil:
+			image = r.CreateImage
+		case r.CreateSlide != nil && r.CreateSlide.ObjectId == m.slideIDs[1]:
+			secondSlide = r.CreateSlide
+		}
+	}
+
+	if insert == nil || insert.Text != "Hello world" {
+		t.Errorf("expected text 'Hello world' without trailing newline, got %+v", insert)
+	}
+	if len(styles) != 1 || styles[0].Fields != "bold" || *styles[0].TextRange.EndIndex != 6 {
+		t.Errorf("expected one bold style over the first run, got %+v", styles)
+	}
+	if secondSlide == nil || secondSlide.InsertionIndex != 4 {
+		t.Errorf("expected second slide at insertion index 4, got %+v", secondSlide)
+	}
+	if image == nil {
+		t.Fatal("expected grouped image to be recreated")
+	}
+	if tr := image.ElementProperties.Transform; tr.ScaleX != 2 || tr.TranslateX != 105 || tr.TranslateY != 105 {
+		t.Errorf("expected group transform composed into child, got %+v", tr)
+	}
+	if image.ElementProperties.PageObjectId != m.slideIDs[1] {
+		t.Errorf("image placed on %s, want %s", image.ElementProperties.PageObjectId, m.slideIDs[1])
+	}
+
+	if len(m.skipped) != 1 || m.skipped[0]["type"] != "word_art" {
+		t.Errorf("expected word art to be skipped, got %v", m.skipped)
+	}
+}
+
+func TestBuildMergeRequests_ScalesToDestinationPage(t *testing.T) {
+	src := &slides.Presentation{Slides: []*slides.Page{{
+		ObjectId: "src-1",
+		PageElements: []*slides.PageElement{{
+			ObjectId: "box",
+			Size: &slides.Size{
+				Width:  &slides.Dimension{Magnitude: 100, Unit: "PT"},
+				Height: &slides.Dimension{Magnitude: 50, Unit: "PT"},
+			},
+			Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: 100, TranslateY: 40, Unit: "PT"},
+			Shape:     &slides.Shape{ShapeType: "RECTANGLE"},
+		}},
+	}}}
+
+	// A 720x540pt slide fitted onto 720x405pt: scale 0.75, centered.
+	m := buildMergeRequests(src, 0, "gws_merge_test", pageScaleFor(720, 540, 720, 405, "fit"))
+	var shape *slides.CreateShapeRequest
+	for _, r := range m.requests {
+		if r.CreateShape != nil {
+			shape = r.CreateShape
+		}
+	}
+	if shape == nil {
+		t.Fatal("expected a CreateShape request")
+	}
+	tr := shape.ElementProperties.Transform
+	if tr.ScaleX != 0.75 || tr.ScaleY != 0.75 || tr.TranslateX != 165 || tr.TranslateY != 30 {
+		t.Errorf("expected the element fitted onto the destination page, got %+v", tr)
+	}
+}
+
+func TestSlidesMerge_InvalidScale(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "merge")
+	cmd.Flags().Set("scale", "shrink")
+	defer cmd.Flags().Set("scale", "fit")
+
+	err := cmd.RunE(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --scale") {
+		t.Errorf("expected invalid --scale error, got %v", err)
+	}
+}
+
+func TestBuildMergeRequests_InsertAtFront(t *testing.T) {
+	src := &slides.Presentation{Slides: []*slides.Page{{ObjectId: "src-1"}}}
+	m := buildMergeRequests(src, 0, "gws_merge_test", nil)
+
+	data, err := json.Marshal(m.requests[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"insertionIndex":0`) {
+		t.Errorf("expected insertionIndex 0 to be sent, got %s", data)
+	}
+}
+
+func TestSlidesMerge_SameDeck(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "merge")
+	cmd.Flags().Set("into", "deck-1")
+	cmd.Flags().Set("from", "deck-1")
+	defer func() {
+		cmd.Flags().Set("into", "")
+		cmd.Flags().Set("from", "")
+	}()
+
+	err := cmd.RunE(cmd, []string{})
+	if err == nil || !strings.Contains(err.Error(), "must be different") {
+		t.Errorf("expected same-deck validation error, got %v", err)
+	}
+}
+
+func TestSlidesSetFont_Flags(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "set-font")
+	if cmd == nil {
+		t.Fatal("slides set-font command not found")
+	}
+	for _, name := range []string{"family", "size"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func TestBuildSetFontRequests(t *testing.T) {
+	textOf := func(s string) *slides.TextContent {
+		return &slides.TextContent{TextElements: []*slides.TextElement{{TextRun: &slides.TextRun{Content: s}}}}
+	}
+	presentation := &slides.Presentation{
+		Slides: []*slides.Page{
+			{PageElements: []*slides.PageElement{
+				{ObjectId: "title", Shape: &slides.Shape{Text: textOf("Hello\n")}},
+				{ObjectId: "empty", Shape: &slides.Shape{Text: textOf("\n")}},
+				{ObjectId: "pic", Image: &slides.Image{}},
+			}},
+			{PageElements: []*slides.PageElement{
+				{ObjectId: "grp", ElementGroup: &slides.Group{Children: []*slides.PageElement{
+					{ObjectId: "inner", Shape: &slides.Shape{Text: textOf("Grouped\n")}},
+				}}},
+				{ObjectId: "tbl", Table: &slides.Table{TableRows: []*slides.TableRow{
+					{TableCells: []*slides.TableCell{{Text: textOf("A\n")}, {Text: textOf("\n")}}},
+					{TableCells: []*slides.TableCell{{}, {Text: textOf("D\n")}}},
+				}}},
+			}},
+		},
+	}
+
+	style := &slides.TextStyle{FontFamily: "Roboto"}
+	requests, elements := buildSetFontRequests(presentation, style, "fontFamily")
+
+	if elements != 3 {
+		t.Errorf("expected 3 elements updated (title, inner, tbl), got %d", elements)
+	}
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 requests (2 shapes + 2 cells), got %d", len(requests))
+	}
+
+	var ids []string
+	for _, r := range requests {
+		u := r.UpdateTextStyle
+		if u.TextRange.Type != "ALL" || u.Fields != "fontFamily" || u.Style.FontFamily != "Roboto" {
+			t.Errorf("unexpected request: %+v", u)
+		}
+		ids = append(ids, u.ObjectId)
+	}
+	if strings.Join(ids, ",") != "title,inner,tbl,tbl" {
+		t.Errorf("unexpected targets: %v", ids)
+	}
+	if cell := requests[3].UpdateTextStyle.CellLocation; cell == nil || cell.RowIndex != 1 || cell.ColumnIndex != 1 {
+		t.Errorf("expected last request on cell (1,1), got %+v", cell)
+	}
+}
+
+func TestCollectFonts(t *testing.T) {
+	run := func(content, family string) *slides.TextElement {
+		te := &slides.TextElement{TextRun: &slides.TextRun{Content: content}}
+		if family != "" {
+			te.TextRun.Style = &slides.TextStyle{FontFamily: family}
+		}
+		return te
+	}
+	presentation := &slides.Presentation{
+		Slides: []*slides.Page{
+			{PageElements: []*slides.PageElement{
+				{Shape: &slides.Shape{Text: &slides.TextContent{TextElements: []*slides.TextElement{
+					{ParagraphMarker: &slides.ParagraphMarker{}},
+					run("Title", "Arial"),
+					run("\n", "Comic Sans MS"),
+					run("inherits", ""),
+				}}}},
+			}},
+			{PageElements: []*slides.PageElement{
+				{ElementGroup: &slides.Group{Children: []*slides.PageElement{
+					{Shape: &slides.Shape{Text: &slides.TextContent{TextElements: []*slides.TextElement{run("Body", "Roboto")}}}},
+				}}},
+				{Table: &slides.Table{TableRows: []*slides.TableRow{
+					{TableCells: []*slides.TableCell{
+						{Text: &slides.TextContent{TextElements: []*slides.TextElement{run("A", "Arial"), run("B", "Arial")}}},
+						{},
+					}},
+				}}},
+			}},
+		},
+	}
+
+	fonts, inherited := collectFonts(presentation)
+
+	if inherited != 1 {
+		t.Errorf("expected 1 inherited run, got %d", inherited)
+	}
+	if len(fonts) != 2 {
+		t.Fatalf("expected Arial and Roboto (blank runs ignored), got %+v", fonts)
+	}
+	if fonts[0].Family != "Arial" || fonts[0].Runs != 3 || len(fonts[0].Slides) != 2 || fonts[0].Slides[0] != 1 || fonts[0].Slides[1] != 2 {
+		t.Errorf("unexpected Arial usage: %+v", fonts[0])
+	}
+	if fonts[1].Family != "Roboto" || fonts[1].Runs != 1 || len(fonts[1].Slides) != 1 || fonts[1].Slides[0] != 2 {
+		t.Errorf("unexpected Roboto usage: %+v", fonts[1])
+	}
+}
+
+func TestSlidesLineStyleFlags(t *testing.T) {
+	for _, name := range []string{"add-line", "update-line"} {
+		cmd := findSubcommand(slidesCmd, name)
+		if cmd == nil {
+			t.Fatalf("slides %s command not found", name)
+		}
+		for _, flag := range []string{"color", "weight", "dash", "start-arrow", "end-arrow"} {
+			if cmd.Flags().Lookup(flag) == nil {
+				t.Errorf("%s: expected --%s flag", name, flag)
+			}
+		}
+	}
+}
+
+func TestBuildLineProperties(t *testing.T) {
+	props, fields, err := buildLineProperties(lineStyle{
+		Color:      "#FF0000",
+		Weight:     2,
+		WeightSet:  true,
+		Dash:       "dash_dot",
+		StartArrow: "open_circle",
+		EndArrow:   "FILL_ARROW",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields != "lineFill,weight,dashStyle,startArrow,endArrow" {
+		t.Errorf("unexpected fields %q", fields)
+	}
+	if props.DashStyle != "DASH_DOT" || props.StartArrow != "OPEN_CIRCLE" || props.EndArrow != "FILL_ARROW" {
+		t.Errorf("unexpected styles: %+v", props)
+	}
+	if props.Weight.Magnitude != 2 || props.LineFill.SolidFill.Color.RgbColor.Red != 1 {
+		t.Errorf("unexpected weight/color: %+v", props)
+	}
+
+	if _, fields, err := buildLineProperties(lineStyle{}); err != nil || fields != "" {
+		t.Errorf("expected empty mask for no flags, got %q (err %v)", fields, err)
+	}
+
+	for _, bad := range []lineStyle{
+		{Dash: "WAVY"},
+		{EndArrow: "BIG_ARROW"},
+		{Weight: 0, WeightSet: true},
+		{Color: "red"},
+	} {
+		if _, _, err := buildLineProperties(bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestSlidesUpdateLine_RequiresStyle(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "update-line")
+	cmd.Flags().Set("object-id", "line1")
+	defer cmd.Flags().Set("object-id", "")
+
+	err := cmd.RunE(cmd, []string{"deck"})
+	if err == nil || !strings.Contains(err.Error(), "at least one of") {
+		t.Errorf("expected missing-style error, got %v", err)
+	}
+}
+
+func slideNumberTestPresentation() *slides.Presentation {
+	textShape := func(text string) *slides.Shape {
+		return &slides.Shape{Text: &slides.TextContent{TextElements: []*slides.TextElement{
+			{TextRun: &slides.TextRun{Content: text}},
+		}}}
+	}
+	return &slides.Presentation{
+		Layouts: []*slides.Page{{
+			ObjectId: "layout1",
+			PageElements: []*slides.PageElement{{
+				ObjectId:  "layout_num",
+				Size:      &slides.Size{Width: &slides.Dimension{Magnitude: 60, Unit: "PT"}},
+				Transform: &slides.AffineTransform{TranslateX: 600, Unit: "PT"},
+				Shape:     &slides.Shape{Placeholder: &slides.Placeholder{Type: "SLIDE_NUMBER"}},
+			}},
+		}},
+		Slides: []*slides.Page{
+			{ObjectId: "s1", SlideProperties: &slides.SlideProperties{LayoutObjectId: "layout1"}},
+			{ObjectId: "s2", PageElements: []*slides.PageElement{
+				{ObjectId: "native", Shape: &slides.Shape{Placeholder: &slides.Placeholder{Type: "SLIDE_NUMBER"}}},
+			}},
+			{ObjectId: "s3", PageElements: []*slides.PageElement{
+				{ObjectId: slideNumberIDPrefix + "old_2", Shape: textShape("2\n")},
+			}},
+			{ObjectId: "s4", PageElements: []*slides.PageElement{
+				{ObjectId: slideNumberIDPrefix + "old_3", Shape: textShape("4\n")},
+			}},
+		},
+	}
+}
+
+func TestBuildSlideNumberRequests_On(t *testing.T) {
+	requests, summary := buildSlideNumberRequests(slideNumberTestPresentation(), true, false, 10, slideNumberIDPrefix+"new")
+
+	if summary != (slideNumberSummary{Added: 1, Renumbered: 1, Native: 1}) {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	// s1: create + insert + text style + paragraph style; s3: delete + insert; s4 already correct.
+	if len(requests) != 6 {
+		t.Fatalf("expected 6 requests, got %d", len(requests))
+	}
+	create := requests[0].CreateShape
+	if create == nil || create.ElementProperties.PageObjectId != "s1" || create.ElementProperties.Transform.TranslateX != 600 {
+		t.Errorf("expected text box on s1 at the layout placeholder position, got %+v", requests[0])
+	}
+	if requests[1].InsertText == nil || requests[1].InsertText.Text != "1" {
+		t.Errorf("expected slide 1 to be numbered 1, got %+v", requests[1])
+	}
+	if requests[4].DeleteText == nil || requests[4].DeleteText.ObjectId != slideNumberIDPrefix+"old_2" || requests[5].InsertText.Text != "3" {
+		t.Errorf("expected stale number on s3 to become 3, got %+v / %+v", requests[4], requests[5])
+	}
+}
+
+func TestBuildSlideNumberRequests_Off(t *testing.T) {
+	requests, summary := buildSlideNumberRequests(slideNumberTestPresentation(), false, true, 10, slideNumberIDPrefix+"new")
+
+	if summary.Removed != 3 || summary.Added != 0 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	for _, r := range requests {
+		if r.DeleteObject == nil {
+			t.Fatalf("expected only delete requests, got %+v", r)
+		}
+	}
+	if requests[0].DeleteObject.ObjectId != "native" {
+		t.Errorf("expected native placeholder to be deleted first, got %s", requests[0].DeleteObject.ObjectId)
+	}
+}
+
+func TestSlideNumberPlacement_DefaultsToBottomRight(t *testing.T) {
+	presentation := &slides.Presentation{Slides: []*slides.Page{{ObjectId: "s1"}}}
+	size, transform := slideNumberPlacement(presentation, presentation.Slides[0])
+	if size.Width.Magnitude != slideNumberWidth || transform.TranslateX != 720-footerMargin-slideNumberWidth || transform.TranslateY != 405-footerMargin-slideNumberHeight {
+		t.Errorf("unexpected default placement: %+v %+v", size, transform)
+	}
+}
+
+func TestPageBackgroundFill(t *testing.T) {
+	fill, err := pageBackgroundFill("#FF0000", "")
+	if err != nil {
+		t.Fatalf("pageBackgroundFill: %v", err)
+	}
+	if fill.SolidFill == nil || fill.SolidFill.Color.RgbColor.Red != 1 || fill.StretchedPictureFill != nil {
+		t.Errorf("expected red solid fill, got %+v", fill)
+	}
+
+	fill, err = pageBackgroundFill("", "https://example.com/bg.png")
+	if err != nil || fill.StretchedPictureFill == nil || fill.StretchedPictureFill.ContentUrl != "https://example.com/bg.png" {
+		t.Errorf("expected picture fill, got %+v (%v)", fill, err)
+	}
+
+	req := backgroundRequest("s1", fill)
+	if req.UpdatePageProperties.ObjectId != "s1" || req.UpdatePageProperties.Fields != "pageBackgroundFill" {
+		t.Errorf("unexpected request: %+v", req.UpdatePageProperties)
+	}
+}
+
+func TestSlidesSetAllBackgrounds_RequiresOneFill(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "set-all-backgrounds")
+	err := cmd.RunE(cmd, []string{"deck"})
+	if err == nil || !strings.Contains(err.Error(), "--color or --image-url") {
+		t.Errorf("expected missing-fill error, got %v", err)
+	}
+
+	cmd.Flags().Set("color", "#FFFFFF")
+	cmd.Flags().Set("image-url", "https://example.com/bg.png")
+	defer func() {
+		cmd.Flags().Set("color", "")
+		cmd.Flags().Set("image-url", "")
+	}()
+	err = cmd.RunE(cmd, []string{"deck"})
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+}
+
+func TestParseTableData(t *testing.T) {
+	data, err := parseTableData(`[["Region","Revenue","Note"],["EMEA",120],["APAC",95.5,null]]`)
+	if err != nil {
+		t.Fatalf("parseTableData: %v", err)
+	}
+	want := [][]string{{"Region", "Revenue", "Note"}, {"EMEA", "120", ""}, {"APAC", "95.5", ""}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("parseTableData = %q, want %q", data, want)
+	}
+
+	for _, bad := range []string{`not json`, `[]`, `[[]]`} {
+		if _, err := parseTableData(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestBuildDataTableRequests(t *testing.T) {
+	data := [][]string{{"H1", "H2"}, {"a", ""}}
+	requests := buildDataTableRequests("slide1", "tbl", data, &slides.PageElementProperties{}, true)
+
+	// create + 3 non-empty inserts + 2 header bolds
+	if len(requests) != 6 {
+		t.Fatalf("expected 6 requests, got %d", len(requests))
+	}
+	create := requests[0].CreateTable
+	if create == nil || create.ObjectId != "tbl" || create.Rows != 2 || create.Columns != 2 || create.ElementProperties.PageObjectId != "slide1" {
+		t.Errorf("unexpected create request: %+v", create)
+	}
+	if requests[1].InsertText == nil || requests[1].InsertText.Text != "H1" || requests[1].InsertText.CellLocation.ColumnIndex != 0 {
+		t.Errorf("unexpected first insert: %+v", requests[1].InsertText)
+	}
+	if requests[2].UpdateTextStyle == nil || !requests[2].UpdateTextStyle.Style.Bold {
+		t.Errorf("expected header cell to be bolded, got %+v", requests[2])
+	}
+	last := requests[5].InsertText
+	if last == nil || last.Text != "a" || last.CellLocation.RowIndex != 1 {
+		t.Errorf("unexpected body insert: %+v", last)
+	}
+}
+
+func TestParseMarkdownBullets(t *testing.T) {
+	items, ordered, err := parseMarkdownBullets("- a\n  - a1\n      - a1x\n  - a2\n\n- b\n\t* b1")
+	if err != nil {
+		t.Fatalf("parseMarkdownBullets: %v", err)
+	}
+	want := []bulletItem{
+		{Level: 0, Text: "a"},
+		{Level: 1, Text: "a1"},
+		{Level: 2, Text: "a1x"},
+		{Level: 1, Text: "a2"},
+		{Level: 0, Text: "b"},
+		{Level: 1, Text: "b1"},
+	}
+	if !reflect.DeepEqual(items, want) || ordered {
+		t.Errorf("items = %+v (ordered %v), want %+v", items, ordered, want)
+	}
+
+	_, ordered, err = parseMarkdownBullets("1. Plan\n2. Build")
+	if err != nil || !ordered {
+		t.Errorf("expected an ordered list, got ordered=%v err=%v", ordered, err)
+	}
+
+	if _, _, err := parseMarkdownBullets("\n  \n"); err == nil {
+		t.Error("expected error for an empty list")
+	}
+}
+
+func TestBuildSetBodyRequests(t *testing.T) {
+	items := []bulletItem{{Level: 0, Text: "a"}, {Level: 1, Text: "a1"}, {Level: 0, Text: "b"}}
+
+	reqs := buildSetBodyRequests("body_1", true, items, "BULLET_DISC_CIRCLE_SQUARE")
+	if len(reqs) != 3 || reqs[0].DeleteText == nil {
+		t.Fatalf("expected delete, insert, bullets; got %d requests", len(reqs))
+	}
+	if reqs[1].InsertText.Text != "a\n\ta1\nb" {
+		t.Errorf("unexpected inserted text: %q", reqs[1].InsertText.Text)
+	}
+	bullets := reqs[2].CreateParagraphBullets
+	if bullets.ObjectId != "body_1" || bullets.BulletPreset != "BULLET_DISC_CIRCLE_SQUARE" || bullets.TextRange.Type != "ALL" {
+		t.Errorf("unexpected bullets request: %+v", bullets)
+	}
+
+	if reqs := buildSetBodyRequests("body_1", false, items, "BULLET_DISC_CIRCLE_SQUARE"); len(reqs) != 2 || reqs[0].InsertText == nil {
+		t.Error("an empty shape should not be cleared first")
+	}
+}
+
+func TestBuildAddBulletsRequests(t *testing.T) {
+	// "Intro\nOutro\n": "Intro" is 0-4, its newline 5, "Outro" 6-10.
+	existing := utf16.Encode([]rune("Intro\nOutro\n"))
+	tests := []struct {
+		name      string
+		at        int64
+		wantText  string
+		wantStart int64
+		wantEnd   int64
+	}{
+		{"empty shape", 0, "a\nb", 0, 3},
+		{"start of paragraph", 6, "a\nb\n", 6, 9},
+		{"end of paragraph", 5, "\na\nb", 6, 9},
+		{"inside paragraph", 2, "\na\nb\n", 3, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := existing
+			if tt.name == "empty shape" {
+				text = nil
+			}
+			reqs := buildAddBulletsRequests("box", text, tt.at, []string{"a", "b"}, "BULLET_CHECKBOX")
+			if len(reqs) != 2 || reqs[0].InsertText == nil || reqs[1].CreateParagraphBullets == nil {
+				t.Fatalf("expected insert then bullets, got %+v", reqs)
+			}
+			if insert := reqs[0].InsertText; insert.Text != tt.wantText || insert.InsertionIndex != tt.at {
+				t.Errorf("inserted %q at %d, want %q at %d", insert.Text, insert.InsertionIndex, tt.wantText, tt.at)
+			}
+			r := reqs[1].CreateParagraphBullets.TextRange
+			if r.Type != "FIXED_RANGE" || *r.StartIndex != tt.wantStart || *r.EndIndex != tt.wantEnd {
+				t.Errorf("bulleted %s [%d,%d), want [%d,%d)", r.Type, *r.StartIndex, *r.EndIndex, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCollectBulletItems(t *testing.T) {
+	got := collectBulletItems([]string{"one", "two, with comma"}, `three\n\nfour`)
+	if strings.Join(got, "|") != "one|two, with comma|three|four" {
+		t.Errorf("unexpected items: %q", got)
+	}
+}
+
+func TestNormalizeBulletPreset(t *testing.T) {
+	for in, want := range map[string]string{
+		"bullet":          "BULLET_DISC_CIRCLE_SQUARE",
+		"numbered":        "NUMBERED_DIGIT_ALPHA_ROMAN",
+		"bullet_checkbox": "BULLET_CHECKBOX",
+	} {
+		if got, err := normalizeBulletPreset(in); err != nil || got != want {
+			t.Errorf("normalizeBulletPreset(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := normalizeBulletPreset("squares"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+}
+
+func TestSlidesAddBullets_OnlyBulletsNewParagraphs(t *testing.T) {
+	var sent slides.BatchUpdatePresentationRequest
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-b": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: []*slides.Page{{
+				ObjectId: "p1",
+				PageElements: []*slides.PageElement{{
+					ObjectId: "box",
+					Shape: &slides.Shape{Text: &slides.TextContent{TextElements: []*slides.TextElement{
+						{TextRun: &slides.TextRun{Content: "Agenda\n"}},
+					}}},
+				}},
+			}}})
+		},
+		"/v1/presentations/pres-b:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesAddBulletsWithService(svc, "pres-b", "box", []string{"Goals", "Risks"}, "BULLET_DISC_CIRCLE_SQUARE", 6, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(sent.Requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(sent.Requests))
+	}
+	if got := sent.Requests[0].InsertText.Text; got != "\nGoals\nRisks" {
+		t.Errorf("unexpected inserted text %q", got)
+	}
+	r := sent.Requests[1].CreateParagraphBullets.TextRange
+	if *r.StartIndex != 7 || *r.EndIndex != 18 {
+		t.Errorf("expected bullets over [7,18), got [%d,%d)", *r.StartIndex, *r.EndIndex)
+	}
+	if !strings.Contains(buf.String(), `"items": 2`) || !strings.Contains(buf.String(), `"object_id": "box"`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	err = runSlidesAddBulletsWithService(svc, "pres-b", "box", []string{"x"}, "BULLET_DISC_CIRCLE_SQUARE", 7, printer.New(&bytes.Buffer{}, "json"))
+	if err == nil || !strings.Contains(err.Error(), "past the end of the text") {
+		t.Errorf("expected out-of-range error, got %v", err)
+	}
+}
+
+func TestSlidesAddHyperlink(t *testing.T) {
+	var bodies []string
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-h": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: []*slides.Page{
+				{
+					ObjectId: "p1",
+					PageElements: []*slides.PageElement{{
+						ObjectId: "box",
+						Shape: &slides.Shape{Text: &slides.TextContent{TextElements: []*slides.TextElement{
+							{TextRun: &slides.TextRun{Content: "See the docs\n"}},
+						}}},
+					}},
+				},
+				{ObjectId: "p2"},
+			}})
+		},
+		"/v1/presentations/pres-h:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesAddHyperlinkWithService(svc, "pres-h", "box", 8, 12, "https://example.com", 0, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	var sent slides.BatchUpdatePresentationRequest
+	json.Unmarshal([]byte(bodies[0]), &sent)
+	req := sent.Requests[0].UpdateTextStyle
+	if req.Fields != "link" || req.Style.Link.Url != "https://example.com" {
+		t.Errorf("unexpected request: fields=%q link=%+v", req.Fields, req.Style.Link)
+	}
+	if req.TextRange.Type != "FIXED_RANGE" || *req.TextRange.StartIndex != 8 || *req.TextRange.EndIndex != 12 {
+		t.Errorf("unexpected range: %+v", req.TextRange)
+	}
+	if !strings.Contains(buf.String(), `"text": "docs"`) || !strings.Contains(buf.String(), `"url": "https://example.com"`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	// Linking to slide 1 must still send slideIndex 0.
+	buf.Reset()
+	if err := runSlidesAddHyperlinkWithService(svc, "pres-h", "box", 0, -1, "", 1, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if !strings.Contains(bodies[1], `"slideIndex":0`) || !strings.Contains(bodies[1], `"type":"ALL"`) {
+		t.Errorf("expected slideIndex 0 over ALL text, got %s", bodies[1])
+	}
+	if !strings.Contains(buf.String(), `"text": "See the docs"`) || !strings.Contains(buf.String(), `"slide_number": 1`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	err = runSlidesAddHyperlinkWithService(svc, "pres-h", "box", 0, -1, "", 3, printer.New(&bytes.Buffer{}, "json"))
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected slide out-of-range error, got %v", err)
+	}
+	err = runSlidesAddHyperlinkWithService(svc, "pres-h", "box", 0, 20, "https://example.com", 0, printer.New(&bytes.Buffer{}, "json"))
+	if err == nil || !strings.Contains(err.Error(), "past the end of the text") {
+		t.Errorf("expected text out-of-range error, got %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Errorf("expected no batch update for invalid input, got %d", len(bodies))
+	}
+
+	// --from without --to links from that index to the end, not all text.
+	buf.Reset()
+	if err := runSlidesAddHyperlinkWithService(svc, "pres-h", "box", 4, -1, "https://example.com", 0, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	json.Unmarshal([]byte(bodies[2]), &sent)
+	rng := sent.Requests[0].UpdateTextStyle.TextRange
+	if rng.Type != "FROM_START_INDEX" || rng.StartIndex == nil || *rng.StartIndex != 4 {
+		t.Errorf("expected FROM_START_INDEX at 4, got %+v", rng)
+	}
+	if !strings.Contains(buf.String(), `"text": "the docs"`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestSlidesAddHyperlink_Validation(t *testing.T) {
+	cmd := findSubcommand(slidesCmd, "add-hyperlink")
+	if cmd == nil {
+		t.Fatal("add-hyperlink command not found")
+	}
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"no target", map[string]string{}, "exactly one of --url or --slide-number"},
+		{"both targets", map[string]string{"url": "https://example.com", "slide-number": "2"}, "exactly one of --url or --slide-number"},
+		{"relative url", map[string]string{"url": "example.com"}, "absolute URL"},
+		{"empty range", map[string]string{"url": "https://example.com", "from": "4", "to": "4"}, "--to must be greater than --from"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd.Flags().Set("url", "")
+			cmd.Flags().Set("slide-number", "0")
+			cmd.Flags().Set("from", "0")
+			cmd.Flags().Set("to", "-1")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := runSlidesAddHyperlink(cmd, []string{"pres"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFindBodyShape(t *testing.T) {
+	slide := &slides.Page{
+		ObjectId: "p1",
+		PageElements: []*slides.PageElement{
+			{ObjectId: "title", Shape: &slides.Shape{Placeholder: &slides.Placeholder{Type: "TITLE"}}},
+			{ObjectId: "body", Shape: &slides.Shape{Placeholder: &slides.Placeholder{Type: "BODY"}}},
+			{ObjectId: "box", Shape: &slides.Shape{ShapeType: "TEXT_BOX"}},
+		},
+	}
+	if el, err := findBodyShape(slide, ""); err != nil || el.ObjectId != "body" {
+		t.Errorf("expected BODY placeholder, got %v, %v", el, err)
+	}
+	if el, err := findBodyShape(slide, "box"); err != nil || el.ObjectId != "box" {
+		t.Errorf("expected box, got %v, %v", el, err)
+	}
+	if _, err := findBodyShape(&slides.Page{ObjectId: "p2"}, ""); err == nil {
+		t.Error("expected error for a slide without a body")
+	}
+}
+
+func TestGridLayout(t *testing.T) {
+	cells, err := gridLayout(3, 2, 720, 405, 10, 20)
+	if err != nil {
+		t.Fatalf("gridLayout: %v", err)
+	}
+	// (720 - 40 - 10) / 2 = 335 wide; (405 - 40 - 10) / 2 = 177.5 tall
+	want := []gridCell{
+		{X: 20, Y: 20, Width: 335, Height: 177.5},
+		{X: 365, Y: 20, Width: 335, Height: 177.5},
+		{X: 20, Y: 207.5, Width: 335, Height: 177.5},
+	}
+	if !reflect.DeepEqual(cells, want) {
+		t.Errorf("cells = %+v, want %+v", cells, want)
+	}
+
+	if _, err := gridLayout(4, 4, 100, 100, 30, 10); err == nil {
+		t.Error("expected error when the grid does not fit")
+	}
+}
+
+func TestFitTransform(t *testing.T) {
+	cell := gridCell{X: 20, Y: 20, Width: 300, Height: 200}
+
+	// 400x200 image: width-bound, scaled by 0.75 to 300x150 and centered vertically.
+	tr := fitTransform(400, 200, cell, false)
+	if tr.ScaleX != 0.75 || tr.ScaleY != 0.75 || tr.TranslateX != 20 || tr.TranslateY != 45 || tr.Unit != "PT" {
+		t.Errorf("unexpected fit transform: %+v", tr)
+	}
+
+	tr = fitTransform(400, 200, cell, true)
+	if tr.ScaleX != 0.75 || tr.ScaleY != 1 || tr.TranslateX != 20 || tr.TranslateY != 20 {
+		t.Errorf("unexpected stretch transform: %+v", tr)
+	}
+}
+
+func TestPageScaleFor(t *testing.T) {
+	// 4:3 (720x540) onto 16:9 (720x405): fit scales by 0.75 and centers horizontally.
+	fit := pageScaleFor(720, 540, 720, 405, "fit")
+	if fit.ScaleX != 0.75 || fit.ScaleY != 0.75 || fit.OffsetX != 90 || fit.OffsetY != 0 {
+		t.Errorf("unexpected fit scale: %+v", fit)
+	}
+	stretch := pageScaleFor(720, 540, 720, 405, "stretch")
+	if stretch.ScaleX != 1 || stretch.ScaleY != 0.75 || stretch.OffsetX != 0 {
+		t.Errorf("unexpected stretch scale: %+v", stretch)
+	}
+	if pageScaleFor(720, 540, 720, 405, "none") != nil {
+		t.Error("none should not scale")
+	}
+}
+
+func TestSlideMerger_ScalesTopLevelElements(t *testing.T) {
+	src := &slides.Presentation{Slides: []*slides.Page{{
+		ObjectId: "src-1",
+		PageElements: []*slides.PageElement{{
+			ObjectId: "box",
+			Size: &slides.Size{
+				Width:  &slides.Dimension{Magnitude: 100, Unit: "PT"},
+				Height: &slides.Dimension{Magnitude: 50, Unit: "PT"},
+			},
+			Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: 40, TranslateY: 20, Unit: "EMU"},
+			Shape:     &slides.Shape{ShapeType: "RECTANGLE"},
+		}},
+	}}}
+
+	m := &slideMerger{idPrefix: "c", scale: &pageScale{ScaleX: 0.5, ScaleY: 0.5, OffsetX: 10, OffsetY: 0}}
+	m.addSlides(src, 0)
+
+	var shape *slides.CreateShapeRequest
+	for _, r := range m.requests {
+		if r.CreateShape != nil {
+			shape = r.CreateShape
+		}
+	}
+	if shape == nil {
+		t.Fatal("expected a CreateShape request")
+	}
+	tr := shape.ElementProperties.Transform
+	if tr.ScaleX != 0.5 || tr.ScaleY != 0.5 {
+		t.Errorf("expected scale 0.5, got %+v", tr)
+	}
+	// 40 EMU * 0.5 + 10pt (127000 EMU)
+	if tr.TranslateX != 127020 || tr.TranslateY != 10 || tr.Unit != "EMU" {
+		t.Errorf("unexpected translate: %+v", tr)
+	}
+}
+
+func TestSlidesCommentCommands_Flags(t *testing.T) {
+	for _, name := range []string{"comments", "resolve-comment", "delete-comment"} {
+		if findSubcommand(slidesCmd, name) == nil {
+			t.Fatalf("slides %s command not found", name)
+		}
+	}
+	for _, flag := range []string{"id", "all", "resolved"} {
+		if slidesDeleteCommentCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag '--%s' on delete-comment", flag)
+		}
+	}
+}
+
+// mockSlidesCommentsServer serves two comments on deck-1 (c1 open, c2
+// resolved) and records deleted comment IDs and posted replies.
+func mockSlidesCommentsServer(t *testing.T, deleted *[]string, reply *drive.Reply) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/files/deck-1/comments":
+			json.NewEncoder(w).Encode(&drive.CommentList{Comments: []*drive.Comment{
+				{
+					Id: "c1", Content: "tighten this", Anchor: `{"r":"head"}`,
+					QuotedFileContent: &drive.CommentQuotedFileContent{Value: "Q3 revenue"},
+					Author:            &drive.User{DisplayName: "Dana", EmailAddress: "dana@example.com"},
+				},
+				{Id: "c2", Content: "typo", Resolved: true},
+			}})
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/files/deck-1/comments/"):
+			*deleted = append(*deleted, strings.TrimPrefix(r.URL.Path, "/files/deck-1/comments/"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "POST" && r.URL.Path == "/files/deck-1/comments/c1/replies":
+			json.NewDecoder(r.Body).Decode(reply)
+			json.NewEncoder(w).Encode(&drive.Reply{Id: "r1", Action: reply.Action, Content: reply.Content})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSlidesComments_ListsWithQuotedText(t *testing.T) {
+	var deleted []string
+	var reply drive.Reply
+	server := mockSlidesCommentsServer(t, &deleted, &reply)
+	defer server.Close()
+
+	svc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesCommentsWithService(svc, "deck-1", 100, false, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSlidesCommentsWithService: %v", err)
+	}
+
+	var out struct {
+		Count    int                      `json:"count"`
+		Resolved int                      `json:"resolved"`
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Count != 1 || out.Resolved != 1 {
+		t.Fatalf("expected 1 open and 1 resolved comment, got count=%d resolved=%d", out.Count, out.Resolved)
+	}
+	c := out.Comments[0]
+	if c["id"] != "c1" || c["quoted_text"] != "Q3 revenue" || c["anchor"] != `{"r":"head"}` {
+		t.Errorf("unexpected comment: %v", c)
+	}
+}
+
+func TestSlidesResolveComment_PostsResolveReply(t *testing.T) {
+	var deleted []string
+	var reply drive.Reply
+	server := mockSlidesCommentsServer(t, &deleted, &reply)
+	defer server.Close()
+
+	svc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesResolveCommentWithService(svc, "deck-1", "c1", "fixed", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSlidesResolveCommentWithService: %v", err)
+	}
+	if reply.Action != "resolve" || reply.Content != "fixed" {
+		t.Errorf("expected resolve reply with content, got %+v", reply)
+	}
+	if !strings.Contains(buf.String(), `"status": "resolved"`) {
+		t.Errorf("expected resolved status, got %s", buf.String())
+	}
+}
+
+func TestSlidesDeleteAllComments(t *testing.T) {
+	tests := []struct {
+		name         string
+		resolvedOnly bool
+		want         []string
+	}{
+		{"all", false, []string{"c1", "c2"}},
+		{"resolved only", true, []string{"c2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			var reply drive.Reply
+			server := mockSlidesCommentsServer(t, &deleted, &reply)
+			defer server.Close()
+
+			svc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+			if err != nil {
+				t.Fatalf("failed to create drive service: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := runSlidesDeleteAllCommentsWithService(svc, "deck-1", tt.resolvedOnly, printer.New(&buf, "json")); err != nil {
+				t.Fatalf("runSlidesDeleteAllCommentsWithService: %v", err)
+			}
+			if !reflect.DeepEqual(deleted, tt.want) {
+				t.Errorf("deleted %v, want %v", deleted, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSlidePages(t *testing.T) {
+	got, err := parseSlidePages("4-5, 1,2-2,5", 6)
+	if err != nil {
+		t.Fatalf("parseSlidePages: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 3, 4}) {
+		t.Errorf("parseSlidePages = %v, want [0 1 3 4]", got)
+	}
+	for _, spec := range []string{"0", "3-2", "x", "", "5-7"} {
+		if _, err := parseSlidePages(spec, 6); err == nil {
+			t.Errorf("parseSlidePages(%q) expected an error", spec)
+		}
+	}
+}
+
+func TestPdfFileName(t *testing.T) {
+	if got := pdfFileName("Q3 Review: Draft/v2", "id"); got != "Q3 Review_ Draft_v2.pdf" {
+		t.Errorf("pdfFileName = %q", got)
+	}
+	if got := pdfFileName("  ", "deck-1"); got != "deck-1.pdf" {
+		t.Errorf("pdfFileName fallback = %q", got)
+	}
+}
+
+func TestSlidesExportPDF_Pages(t *testing.T) {
+	var deletedSlides []string
+	copyDeleted := false
+	exported := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/files/deck-1":
+			json.NewEncoder(w).Encode(&drive.File{Name: "Board deck", MimeType: "application/vnd.google-apps.presentation"})
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/v1/presentations/"):
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: []*slides.Page{{ObjectId: "s1"}, {ObjectId: "s2"}, {ObjectId: "s3"}}})
+		case r.Method == "POST" && r.URL.Path == "/files/deck-1/copy":
+			json.NewEncoder(w).Encode(&drive.File{Id: "copy-1"})
+		case r.Method == "POST" && r.URL.Path == "/v1/presentations/copy-1:batchUpdate":
+			var req slides.BatchUpdatePresentationRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			for _, rq := range req.Requests {
+				deletedSlides = append(deletedSlides, rq.DeleteObject.ObjectId)
+			}
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/export"):
+			exported = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/files/"), "/export")
+			w.Write([]byte("%PDF-1.7 fake"))
+		case r.Method == "DELETE" && r.URL.Path == "/files/copy-1":
+			copyDeleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+	slidesSvc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	output := t.TempDir() + "/out.pdf"
+	var buf bytes.Buffer
+	if err := runSlidesExportPDFWithServices(driveSvc, slidesSvc, "deck-1", output, "2", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runSlidesExportPDFWithServices: %v", err)
+	}
+	if exported != "copy-1" || !copyDeleted {
+		t.Errorf("expected the trimmed copy to be exported and deleted (exported=%q deleted=%v)", exported, copyDeleted)
+	}
+	if !reflect.DeepEqual(deletedSlides, []string{"s1", "s3"}) {
+		t.Errorf("deleted slides = %v, want [s1 s3]", deletedSlides)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil || string(data) != "%PDF-1.7 fake" {
+		t.Errorf("unexpected output file: %q, %v", data, err)
+	}
+	if !strings.Contains(buf.String(), `"size": 13`) || !strings.Contains(buf.String(), `"slide_count": 1`) {
+		t.Errorf("unexpected result: %s", buf.String())
+	}
+}
+
+func TestSlidesExportPDF_PermissionDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"code":403,"message":"The user does not have sufficient permissions for this file."}}`))
+	}))
+	defer server.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create drive service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = runSlidesExportPDFWithServices(driveSvc, nil, "deck-1", t.TempDir()+"/out.pdf", "", printer.New(&buf, "json"))
+	if err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Errorf("expected an access denied error, got %v", err)
+	}
+}
+
+func TestBuildSetDefaultsRequests(t *testing.T) {
+	text := &slides.TextContent{TextElements: []*slides.TextElement{{TextRun: &slides.TextRun{Content: "Click to edit\n"}}}}
+	placeholder := func(id, kind string, withText bool) *slides.PageElement {
+		shape := &slides.Shape{Placeholder: &slides.Placeholder{Type: kind}}
+		if withText {
+			shape.Text = text
+		}
+		return &slides.PageElement{ObjectId: id, Shape: shape}
+	}
+	presentation := &slides.Presentation{
+		Masters: []*slides.Page{{PageElements: []*slides.PageElement{
+			placeholder("m-title", "TITLE", true),
+			placeholder("m-body", "BODY", true),
+			placeholder("m-num", "SLIDE_NUMBER", true),
+		}}},
+		Layouts: []*slides.Page{{PageElements: []*slides.PageElement{
+			placeholder("l-title", "CENTERED_TITLE", true),
+			placeholder("l-sub", "SUBTITLE", true),
+			placeholder("l-empty", "BODY", false),
+			{ObjectId: "l-box", Shape: &slides.Shape{Text: text}},
+		}}},
+	}
+
+	title := newPlaceholderTextStyle("Arial", 30)
+	body := newPlaceholderTextStyle("", 14)
+	requests, titles, bodies := buildSetDefaultsRequests(presentation, title, body)
+	if titles != 2 || bodies != 2 || len(requests) != 4 {
+		t.Fatalf("expected 2 title and 2 body updates, got titles=%d bodies=%d requests=%d", titles, bodies, len(requests))
+	}
+	var ids []string
+	for _, r := range requests {
+		ids = append(ids, r.UpdateTextStyle.ObjectId)
+	}
+	if !reflect.DeepEqual(ids, []string{"m-title", "m-body", "l-title", "l-sub"}) {
+		t.Errorf("updated objects = %v", ids)
+	}
+	if requests[0].UpdateTextStyle.Fields != "fontFamily,fontSize" || requests[1].UpdateTextStyle.Fields != "fontSize" {
+		t.Errorf("unexpected fields: %q, %q", requests[0].UpdateTextStyle.Fields, requests[1].UpdateTextStyle.Fields)
+	}
+
+	// An unset group is left alone.
+	requests, titles, bodies = buildSetDefaultsRequests(presentation, placeholderTextStyle{}, body)
+	if titles != 0 || bodies != 2 || len(requests) != 2 {
+		t.Errorf("expected body updates only, got titles=%d bodies=%d requests=%d", titles, bodies, len(requests))
+	}
+}
+
+func TestParseSlidesRequests(t *testing.T) {
+	reqs, err := parseSlidesRequests([]byte(`[
+		{"createParagraphBullets": {"objectId": "box", "textRange": {"type": "ALL"}}},
+		{"updateTextStyle": {"objectId": "box", "style": {"bold": true}, "fields": "bold"}}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 2 || reqs[0].CreateParagraphBullets == nil || reqs[1].UpdateTextStyle.Fields != "bold" {
+		t.Errorf("unexpected requests: %+v", reqs)
+	}
+
+	reqs, err = parseSlidesRequests([]byte(`{"requests": [{"deleteObject": {"objectId": "box"}}]}`))
+	if err != nil || len(reqs) != 1 || reqs[0].DeleteObject.ObjectId != "box" {
+		t.Errorf("expected batchUpdate body form to parse, got %+v, %v", reqs, err)
+	}
+
+	bad := map[string]string{
+		"empty":          ``,
+		"no requests":    `[]`,
+		"malformed":      `[{"deleteObject": }]`,
+		"unknown kind":   `[{"deleteObjects": {"objectId": "box"}}]`,
+		"unknown field":  `[{"deleteObject": {"objectName": "box"}}]`,
+		"empty request":  `[{}]`,
+		"trailing data":  `[{"deleteObject": {"objectId": "box"}}] []`,
+		"null request":   `[null]`,
+		"body wrong key": `{"request": []}`,
+	}
+	for name, in := range bad {
+		if _, err := parseSlidesRequests([]byte(in)); err == nil {
+			t.Errorf("%s: expected error for %q", name, in)
+		}
+	}
+}
+
+func TestParseSlidesRequests_KeepsZeroValues(t *testing.T) {
+	for _, in := range []string{
+		`[{"updateSlidesPosition": {"slideObjectIds": ["s1"], "insertionIndex": 0}},
+		  {"updateTextStyle": {"objectId": "box", "style": {"bold": false}, "fields": "bold"}}]`,
+		`{"requests": [{"updateSlidesPosition": {"slideObjectIds": ["s1"], "insertionIndex": 0}},
+		  {"updateTextStyle": {"objectId": "box", "style": {"bold": false}, "fields": "bold"}}]}`,
+	} {
+		reqs, err := parseSlidesRequests([]byte(in))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, err := json.Marshal(reqs)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		for _, want := range []string{`"insertionIndex":0`, `"bold":false`} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("expected %s to be sent, got %s", want, data)
+			}
+		}
+	}
+}
+
+func TestSlidesBatch_EchoesReplies(t *testing.T) {
+	var sent slides.BatchUpdatePresentationRequest
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-x:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{Replies: []*slides.Response{
+				{CreateShape: &slides.CreateShapeResponse{ObjectId: "new_box"}},
+				{},
+			}})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	reqs, err := parseSlidesRequests([]byte(`[
+		{"createShape": {"objectId": "new_box", "shapeType": "TEXT_BOX", "elementProperties": {"pageObjectId": "p1"}}},
+		{"insertText": {"objectId": "new_box", "text": "Hello"}}
+	]`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := runSlidesBatchWithService(svc, "pres-x", reqs, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(sent.Requests) != 2 || sent.Requests[1].InsertText.Text != "Hello" {
+		t.Errorf("expected both requests in one batch, got %+v", sent.Requests)
+	}
+	var out struct {
+		Requests int                      `json:"requests"`
+		Replies  []map[string]interface{} `json:"replies"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.Requests != 2 || len(out.Replies) != 2 {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	if shape, _ := out.Replies[0]["createShape"].(map[string]interface{}); shape["objectId"] != "new_box" {
+		t.Errorf("expected createShape reply echoed, got %v", out.Replies[0])
+	}
+}
+
+func TestParseSlideSpec(t *testing.T) {
+	spec, err := parseSlideSpec([]byte(`{
+		"title": "Q3",
+		"elements": [
+			{"type": "Shape", "shape_type": "ellipse", "x": 10, "y": 10, "width": 50, "height": 50, "style": {"alignment": "center"}},
+			{"type": "table", "rows": [["a", "b"], ["c"]], "width": 100, "height": 40}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if *spec.Title != "Q3" || spec.Body != nil {
+		t.Errorf("expected title set and body untouched, got %+v", spec)
+	}
+	if el := spec.Elements[0]; el.Type != "shape" || el.ShapeType != "ELLIPSE" || el.Style.Alignment != "CENTER" {
+		t.Errorf("expected normalized shape element, got %+v", el)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{"unknown field", `{"titel": "x"}`, "unknown field"},
+		{"missing type", `{"elements": [{"width": 1, "height": 1}]}`, "type is required"},
+		{"no size", `{"elements": [{"type": "text", "text": "x"}]}`, "width and height"},
+		{"bad color", `{"elements": [{"type": "text", "width": 1, "height": 1, "style": {"color": "red"}}]}`, "invalid hex color"},
+		{"bad shape", `{"elements": [{"type": "shape", "shape_type": "BLOB", "width": 1, "height": 1}]}`, "invalid shape_type"},
+		{"relative image", `{"elements": [{"type": "image", "url": "chart.png", "width": 1, "height": 1}]}`, "http or https"},
+		{"empty table", `{"elements": [{"type": "table", "rows": [], "width": 1, "height": 1}]}`, "at least one row"},
+		{"rows on text", `{"elements": [{"type": "text", "rows": [["a"]], "width": 1, "height": 1}]}`, "only valid for table"},
+		{"duplicate id", `{"elements": [{"type": "text", "id": "box_1", "width": 1, "height": 1}, {"type": "text", "id": "box_1", "width": 1, "height": 1}]}`, "duplicate id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSlideSpec([]byte(tt.spec))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBuildSetSlideRequests(t *testing.T) {
+	slide := &slides.Page{
+		ObjectId: "p1",
+		PageElements: []*slides.PageElement{
+			{ObjectId: "title", Shape: &slides.Shape{
+				Placeholder: &slides.Placeholder{Type: "TITLE"},
+				Text:        &slides.TextContent{TextElements: []*slides.TextElement{{TextRun: &slides.TextRun{Content: "Old\n"}}}},
+			}},
+			{ObjectId: "body", Shape: &slides.Shape{Placeholder: &slides.Placeholder{Type: "BODY"}}},
+			{ObjectId: "old_box", Shape: &slides.Shape{ShapeType: "TEXT_BOX"}},
+			{ObjectId: "old_img", Image: &slides.Image{}},
+		},
+	}
+	spec, err := parseSlideSpec([]byte(`{
+		"title": "New",
+		"body": "- a\n  - b",
+		"elements": [
+			{"type": "text", "text": "Hi", "x": 5, "y": 6, "width": 100, "height": 20, "style": {"bold": true, "fill": "#FFFFFF"}},
+			{"type": "image", "id": "chart_1", "url": "https://example.com/c.png", "width": 300, "height": 200}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	reqs, summary, err := buildSetSlideRequests(slide, spec, "gws_slide_1")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(summary.Deleted) != 2 || summary.Deleted[0] != "old_box" || summary.Deleted[1] != "old_img" {
+		t.Errorf("expected only non-placeholders deleted, got %v", summary.Deleted)
+	}
+	if reqs[0].DeleteObject == nil || reqs[1].DeleteObject == nil {
+		t.Fatal("expected deletions first")
+	}
+	if reqs[2].DeleteText == nil || reqs[2].DeleteText.ObjectId != "title" || reqs[3].InsertText.Text != "New" {
+		t.Errorf("expected title cleared then filled, got %+v %+v", reqs[2], reqs[3])
+	}
+	if reqs[4].InsertText == nil || reqs[4].InsertText.ObjectId != "body" || reqs[4].InsertText.Text != "a\n\tb" || reqs[5].CreateParagraphBullets == nil {
+		t.Errorf("expected body filled as a bulleted list, got %+v %+v", reqs[4], reqs[5])
+	}
+	shape := reqs[6].CreateShape
+	if shape == nil || shape.ObjectId != "gws_slide_1_1" || shape.ShapeType != "TEXT_BOX" || shape.ElementProperties.Transform.TranslateX != 5 {
+		t.Fatalf("unexpected text box request: %+v", reqs[6])
+	}
+	if reqs[7].UpdateShapeProperties == nil || reqs[8].InsertText.Text != "Hi" || reqs[9].UpdateTextStyle.Fields != "bold" {
+		t.Errorf("expected fill, text, and style for the text box")
+	}
+	if img := reqs[10].CreateImage; img == nil || img.ObjectId != "chart_1" || img.ElementProperties.PageObjectId != "p1" {
+		t.Errorf("unexpected image request: %+v", reqs[10])
+	}
+	if len(reqs) != 11 || summary.TitleID != "title" || summary.BodyID != "body" || len(summary.Created) != 2 {
+		t.Errorf("unexpected result: %d requests, %+v", len(reqs), summary)
+	}
+
+	if _, _, err := buildSetSlideRequests(&slides.Page{ObjectId: "p2"}, spec, "x"); err == nil || !strings.Contains(err.Error(), "no title placeholder") {
+		t.Errorf("expected missing title placeholder error, got %v", err)
+	}
+}
+
+func TestSlidesSetSlide_SendsOneBatch(t *testing.T) {
+	var sent slides.BatchUpdatePresentationRequest
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-s": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: []*slides.Page{
+				{ObjectId: "p1"},
+				{ObjectId: "p2", PageElements: []*slides.PageElement{{ObjectId: "stale", Shape: &slides.Shape{}}}},
+			}})
+		},
+		"/v1/presentations/pres-s:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	spec, err := parseSlideSpec([]byte(`{"elements": [{"type": "table", "rows": [["Region", "Revenue"], ["EMEA", 120]], "bold_header": true, "width": 300, "height": 100}]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := runSlidesSetSlideWithService(svc, "pres-s", "", 2, spec, "gws_slide_9", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runner failed: %v", err)
+	}
+	if len(sent.Requests) < 3 || sent.Requests[0].DeleteObject.ObjectId != "stale" || sent.Requests[1].CreateTable == nil {
+		t.Fatalf("expected delete then table creation, got %+v", sent.Requests)
+	}
+	if got := sent.Requests[1].CreateTable; got.ObjectId != "gws_slide_9_1" || got.ElementProperties.PageObjectId != "p2" || got.Rows != 2 {
+		t.Errorf("unexpected table request: %+v", got)
+	}
+	var out struct {
+		SlideID  string                   `json:"slide_id"`
+		Deleted  []string                 `json:"deleted"`
+		Created  []map[string]interface{} `json:"created"`
+		Requests int                      `json:"requests"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out.SlideID != "p2" || len(out.Deleted) != 1 || len(out.Created) != 1 || out.Created[0]["type"] != "table" || out.Requests != len(sent.Requests) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestSlidesSkip_OnlyChangesDifferingSlides(t *testing.T) {
+	var bodies []string
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/v1/presentations/pres-k": func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&slides.Presentation{Slides: []*slides.Page{
+				{ObjectId: "s1"},
+				{ObjectId: "s2"},
+				{ObjectId: "s3", SlideProperties: &slides.SlideProperties{IsSkipped: true}},
+				{ObjectId: "s4"},
+			}})
+		},
+		"/v1/presentations/pres-k:batchUpdate": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			json.NewEncoder(w).Encode(&slides.BatchUpdatePresentationResponse{})
+		},
+	}
+	server := mockSlidesServer(t, handlers)
+	defer server.Close()
+
+	svc, err := slides.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create slides service: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runSlidesSkipWithService(svc, "pres-k", "2-3", true, printer.New(&buf, "json")); err != nil {
+		t.Fatalf("skip failed: %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 batch update, got %d", len(bodies))
+	}
+	var sent slides.BatchUpdatePresentationRequest
+	json.Unmarshal([]byte(bodies[0]), &sent)
+	if len(sent.Requests) != 1 {
+		t.Fatalf("expected only slide 2 to change, got %d requests", len(sent.Requests))
+	}
+	if u := sent.Requests[0].UpdateSlideProperties; u.ObjectId != "s2" || !u.SlideProperties.IsSkipped || u.Fields != "isSkipped" {
+		t.Errorf("unexpected request: %+v", u)
+	}
+
+	var out struct {
+		Status string `json:"status"`
+		Slides []struct {
+			SlideNumber int  `json:"slide_number"`
+			Skipped     bool `json:"skipped"`
+			Changed     bool `json:"changed"`
+		} `json:"slides"`
+		Changed int `json:"changed"`
+	}
+	json.Unmarshal(buf.Bytes(), &out)
+	if out.Status != "skipped" || out.Changed != 1 || len(out.Slides) != 2 {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	if !out.Slides[0].Changed || out.Slides[1].Changed || !out.Slides[1].Skipped {
+		t.Errorf("unexpected per-slide results: %+v", out.Slides)
+	}
+
+	if err := runSlidesSkipWithService(svc, "pres-k", "3", false, printer.New(&bytes.Buffer{}, "json")); err != nil {
+		t.Fatalf("unskip failed: %v", err)
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[1], `"isSkipped":false`) {
+		t.Errorf("expected unskip to send isSkipped false, got %v", bodies)
+	}
+
+	if err := runSlidesSkipWithService(svc, "pres-k", "5", true, printer.New(&bytes.Buffer{}, "json")); err == nil {
+		t.Error("expected an error for a slide beyond the deck")
+	}
+}
+
+func TestSlidesSkip_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  string
+	}{
+		{"no selection", map[string]string{}, "must specify --slide-number or --slides"},
+		{"both", map[string]string{"slide-number": "2", "slides": "3"}, "mutually exclusive"},
+		{"bad range", map[string]string{"slides": "5-2"}, "invalid --slides"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := findSubcommand(slidesCmd, "skip")
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			defer func() {
+				for _, name := range []string{"slide-number", "slides", "unskip"} {
+					f := cmd.Flags().Lookup(name)
+					f.Value.Set(f.DefValue)
+					f.Changed = false
+				}
+			}()
+			err := runSlidesSkip(cmd, []string{"pres"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBuildCloneRequests_KeepsSlideOrder(t *testing.T) {
+	src := &slides.Presentation{Slides: []*slides.Page{{ObjectId: "s1"}, {ObjectId: "s2"}, {ObjectId: "s3"}}}
+	created := &slides.Presentation{Slides: []*slides.Page{{ObjectId: "default"}}}
+	m := buildCloneRequests(src, created, "gws_clone_test", nil)
+
+	// Replay the requests as the API would: a createSlide without an
+	// insertionIndex goes to the end of the deck.
+	data, err := json.Marshal(m.requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requests []map[string]map[string]interface{}
+	json.Unmarshal(data, &requests)
+	deck := []string{"default"}
+	for _, r := range requests {
+		if c, ok := r["createSlide"]; ok {
+			id := c["objectId"].(string)
+			idx, ok := c["insertionIndex"].(float64)
+			if !ok {
+				idx = float64(len(deck))
+			}
+			deck = append(deck[:int(idx)], append([]string{id}, deck[int(idx):]...)...)
+		}
+		if d, ok := r["deleteObject"]; ok {
+			for i, id := range deck {
+				if id == d["objectId"] {
+					deck = append(deck[:i], deck[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	if !reflect.DeepEqual(deck, m.slideIDs) {
+		t.Errorf("cloned deck order = %v, want %v", deck, m.slideIDs)
+	}
+}
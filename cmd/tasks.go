@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
+	"sort"
 	"time"
 
 	"github.com/omriariav/workspace-cli/internal/client"
+	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
 	"google.golang.org/api/tasks/v1"
 )
@@ -142,6 +146,44 @@ var tasksClearCmd = &cobra.Command{
 	RunE:  runTasksClear,
 }
 
+var tasksExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export task lists and their tasks as JSON",
+	Long: `Exports every task list (or just --list) with all of its tasks, including
+completed and hidden ones, as a JSON document for backup or migration. Each
+task keeps its title, notes, status, due date, completion time, parent, and
+position, so the subtask hierarchy and order can be rebuilt by import.
+
+Without --output the document is printed; with --output it is written to the
+file and a summary is printed.
+
+Examples:
+  gws tasks export --output tasks.json
+  gws tasks export --list MTIzNDU2 --output work.json`,
+	Args: cobra.NoArgs,
+	RunE: runTasksExport,
+}
+
+var tasksImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Recreate task lists and tasks from an export",
+	Long: `Recreates the task lists and tasks in a file written by "tasks export".
+Each exported list becomes a new task list with the same title, or all
+tasks go into an existing list with --into.
+
+Parents are created before their subtasks and siblings keep their exported
+order; exported task IDs are mapped to the new IDs as tasks are created.
+A subtask whose parent is missing from the file is created at the top level.
+Completed tasks are recreated as completed; completion times are set anew
+by the server.
+
+Examples:
+  gws tasks import --file tasks.json
+  gws tasks import --file work.json --into @default`,
+	Args: cobra.NoArgs,
+	RunE: runTasksImport,
+}
+
 func init() {
 	rootCmd.AddCommand(tasksCmd)
 	tasksCmd.AddCommand(tasksListsCmd)
@@ -157,6 +199,8 @@ func init() {
 	tasksCmd.AddCommand(tasksDeleteCmd)
 	tasksCmd.AddCommand(tasksMoveCmd)
 	tasksCmd.AddCommand(tasksClearCmd)
+	tasksCmd.AddCommand(tasksExportCmd)
+	tasksCmd.AddCommand(tasksImportCmd)
 
 	// Update flags
 	tasksUpdateCmd.Flags().String("title", "", "New task title")
@@ -186,6 +230,15 @@ func init() {
 	tasksMoveCmd.Flags().String("parent", "", "Parent task ID (makes this a subtask)")
 	tasksMoveCmd.Flags().String("previous", "", "Previous sibling task ID (positions after this task)")
 	tasksMoveCmd.Flags().String("destination-list", "", "Destination task list ID (moves to another list)")
+
+	// Export flags
+	tasksExportCmd.Flags().String("list", "", "Export only this task list ID (default: all lists)")
+	tasksExportCmd.Flags().String("output", "", "Write the export to this file instead of printing it")
+
+	// Import flags
+	tasksImportCmd.Flags().String("file", "", "Export file to import (required)")
+	tasksImportCmd.Flags().String("into", "", "Import every task into this existing task list instead of creating lists")
+	tasksImportCmd.MarkFlagRequired("file")
 }
 
 func runTasksLists(cmd *cobra.Command, args []string) error {
@@ -698,3 +751,276 @@ func runTasksComplete(cmd *cobra.Command, args []string) error {
 		"title":  updated.Title,
 	})
 }
+
+// taskExportVersion is the format version written by tasks export.
+const taskExportVersion = 1
+
+// taskListExport is one task list in an export file.
+type taskListExport struct {
+	ID    string           `json:"id"`
+	Title string           `json:"title"`
+	Tasks []taskExportItem `json:"tasks"`
+}
+
+// taskExportItem is one task in an export file. Parent refers to the
+// exported ID of another task in the same list.
+type taskExportItem struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Notes     string `json:"notes,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Due       string `json:"due,omitempty"`
+	Completed string `json:"completed,omitempty"`
+	Parent    string `json:"parent,omitempty"`
+	Position  string `json:"position,omitempty"`
+}
+
+// taskExportFile is the document read by tasks import.
+type taskExportFile struct {
+	Version   int              `json:"version"`
+	TaskLists []taskListExport `json:"tasklists"`
+}
+
+// exportTaskLists reads the given lists (all lists when listID is empty)
+// with every task, including completed and hidden ones.
+func exportTaskLists(svc *tasks.Service, listID string) ([]taskListExport, error) {
+	var lists []*tasks.TaskList
+	if listID != "" {
+		list, err := svc.Tasklists.Get(listID).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task list: %w", err)
+		}
+		lists = append(lists, list)
+	} else {
+		err := svc.Tasklists.List().MaxResults(100).Pages(context.Background(), func(resp *tasks.TaskLists) error {
+			lists = append(lists, resp.Items...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list task lists: %w", err)
+		}
+	}
+
+	exported := make([]taskListExport, 0, len(lists))
+	for _, list := range lists {
+		items := []taskExportItem{}
+		err := svc.Tasks.List(list.Id).MaxResults(100).ShowCompleted(true).ShowHidden(true).
+			Pages(context.Background(), func(resp *tasks.Tasks) error {
+				for _, t := range resp.Items {
+					if t.Deleted {
+						continue
+					}
+					item := taskExportItem{
+						ID:       t.Id,
+						Title:    t.Title,
+						Notes:    t.Notes,
+						Status:   t.Status,
+						Due:      t.Due,
+						Parent:   t.Parent,
+						Position: t.Position,
+					}
+					if t.Completed != nil {
+						item.Completed = *t.Completed
+					}
+					items = append(items, item)
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks in %s: %w", list.Title, err)
+		}
+		exported = append(exported, taskListExport{ID: list.Id, Title: list.Title, Tasks: items})
+	}
+	return exported, nil
+}
+
+// orderTasksForImport returns the tasks with every parent before its
+// subtasks and siblings in position order. Tasks whose parent is not in
+// the list are treated as top level.
+func orderTasksForImport(items []taskExportItem) []taskExportItem {
+	known := make(map[string]bool, len(items))
+	for _, t := range items {
+		known[t.ID] = true
+	}
+	children := make(map[string][]taskExportItem)
+	for _, t := range items {
+		parent := t.Parent
+		if !known[parent] || parent == t.ID {
+			parent = ""
+		}
+		children[parent] = append(children[parent], t)
+	}
+
+	ordered := make([]taskExportItem, 0, len(items))
+	visited := make(map[string]bool, len(items))
+	var walk func(parent string)
+	walk = func(parent string) {
+		siblings := children[parent]
+		sort.SliceStable(siblings, func(i, j int) bool { return siblings[i].Position < siblings[j].Position })
+		for _, t := range siblings {
+			if visited[t.ID] {
+				continue
+			}
+			visited[t.ID] = true
+			if !known[t.Parent] || t.Parent == t.ID {
+				t.Parent = ""
+			}
+			ordered = append(ordered, t)
+			walk(t.ID)
+		}
+	}
+	walk("")
+	return ordered
+}
+
+// importTasks creates items in listID in parent-first order and returns
+// how many were created. Old IDs are mapped to new ones so subtasks land
+// under their recreated parents, after their previous sibling.
+func importTasks(svc *tasks.Service, listID string, items []taskExportItem) (int, error) {
+	newIDs := make(map[string]string, len(items))
+	lastChild := make(map[string]string)
+	created := 0
+	for _, t := range orderTasksForImport(items) {
+		task := &tasks.Task{Title: t.Title, Notes: t.Notes, Due: t.Due}
+		if t.Status == "completed" {
+			task.Status = "completed"
+		}
+		call := svc.Tasks.Insert(listID, task)
+		parent := ""
+		if t.Parent != "" {
+			parent = newIDs[t.Parent]
+			call = call.Parent(parent)
+		}
+		if prev := lastChild[parent]; prev != "" {
+			call = call.Previous(prev)
+		}
+		newTask, err := call.Do()
+		if err != nil {
+			return created, fmt.Errorf("failed to create task %q: %w", t.Title, err)
+		}
+		newIDs[t.ID] = newTask.Id
+		lastChild[parent] = newTask.Id
+		created++
+	}
+	return created, nil
+}
+
+func runTasksExport(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	listID, _ := cmd.Flags().GetString("list")
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Tasks()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	lists, err := exportTaskLists(svc, listID)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	taskCount := 0
+	for _, list := range lists {
+		taskCount += len(list.Tasks)
+	}
+	doc := map[string]interface{}{
+		"version":     taskExportVersion,
+		"exported_at": time.Now().UTC().Format(time.RFC3339),
+		"tasklists":   lists,
+	}
+
+	if outputPath == "" {
+		return p.Print(doc)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to encode export: %w", err))
+	}
+	if err := os.WriteFile(outputPath, append(data, '\n'), 0600); err != nil {
+		return p.PrintError(fmt.Errorf("failed to write file: %w", err))
+	}
+	return p.Print(map[string]interface{}{
+		"status":    "exported",
+		"file":      outputPath,
+		"tasklists": len(lists),
+		"tasks":     taskCount,
+	})
+}
+
+func runTasksImport(cmd *cobra.Command, args []string) error {
+	p := GetPrinter()
+	ctx := context.Background()
+
+	filePath, _ := cmd.Flags().GetString("file")
+	into, _ := cmd.Flags().GetString("into")
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return p.PrintError(fmt.Errorf("failed to read file %s: %w", filePath, err))
+	}
+	var doc taskExportFile
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return usageErrorf("invalid export file %s: %v", filePath, err)
+	}
+	if doc.Version > taskExportVersion {
+		return usageErrorf("export file version %d is newer than this gws supports (%d)", doc.Version, taskExportVersion)
+	}
+	if len(doc.TaskLists) == 0 {
+		return usageErrorf("export file %s has no task lists", filePath)
+	}
+
+	factory, err := client.NewFactory(ctx)
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	svc, err := factory.Tasks()
+	if err != nil {
+		return p.PrintError(err)
+	}
+
+	return runTasksImportWithService(svc, doc, into, p)
+}
+
+// runTasksImportWithService recreates the exported lists (or fills into)
+// and reports the new list IDs with per-list task counts.
+func runTasksImportWithService(svc *tasks.Service, doc taskExportFile, into string, p printer.Printer) error {
+	results := make([]map[string]interface{}, 0, len(doc.TaskLists))
+	total := 0
+	for _, list := range doc.TaskLists {
+		listID := into
+		if listID == "" {
+			newList, err := svc.Tasklists.Insert(&tasks.TaskList{Title: list.Title}).Do()
+			if err != nil {
+				return p.PrintError(fmt.Errorf("failed to create task list %q: %w", list.Title, err))
+			}
+			listID = newList.Id
+		}
+		created, err := importTasks(svc, listID, list.Tasks)
+		total += created
+		if err != nil {
+			return p.PrintError(fmt.Errorf("%w (%d tasks imported before the failure)", err, total))
+		}
+		results = append(results, map[string]interface{}{
+			"title":  list.Title,
+			"old_id": list.ID,
+			"id":     listID,
+			"tasks":  created,
+		})
+	}
+
+	return p.Print(map[string]interface{}{
+		"status":    "imported",
+		"tasklists": results,
+		"tasks":     total,
+	})
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/omriariav/workspace-cli/internal/printer"
 	"github.com/spf13/cobra"
 	"google.golang.org/api/option"
 	"google.golang.org/api/tasks/v1"
@@ -650,3 +652,92 @@ func TestTasksUpdate_MockServer(t *testing.T) {
 		t.Errorf("unexpected updated title: %s", updated.Title)
 	}
 }
+
+func TestOrderTasksForImport(t *testing.T) {
+	items := []taskExportItem{
+		{ID: "c2", Title: "child 2", Parent: "p1", Position: "00000000000000000001"},
+		{ID: "p2", Title: "parent 2", Position: "00000000000000000001"},
+		{ID: "g1", Title: "grandchild", Parent: "c1", Position: "00000000000000000000"},
+		{ID: "c1", Title: "child 1", Parent: "p1", Position: "00000000000000000000"},
+		{ID: "p1", Title: "parent 1", Position: "00000000000000000000"},
+		{ID: "o1", Title: "orphan", Parent: "gone", Position: "00000000000000000002"},
+	}
+	var got []string
+	for _, t := range orderTasksForImport(items) {
+		got = append(got, t.ID+":"+t.Parent)
+	}
+	want := []string{"p1:", "c1:p1", "g1:c1", "c2:p1", "p2:", "o1:"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTasksImport_MapsParentsAndOrder(t *testing.T) {
+	type insert struct{ title, parent, previous string }
+	var inserts []insert
+	next := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/tasks/v1/users/@me/lists":
+			json.NewEncoder(w).Encode(&tasks.TaskList{Id: "new-list", Title: "Work"})
+		case r.Method == "POST" && r.URL.Path == "/tasks/v1/lists/new-list/tasks":
+			var task tasks.Task
+			json.NewDecoder(r.Body).Decode(&task)
+			inserts = append(inserts, insert{task.Title, r.URL.Query().Get("parent"), r.URL.Query().Get("previous")})
+			next++
+			json.NewEncoder(w).Encode(&tasks.Task{Id: "n" + string(rune('0'+next)), Title: task.Title})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc, err := tasks.NewService(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create tasks service: %v", err)
+	}
+
+	doc := taskExportFile{Version: 1, TaskLists: []taskListExport{{
+		ID:    "old-list",
+		Title: "Work",
+		Tasks: []taskExportItem{
+			{ID: "c1", Title: "Draft", Parent: "p1", Position: "1"},
+			{ID: "p1", Title: "Report", Position: "1"},
+			{ID: "p2", Title: "Review", Position: "2", Status: "completed"},
+			{ID: "c0", Title: "Outline", Parent: "p1", Position: "0"},
+		},
+	}}}
+
+	var buf bytes.Buffer
+	if err := runTasksImportWithService(svc, doc, "", printer.New(&buf, "json")); err != nil {
+		t.Fatalf("runTasksImportWithService: %v", err)
+	}
+
+	want := []insert{
+		{"Report", "", ""},
+		{"Outline", "n1", ""},
+		{"Draft", "n1", "n2"},
+		{"Review", "", "n1"},
+	}
+	if len(inserts) != len(want) {
+		t.Fatalf("inserts = %v, want %v", inserts, want)
+	}
+	for i := range want {
+		if inserts[i] != want[i] {
+			t.Errorf("insert %d = %+v, want %+v", i, inserts[i], want[i])
+		}
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if out["tasks"] != float64(4) || out["status"] != "imported" {
+		t.Errorf("unexpected output: %v", out)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/omriariav/workspace-cli/internal/client"
 	"github.com/omriariav/workspace-cli/internal/config"
 	"github.com/omriariav/workspace-cli/internal/updatecheck"
 	"github.com/spf13/cobra"
@@ -71,6 +72,11 @@ var versionCmd = &cobra.Command{
 		out := cmd.OutOrStdout()
 		fmt.Fprintln(out)
 
+		if client.IsOffline(cmd.Context()) {
+			fmt.Fprintln(out, "update check: skipped (offline)")
+			return nil
+		}
+
 		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
 		defer cancel()
 
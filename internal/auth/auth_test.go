@@ -2,6 +2,7 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"github.com/omriariav/workspace-cli/internal/config"
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
 	"golang.org/x/oauth2"
 )
 
@@ -316,6 +319,128 @@ func TestLoadToken_NotExists(t *testing.T) {
 	}
 }
 
+// useTokenStore selects a --token-store for one test.
+func useTokenStore(t *testing.T, store string) {
+	t.Helper()
+	viper.Set(config.KeyTokenStore, store)
+	t.Cleanup(func() { viper.Set(config.KeyTokenStore, "") })
+}
+
+func TestKeyringStore_SaveLoadDelete(t *testing.T) {
+	cleanup := setupTempConfigDir(t)
+	defer cleanup()
+	keyring.MockInit()
+	useTokenStore(t, config.TokenStoreKeyring)
+
+	token := &oauth2.Token{AccessToken: "kr-access", RefreshToken: "kr-refresh", TokenType: "Bearer"}
+	if err := SaveToken(token); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	if _, err := os.Stat(config.GetTokenPath()); !os.IsNotExist(err) {
+		t.Errorf("expected no token file with the keyring store, got err=%v", err)
+	}
+	if !TokenExists() {
+		t.Error("TokenExists should see the keyring token")
+	}
+
+	loaded, err := LoadToken()
+	if err != nil {
+		t.Fatalf("failed to load token: %v", err)
+	}
+	if loaded.AccessToken != "kr-access" || loaded.RefreshToken != "kr-refresh" {
+		t.Errorf("unexpected token: %+v", loaded)
+	}
+
+	if err := DeleteToken(); err != nil {
+		t.Fatalf("failed to delete token: %v", err)
+	}
+	if TokenExists() {
+		t.Error("expected no token after DeleteToken")
+	}
+	if _, err := LoadToken(); !errors.Is(err, ErrNotAuthenticated) {
+		t.Errorf("expected ErrNotAuthenticated, got %v", err)
+	}
+}
+
+func TestKeyringStore_MovesTokenFileIntoKeyring(t *testing.T) {
+	cleanup := setupTempConfigDir(t)
+	defer cleanup()
+	keyring.MockInit()
+
+	if err := SaveToken(&oauth2.Token{AccessToken: "from-file", RefreshToken: "r"}); err != nil {
+		t.Fatalf("failed to save file token: %v", err)
+	}
+
+	useTokenStore(t, config.TokenStoreKeyring)
+	loaded, err := LoadToken()
+	if err != nil || loaded.AccessToken != "from-file" {
+		t.Fatalf("expected the existing token file to be read, got %+v (err=%v)", loaded, err)
+	}
+	if err := SaveToken(loaded); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	if _, err := os.Stat(config.GetTokenPath()); !os.IsNotExist(err) {
+		t.Errorf("expected the token file removed after saving to the keyring, got err=%v", err)
+	}
+	if data, err := keyring.Get(keyringService, keyringUser); err != nil || !strings.Contains(data, "from-file") {
+		t.Errorf("expected the token in the keyring, got %q (err=%v)", data, err)
+	}
+}
+
+func TestKeyringStore_SeparatesProfiles(t *testing.T) {
+	cleanup := setupTempConfigDir(t)
+	defer cleanup()
+	keyring.MockInit()
+	useTokenStore(t, config.TokenStoreKeyring)
+
+	config.SetProfile("work")
+	t.Cleanup(func() { config.SetProfile("") })
+	if err := SaveToken(&oauth2.Token{AccessToken: "work-access"}); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	if data, err := keyring.Get(keyringService, keyringUser+":work"); err != nil || !strings.Contains(data, "work-access") {
+		t.Errorf("expected the work token under its own entry, got %q (err=%v)", data, err)
+	}
+
+	config.SetProfile("")
+	if _, err := LoadToken(); !errors.Is(err, ErrNotAuthenticated) {
+		t.Errorf("expected the default profile to have no token, got %v", err)
+	}
+}
+
+func TestKeyringStore_Unavailable(t *testing.T) {
+	cleanup := setupTempConfigDir(t)
+	defer cleanup()
+	keyring.MockInitWithError(errors.New("secret service not running"))
+	t.Cleanup(keyring.MockInit)
+	useTokenStore(t, config.TokenStoreKeyring)
+
+	_, err := LoadToken()
+	if err == nil || errors.Is(err, ErrNotAuthenticated) || !strings.Contains(err.Error(), "keyring") {
+		t.Errorf("expected a keyring read error, got %v", err)
+	}
+	err = SaveToken(&oauth2.Token{AccessToken: "a"})
+	if err == nil || !strings.Contains(err.Error(), "--token-store file") {
+		t.Errorf("expected a save error pointing at --token-store file, got %v", err)
+	}
+	if _, statErr := os.Stat(config.GetTokenPath()); !os.IsNotExist(statErr) {
+		t.Error("expected no plaintext fallback file when the keyring fails")
+	}
+}
+
+func TestInvalidTokenStore(t *testing.T) {
+	cleanup := setupTempConfigDir(t)
+	defer cleanup()
+	useTokenStore(t, "vault")
+
+	if _, err := LoadToken(); err == nil || !strings.Contains(err.Error(), "invalid token store") {
+		t.Errorf("expected invalid token store error, got %v", err)
+	}
+	if err := SaveToken(&oauth2.Token{AccessToken: "a"}); err == nil {
+		t.Error("expected SaveToken to reject an invalid token store")
+	}
+}
+
 func TestLoadToken_InvalidJSON(t *testing.T) {
 	cleanup := setupTempConfigDir(t)
 	defer cleanup()
@@ -2,12 +2,14 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/omriariav/workspace-cli/internal/config"
+	"github.com/zalando/go-keyring"
 	"golang.org/x/oauth2"
 )
 
@@ -18,18 +20,70 @@ const (
 	staleLockAge     = 30 * time.Second
 )
 
-// LoadToken loads the OAuth token from the token file.
+// ErrNotAuthenticated is returned by LoadToken when no token is stored.
+var ErrNotAuthenticated = errors.New("not authenticated, run: gws auth login")
+
+// keyringService and keyringUser identify the token entry in the OS secret
+// store (macOS Keychain, Linux Secret Service, Windows Credential Manager).
+const (
+	keyringService = "gws"
+	keyringUser    = "oauth-token"
+)
+
+// keyringAccount returns the keyring entry for the active profile; the
+// default profile keeps the original entry name.
+func keyringAccount() string {
+	if profile := config.GetProfile(); profile != "" {
+		return keyringUser + ":" + profile
+	}
+	return keyringUser
+}
+
+// useKeyring reports whether the configured --token-store is the OS secret
+// store rather than the token file.
+func useKeyring() (bool, error) {
+	switch store := config.GetTokenStore(); store {
+	case config.TokenStoreFile:
+		return false, nil
+	case config.TokenStoreKeyring:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid token store %q: must be %s or %s", store, config.TokenStoreFile, config.TokenStoreKeyring)
+	}
+}
+
+// LoadToken loads the OAuth token from the configured token store. With the
+// keyring store, a token file left from before the switch is still read;
+// the next SaveToken moves it into the keyring.
 func LoadToken() (*oauth2.Token, error) {
+	keyringStore, err := useKeyring()
+	if err != nil {
+		return nil, err
+	}
+	if keyringStore {
+		data, err := keyring.Get(keyringService, keyringAccount())
+		if err == nil {
+			return parseToken([]byte(data))
+		}
+		if !errors.Is(err, keyring.ErrNotFound) {
+			return nil, fmt.Errorf("failed to read token from keyring: %w", err)
+		}
+	}
+
 	tokenPath := config.GetTokenPath()
 
 	data, err := os.ReadFile(tokenPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("not authenticated, run: gws auth login")
+			return nil, ErrNotAuthenticated
 		}
 		return nil, fmt.Errorf("failed to read token: %w", err)
 	}
+	return parseToken(data)
+}
 
+// parseToken decodes a stored token.
+func parseToken(data []byte) (*oauth2.Token, error) {
 	var token oauth2.Token
 	if err := json.Unmarshal(data, &token); err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -38,9 +92,27 @@ func LoadToken() (*oauth2.Token, error) {
 	return &token, nil
 }
 
-// SaveToken saves the OAuth token to the token file with secure permissions.
-// Uses atomic write (temp file + rename) and file locking for safety.
+// SaveToken saves the OAuth token to the configured token store. The token
+// file is written with secure permissions, using atomic write (temp file +
+// rename) and file locking for safety. With the keyring store, any token
+// file is removed once the keyring holds the token, so no plaintext copy is
+// left on disk.
 func SaveToken(token *oauth2.Token) error {
+	keyringStore, err := useKeyring()
+	if err != nil {
+		return err
+	}
+	if keyringStore {
+		data, err := json.Marshal(token)
+		if err != nil {
+			return fmt.Errorf("failed to marshal token: %w", err)
+		}
+		if err := keyring.Set(keyringService, keyringAccount(), string(data)); err != nil {
+			return fmt.Errorf("failed to save token to keyring (use --token-store file to keep it on disk): %w", err)
+		}
+		return deleteTokenFile()
+	}
+
 	if err := config.EnsureConfigDir(); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
@@ -110,9 +182,24 @@ func MergeToken(existing, incoming *oauth2.Token) *oauth2.Token {
 	return incoming
 }
 
-// DeleteToken removes the token file.
-// Uses file locking to coordinate with SaveToken.
+// DeleteToken removes the stored token: the keyring entry when the keyring
+// store is configured, and the token file in either case.
 func DeleteToken() error {
+	keyringStore, err := useKeyring()
+	if err != nil {
+		return err
+	}
+	if keyringStore {
+		if err := keyring.Delete(keyringService, keyringAccount()); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("failed to delete token from keyring: %w", err)
+		}
+	}
+	return deleteTokenFile()
+}
+
+// deleteTokenFile removes the token file.
+// Uses file locking to coordinate with SaveToken.
+func deleteTokenFile() error {
 	tokenPath := config.GetTokenPath()
 
 	// Check if the token file even exists before locking
@@ -160,8 +247,14 @@ func LoadGrantedServices() []string {
 	return services
 }
 
-// TokenExists checks if a token file exists.
+// TokenExists checks if a token is stored, in the keyring (with the keyring
+// store) or the token file.
 func TokenExists() bool {
+	if keyringStore, _ := useKeyring(); keyringStore {
+		if _, err := keyring.Get(keyringService, keyringAccount()); err == nil {
+			return true
+		}
+	}
 	tokenPath := config.GetTokenPath()
 	_, err := os.Stat(tokenPath)
 	return err == nil
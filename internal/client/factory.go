@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -49,10 +50,109 @@ type Factory struct {
 	driveActivity *driveactivity.Service
 }
 
-// NewFactory creates a new client factory.
+// ErrOffline is returned by NewFactory when network access is disabled.
+var ErrOffline = errors.New("network access is disabled (--offline); only cache-backed commands are available")
+
+type offlineKey struct{}
+
+// WithOffline returns a copy of ctx that marks network access as disabled.
+func WithOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineKey{}, true)
+}
+
+// IsOffline reports whether network access is disabled, either on ctx via
+// WithOffline or globally via the --offline flag / GWS_OFFLINE.
+func IsOffline(ctx context.Context) bool {
+	if ctx != nil {
+		if v, _ := ctx.Value(offlineKey{}).(bool); v {
+			return true
+		}
+	}
+	return config.IsOffline()
+}
+
+// ErrReauthRequired matches (via errors.Is) the error NewFactory returns when
+// there is no stored token or the stored token can no longer be refreshed,
+// e.g. because it was revoked. The fix is always `gws auth login`.
+var ErrReauthRequired = errors.New("re-authentication required")
+
+// ReauthError explains why the stored credentials cannot be used.
+type ReauthError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ReauthError) Error() string {
+	return e.Reason + "; run: gws auth login"
+}
+
+func (e *ReauthError) Unwrap() error { return e.Err }
+
+func (e *ReauthError) Is(target error) bool { return target == ErrReauthRequired }
+
+// ReauthHandler, when set, is called once when NewFactory hits
+// ErrReauthRequired; if it returns nil, NewFactory tries again. The CLI sets
+// it for --interactive to run the login flow in place.
+var ReauthHandler func(ctx context.Context) error
+
+// sharing holds the process-wide factory reused across commands while
+// EnableSharing is in effect (see `gws run`).
+var sharing struct {
+	mu      sync.Mutex
+	enabled bool
+	factory *Factory
+}
+
+// EnableSharing makes NewFactory build one factory on first use and return it
+// to every later caller, so a process running many commands authenticates
+// once. The returned function turns sharing off and drops the cached factory.
+func EnableSharing() (disable func()) {
+	sharing.mu.Lock()
+	sharing.enabled = true
+	sharing.mu.Unlock()
+	return func() {
+		sharing.mu.Lock()
+		sharing.enabled = false
+		sharing.factory = nil
+		sharing.mu.Unlock()
+	}
+}
+
+// NewFactory creates a new client factory. It fails fast with ErrOffline
+// when network access is disabled. While EnableSharing is in effect, the
+// first successfully created factory is returned to every caller.
 func NewFactory(ctx context.Context) (*Factory, error) {
+	if IsOffline(ctx) {
+		return nil, ErrOffline
+	}
+
+	sharing.mu.Lock()
+	defer sharing.mu.Unlock()
+	if sharing.enabled && sharing.factory != nil {
+		return sharing.factory, nil
+	}
+	f, err := newFactory(ctx)
+	if errors.Is(err, ErrReauthRequired) && ReauthHandler != nil {
+		if herr := ReauthHandler(ctx); herr != nil {
+			return nil, fmt.Errorf("%w (login failed: %v)", err, herr)
+		}
+		f, err = newFactory(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sharing.enabled {
+		sharing.factory = f
+	}
+	return f, nil
+}
+
+func newFactory(ctx context.Context) (*Factory, error) {
 	token, err := auth.LoadToken()
 	if err != nil {
+		if errors.Is(err, auth.ErrNotAuthenticated) {
+			return nil, &ReauthError{Reason: "not authenticated", Err: err}
+		}
 		return nil, err
 	}
 
@@ -68,7 +168,7 @@ func NewFactory(ctx context.Context) (*Factory, error) {
 	// Check if token is valid by trying to get a token
 	newToken, err := ts.Token()
 	if err != nil {
-		return nil, fmt.Errorf("token expired, run: gws auth login")
+		return nil, refreshError(err)
 	}
 
 	// Save refreshed token if it changed
@@ -84,6 +184,22 @@ func NewFactory(ctx context.Context) (*Factory, error) {
 	}, nil
 }
 
+// refreshError classifies a token refresh failure. An OAuth error response
+// from Google (invalid_grant and friends) means the refresh token was revoked
+// or expired and only a new login helps; anything else, like a network
+// failure, is reported as-is so it can be retried.
+func refreshError(err error) error {
+	var rerr *oauth2.RetrieveError
+	if errors.As(err, &rerr) {
+		reason := "stored credentials were revoked or have expired"
+		if rerr.ErrorCode != "" {
+			reason += " (" + rerr.ErrorCode + ")"
+		}
+		return &ReauthError{Reason: reason, Err: err}
+	}
+	return fmt.Errorf("failed to refresh token: %w", err)
+}
+
 // serviceAliases maps every accepted alias for a service to its canonical
 // name. Aliases share the same OAuth scope set in auth.ServiceScopes — see
 // `people` ↔ `contacts` (both surface the People API).
@@ -368,6 +484,12 @@ func (f *Factory) DriveActivity() (*driveactivity.Service, error) {
 	return svc, nil
 }
 
+// TokenSource returns the OAuth token source the service clients use.
+// Calling Token on it refreshes an expired access token.
+func (f *Factory) TokenSource() oauth2.TokenSource {
+	return f.tokenSource
+}
+
 // People returns the People API service client. Used by Contacts commands
 // and any path that needs the directory/contacts scopes.
 func (f *Factory) People() (*people.Service, error) {
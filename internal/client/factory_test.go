@@ -3,11 +3,14 @@ package client
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"os"
 	"strings"
 	"sync"
 	"testing"
+
+	"golang.org/x/oauth2"
 )
 
 // captureStderr swaps os.Stderr for a pipe, runs fn, and returns whatever was
@@ -112,3 +115,83 @@ func TestPeopleProfile_NoWarnEvenAfterPeopleWarned(t *testing.T) {
 		t.Errorf("PeopleProfile must not warn even after People warned; got %q", out)
 	}
 }
+
+func TestNewFactory_OfflineFailsFast(t *testing.T) {
+	_, err := NewFactory(WithOffline(context.Background()))
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected ErrOffline, got %v", err)
+	}
+}
+
+func TestNewFactory_SharingReusesFactory(t *testing.T) {
+	disable := EnableSharing()
+	cached := &Factory{scopeWarned: make(map[string]bool)}
+	sharing.factory = cached
+
+	f, err := NewFactory(context.Background())
+	if err != nil {
+		t.Fatalf("NewFactory: %v", err)
+	}
+	if f != cached {
+		t.Error("expected the shared factory to be returned")
+	}
+
+	disable()
+	if sharing.enabled || sharing.factory != nil {
+		t.Error("disable must turn sharing off and drop the cached factory")
+	}
+}
+
+func TestIsOffline_Context(t *testing.T) {
+	if IsOffline(context.Background()) {
+		t.Error("plain context must not be offline")
+	}
+	if !IsOffline(WithOffline(context.Background())) {
+		t.Error("WithOffline context must be offline")
+	}
+}
+
+func TestNewFactory_MissingTokenRequiresReauth(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, err := NewFactory(context.Background())
+	if !errors.Is(err, ErrReauthRequired) {
+		t.Fatalf("expected ErrReauthRequired, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gws auth login") {
+		t.Errorf("expected login hint in %q", err.Error())
+	}
+}
+
+func TestNewFactory_ReauthHandlerCalledOnce(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	calls := 0
+	ReauthHandler = func(ctx context.Context) error {
+		calls++
+		return errors.New("user closed the browser")
+	}
+	defer func() { ReauthHandler = nil }()
+
+	_, err := NewFactory(context.Background())
+	if calls != 1 {
+		t.Errorf("expected handler to be called once, got %d", calls)
+	}
+	if !errors.Is(err, ErrReauthRequired) || !strings.Contains(err.Error(), "user closed the browser") {
+		t.Errorf("expected reauth error with login failure, got %v", err)
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	revoked := refreshError(&oauth2.RetrieveError{ErrorCode: "invalid_grant"})
+	if !errors.Is(revoked, ErrReauthRequired) {
+		t.Errorf("expected OAuth error response to require reauth, got %v", revoked)
+	}
+	if !strings.Contains(revoked.Error(), "invalid_grant") {
+		t.Errorf("expected error code in message, got %q", revoked.Error())
+	}
+
+	network := refreshError(errors.New("dial tcp: connection refused"))
+	if errors.Is(network, ErrReauthRequired) {
+		t.Errorf("network failure must not require reauth, got %v", network)
+	}
+}
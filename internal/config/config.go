@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -18,6 +22,14 @@ const (
 	KeyClientSecret = "client_secret"
 	KeyFormat       = "format"
 	KeyServices     = "services"
+	KeyOffline      = "offline"
+	KeyTokenStore   = "token_store"
+)
+
+// Token stores selectable with --token-store.
+const (
+	TokenStoreFile    = "file"
+	TokenStoreKeyring = "keyring"
 )
 
 // GetClientID returns the OAuth client ID from config or environment.
@@ -44,6 +56,48 @@ func GetServices() []string {
 	return viper.GetStringSlice(KeyServices)
 }
 
+// IsOffline reports whether network access is disabled (--offline or GWS_OFFLINE).
+func IsOffline() bool {
+	return viper.GetBool(KeyOffline)
+}
+
+// GetTokenStore returns where OAuth tokens are kept (--token-store or
+// GWS_TOKEN_STORE), defaulting to the token file.
+func GetTokenStore() string {
+	store := strings.ToLower(strings.TrimSpace(viper.GetString(KeyTokenStore)))
+	if store == "" {
+		return TokenStoreFile
+	}
+	return store
+}
+
+// profilePattern matches profile names, which become a directory name.
+var profilePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// activeProfile is the profile selected with --profile or GWS_PROFILE. It is
+// set once at startup instead of being read through viper, so a profile key
+// in a config file cannot switch directories after that file was read.
+var activeProfile string
+
+// SetProfile selects the active profile; "" is the default profile.
+func SetProfile(name string) {
+	activeProfile = strings.TrimSpace(name)
+}
+
+// GetProfile returns the active profile, or "" for the default profile.
+func GetProfile() string {
+	return activeProfile
+}
+
+// ValidateProfile checks that name is usable as a profile: letters, digits,
+// dots, dashes, and underscores, starting with a letter or digit.
+func ValidateProfile(name string) error {
+	if name != "" && !profilePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile %q: use up to 64 letters, digits, '.', '-', or '_', starting with a letter or digit", name)
+	}
+	return nil
+}
+
 // SetDefaults sets default configuration values.
 func SetDefaults() {
 	viper.SetDefault(KeyFormat, "json")
@@ -137,6 +137,19 @@ func TestGetClientSecret(t *testing.T) {
 	}
 }
 
+func TestGetTokenStore(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	if store := GetTokenStore(); store != TokenStoreFile {
+		t.Errorf("expected default '%s', got '%s'", TokenStoreFile, store)
+	}
+	viper.Set(KeyTokenStore, " Keyring ")
+	if store := GetTokenStore(); store != TokenStoreKeyring {
+		t.Errorf("expected '%s', got '%s'", TokenStoreKeyring, store)
+	}
+}
+
 func TestSetDefaults(t *testing.T) {
 	viper.Reset()
 	t.Cleanup(viper.Reset)
@@ -186,3 +199,65 @@ func TestConfigConstants(t *testing.T) {
 		t.Errorf("unexpected KeyFormat: %s", KeyFormat)
 	}
 }
+
+func TestIsOffline(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	if IsOffline() {
+		t.Error("expected IsOffline to default to false")
+	}
+	viper.Set(KeyOffline, true)
+	if !IsOffline() {
+		t.Error("expected IsOffline to be true after setting offline")
+	}
+}
+
+func TestGetConfigDir_Profile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
+	SetProfile("work")
+	t.Cleanup(func() { SetProfile("") })
+
+	expected := filepath.Join("/custom/config", "gws", "profiles", "work")
+	if dir := GetConfigDir(); dir != expected {
+		t.Errorf("expected %s, got %s", expected, dir)
+	}
+	if path := GetTokenPath(); path != filepath.Join(expected, "token.json") {
+		t.Errorf("expected the token inside the profile dir, got %s", path)
+	}
+
+	SetProfile("../escape")
+	if dir := GetConfigDir(); dir != filepath.Join("/custom/config", "gws") {
+		t.Errorf("expected an invalid profile to be ignored, got %s", dir)
+	}
+}
+
+func TestGetCacheDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
+
+	// The default profile keeps its caches where they always were.
+	if dir := GetCacheDir(); dir != filepath.Join(home, ".config", "gws") {
+		t.Errorf("expected the legacy cache dir, got %s", dir)
+	}
+
+	SetProfile("work")
+	t.Cleanup(func() { SetProfile("") })
+	if dir := GetCacheDir(); dir != filepath.Join("/custom/config", "gws", "profiles", "work") {
+		t.Errorf("expected the profile dir, got %s", dir)
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	for _, name := range []string{"", "work", "personal", "client-a.prod", "team_2"} {
+		if err := ValidateProfile(name); err != nil {
+			t.Errorf("ValidateProfile(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"../work", "a/b", ".hidden", "-x", "with space", strings.Repeat("a", 65)} {
+		if err := ValidateProfile(name); err == nil {
+			t.Errorf("ValidateProfile(%q) = nil, want error", name)
+		}
+	}
+}
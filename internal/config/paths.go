@@ -11,12 +11,27 @@ const (
 	tokenFileName       = "token.json"
 	configName          = "config.yaml"
 	grantedServicesFile = "granted_services.json"
+	profilesDirName     = "profiles"
 )
 
 // GetConfigDir returns the configuration directory path.
 // On macOS/Linux: ~/.config/gws/
 // On Windows: %APPDATA%/gws/
+// With a profile, it is profiles/<name>/ inside that directory, so each
+// profile has its own config file, token, and local stores. An invalid
+// profile name is ignored here; the root command rejects it before any
+// command runs.
 func GetConfigDir() string {
+	base := getBaseConfigDir()
+	if profile := GetProfile(); profile != "" && ValidateProfile(profile) == nil {
+		return filepath.Join(base, profilesDirName, profile)
+	}
+	return base
+}
+
+// getBaseConfigDir returns the configuration directory of the default
+// profile.
+func getBaseConfigDir() string {
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
 		return filepath.Join(xdgConfig, configDirName)
 	}
@@ -29,6 +44,21 @@ func GetConfigDir() string {
 	return filepath.Join(homeDir, ".config", configDirName)
 }
 
+// GetCacheDir returns the directory of local caches (user names, space
+// members). The default profile keeps them in ~/.config/gws, where they
+// have always lived regardless of XDG_CONFIG_HOME; a named profile keeps
+// its own in the profile directory.
+func GetCacheDir() string {
+	if profile := GetProfile(); profile != "" && ValidateProfile(profile) == nil {
+		return GetConfigDir()
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return getBaseConfigDir()
+	}
+	return filepath.Join(homeDir, ".config", configDirName)
+}
+
 // GetTokenPath returns the full path to the token file.
 func GetTokenPath() string {
 	return filepath.Join(GetConfigDir(), tokenFileName)
@@ -0,0 +1,105 @@
+// Package snooze stores local snoozes for Chat spaces. The Chat API has no
+// mute control, so snoozes live in a JSON file in the config directory and
+// commands that triage many spaces skip the snoozed ones.
+package snooze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/omriariav/workspace-cli/internal/config"
+)
+
+const fileName = "chat-snoozes.json"
+
+// Entry is one snoozed space.
+type Entry struct {
+	Until   time.Time `json:"until"`
+	Created time.Time `json:"created"`
+}
+
+// Data is the on-disk format of the snooze store, keyed by space resource
+// name (spaces/AAAA).
+type Data struct {
+	Spaces map[string]Entry `json:"spaces"`
+}
+
+// DefaultPath returns the default snooze file location.
+func DefaultPath() string {
+	return filepath.Join(config.GetConfigDir(), fileName)
+}
+
+// Load reads the snooze store. A missing file yields an empty store; a
+// corrupted one is an error so snoozes are never dropped silently.
+func Load(path string) (*Data, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Data{Spaces: make(map[string]Entry)}, nil
+		}
+		return nil, err
+	}
+
+	var d Data
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("invalid snooze file %s: %w", path, err)
+	}
+	if d.Spaces == nil {
+		d.Spaces = make(map[string]Entry)
+	}
+	return &d, nil
+}
+
+// Save writes the snooze store atomically.
+func Save(path string, d *Data) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Active returns the snooze for space if it has not expired at now.
+func (d *Data) Active(space string, now time.Time) (Entry, bool) {
+	e, ok := d.Spaces[space]
+	if !ok || !now.Before(e.Until) {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Prune removes snoozes that have expired at now and returns how many were
+// removed.
+func (d *Data) Prune(now time.Time) int {
+	removed := 0
+	for space, e := range d.Spaces {
+		if !now.Before(e.Until) {
+			delete(d.Spaces, space)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Names returns the snoozed space names, sorted.
+func (d *Data) Names() []string {
+	names := make([]string, 0, len(d.Spaces))
+	for space := range d.Spaces {
+		names = append(names, space)
+	}
+	sort.Strings(names)
+	return names
+}
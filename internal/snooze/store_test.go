@@ -0,0 +1,79 @@
+package snooze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozes.json")
+
+	// Load from non-existent file returns an empty store
+	d, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading non-existent store: %v", err)
+	}
+	if len(d.Spaces) != 0 {
+		t.Errorf("expected empty store, got %d spaces", len(d.Spaces))
+	}
+
+	until := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	d.Spaces["spaces/AAAA"] = Entry{Until: until, Created: until.Add(-72 * time.Hour)}
+	if err := Save(path, d); err != nil {
+		t.Fatalf("failed to save store: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to reload store: %v", err)
+	}
+	if !loaded.Spaces["spaces/AAAA"].Until.Equal(until) {
+		t.Errorf("expected until %v, got %+v", until, loaded.Spaces["spaces/AAAA"])
+	}
+}
+
+func TestLoad_CorruptedFileIsAnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozes.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a corrupted snooze file")
+	}
+}
+
+func TestActiveAndPrune(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	d := &Data{Spaces: map[string]Entry{
+		"spaces/LATER": {Until: now.Add(time.Hour)},
+		"spaces/NOW":   {Until: now},
+		"spaces/PAST":  {Until: now.Add(-time.Hour)},
+	}}
+
+	if _, ok := d.Active("spaces/LATER", now); !ok {
+		t.Error("expected spaces/LATER to be snoozed")
+	}
+	if _, ok := d.Active("spaces/NOW", now); ok {
+		t.Error("expected a snooze to end at its until time")
+	}
+	if _, ok := d.Active("spaces/OTHER", now); ok {
+		t.Error("expected an unknown space not to be snoozed")
+	}
+
+	if removed := d.Prune(now); removed != 2 {
+		t.Errorf("expected 2 expired snoozes pruned, got %d", removed)
+	}
+	if names := d.Names(); len(names) != 1 || names[0] != "spaces/LATER" {
+		t.Errorf("unexpected remaining snoozes: %v", names)
+	}
+}
+
+func TestDefaultPath_UsesConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if got, want := DefaultPath(), filepath.Join(dir, "gws", "chat-snoozes.json"); got != want {
+		t.Errorf("DefaultPath() = %q, want %q", got, want)
+	}
+}
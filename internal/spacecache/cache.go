@@ -2,13 +2,17 @@ package spacecache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/omriariav/workspace-cli/internal/config"
 	"github.com/omriariav/workspace-cli/internal/usercache"
 	"google.golang.org/api/chat/v1"
 	"google.golang.org/api/people/v1"
@@ -33,10 +37,10 @@ type CacheData struct {
 	Spaces      map[string]SpaceEntry `json:"spaces"`
 }
 
-// DefaultPath returns the default cache file location.
+// DefaultPath returns the default cache file location, in the active
+// profile's cache directory (~/.config/gws/ by default).
 func DefaultPath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".config", "gws", "space-members-cache.json")
+	return filepath.Join(config.GetCacheDir(), "space-members-cache.json")
 }
 
 // Load reads the cache from disk. Returns empty CacheData if file doesn't exist.
@@ -94,6 +98,79 @@ func FindByMembers(cache *CacheData, emails []string) map[string]SpaceEntry {
 	return results
 }
 
+// MemberIndex returns a reverse index from lowercased member email to the
+// space resource names that contain it. Entries flagged MembersUnresolved are
+// skipped for the same reason FindByMembers skips them. Space names within
+// each slice are sorted for stable output.
+func MemberIndex(cache *CacheData) map[string][]string {
+	index := make(map[string][]string)
+	if cache == nil {
+		return index
+	}
+	for name, entry := range cache.Spaces {
+		if entry.MembersUnresolved {
+			continue
+		}
+		for _, m := range entry.Members {
+			key := strings.ToLower(m)
+			index[key] = append(index[key], name)
+		}
+	}
+	for key := range index {
+		sort.Strings(index[key])
+	}
+	return index
+}
+
+// MemberSignature returns a stable signature for a member set: the SHA-256
+// of its sorted, lowercased, de-duplicated members, hex-encoded and cut to
+// 16 characters. Member order and case do not change it.
+func MemberSignature(members []string) string {
+	seen := make(map[string]bool, len(members))
+	var sorted []string
+	for _, m := range members {
+		key := strings.ToLower(m)
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// GroupByMembers groups spaces with identical member sets, keyed by
+// MemberSignature. Only groups of two or more spaces are returned, with space
+// names sorted. If spaceType is non-empty, only spaces of that type are
+// grouped. Entries flagged MembersUnresolved or with fewer than two members
+// are skipped: an incomplete or trivial member list is no evidence of a
+// duplicate.
+func GroupByMembers(cache *CacheData, spaceType string) map[string][]string {
+	groups := make(map[string][]string)
+	if cache == nil {
+		return groups
+	}
+	for name, entry := range cache.Spaces {
+		if entry.MembersUnresolved || len(entry.Members) < 2 {
+			continue
+		}
+		if spaceType != "" && !strings.EqualFold(entry.Type, spaceType) {
+			continue
+		}
+		sig := MemberSignature(entry.Members)
+		groups[sig] = append(groups[sig], name)
+	}
+	for sig, names := range groups {
+		if len(names) < 2 {
+			delete(groups, sig)
+			continue
+		}
+		sort.Strings(names)
+	}
+	return groups
+}
+
 // FindByDisplayName returns spaces whose display_name contains the (case-insensitive)
 // query substring. If spaceType is non-empty, results are filtered to that type
 // (e.g. "SPACE", "GROUP_CHAT", "DIRECT_MESSAGE"). Spaces without a display_name
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,17 @@ import (
 	"google.golang.org/api/option"
 )
 
+func TestDefaultPath_DefaultProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	want := filepath.Join(home, ".config", "gws", "space-members-cache.json")
+	if got := DefaultPath(); got != want {
+		t.Errorf("DefaultPath() = %s, want %s", got, want)
+	}
+}
+
 func TestLoadSave(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "cache.json")
@@ -450,3 +462,75 @@ func TestFindByMembers_SkipsMembersUnresolved(t *testing.T) {
 		t.Error("expected spaces/RESOLVED to match")
 	}
 }
+
+func TestMemberIndex(t *testing.T) {
+	cache := &CacheData{
+		Spaces: map[string]SpaceEntry{
+			"spaces/BBBB": {Members: []string{"Alice@Example.com", "bob@example.com"}},
+			"spaces/AAAA": {Members: []string{"alice@example.com"}},
+			"spaces/CCCC": {Members: nil, MembersUnresolved: true},
+		},
+	}
+
+	index := MemberIndex(cache)
+	got := index["alice@example.com"]
+	if len(got) != 2 || got[0] != "spaces/AAAA" || got[1] != "spaces/BBBB" {
+		t.Errorf("expected [spaces/AAAA spaces/BBBB] for alice, got %v", got)
+	}
+	if got := index["bob@example.com"]; len(got) != 1 || got[0] != "spaces/BBBB" {
+		t.Errorf("expected [spaces/BBBB] for bob, got %v", got)
+	}
+	if len(index) != 2 {
+		t.Errorf("expected 2 indexed members, got %d", len(index))
+	}
+	if len(MemberIndex(nil)) != 0 {
+		t.Error("expected empty index for nil cache")
+	}
+}
+
+func TestMemberSignature(t *testing.T) {
+	a := MemberSignature([]string{"Bob@example.com", "alice@example.com"})
+	b := MemberSignature([]string{"alice@example.com", "bob@example.com", "BOB@example.com"})
+	if a != b {
+		t.Errorf("expected order, case, and duplicates to be ignored: %s != %s", a, b)
+	}
+	if len(a) != 16 {
+		t.Errorf("expected a 16-character signature, got %q", a)
+	}
+	if a == MemberSignature([]string{"alice@example.com"}) {
+		t.Error("expected different member sets to have different signatures")
+	}
+}
+
+func TestGroupByMembers(t *testing.T) {
+	cache := &CacheData{
+		Spaces: map[string]SpaceEntry{
+			"spaces/B": {Type: "GROUP_CHAT", Members: []string{"bob@example.com", "alice@example.com"}},
+			"spaces/A": {Type: "GROUP_CHAT", Members: []string{"Alice@Example.com", "bob@example.com"}},
+			"spaces/C": {Type: "SPACE", Members: []string{"alice@example.com", "bob@example.com"}},
+			"spaces/D": {Type: "GROUP_CHAT", Members: []string{"alice@example.com", "carol@example.com"}},
+			"spaces/E": {Type: "GROUP_CHAT", Members: []string{"alice@example.com"}},
+			"spaces/F": {Type: "GROUP_CHAT", Members: []string{"alice@example.com"}},
+			"spaces/G": {Type: "GROUP_CHAT", MembersUnresolved: true},
+		},
+	}
+
+	groups := GroupByMembers(cache, "")
+	if len(groups) != 1 {
+		t.Fatalf("expected one duplicate group, got %v", groups)
+	}
+	got := groups[MemberSignature([]string{"alice@example.com", "bob@example.com"})]
+	if strings.Join(got, ",") != "spaces/A,spaces/B,spaces/C" {
+		t.Errorf("expected [spaces/A spaces/B spaces/C], got %v", got)
+	}
+
+	groups = GroupByMembers(cache, "group_chat")
+	for _, names := range groups {
+		if strings.Join(names, ",") != "spaces/A,spaces/B" {
+			t.Errorf("expected the SPACE to be filtered out, got %v", names)
+		}
+	}
+	if len(GroupByMembers(nil, "")) != 0 {
+		t.Error("expected no groups for nil cache")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/omriariav/workspace-cli/internal/config"
 	"google.golang.org/api/people/v1"
 )
 
@@ -24,13 +25,10 @@ type Cache struct {
 	entries map[string]UserInfo
 }
 
-// New loads or creates a user cache at ~/.config/gws/user-cache.json.
+// New loads or creates a user cache at user-cache.json in the active
+// profile's cache directory (~/.config/gws/ by default).
 func New() (*Cache, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return nil, err
-	}
-	path := filepath.Join(home, ".config", "gws", "user-cache.json")
+	path := filepath.Join(config.GetCacheDir(), "user-cache.json")
 
 	c := &Cache{
 		path:    path,